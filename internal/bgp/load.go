@@ -0,0 +1,180 @@
+package bgp
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KincaidYang/whois/internal/metrics"
+)
+
+// originCount is how many RIB entries for a prefix named one origin AS.
+type originCount struct {
+	asn   uint32
+	count int
+}
+
+// tally collects the origins a dump reports for each prefix: one per peer,
+// and peers can disagree (MOAS, or a peer's stale path).
+type tally map[netip.Prefix][]originCount
+
+func (t tally) add(p netip.Prefix, asn uint32) {
+	counts := t[p]
+	for i := range counts {
+		if counts[i].asn == asn {
+			counts[i].count++
+			return
+		}
+	}
+	t[p] = append(counts, originCount{asn: asn, count: 1})
+}
+
+// table reduces the tally to the origin most peers saw for each prefix,
+// ties going to the lowest ASN.
+func (t tally) table() *Table {
+	origins := make(map[netip.Prefix]uint32, len(t))
+	for p, counts := range t {
+		best := counts[0]
+		for _, c := range counts[1:] {
+			if c.count > best.count || c.count == best.count && c.asn < best.asn {
+				best = c
+			}
+		}
+		origins[p] = best.asn
+	}
+	return newTable(origins)
+}
+
+// bgpdumpMarker starts every RIB line of bgpdump's one-line output
+// ("TABLE_DUMP2|..." or "TABLE_DUMP|...").
+const bgpdumpMarker = "TABLE_DUMP"
+
+// Load reads a RIB dump: a binary MRT file (TABLE_DUMP_V2, as published by
+// RouteViews and RIPE RIS, or legacy TABLE_DUMP) or the text bgpdump -m
+// makes of one. Either may be gzip- or bzip2-compressed; the format is
+// detected from the content, not the file name.
+func Load(path string) (*Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var r io.Reader = bufio.NewReaderSize(f, 1<<20)
+	magic, _ := r.(*bufio.Reader).Peek(3)
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		r = gz
+	case bytes.HasPrefix(magic, []byte("BZh")):
+		r = bzip2.NewReader(r)
+	}
+	br := bufio.NewReaderSize(r, 1<<20)
+
+	t := make(tally)
+	if marker, _ := br.Peek(len(bgpdumpMarker)); string(marker) == bgpdumpMarker {
+		err = parseBGPDump(br, t)
+	} else {
+		err = parseMRT(br, t)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(t) == 0 {
+		return nil, fmt.Errorf("%s: no IPv4 or IPv6 unicast routes found", path)
+	}
+	return t.table(), nil
+}
+
+// parseBGPDump reads bgpdump -m lines
+// ("TABLE_DUMP2|<time>|B|<peer>|<peer AS>|<prefix>|<AS path>|..."). Lines of
+// any other kind, and routes without a single origin, are skipped.
+func parseBGPDump(r io.Reader, t tally) error {
+	scanner := bufio.NewScanner(r)
+	// Long community lists make for long lines.
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), "|", 8)
+		if len(fields) < 7 || !strings.HasPrefix(fields[0], bgpdumpMarker) {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(fields[5])
+		if err != nil {
+			continue
+		}
+		path := strings.Fields(fields[6])
+		if len(path) == 0 {
+			continue
+		}
+		// An AS_SET prints as "{64500,64501}".
+		origin, err := strconv.ParseUint(path[len(path)-1], 10, 32)
+		if err != nil {
+			continue
+		}
+		t.add(prefix.Masked(), uint32(origin))
+	}
+	return scanner.Err()
+}
+
+// StartWatcher loads the dump at path, then polls it every interval and
+// reloads it when its size or modification time changes. A dump that fails
+// to load leaves the current table in place. The first load happens before
+// StartWatcher returns, so lookups are enriched from the first request; the
+// polling stops when ctx is cancelled.
+func StartWatcher(ctx context.Context, path string, interval time.Duration) {
+	var lastSize int64
+	var lastMod time.Time
+	reload := func() {
+		info, err := os.Stat(path)
+		if err != nil {
+			slog.Warn("BGP table unavailable", "path", path, "err", err)
+			return
+		}
+		if info.Size() == lastSize && info.ModTime().Equal(lastMod) {
+			return
+		}
+		lastSize, lastMod = info.Size(), info.ModTime()
+
+		start := time.Now()
+		t, err := Load(path)
+		if err != nil {
+			metrics.BGPTableLoadsTotal.WithLabelValues("failure").Inc()
+			slog.Warn("BGP table load failed, keeping the current table", "path", path, "err", err)
+			return
+		}
+		SetActive(t)
+		metrics.BGPTableLoadsTotal.WithLabelValues("success").Inc()
+		metrics.BGPTablePrefixes.Set(float64(t.Len()))
+		slog.Info("BGP table loaded", "path", path, "prefixes", t.Len(), "duration", time.Since(start))
+	}
+
+	reload()
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				reload()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
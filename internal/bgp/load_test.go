@@ -0,0 +1,213 @@
+package bgp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// mrtRecord frames body as an MRT record of the given type and subtype.
+func mrtRecord(typ, subtype uint16, body []byte) []byte {
+	b := make([]byte, 12, 12+len(body))
+	binary.BigEndian.PutUint16(b[4:], typ)
+	binary.BigEndian.PutUint16(b[6:], subtype)
+	binary.BigEndian.PutUint32(b[8:], uint32(len(body)))
+	return append(b, body...)
+}
+
+// asPathAttr encodes an AS_PATH (or AS4_PATH) attribute of segments, each
+// a segment type followed by its ASNs.
+func asPathAttr(typ byte, asSize int, segments ...[]uint32) []byte {
+	var value []byte
+	for _, seg := range segments {
+		value = append(value, byte(seg[0]), byte(len(seg)-1))
+		for _, asn := range seg[1:] {
+			if asSize == 2 {
+				value = binary.BigEndian.AppendUint16(value, uint16(asn))
+			} else {
+				value = binary.BigEndian.AppendUint32(value, asn)
+			}
+		}
+	}
+	attr := []byte{0x40, 1, 1, 0} // ORIGIN IGP, which the parser skips
+	if len(value) > 255 {
+		attr = append(attr, 0x50, typ)
+		attr = binary.BigEndian.AppendUint16(attr, uint16(len(value)))
+	} else {
+		attr = append(attr, 0x40, typ, byte(len(value)))
+	}
+	return append(attr, value...)
+}
+
+// ribRecord encodes a TABLE_DUMP_V2 RIB_IPV4_UNICAST or RIB_IPV6_UNICAST
+// record with one entry per attribute set.
+func ribRecord(prefix string, attrs ...[]byte) []byte {
+	p := netip.MustParsePrefix(prefix)
+	subtype := uint16(ribIPv4Unicast)
+	if p.Addr().Is6() {
+		subtype = ribIPv6Unicast
+	}
+	body := []byte{0, 0, 0, 1, byte(p.Bits())}
+	body = append(body, p.Addr().AsSlice()[:(p.Bits()+7)/8]...)
+	body = binary.BigEndian.AppendUint16(body, uint16(len(attrs)))
+	for i, a := range attrs {
+		body = binary.BigEndian.AppendUint16(body, uint16(i)) // peer index
+		body = append(body, 0, 0, 0, 0)                       // originated time
+		body = binary.BigEndian.AppendUint16(body, uint16(len(a)))
+		body = append(body, a...)
+	}
+	return mrtRecord(mrtTableDumpV2, subtype, body)
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func assertRoute(t *testing.T, table *Table, query, wantPrefix string, wantOrigin uint32) {
+	t.Helper()
+	prefix, origin, ok := table.Lookup(netip.MustParsePrefix(query))
+	if !ok || prefix.String() != wantPrefix || origin != wantOrigin {
+		t.Errorf("Lookup(%s) = %s, %d, %v; want %s, %d", query, prefix, origin, ok, wantPrefix, wantOrigin)
+	}
+}
+
+const seq, set = segmentASSequence, segmentASSet
+
+// TestLoadMRT covers TABLE_DUMP_V2: the majority origin across peers, a
+// 4-byte ASN, IPv6, paths ending in an AS_SET, and records of other types.
+func TestLoadMRT(t *testing.T) {
+	var dump []byte
+	dump = append(dump, mrtRecord(mrtTableDumpV2, 1, []byte{1, 2, 3, 4, 0, 0, 0, 0})...) // PEER_INDEX_TABLE
+	dump = append(dump, ribRecord("192.0.2.0/24",
+		asPathAttr(attrASPath, 4, []uint32{seq, 64500, 64496}),
+		asPathAttr(attrASPath, 4, []uint32{seq, 64501, 64497}),
+		asPathAttr(attrASPath, 4, []uint32{seq, 64502, 64510, 64496}),
+	)...)
+	dump = append(dump, ribRecord("198.51.100.0/22",
+		asPathAttr(attrASPath, 4, []uint32{seq, 64500, 4200000000}),
+	)...)
+	dump = append(dump, ribRecord("203.0.113.0/24",
+		asPathAttr(attrASPath, 4, []uint32{seq, 64500}, []uint32{set, 64511, 64512}),
+	)...)
+	dump = append(dump, ribRecord("2001:db8::/32",
+		asPathAttr(attrASPath, 4, []uint32{seq, 64500, 64499}),
+	)...)
+	dump = append(dump, mrtRecord(16, 4, []byte{0xff})...) // BGP4MP message
+
+	table, err := Load(writeFile(t, "rib.mrt", dump))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	assertRoute(t, table, "192.0.2.1/32", "192.0.2.0/24", 64496)
+	assertRoute(t, table, "198.51.101.9/32", "198.51.100.0/22", 4200000000)
+	assertRoute(t, table, "2001:db8::1/128", "2001:db8::/32", 64499)
+	if _, _, ok := table.Lookup(netip.MustParsePrefix("203.0.113.1/32")); ok {
+		t.Error("route ending in an AS_SET was given an origin")
+	}
+}
+
+// TestLoadLegacyTableDump covers TABLE_DUMP with 2-byte paths, where a
+// 4-byte origin is only in AS4_PATH.
+func TestLoadLegacyTableDump(t *testing.T) {
+	body := []byte{0, 0, 0, 1}                 // view, sequence
+	body = append(body, 192, 0, 2, 0, 24, 1)   // prefix, length, status
+	body = append(body, 0, 0, 0, 0)            // time
+	body = append(body, 198, 51, 100, 1, 0, 1) // peer address, peer AS
+	attrs := asPathAttr(attrASPath, 2, []uint32{seq, 64500, 23456})
+	attrs = append(attrs, asPathAttr(attrAS4Path, 4, []uint32{seq, 64500, 4200000000})...)
+	body = binary.BigEndian.AppendUint16(body, uint16(len(attrs)))
+	body = append(body, attrs...)
+
+	table, err := Load(writeFile(t, "rib.mrt", mrtRecord(mrtTableDump, tableDumpIPv4, body)))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	assertRoute(t, table, "192.0.2.1/32", "192.0.2.0/24", 4200000000)
+}
+
+// TestLoadBGPDumpGzip covers gzip-compressed bgpdump -m text.
+func TestLoadBGPDumpGzip(t *testing.T) {
+	text := "TABLE_DUMP2|1700000000|B|198.51.100.1|64500|192.0.2.0/24|64500 64496|IGP|198.51.100.1|0|0||NAG||\n" +
+		"TABLE_DUMP2|1700000000|B|198.51.100.1|64500|192.0.2.128/25|64500 64497 64497|IGP|198.51.100.1|0|0||NAG||\n" +
+		"TABLE_DUMP2|1700000000|B|198.51.100.1|64500|203.0.113.0/24|64500 {64511,64512}|IGP|198.51.100.1|0|0||NAG||\n" +
+		"BGP4MP|1700000000|A|198.51.100.1|64500|198.51.100.0/24|64500 64498|IGP|198.51.100.1|0|0||NAG||\n" +
+		"TABLE_DUMP2|1700000000|B|2001:db8::1|64500|2001:db8::/32|64500 64499|IGP|2001:db8::1|0|0||NAG||\n"
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write([]byte(text))
+	_ = gz.Close()
+
+	table, err := Load(writeFile(t, "rib.txt.gz", buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	assertRoute(t, table, "192.0.2.1/32", "192.0.2.0/24", 64496)
+	assertRoute(t, table, "192.0.2.129/32", "192.0.2.128/25", 64497)
+	assertRoute(t, table, "2001:db8::1/128", "2001:db8::/32", 64499)
+	if table.Len() != 3 {
+		t.Errorf("Len() = %d, want 3 (AS_SET and BGP4MP lines skipped)", table.Len())
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("missing file: expected error")
+	}
+	if _, err := Load(writeFile(t, "empty.txt", []byte("# nothing here\n"))); err == nil {
+		t.Error("no routes: expected error")
+	}
+	truncated := ribRecord("192.0.2.0/24", asPathAttr(attrASPath, 4, []uint32{seq, 64496}))
+	if _, err := Load(writeFile(t, "truncated.mrt", truncated[:len(truncated)-3])); err == nil {
+		t.Error("truncated record: expected error")
+	}
+}
+
+// TestStartWatcher verifies the dump is loaded before StartWatcher returns
+// and reloaded once it changes, while a broken dump keeps the last table.
+func TestStartWatcher(t *testing.T) {
+	t.Cleanup(func() { SetActive(nil) })
+	line := "TABLE_DUMP2|1700000000|B|198.51.100.1|64500|192.0.2.0/24|64500 %s|IGP\n"
+	path := writeFile(t, "rib.txt", []byte(fmt.Sprintf(line, "64496")))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	StartWatcher(ctx, path, 10*time.Millisecond)
+	assertRoute(t, Active(), "192.0.2.1/32", "192.0.2.0/24", 64496)
+
+	if err := os.WriteFile(path, []byte(fmt.Sprintf(line, "64497")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		_, origin, _ := Active().Lookup(netip.MustParsePrefix("192.0.2.1/32"))
+		return origin == 64497
+	})
+
+	if err := os.WriteFile(path, []byte("garbage"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	assertRoute(t, Active(), "192.0.2.1/32", "192.0.2.0/24", 64497)
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 2s")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,213 @@
+package bgp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+)
+
+// MRT record types and subtypes (RFC 6396, RFC 8050) carrying RIB entries.
+// Every other record (BGP4MP updates, peer index tables, multicast RIBs) is
+// skipped.
+const (
+	mrtTableDump   = 12
+	mrtTableDumpV2 = 13
+
+	tableDumpIPv4 = 1
+	tableDumpIPv6 = 2
+
+	ribIPv4Unicast        = 2
+	ribIPv6Unicast        = 4
+	ribIPv4UnicastAddPath = 8
+	ribIPv6UnicastAddPath = 10
+)
+
+// BGP path attribute types read from RIB entries.
+const (
+	attrASPath    = 2
+	attrAS4Path   = 17
+	attrExtLength = 0x10 // flag: the attribute length takes two bytes
+
+	segmentASSet      = 1
+	segmentASSequence = 2
+)
+
+// maxMRTRecord caps the length a record header may claim. A full-table
+// TABLE_DUMP_V2 RIB record is a few hundred KB at most; anything larger is
+// a corrupt file, not a reason to allocate gigabytes.
+const maxMRTRecord = 16 << 20
+
+var errTruncated = errors.New("truncated MRT record")
+
+// parseMRT reads the RIB entries of an MRT dump (TABLE_DUMP or
+// TABLE_DUMP_V2) into tally.
+func parseMRT(r io.Reader, t tally) error {
+	var header [12]byte
+	for {
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("reading MRT header: %w", err)
+		}
+		typ := binary.BigEndian.Uint16(header[4:6])
+		subtype := binary.BigEndian.Uint16(header[6:8])
+		length := binary.BigEndian.Uint32(header[8:12])
+		if length > maxMRTRecord {
+			return fmt.Errorf("MRT record of %d bytes exceeds %d", length, maxMRTRecord)
+		}
+		body := make([]byte, length)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return fmt.Errorf("reading MRT record: %w", err)
+		}
+
+		var err error
+		switch {
+		case typ == mrtTableDumpV2 && (subtype == ribIPv4Unicast || subtype == ribIPv4UnicastAddPath):
+			err = parseRIB(body, 4, subtype == ribIPv4UnicastAddPath, t)
+		case typ == mrtTableDumpV2 && (subtype == ribIPv6Unicast || subtype == ribIPv6UnicastAddPath):
+			err = parseRIB(body, 16, subtype == ribIPv6UnicastAddPath, t)
+		case typ == mrtTableDump && subtype == tableDumpIPv4:
+			err = parseTableDump(body, 4, t)
+		case typ == mrtTableDump && subtype == tableDumpIPv6:
+			err = parseTableDump(body, 16, t)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// parseRIB reads a TABLE_DUMP_V2 RIB record: one prefix and the entry each
+// peer holds for it (RFC 6396 section 4.3.2). Paths carry 4-byte ASNs.
+func parseRIB(b []byte, addrLen int, addPath bool, t tally) error {
+	if len(b) < 5 {
+		return errTruncated
+	}
+	bits := int(b[4])
+	n := (bits + 7) / 8
+	if bits > addrLen*8 || len(b) < 5+n+2 {
+		return errTruncated
+	}
+	prefix, ok := makePrefix(b[5:5+n], addrLen, bits)
+	b = b[5+n:]
+	count := int(binary.BigEndian.Uint16(b))
+	b = b[2:]
+
+	for range count {
+		skip := 6 // peer index, originated time
+		if addPath {
+			skip += 4 // path identifier (RFC 8050)
+		}
+		if len(b) < skip+2 {
+			return errTruncated
+		}
+		attrLen := int(binary.BigEndian.Uint16(b[skip:]))
+		b = b[skip+2:]
+		if len(b) < attrLen {
+			return errTruncated
+		}
+		if origin, found := originFromAttributes(b[:attrLen], 4); ok && found {
+			t.add(prefix, origin)
+		}
+		b = b[attrLen:]
+	}
+	return nil
+}
+
+// parseTableDump reads a legacy TABLE_DUMP record: one prefix as seen by one
+// peer (RFC 6396 section 4.2). Paths carry 2-byte ASNs, with any 4-byte ASN
+// in AS4_PATH.
+func parseTableDump(b []byte, addrLen int, t tally) error {
+	// view, sequence, prefix, length, status, time, peer address, peer AS,
+	// attribute length
+	fixed := 2 + 2 + addrLen + 1 + 1 + 4 + addrLen + 2 + 2
+	if len(b) < fixed {
+		return errTruncated
+	}
+	bits := int(b[4+addrLen])
+	prefix, ok := makePrefix(b[4:4+addrLen], addrLen, bits)
+	attrLen := int(binary.BigEndian.Uint16(b[fixed-2:]))
+	if len(b) < fixed+attrLen {
+		return errTruncated
+	}
+	if origin, found := originFromAttributes(b[fixed:fixed+attrLen], 2); ok && found {
+		t.add(prefix, origin)
+	}
+	return nil
+}
+
+// makePrefix builds a prefix from its leading address bytes.
+func makePrefix(b []byte, addrLen, bits int) (netip.Prefix, bool) {
+	var buf [16]byte
+	copy(buf[:], b)
+	addr := netip.AddrFrom16(buf)
+	if addrLen == 4 {
+		addr = netip.AddrFrom4([4]byte(buf[:4]))
+	}
+	p, err := addr.Prefix(bits)
+	return p, err == nil
+}
+
+// originFromAttributes returns the origin AS of a path: the last AS of its
+// AS4_PATH when present (RFC 6793: a 2-byte AS_PATH then ends in AS_TRANS),
+// else of its AS_PATH. asSize is the width of AS_PATH's ASNs.
+func originFromAttributes(b []byte, asSize int) (uint32, bool) {
+	var origin, origin4 uint32
+	var found, found4 bool
+	for len(b) >= 3 {
+		flags, typ := b[0], b[1]
+		n, hdr := int(b[2]), 3
+		if flags&attrExtLength != 0 {
+			if len(b) < 4 {
+				break
+			}
+			n, hdr = int(binary.BigEndian.Uint16(b[2:4])), 4
+		}
+		if len(b) < hdr+n {
+			break
+		}
+		switch typ {
+		case attrASPath:
+			origin, found = pathOrigin(b[hdr:hdr+n], asSize)
+		case attrAS4Path:
+			origin4, found4 = pathOrigin(b[hdr:hdr+n], 4)
+		}
+		b = b[hdr+n:]
+	}
+	if found4 {
+		return origin4, true
+	}
+	return origin, found
+}
+
+// pathOrigin returns the last AS of an AS path's last segment. A path ending
+// in an AS_SET (aggregation) has no single origin.
+func pathOrigin(b []byte, asSize int) (uint32, bool) {
+	var origin uint32
+	var found bool
+	for len(b) >= 2 {
+		segType, count := b[0], int(b[1])
+		if len(b) < 2+count*asSize {
+			return 0, false
+		}
+		switch segType {
+		case segmentASSequence:
+			if count > 0 {
+				last := b[2+(count-1)*asSize:]
+				if asSize == 2 {
+					origin = uint32(binary.BigEndian.Uint16(last))
+				} else {
+					origin = binary.BigEndian.Uint32(last)
+				}
+				found = true
+			}
+		case segmentASSet:
+			found = false
+		}
+		b = b[2+count*asSize:]
+	}
+	return origin, found
+}
@@ -0,0 +1,141 @@
+// Package bgp maps IP prefixes to the AS originating them, from a BGP RIB
+// dump supplied by the operator (see Load for the formats). IP lookups use it
+// to report the announced prefix covering the query and its origin AS, and
+// ASN lookups to list the prefixes an AS originates, without any upstream
+// query.
+package bgp
+
+import (
+	"net/netip"
+	"sort"
+	"sync"
+)
+
+// route is one announced prefix and the AS originating it.
+type route struct {
+	prefix netip.Prefix
+	origin uint32
+	// parent is the index of the most specific route covering this one, or
+	// -1 when none does.
+	parent int
+}
+
+// Table is a routing table reduced to one origin AS per prefix. It is
+// immutable once built.
+type Table struct {
+	v4, v6   []route                   // sorted by address, then by prefix length
+	byOrigin map[uint32][]netip.Prefix // sorted, IPv4 first
+}
+
+var (
+	mu     sync.RWMutex
+	active *Table
+)
+
+// SetActive replaces the table lookups are answered from; nil disables them.
+func SetActive(t *Table) {
+	mu.Lock()
+	active = t
+	mu.Unlock()
+}
+
+// Active returns the table lookups are answered from, or nil when no table is
+// loaded.
+func Active() *Table {
+	mu.RLock()
+	defer mu.RUnlock()
+	return active
+}
+
+// newTable builds a Table from each prefix's origin AS.
+func newTable(origins map[netip.Prefix]uint32) *Table {
+	t := &Table{byOrigin: make(map[uint32][]netip.Prefix)}
+	for p, origin := range origins {
+		r := route{prefix: p, origin: origin}
+		if p.Addr().Is4() {
+			t.v4 = append(t.v4, r)
+		} else {
+			t.v6 = append(t.v6, r)
+		}
+		t.byOrigin[origin] = append(t.byOrigin[origin], p)
+	}
+	linkParents(t.v4)
+	linkParents(t.v6)
+	for _, prefixes := range t.byOrigin {
+		sort.Slice(prefixes, func(i, j int) bool { return comparePrefixes(prefixes[i], prefixes[j]) < 0 })
+	}
+	return t
+}
+
+// comparePrefixes orders prefixes by family, address, then length, so a
+// covering prefix sorts before the prefixes it covers.
+func comparePrefixes(a, b netip.Prefix) int {
+	if c := a.Addr().Compare(b.Addr()); c != 0 {
+		return c
+	}
+	return a.Bits() - b.Bits()
+}
+
+// linkParents sorts routes and points each at the most specific route
+// covering it. Prefixes either nest or are disjoint, so walking in order
+// with a stack of the currently open prefixes finds every parent.
+func linkParents(routes []route) {
+	sort.Slice(routes, func(i, j int) bool { return comparePrefixes(routes[i].prefix, routes[j].prefix) < 0 })
+	var open []int
+	for i := range routes {
+		for len(open) > 0 && !covers(routes[open[len(open)-1]].prefix, routes[i].prefix) {
+			open = open[:len(open)-1]
+		}
+		routes[i].parent = -1
+		if len(open) > 0 {
+			routes[i].parent = open[len(open)-1]
+		}
+		open = append(open, i)
+	}
+}
+
+// covers reports whether outer contains all of inner.
+func covers(outer, inner netip.Prefix) bool {
+	return outer.Bits() <= inner.Bits() && outer.Contains(inner.Addr())
+}
+
+// Len is the number of prefixes in the table.
+func (t *Table) Len() int {
+	return len(t.v4) + len(t.v6)
+}
+
+// Lookup returns the most specific announced prefix covering p (an address
+// is its /32 or /128) and the AS originating it. Like
+// serverlist.LookupIPKey it binary-searches the rightmost route starting at
+// or before p; any route covering p is that route or one of its parents.
+func (t *Table) Lookup(p netip.Prefix) (prefix netip.Prefix, origin uint32, ok bool) {
+	if t == nil || !p.IsValid() {
+		return netip.Prefix{}, 0, false
+	}
+	if p.Addr().Is4In6() {
+		p = netip.PrefixFrom(p.Addr().Unmap(), max(p.Bits()-96, 0))
+	}
+	p = p.Masked()
+	routes := t.v6
+	if p.Addr().Is4() {
+		routes = t.v4
+	}
+
+	i := sort.Search(len(routes), func(i int) bool { return routes[i].prefix.Addr().Compare(p.Addr()) > 0 }) - 1
+	for i >= 0 {
+		if covers(routes[i].prefix, p) {
+			return routes[i].prefix, routes[i].origin, true
+		}
+		i = routes[i].parent
+	}
+	return netip.Prefix{}, 0, false
+}
+
+// Prefixes returns the prefixes asn originates, IPv4 first, in address
+// order. The slice is shared and must not be modified.
+func (t *Table) Prefixes(asn uint32) []netip.Prefix {
+	if t == nil {
+		return nil
+	}
+	return t.byOrigin[asn]
+}
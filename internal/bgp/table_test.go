@@ -0,0 +1,70 @@
+package bgp
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func testTable() *Table {
+	return newTable(map[netip.Prefix]uint32{
+		netip.MustParsePrefix("192.0.0.0/16"):    64496,
+		netip.MustParsePrefix("192.0.2.0/24"):    64497,
+		netip.MustParsePrefix("192.0.2.128/25"):  64498,
+		netip.MustParsePrefix("192.0.4.0/24"):    64497,
+		netip.MustParsePrefix("198.51.100.0/24"): 64499,
+		netip.MustParsePrefix("2001:db8::/32"):   64497,
+	})
+}
+
+// TestTableLookup verifies the most specific covering prefix wins, including
+// after a more specific route that does not cover the query.
+func TestTableLookup(t *testing.T) {
+	table := testTable()
+	tests := []struct {
+		query, prefix string
+		origin        uint32
+	}{
+		{"192.0.2.1/32", "192.0.2.0/24", 64497},
+		{"192.0.2.200/32", "192.0.2.128/25", 64498},
+		{"192.0.3.1/32", "192.0.0.0/16", 64496},     // after 192.0.2.128/25
+		{"192.0.255.255/32", "192.0.0.0/16", 64496}, // after 192.0.4.0/24
+		{"192.0.2.0/24", "192.0.2.0/24", 64497},     // a prefix query is not covered by its own subnets
+		{"192.0.2.0/23", "192.0.0.0/16", 64496},
+		{"2001:db8:1::1/128", "2001:db8::/32", 64497},
+		{"::ffff:198.51.100.7/128", "198.51.100.0/24", 64499},
+	}
+	for _, tt := range tests {
+		prefix, origin, ok := table.Lookup(netip.MustParsePrefix(tt.query))
+		if !ok || prefix.String() != tt.prefix || origin != tt.origin {
+			t.Errorf("Lookup(%s) = %s, %d, %v; want %s, %d", tt.query, prefix, origin, ok, tt.prefix, tt.origin)
+		}
+	}
+
+	for _, query := range []string{"10.0.0.1/32", "192.0.0.0/8", "2001:db9::1/128"} {
+		if prefix, _, ok := table.Lookup(netip.MustParsePrefix(query)); ok {
+			t.Errorf("Lookup(%s) = %s, want no route", query, prefix)
+		}
+	}
+	if _, _, ok := (*Table)(nil).Lookup(netip.MustParsePrefix("192.0.2.1/32")); ok {
+		t.Error("nil table answered a lookup")
+	}
+}
+
+func TestTablePrefixes(t *testing.T) {
+	table := testTable()
+	want := []netip.Prefix{
+		netip.MustParsePrefix("192.0.2.0/24"),
+		netip.MustParsePrefix("192.0.4.0/24"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}
+	if got := table.Prefixes(64497); !reflect.DeepEqual(got, want) {
+		t.Errorf("Prefixes(64497) = %v, want %v", got, want)
+	}
+	if got := table.Prefixes(65000); got != nil {
+		t.Errorf("Prefixes(65000) = %v, want none", got)
+	}
+	if table.Len() != 6 {
+		t.Errorf("Len() = %d, want 6", table.Len())
+	}
+}
@@ -0,0 +1,253 @@
+// Package breaker keeps a circuit breaker per upstream server, so a registry
+// that is down costs callers an immediate 503 instead of the full upstream
+// timeout and a concurrency slot each.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/metrics"
+	"github.com/KincaidYang/whois/internal/utils"
+)
+
+// Breaker states, also the values of the whois_circuit_breaker_state gauge.
+const (
+	StateClosed   = 0 // requests pass; outcomes are counted
+	StateHalfOpen = 1 // one probe request is let through
+	StateOpen     = 2 // requests fail fast until the cooldown ends
+)
+
+const (
+	// window is how many recent outcomes the error rate is computed over;
+	// the rate only opens a breaker once the window is full.
+	window = 20
+	// maxBreakers caps how many upstreams are tracked. Registrar RDAP hosts
+	// come from registry answers, so the set is open-ended; closed breakers
+	// with a clean record are dropped first when the cap is reached.
+	maxBreakers = 10000
+)
+
+// now is the clock; tests replace it.
+var now = time.Now
+
+// OpenError is returned instead of querying an upstream whose breaker is
+// open. It wraps utils.ErrUpstreamUnavailable.
+type OpenError struct {
+	Upstream string
+	retryAt  time.Time
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("circuit breaker open for %s", e.Upstream)
+}
+
+func (e *OpenError) Unwrap() error {
+	return utils.ErrUpstreamUnavailable
+}
+
+// RetryAfter is how long until the breaker lets a request through again.
+func (e *OpenError) RetryAfter() time.Duration {
+	return max(e.retryAt.Sub(now()), time.Second)
+}
+
+// breaker is one upstream's state. Guarded by mu.
+type breaker struct {
+	state       int
+	consecutive int          // failures in a row
+	outcomes    [window]bool // ring of recent outcomes, true = failure
+	count       int          // outcomes recorded, up to window
+	next        int          // ring position of the next outcome
+	retryAt     time.Time    // when an open breaker half-opens
+	probing     bool         // a half-open probe is in flight
+	lastFailure string       // the last failure's error text
+	lastChange  time.Time    // when state last changed
+}
+
+var (
+	mu       sync.Mutex
+	breakers = make(map[string]*breaker)
+)
+
+// ErrNotSent is passed to done when a request allowed through was never sent
+// (its outbound rate limit refused it); like context.Canceled it counts as
+// neither a success nor a failure.
+var ErrNotSent = errors.New("request not sent")
+
+// Allow asks whether a request to upstream may go ahead. When it may, the
+// caller must call done once the request finishes, with the error if the
+// upstream misbehaved (unreachable, timeout, 5xx) and nil otherwise — an
+// answer such as "not found" is a success. A context.Canceled error (the
+// caller went away) or ErrNotSent counts as neither. When it may not, the
+// error is an *OpenError.
+func Allow(upstream string) (done func(failure error), err error) {
+	if config.BreakerFailures <= 0 {
+		return func(error) {}, nil
+	}
+	mu.Lock()
+	defer mu.Unlock()
+
+	b := breakers[upstream]
+	if b == nil {
+		evict()
+		b = &breaker{lastChange: now()}
+		breakers[upstream] = b
+		metrics.CircuitBreakerState.WithLabelValues(upstream).Set(StateClosed)
+	}
+	switch b.state {
+	case StateOpen:
+		if now().Before(b.retryAt) {
+			metrics.CircuitBreakerRejectionsTotal.WithLabelValues(upstream).Inc()
+			return nil, &OpenError{Upstream: upstream, retryAt: b.retryAt}
+		}
+		b.setState(upstream, StateHalfOpen)
+		fallthrough
+	case StateHalfOpen:
+		if b.probing {
+			metrics.CircuitBreakerRejectionsTotal.WithLabelValues(upstream).Inc()
+			return nil, &OpenError{Upstream: upstream, retryAt: now().Add(time.Second)}
+		}
+		b.probing = true
+	}
+	return func(failure error) { record(upstream, b, failure) }, nil
+}
+
+// record folds one outcome into b and moves it between states.
+func record(upstream string, b *breaker, failure error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if errors.Is(failure, context.Canceled) || errors.Is(failure, ErrNotSent) {
+		b.probing = false
+		return
+	}
+	failed := failure != nil
+	if b.state == StateHalfOpen {
+		b.probing = false
+		if failed {
+			b.lastFailure = failure.Error()
+			b.open(upstream)
+		} else {
+			b.reset()
+			b.setState(upstream, StateClosed)
+		}
+		return
+	}
+
+	b.outcomes[b.next] = failed
+	b.next = (b.next + 1) % window
+	b.count = min(b.count+1, window)
+	if !failed {
+		b.consecutive = 0
+		return
+	}
+	b.lastFailure = failure.Error()
+	b.consecutive++
+	if b.state == StateClosed && (b.consecutive >= config.BreakerFailures || b.errorRate() >= config.BreakerErrorRate) {
+		b.open(upstream)
+	}
+}
+
+// errorRate is the percentage of failures in a full window, or 0 while the
+// window is still filling.
+func (b *breaker) errorRate() int {
+	if b.count < window {
+		return 0
+	}
+	failed := 0
+	for _, f := range b.outcomes {
+		if f {
+			failed++
+		}
+	}
+	return failed * 100 / window
+}
+
+func (b *breaker) open(upstream string) {
+	b.retryAt = now().Add(config.BreakerCooldown)
+	b.setState(upstream, StateOpen)
+}
+
+func (b *breaker) reset() {
+	b.consecutive, b.count, b.next = 0, 0, 0
+	b.outcomes = [window]bool{}
+}
+
+func (b *breaker) setState(upstream string, state int) {
+	if b.state == state {
+		return
+	}
+	b.state = state
+	b.lastChange = now()
+	metrics.CircuitBreakerState.WithLabelValues(upstream).Set(float64(state))
+}
+
+// evict makes room for a new breaker by dropping closed ones with no recent
+// failures, or every closed one when that is not enough. Callers hold mu.
+func evict() {
+	if len(breakers) < maxBreakers {
+		return
+	}
+	for upstream, b := range breakers {
+		if b.state == StateClosed && b.consecutive == 0 {
+			delete(breakers, upstream)
+			metrics.CircuitBreakerState.DeleteLabelValues(upstream)
+		}
+	}
+	if len(breakers) < maxBreakers {
+		return
+	}
+	for upstream, b := range breakers {
+		if b.state == StateClosed {
+			delete(breakers, upstream)
+			metrics.CircuitBreakerState.DeleteLabelValues(upstream)
+		}
+	}
+}
+
+// Status is one upstream's breaker as reported on /breakers.
+type Status struct {
+	Upstream            string `json:"upstream"`
+	State               string `json:"state"` // "closed", "half-open" or "open"
+	ConsecutiveFailures int    `json:"consecutiveFailures"`
+	ErrorRate           int    `json:"errorRate"` // percent of the last 20 requests; 0 until 20 were made
+	LastFailure         string `json:"lastFailure,omitempty"`
+	Since               string `json:"since"`             // when the state last changed, RFC 3339
+	RetryAt             string `json:"retryAt,omitempty"` // when an open breaker half-opens, RFC 3339
+}
+
+var stateNames = [...]string{StateClosed: "closed", StateHalfOpen: "half-open", StateOpen: "open"}
+
+// Snapshot returns the state of every tracked upstream, open breakers first.
+func Snapshot() []Status {
+	mu.Lock()
+	out := make([]Status, 0, len(breakers))
+	for upstream, b := range breakers {
+		s := Status{
+			Upstream:            upstream,
+			State:               stateNames[b.state],
+			ConsecutiveFailures: b.consecutive,
+			ErrorRate:           b.errorRate(),
+			LastFailure:         b.lastFailure,
+			Since:               b.lastChange.UTC().Format(time.RFC3339),
+		}
+		if b.state == StateOpen {
+			s.RetryAt = b.retryAt.UTC().Format(time.RFC3339)
+		}
+		out = append(out, s)
+	}
+	mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].State != out[j].State {
+			return out[i].State > out[j].State // "open" > "half-open" > "closed"
+		}
+		return out[i].Upstream < out[j].Upstream
+	})
+	return out
+}
@@ -0,0 +1,158 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/utils"
+)
+
+var errDown = errors.New("connection refused")
+
+// withBreakers sets the thresholds and a controllable clock, and starts from
+// no tracked upstreams.
+func withBreakers(t *testing.T, failures, errorRate int, cooldown time.Duration) *time.Time {
+	t.Helper()
+	oldFailures, oldRate, oldCooldown := config.BreakerFailures, config.BreakerErrorRate, config.BreakerCooldown
+	config.BreakerFailures, config.BreakerErrorRate, config.BreakerCooldown = failures, errorRate, cooldown
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return clock }
+	mu.Lock()
+	breakers = make(map[string]*breaker)
+	mu.Unlock()
+	t.Cleanup(func() {
+		config.BreakerFailures, config.BreakerErrorRate, config.BreakerCooldown = oldFailures, oldRate, oldCooldown
+		now = time.Now
+	})
+	return &clock
+}
+
+// request runs one request through the breaker, reporting failure.
+func request(t *testing.T, upstream string, failure error) error {
+	t.Helper()
+	done, err := Allow(upstream)
+	if err != nil {
+		return err
+	}
+	done(failure)
+	return nil
+}
+
+func TestBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	clock := withBreakers(t, 3, 100, 30*time.Second)
+
+	for range 2 {
+		if err := request(t, "rdap.example", errDown); err != nil {
+			t.Fatalf("rejected before the threshold: %v", err)
+		}
+	}
+	// A success resets the run.
+	_ = request(t, "rdap.example", nil)
+	for range 3 {
+		_ = request(t, "rdap.example", errDown)
+	}
+
+	err := request(t, "rdap.example", nil)
+	var open *OpenError
+	if !errors.As(err, &open) || !errors.Is(err, utils.ErrUpstreamUnavailable) {
+		t.Fatalf("expected an open breaker, got %v", err)
+	}
+	if got := open.RetryAfter(); got != 30*time.Second {
+		t.Errorf("RetryAfter = %v, want 30s", got)
+	}
+	// Other upstreams are unaffected.
+	if err := request(t, "whois.example:43", nil); err != nil {
+		t.Errorf("unrelated upstream rejected: %v", err)
+	}
+
+	*clock = clock.Add(30 * time.Second)
+	if err := request(t, "rdap.example", nil); err != nil {
+		t.Fatalf("probe after the cooldown rejected: %v", err)
+	}
+	if err := request(t, "rdap.example", nil); err != nil {
+		t.Errorf("closed breaker rejected: %v", err)
+	}
+}
+
+func TestBreakerOpensOnErrorRate(t *testing.T) {
+	withBreakers(t, 100, 50, time.Minute)
+
+	// Alternating outcomes never fail twice in a row, but half of the
+	// window fails.
+	for i := range window {
+		var failure error
+		if i%2 == 1 {
+			failure = errDown
+		}
+		if err := request(t, "rdap.example", failure); err != nil {
+			t.Fatalf("request %d rejected early: %v", i, err)
+		}
+	}
+	if err := request(t, "rdap.example", nil); err == nil {
+		t.Error("breaker still closed at a 50% error rate")
+	}
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	clock := withBreakers(t, 1, 100, 10*time.Second)
+	_ = request(t, "rdap.example", errDown)
+	*clock = clock.Add(10 * time.Second)
+
+	// Only one probe goes through at a time.
+	done, err := Allow("rdap.example")
+	if err != nil {
+		t.Fatalf("probe rejected: %v", err)
+	}
+	if err := request(t, "rdap.example", nil); err == nil {
+		t.Error("second request let through while probing")
+	}
+
+	// A failed probe reopens the breaker for another cooldown.
+	done(errDown)
+	if err := request(t, "rdap.example", nil); err == nil {
+		t.Error("breaker closed after a failed probe")
+	}
+
+	// A probe whose caller went away, or that was never sent, decides
+	// nothing.
+	*clock = clock.Add(10 * time.Second)
+	for _, failure := range []error{context.Canceled, ErrNotSent} {
+		done, _ = Allow("rdap.example")
+		done(failure)
+		if status := Snapshot()[0]; status.State != "half-open" {
+			t.Errorf("state after a probe ending in %v = %s, want half-open", failure, status.State)
+		}
+	}
+	if err := request(t, "rdap.example", nil); err != nil {
+		t.Errorf("probe after a cancelled one rejected: %v", err)
+	}
+	if status := Snapshot()[0]; status.State != "closed" {
+		t.Errorf("state after a successful probe = %s, want closed", status.State)
+	}
+}
+
+func TestBreakerDisabled(t *testing.T) {
+	withBreakers(t, -1, 50, time.Minute)
+	for range 10 {
+		if err := request(t, "rdap.example", errDown); err != nil {
+			t.Fatalf("disabled breaker rejected: %v", err)
+		}
+	}
+}
+
+func TestSnapshotOrder(t *testing.T) {
+	withBreakers(t, 1, 100, time.Minute)
+	_ = request(t, "a.example", nil)
+	_ = request(t, "z.example", errDown)
+
+	got := Snapshot()
+	if len(got) != 2 || got[0].Upstream != "z.example" || got[0].State != "open" || got[0].RetryAt == "" {
+		t.Errorf("snapshot = %+v, want the open breaker first", got)
+	}
+	if got[0].LastFailure != errDown.Error() {
+		t.Errorf("lastFailure = %q", got[0].LastFailure)
+	}
+}
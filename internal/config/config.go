@@ -3,10 +3,13 @@ package config
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -18,8 +21,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/KincaidYang/whois/internal/serverlist"
 	"github.com/KincaidYang/whois/internal/utils"
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/text/encoding/htmlindex"
 	"golang.org/x/time/rate"
 	"gopkg.in/yaml.v3"
 )
@@ -41,8 +46,6 @@ var (
 	RedisClient *redis.Client
 	// CacheManager is the unified cache interface with fallback support
 	CacheManager utils.Cache
-	// CacheExpiration is the cache duration
-	CacheExpiration time.Duration
 	// HttpClient is used to set the timeout for rdapQuery. RDAP queries hit
 	// the same small set of registry servers repeatedly, so the transport
 	// keeps idle connections around for reuse instead of redialing.
@@ -61,34 +64,49 @@ var (
 	// RateLimit is used to set the number of concurrent requests
 	RateLimit          int
 	ConcurrencyLimiter chan struct{}
-	// ProxyServer is the proxy server
-	ProxyServer string
-	// ProxyUsername is the username for the proxy server
-	ProxyUsername string
-	// ProxyPassword is the password for the proxy server
-	ProxyPassword string
-	// ProxySuffixes is the list of TLDs that use a proxy server
-	ProxySuffixes []string
 	// Cache configuration
 	RequireRedis        bool
 	MemoryMaxSize       int
 	MemoryCleanInterval time.Duration
-	// NegativeCacheExpiration is how long not-found/denied results are cached.
-	NegativeCacheExpiration time.Duration
 	// BootstrapInterval is how often to refresh RDAP server lists from IANA.
 	BootstrapInterval time.Duration
+	// BootstrapWhoisInterval is how often to refresh the whole WHOIS server
+	// table from IANA; 0 disables it.
+	BootstrapWhoisInterval time.Duration
+	// BootstrapCacheFile and BootstrapCacheRedis are where the last-known-good
+	// IANA data is kept across restarts; see serverlist.BootstrapCache.
+	// BootstrapWhoisCacheFile is the same for the WHOIS server table.
+	BootstrapCacheFile      string
+	BootstrapWhoisCacheFile string
+	BootstrapCacheRedis     bool
+	// BootstrapWarnAge and BootstrapFailAge are the /ready bootstrap check's
+	// staleness thresholds; 0 disables each.
+	BootstrapWarnAge time.Duration
+	BootstrapFailAge time.Duration
+	// RDAPFollowRegistrar makes every RDAP domain query follow the registry's
+	// link to the registrar's RDAP record (default: false).
+	RDAPFollowRegistrar bool
+	// RDAPWhoisFallback retries a domain query over WHOIS when RDAP fails
+	// transiently (default: false).
+	RDAPWhoisFallback bool
+	// RDAPHedgeDelay is how long an RDAP query waits for a service's first
+	// base URL before also querying the next; 0 disables hedging.
+	RDAPHedgeDelay time.Duration
+	// BreakerFailures, BreakerErrorRate and BreakerCooldown are the circuit
+	// breaker thresholds (see the breaker package). BreakerFailures <= 0
+	// disables the breakers; Load maps an unset breaker.failures to 5, so
+	// only a configured negative value does.
+	BreakerFailures  int
+	BreakerErrorRate int
+	BreakerCooldown  time.Duration
+	// BGPTableFile is the BGP RIB dump IP and ASN responses are enriched
+	// from; empty disables the enrichment.
+	BGPTableFile string
+	// BGPReloadInterval is how often the RIB dump is checked for changes.
+	BGPReloadInterval time.Duration
 	// MCPLocalhostProtection enables DNS-rebinding protection on the /mcp
 	// endpoint. Defaults to false for reverse proxy deployments.
 	MCPLocalhostProtection bool
-	// AuthClients is the list of accepted API clients (key + display name +
-	// optional rate limit). Empty leaves the service open; non-empty enables
-	// authentication on every endpoint except /health and /ready.
-	AuthClients []AuthClient
-	// BatchEnabled turns on the POST /batch bulk-query endpoint and the MCP
-	// batch tool (default: false).
-	BatchEnabled bool
-	// BatchMaxItems caps how many queries one batch request may carry.
-	BatchMaxItems int
 )
 
 // authClientKey is the context key under which the authenticated client is
@@ -122,6 +140,14 @@ type AuthClient struct {
 	Limiter *rate.Limiter
 }
 
+// ID identifies the client by its key without revealing it: the hex SHA-256
+// of Key. Unlike Name, which several keys may share, it is unique per key,
+// and it stays the same across restarts, reloads and replicas.
+func (c *AuthClient) ID() string {
+	sum := sha256.Sum256([]byte(c.Key))
+	return hex.EncodeToString(sum[:])
+}
+
 // RequestTimeout bounds how long a single query may take, so a slow upstream
 // WHOIS/RDAP server cannot hold a concurrency slot indefinitely. It must stay
 // below the HTTP server's WriteTimeout (20s) so the handler returns first, and
@@ -154,7 +180,8 @@ var loadOnce sync.Once
 // Load reads the configuration file, applies environment overrides and
 // initializes all package state (logger, Redis client, cache manager).
 // It must be called once at startup before any other package is used;
-// repeated calls are no-ops. On configuration errors it logs and exits.
+// repeated calls are no-ops (Reload re-reads the file). On configuration
+// errors it logs and exits.
 func Load() {
 	loadOnce.Do(load)
 }
@@ -164,12 +191,12 @@ func load() {
 	initVersionInfo()
 
 	// Load configuration from file
-	data, ext, err := readConfigFile()
+	data, name, err := readConfigFile()
 	if err != nil {
 		slog.Error("failed to open configuration file", "err", err)
 		os.Exit(1)
 	}
-	config, err := parseConfig(data, ext)
+	config, err := parseConfig(data, strings.ToLower(filepath.Ext(name)))
 	if err != nil {
 		slog.Error("invalid configuration", "err", err)
 		os.Exit(1)
@@ -223,14 +250,10 @@ func load() {
 		redis.SetLogger(&discardLogger{})
 	}
 
-	// Set the cache expiration time
-	CacheExpiration = time.Duration(config.Cache.Expiration) * time.Second
-
 	// Set cache configuration
 	RequireRedis = config.Cache.RequireRedis
 	MemoryMaxSize = config.Cache.MemoryMaxSize
 	MemoryCleanInterval = time.Duration(config.Cache.MemoryCleanInterval) * time.Second
-	NegativeCacheExpiration = time.Duration(config.Cache.NegativeExpiration) * time.Second
 
 	// Initialize cache manager with fallback
 	initializeCacheManager()
@@ -242,34 +265,42 @@ func load() {
 	RateLimit = config.Server.RateLimit
 	ConcurrencyLimiter = make(chan struct{}, RateLimit)
 
-	// Set the proxy server. Suffixes are lowercased to match the lookup
-	// side, which normalizes every queried resource to lowercase — an
-	// uppercase suffix in the config would otherwise never match.
-	ProxyServer = config.Proxy.Server
-	ProxyUsername = config.Proxy.Username
-	ProxyPassword = config.Proxy.Password
-	ProxySuffixes = lowercaseAll(config.Proxy.Suffixes)
-
 	// Set the bootstrap interval
 	BootstrapInterval = time.Duration(config.Bootstrap.Interval) * time.Second
+	BootstrapWhoisInterval = time.Duration(config.Bootstrap.WhoisInterval) * time.Second
+	BootstrapCacheFile = config.Bootstrap.CacheFile
+	BootstrapWhoisCacheFile = config.Bootstrap.WhoisCacheFile
+	BootstrapCacheRedis = config.Bootstrap.CacheRedis
+	BootstrapWarnAge = time.Duration(config.Bootstrap.WarnAge) * time.Second
+	BootstrapFailAge = time.Duration(config.Bootstrap.FailAge) * time.Second
+
+	// Set RDAP query options
+	RDAPFollowRegistrar = config.RDAP.FollowRegistrar
+	RDAPWhoisFallback = config.RDAP.WhoisFallback
+	RDAPHedgeDelay = time.Duration(config.RDAP.HedgeDelay) * time.Millisecond
+	BreakerFailures = config.Breaker.Failures
+	BreakerErrorRate = config.Breaker.ErrorRate
+	BreakerCooldown = time.Duration(config.Breaker.Cooldown) * time.Second
+
+	// Set the BGP RIB dump
+	BGPTableFile = config.BGP.TableFile
+	BGPReloadInterval = time.Duration(config.BGP.ReloadInterval) * time.Second
 
 	// Set MCP endpoint options
 	MCPLocalhostProtection = config.MCP.LocalhostProtection
 
-	// Set batch endpoint options
-	BatchEnabled = config.Batch.Enabled
-	BatchMaxItems = config.Batch.MaxItems
-
-	// Set API authentication clients
-	authClients, err := normalizeAuthClients(config.Auth.Keys)
+	// Install the reloadable settings: auth clients, proxy, cache TTLs,
+	// batch and job limits, server overrides
+	s, err := newSettings(&config, nil)
 	if err != nil {
 		slog.Error("invalid configuration", "err", err)
 		os.Exit(1)
 	}
-	AuthClients = authClients
-	if len(AuthClients) > 0 {
-		names := make([]string, len(AuthClients))
-		for i, c := range AuthClients {
+	install(s)
+	loaded, loadedFile, loadedStat = config, name, stampOf(name)
+	if len(s.AuthClients) > 0 {
+		names := make([]string, len(s.AuthClients))
+		for i, c := range s.AuthClients {
 			names[i] = c.Name
 		}
 		slog.Info("API key authentication enabled", "clients", names)
@@ -371,6 +402,42 @@ func applyDefaults(config *Config) {
 	if config.Batch.MaxItems == 0 {
 		config.Batch.MaxItems = 10
 	}
+
+	// Default job size cap: 10000 queries per job
+	if config.Jobs.MaxItems == 0 {
+		config.Jobs.MaxItems = 10000
+	}
+
+	// Default: keep job results for a day
+	if config.Jobs.Retention == 0 {
+		config.Jobs.Retention = 86400
+	}
+
+	// Default: 8 jobs running per instance, 2 of them per API key
+	if config.Jobs.MaxRunning == 0 {
+		config.Jobs.MaxRunning = 8
+	}
+	if config.Jobs.MaxRunningPerKey == 0 {
+		config.Jobs.MaxRunningPerKey = 2
+	}
+
+	// Default: open a circuit breaker after 5 consecutive failures or a 50%
+	// error rate, and probe the upstream again after 30 seconds. A negative
+	// breaker.failures disables the breakers; only 0 (unset) gets the default.
+	if config.Breaker.Failures == 0 {
+		config.Breaker.Failures = 5
+	}
+	if config.Breaker.ErrorRate == 0 {
+		config.Breaker.ErrorRate = 50
+	}
+	if config.Breaker.Cooldown == 0 {
+		config.Breaker.Cooldown = 30
+	}
+
+	// Default: check the BGP RIB dump for changes every minute
+	if config.BGP.ReloadInterval == 0 {
+		config.BGP.ReloadInterval = 60
+	}
 }
 
 // validateConfig rejects negative values in numeric settings after defaults
@@ -380,6 +447,9 @@ func applyDefaults(config *Config) {
 // negative batch.maxItems rejects every batch request). cache.negativeExpiration
 // is exempt: negative is its documented "disable" value.
 //
+// servers.rdap and servers.whois entries must have well-formed keys and
+// servers, so a typo fails startup instead of routing a TLD nowhere.
+//
 // A configured proxy.server must be a usable proxy URL. An invalid one used
 // to be dropped silently at first use, quietly sending traffic the operator
 // meant to proxy over the direct route instead; failing startup keeps that
@@ -395,18 +465,50 @@ func validateConfig(config *Config) error {
 		{"cache.memoryMaxSize", config.Cache.MemoryMaxSize},
 		{"cache.memoryCleanInterval", config.Cache.MemoryCleanInterval},
 		{"bootstrap.interval", config.Bootstrap.Interval},
+		{"bootstrap.whoisInterval", config.Bootstrap.WhoisInterval},
+		{"bootstrap.warnAge", config.Bootstrap.WarnAge},
+		{"bootstrap.failAge", config.Bootstrap.FailAge},
 		{"batch.maxItems", config.Batch.MaxItems},
+		{"jobs.maxItems", config.Jobs.MaxItems},
+		{"jobs.retention", config.Jobs.Retention},
+		{"jobs.maxRunning", config.Jobs.MaxRunning},
+		{"jobs.maxRunningPerKey", config.Jobs.MaxRunningPerKey},
+		{"bgp.reloadInterval", config.BGP.ReloadInterval},
+		{"rdap.hedgeDelay", config.RDAP.HedgeDelay},
+		{"breaker.errorRate", config.Breaker.ErrorRate},
+		{"breaker.cooldown", config.Breaker.Cooldown},
 	}
 	for _, c := range checks {
 		if c.value < 0 {
 			return fmt.Errorf("%s must not be negative (got %d)", c.name, c.value)
 		}
 	}
+	if config.Breaker.ErrorRate > 100 {
+		return fmt.Errorf("breaker.errorRate is a percentage and must not exceed 100 (got %d)", config.Breaker.ErrorRate)
+	}
+	if config.Bootstrap.WarnAge > 0 && config.Bootstrap.FailAge > 0 && config.Bootstrap.WarnAge >= config.Bootstrap.FailAge {
+		return fmt.Errorf("bootstrap.warnAge (%d) must be less than bootstrap.failAge (%d)", config.Bootstrap.WarnAge, config.Bootstrap.FailAge)
+	}
 	if config.Proxy.Server != "" {
 		if err := validateProxyURL(config.Proxy.Server); err != nil {
 			return fmt.Errorf("proxy.server: %w", err)
 		}
 	}
+	if _, err := normalizeServers("servers.rdap", config.Servers.RDAP, serverlist.NormalizeRdapKey, normalizeRdapURL); err != nil {
+		return err
+	}
+	if _, err := normalizeServers("servers.whois", config.Servers.Whois, serverlist.NormalizeWhoisKey, normalizeWhoisAddr); err != nil {
+		return err
+	}
+	if _, err := normalizeServerLimits(config.Servers.Limits); err != nil {
+		return err
+	}
+	if _, err := normalizeServers("servers.charsets", config.Servers.Charsets, serverlist.NormalizeWhoisKey, normalizeCharset); err != nil {
+		return err
+	}
+	if _, err := normalizeWhoisQueries(config.Servers.Queries); err != nil {
+		return err
+	}
 	if config.Cache.RequireRedis && config.Redis.Addr == "" {
 		return fmt.Errorf("cache.requireRedis is true but redis.addr is empty (Redis disabled); set redis.addr or turn requireRedis off")
 	}
@@ -440,6 +542,194 @@ func validateProxyURL(s string) error {
 	return nil
 }
 
+// normalizeServers validates one servers.* section and returns it with keys
+// normalized by normalizeKey and values by normalizeValue. Two keys that
+// normalize to the same one ("IO" and "io") are rejected as ambiguous.
+func normalizeServers(section string, servers map[string]string, normalizeKey, normalizeValue func(string) (string, error)) (map[string]string, error) {
+	if len(servers) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(servers))
+	for key := range servers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	out := make(map[string]string, len(servers))
+	for _, key := range keys {
+		normalized, err := normalizeKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", section, err)
+		}
+		if _, dup := out[normalized]; dup {
+			return nil, fmt.Errorf("%s: duplicate entry for %q", section, normalized)
+		}
+		value, err := normalizeValue(servers[key])
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", section, key, err)
+		}
+		out[normalized] = value
+	}
+	return out, nil
+}
+
+// serverTLDPrefix marks servers.limits and servers.queries keys naming a
+// domain suffix rather than a server host.
+const serverTLDPrefix = "tld:"
+
+// normalizeServerLimits validates servers.limits and returns it with keys
+// lowercased ("tld:" keys normalized like servers.whois keys) and Burst
+// defaulted to 1 where a rate is set.
+func normalizeServerLimits(limits map[string]ServerLimit) (map[string]ServerLimit, error) {
+	if len(limits) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(limits))
+	for key := range limits {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	out := make(map[string]ServerLimit, len(limits))
+	for _, key := range keys {
+		normalized, err := normalizeServerKey("servers.limits", key)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := out[normalized]; dup {
+			return nil, fmt.Errorf("servers.limits: duplicate entry for %q", normalized)
+		}
+
+		limit := limits[key]
+		if limit.Rate < 0 || limit.Burst < 0 || limit.Concurrency < 0 {
+			return nil, fmt.Errorf("servers.limits.%s: rate, burst and concurrency must not be negative", key)
+		}
+		if limit.Rate == 0 && limit.Concurrency == 0 {
+			return nil, fmt.Errorf("servers.limits.%s: set rate, concurrency or both", key)
+		}
+		if limit.Rate > 0 && limit.Burst == 0 {
+			limit.Burst = 1
+		}
+		out[normalized] = limit
+	}
+	return out, nil
+}
+
+// normalizeServerKey normalizes a key of a section keyed by server host name
+// or by "tld:" and a domain suffix: lowercased, the suffix normalized like a
+// servers.whois key.
+func normalizeServerKey(section, key string) (string, error) {
+	if suffix, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(key)), serverTLDPrefix); ok {
+		s, err := serverlist.NormalizeWhoisKey(suffix)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", section, err)
+		}
+		return serverTLDPrefix + s, nil
+	}
+	host, err := normalizeWhoisAddr(key)
+	if err != nil || strings.Contains(host, ":") && net.ParseIP(host) == nil {
+		return "", fmt.Errorf("%s: invalid key %q (want a server host name or tld:<suffix>)", section, key)
+	}
+	return strings.TrimSuffix(host, "."), nil
+}
+
+// normalizeWhoisQueries validates servers.queries and returns it with keys
+// normalized like servers.limits keys, names lowercased and Query defaulted
+// to the plain domain.
+func normalizeWhoisQueries(queries map[string]WhoisQuery) (map[string]WhoisQuery, error) {
+	if len(queries) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(queries))
+	for key := range queries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	out := make(map[string]WhoisQuery, len(queries))
+	for _, key := range keys {
+		normalized, err := normalizeServerKey("servers.queries", key)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := out[normalized]; dup {
+			return nil, fmt.Errorf("servers.queries: duplicate entry for %q", normalized)
+		}
+
+		q := queries[key]
+		if q.Query == "" {
+			q.Query = WhoisQueryDomain
+		}
+		if !strings.Contains(q.Query, WhoisQueryDomain) {
+			return nil, fmt.Errorf("servers.queries.%s: query %q does not contain %s", key, q.Query, WhoisQueryDomain)
+		}
+		if strings.ContainsAny(q.Query, "\r\n") {
+			return nil, fmt.Errorf("servers.queries.%s: query must be a single line", key)
+		}
+		if q.Port < 0 || q.Port > 65535 {
+			return nil, fmt.Errorf("servers.queries.%s: port %d out of range", key, q.Port)
+		}
+		q.Name = strings.ToLower(strings.TrimSpace(q.Name))
+		out[normalized] = q
+	}
+	return out, nil
+}
+
+// normalizeRdapURL checks that s is an absolute http(s) URL with a host and
+// returns it ending in a slash, as the query paths are appended to it.
+func normalizeRdapURL(s string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %q: %w", s, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("unsupported scheme in %q (use http or https)", s)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("missing host in %q", s)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("%q must not carry a query or fragment", s)
+	}
+	out := u.String()
+	if !strings.HasSuffix(out, "/") {
+		out += "/"
+	}
+	return out, nil
+}
+
+// normalizeCharset checks that s names a charset WHOIS answers can be
+// decoded from and returns its canonical name ("latin1" → "windows-1252").
+func normalizeCharset(s string) (string, error) {
+	enc, err := htmlindex.Get(strings.TrimSpace(s))
+	if err != nil {
+		return "", fmt.Errorf("unknown charset %q", s)
+	}
+	return htmlindex.Name(enc)
+}
+
+// normalizeWhoisAddr checks that s is a WHOIS server host, optionally with a
+// port, and returns it lowercased.
+func normalizeWhoisAddr(s string) (string, error) {
+	addr := strings.ToLower(strings.TrimSpace(s))
+	host := addr
+	if h, port, err := net.SplitHostPort(addr); err == nil {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return "", fmt.Errorf("invalid port in %q", s)
+		}
+		host = h
+	}
+	if net.ParseIP(host) != nil {
+		return addr, nil
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(host, "."), ".") {
+		if label == "" || strings.Trim(label, "abcdefghijklmnopqrstuvwxyz0123456789-") != "" {
+			return "", fmt.Errorf("invalid WHOIS server %q (want host or host:port)", s)
+		}
+	}
+	return addr, nil
+}
+
 // initializeCacheManager sets up the cache: Redis primary with memory fallback,
 // or memory alone when Redis is disabled (empty redis.addr).
 func initializeCacheManager() {
@@ -471,12 +761,12 @@ func initializeCacheManager() {
 }
 
 // readConfigFile reads config.yaml (or config.json) and returns the raw bytes
-// together with the file extension that selects the parser.
+// together with the file's name, whose extension selects the parser.
 func readConfigFile() ([]byte, string, error) {
 	for _, name := range []string{"config.yaml", "config.yml", "config.json"} {
 		data, err := os.ReadFile(name)
 		if err == nil {
-			return data, strings.ToLower(filepath.Ext(name)), nil
+			return data, name, nil
 		}
 		if !os.IsNotExist(err) {
 			return nil, "", err
@@ -512,7 +802,8 @@ var legacyKeys = map[string]string{
 var groupKeys = map[string]bool{
 	"server": true, "log": true, "cache": true, "redis": true,
 	"proxy": true, "bootstrap": true, "mcp": true, "auth": true,
-	"batch": true,
+	"batch": true, "jobs": true, "rdap": true, "bgp": true, "servers": true,
+	"breaker": true,
 }
 
 // detectLegacyKeys returns an error describing every pre-v0.9 key found in
@@ -612,6 +903,24 @@ func parseBoolEnv(name, val string, current bool) bool {
 	}
 }
 
+// mergeServerPairs returns servers overlaid with the "key=server,..." pairs
+// in val. A pair without "=" is kept with an empty server, so validateConfig
+// rejects it rather than the entry vanishing silently.
+func mergeServerPairs(servers map[string]string, val string) map[string]string {
+	out := make(map[string]string, len(servers))
+	for k, v := range servers {
+		out[k] = v
+	}
+	for _, pair := range strings.Split(val, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		key, server, _ := strings.Cut(pair, "=")
+		out[strings.TrimSpace(key)] = strings.TrimSpace(server)
+	}
+	return out
+}
+
 func overrideConfigWithEnv(config *Config) {
 	// Override Redis configuration. WHOIS_REDIS_ADDR distinguishes "set to
 	// empty" from "unset": an explicitly empty value disables Redis (memory-only
@@ -678,6 +987,30 @@ func overrideConfigWithEnv(config *Config) {
 			config.Bootstrap.Interval = intervalInt
 		}
 	}
+	if whoisInterval := os.Getenv("WHOIS_BOOTSTRAP_WHOIS_INTERVAL"); whoisInterval != "" {
+		if intervalInt, err := strconv.Atoi(whoisInterval); err == nil {
+			config.Bootstrap.WhoisInterval = intervalInt
+		}
+	}
+	if cacheFile := os.Getenv("WHOIS_BOOTSTRAP_CACHE_FILE"); cacheFile != "" {
+		config.Bootstrap.CacheFile = cacheFile
+	}
+	if whoisCacheFile := os.Getenv("WHOIS_BOOTSTRAP_WHOIS_CACHE_FILE"); whoisCacheFile != "" {
+		config.Bootstrap.WhoisCacheFile = whoisCacheFile
+	}
+	if cacheRedis := os.Getenv("WHOIS_BOOTSTRAP_CACHE_REDIS"); cacheRedis != "" {
+		config.Bootstrap.CacheRedis = parseBoolEnv("WHOIS_BOOTSTRAP_CACHE_REDIS", cacheRedis, config.Bootstrap.CacheRedis)
+	}
+	if warnAge := os.Getenv("WHOIS_BOOTSTRAP_WARN_AGE"); warnAge != "" {
+		if warnAgeInt, err := strconv.Atoi(warnAge); err == nil {
+			config.Bootstrap.WarnAge = warnAgeInt
+		}
+	}
+	if failAge := os.Getenv("WHOIS_BOOTSTRAP_FAIL_AGE"); failAge != "" {
+		if failAgeInt, err := strconv.Atoi(failAge); err == nil {
+			config.Bootstrap.FailAge = failAgeInt
+		}
+	}
 
 	// Override proxy configuration
 	if proxyServer := os.Getenv("WHOIS_PROXY_SERVER"); proxyServer != "" {
@@ -709,6 +1042,81 @@ func overrideConfigWithEnv(config *Config) {
 		}
 	}
 
+	// Override asynchronous job configuration
+	if jobsEnabled := os.Getenv("WHOIS_JOBS_ENABLED"); jobsEnabled != "" {
+		config.Jobs.Enabled = parseBoolEnv("WHOIS_JOBS_ENABLED", jobsEnabled, config.Jobs.Enabled)
+	}
+	if jobsMaxItems := os.Getenv("WHOIS_JOBS_MAX_ITEMS"); jobsMaxItems != "" {
+		if maxItems, err := strconv.Atoi(jobsMaxItems); err == nil {
+			config.Jobs.MaxItems = maxItems
+		}
+	}
+	if jobsRetention := os.Getenv("WHOIS_JOBS_RETENTION"); jobsRetention != "" {
+		if retention, err := strconv.Atoi(jobsRetention); err == nil {
+			config.Jobs.Retention = retention
+		}
+	}
+	if jobsMaxRunning := os.Getenv("WHOIS_JOBS_MAX_RUNNING"); jobsMaxRunning != "" {
+		if maxRunning, err := strconv.Atoi(jobsMaxRunning); err == nil {
+			config.Jobs.MaxRunning = maxRunning
+		}
+	}
+	if jobsMaxRunningPerKey := os.Getenv("WHOIS_JOBS_MAX_RUNNING_PER_KEY"); jobsMaxRunningPerKey != "" {
+		if maxRunning, err := strconv.Atoi(jobsMaxRunningPerKey); err == nil {
+			config.Jobs.MaxRunningPerKey = maxRunning
+		}
+	}
+
+	// Override upstream servers (comma-separated key=server pairs, merged
+	// over the config file's entries key by key)
+	if rdapServers := os.Getenv("WHOIS_SERVERS_RDAP"); rdapServers != "" {
+		config.Servers.RDAP = mergeServerPairs(config.Servers.RDAP, rdapServers)
+	}
+	if whoisServers := os.Getenv("WHOIS_SERVERS_WHOIS"); whoisServers != "" {
+		config.Servers.Whois = mergeServerPairs(config.Servers.Whois, whoisServers)
+	}
+	if charsets := os.Getenv("WHOIS_SERVERS_CHARSETS"); charsets != "" {
+		config.Servers.Charsets = mergeServerPairs(config.Servers.Charsets, charsets)
+	}
+
+	// Override RDAP query options
+	if follow := os.Getenv("WHOIS_RDAP_FOLLOW_REGISTRAR"); follow != "" {
+		config.RDAP.FollowRegistrar = parseBoolEnv("WHOIS_RDAP_FOLLOW_REGISTRAR", follow, config.RDAP.FollowRegistrar)
+	}
+	if fallback := os.Getenv("WHOIS_RDAP_WHOIS_FALLBACK"); fallback != "" {
+		config.RDAP.WhoisFallback = parseBoolEnv("WHOIS_RDAP_WHOIS_FALLBACK", fallback, config.RDAP.WhoisFallback)
+	}
+	if hedgeDelay := os.Getenv("WHOIS_RDAP_HEDGE_DELAY"); hedgeDelay != "" {
+		if hedgeDelayInt, err := strconv.Atoi(hedgeDelay); err == nil {
+			config.RDAP.HedgeDelay = hedgeDelayInt
+		}
+	}
+
+	// Override BGP RIB dump configuration
+	if tableFile := os.Getenv("WHOIS_BGP_TABLE_FILE"); tableFile != "" {
+		config.BGP.TableFile = tableFile
+	}
+	if reloadInterval := os.Getenv("WHOIS_BGP_RELOAD_INTERVAL"); reloadInterval != "" {
+		if intervalInt, err := strconv.Atoi(reloadInterval); err == nil {
+			config.BGP.ReloadInterval = intervalInt
+		}
+	}
+	if failures := os.Getenv("WHOIS_BREAKER_FAILURES"); failures != "" {
+		if failuresInt, err := strconv.Atoi(failures); err == nil {
+			config.Breaker.Failures = failuresInt
+		}
+	}
+	if errorRate := os.Getenv("WHOIS_BREAKER_ERROR_RATE"); errorRate != "" {
+		if errorRateInt, err := strconv.Atoi(errorRate); err == nil {
+			config.Breaker.ErrorRate = errorRateInt
+		}
+	}
+	if cooldown := os.Getenv("WHOIS_BREAKER_COOLDOWN"); cooldown != "" {
+		if cooldownInt, err := strconv.Atoi(cooldown); err == nil {
+			config.Breaker.Cooldown = cooldownInt
+		}
+	}
+
 	if logLevel := os.Getenv("WHOIS_LOG_LEVEL"); logLevel != "" {
 		config.Log.Level = logLevel
 	}
@@ -25,16 +25,39 @@ func TestOverrideConfigWithEnvAllFields(t *testing.T) {
 	t.Setenv("WHOIS_NEGATIVE_CACHE_EXPIRATION", "30")
 	t.Setenv("WHOIS_PORT", "9999")
 	t.Setenv("WHOIS_RATE_LIMIT", "77")
+	t.Setenv("WHOIS_BOOTSTRAP_WHOIS_INTERVAL", "604800")
+	t.Setenv("WHOIS_BOOTSTRAP_CACHE_FILE", "/data/bootstrap.json")
+	t.Setenv("WHOIS_BOOTSTRAP_WHOIS_CACHE_FILE", "/data/whois.json")
+	t.Setenv("WHOIS_BOOTSTRAP_CACHE_REDIS", "true")
+	t.Setenv("WHOIS_BOOTSTRAP_WARN_AGE", "172800")
+	t.Setenv("WHOIS_BOOTSTRAP_FAIL_AGE", "604800")
 	t.Setenv("WHOIS_PROXY_SERVER", "socks5://proxy.example:1080")
 	t.Setenv("WHOIS_PROXY_USERNAME", "user")
 	t.Setenv("WHOIS_PROXY_PASSWORD", "pass")
 	t.Setenv("WHOIS_BATCH_ENABLED", "true")
 	t.Setenv("WHOIS_BATCH_MAX_ITEMS", "42")
+	t.Setenv("WHOIS_JOBS_ENABLED", "true")
+	t.Setenv("WHOIS_JOBS_MAX_ITEMS", "2000")
+	t.Setenv("WHOIS_JOBS_MAX_RUNNING", "12")
+	t.Setenv("WHOIS_JOBS_MAX_RUNNING_PER_KEY", "3")
+	t.Setenv("WHOIS_JOBS_RETENTION", "3600")
 	t.Setenv("WHOIS_LOG_LEVEL", "debug")
 	t.Setenv("WHOIS_MCP_LOCALHOST_PROTECTION", "false")
+	t.Setenv("WHOIS_RDAP_FOLLOW_REGISTRAR", "true")
+	t.Setenv("WHOIS_RDAP_WHOIS_FALLBACK", "true")
+	t.Setenv("WHOIS_RDAP_HEDGE_DELAY", "500")
+	t.Setenv("WHOIS_BGP_TABLE_FILE", "/data/rib.mrt")
+	t.Setenv("WHOIS_BGP_RELOAD_INTERVAL", "30")
+	t.Setenv("WHOIS_BREAKER_FAILURES", "-1")
+	t.Setenv("WHOIS_BREAKER_ERROR_RATE", "75")
+	t.Setenv("WHOIS_BREAKER_COOLDOWN", "10")
+	t.Setenv("WHOIS_SERVERS_RDAP", "io=https://rdap.nic.io/, 192.0.2.0/24=https://rdap.example.net/")
+	t.Setenv("WHOIS_SERVERS_WHOIS", "io=whois.nic.io")
+	t.Setenv("WHOIS_SERVERS_CHARSETS", "kr=euc-kr")
 
 	var cfg Config
 	cfg.MCP.LocalhostProtection = true
+	cfg.Servers.RDAP = map[string]string{"io": "https://old.example/", "de": "https://rdap.denic.de/"}
 	overrideConfigWithEnv(&cfg)
 
 	checks := []struct {
@@ -54,13 +77,37 @@ func TestOverrideConfigWithEnvAllFields(t *testing.T) {
 		{"cache.negativeExpiration", cfg.Cache.NegativeExpiration, 30},
 		{"server.port", cfg.Server.Port, 9999},
 		{"server.rateLimit", cfg.Server.RateLimit, 77},
+		{"bootstrap.whoisInterval", cfg.Bootstrap.WhoisInterval, 604800},
+		{"bootstrap.cacheFile", cfg.Bootstrap.CacheFile, "/data/bootstrap.json"},
+		{"bootstrap.whoisCacheFile", cfg.Bootstrap.WhoisCacheFile, "/data/whois.json"},
+		{"bootstrap.cacheRedis", cfg.Bootstrap.CacheRedis, true},
+		{"bootstrap.warnAge", cfg.Bootstrap.WarnAge, 172800},
+		{"bootstrap.failAge", cfg.Bootstrap.FailAge, 604800},
 		{"proxy.server", cfg.Proxy.Server, "socks5://proxy.example:1080"},
 		{"proxy.username", cfg.Proxy.Username, "user"},
 		{"proxy.password", cfg.Proxy.Password, "pass"},
 		{"batch.enabled", cfg.Batch.Enabled, true},
 		{"batch.maxItems", cfg.Batch.MaxItems, 42},
+		{"jobs.enabled", cfg.Jobs.Enabled, true},
+		{"jobs.maxItems", cfg.Jobs.MaxItems, 2000},
+		{"jobs.retention", cfg.Jobs.Retention, 3600},
+		{"jobs.maxRunning", cfg.Jobs.MaxRunning, 12},
+		{"jobs.maxRunningPerKey", cfg.Jobs.MaxRunningPerKey, 3},
 		{"log.level", cfg.Log.Level, "debug"},
 		{"mcp.localhostProtection", cfg.MCP.LocalhostProtection, false},
+		{"rdap.followRegistrar", cfg.RDAP.FollowRegistrar, true},
+		{"rdap.whoisFallback", cfg.RDAP.WhoisFallback, true},
+		{"rdap.hedgeDelay", cfg.RDAP.HedgeDelay, 500},
+		{"bgp.tableFile", cfg.BGP.TableFile, "/data/rib.mrt"},
+		{"bgp.reloadInterval", cfg.BGP.ReloadInterval, 30},
+		{"breaker.failures", cfg.Breaker.Failures, -1},
+		{"breaker.errorRate", cfg.Breaker.ErrorRate, 75},
+		{"breaker.cooldown", cfg.Breaker.Cooldown, 10},
+		{"servers.rdap.io", cfg.Servers.RDAP["io"], "https://rdap.nic.io/"},
+		{"servers.rdap.192.0.2.0/24", cfg.Servers.RDAP["192.0.2.0/24"], "https://rdap.example.net/"},
+		{"servers.rdap.de", cfg.Servers.RDAP["de"], "https://rdap.denic.de/"}, // merged, not replaced
+		{"servers.whois.io", cfg.Servers.Whois["io"], "whois.nic.io"},
+		{"servers.charsets.kr", cfg.Servers.Charsets["kr"], "euc-kr"},
 	}
 	for _, c := range checks {
 		if c.got != c.want {
@@ -133,9 +180,9 @@ func TestReadConfigFile(t *testing.T) {
 		if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("server:\n  port: 1\n"), 0o644); err != nil {
 			t.Fatal(err)
 		}
-		data, ext, err := readConfigFile()
-		if err != nil || ext != ".yaml" || len(data) == 0 {
-			t.Errorf("got ext %q err %v, want .yaml", ext, err)
+		data, name, err := readConfigFile()
+		if err != nil || name != "config.yaml" || len(data) == 0 {
+			t.Errorf("got %q err %v, want config.yaml", name, err)
 		}
 	})
 
@@ -145,9 +192,9 @@ func TestReadConfigFile(t *testing.T) {
 		if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte("{}"), 0o644); err != nil {
 			t.Fatal(err)
 		}
-		_, ext, err := readConfigFile()
-		if err != nil || ext != ".json" {
-			t.Errorf("got ext %q err %v, want .json", ext, err)
+		_, name, err := readConfigFile()
+		if err != nil || name != "config.json" {
+			t.Errorf("got %q err %v, want config.json", name, err)
 		}
 	})
 
@@ -3,6 +3,8 @@ package config
 import (
 	"strings"
 	"testing"
+
+	"github.com/KincaidYang/whois/internal/serverlist"
 )
 
 const validYAML = `
@@ -30,6 +32,44 @@ proxy:
   suffixes: ["cn", "jp"]
 bootstrap:
   interval: 3600
+  whoisInterval: 604800
+  cacheFile: /var/lib/whois/bootstrap.json
+  whoisCacheFile: /var/lib/whois/whois.json
+  cacheRedis: true
+  warnAge: 172800
+  failAge: 604800
+rdap:
+  followRegistrar: true
+  whoisFallback: true
+  hedgeDelay: 800
+servers:
+  rdap:
+    io: "https://rdap.nic.io/"
+    "192.0.2.0/24": "https://rdap.example.net/"
+    "64496-64511": "https://rdap.example.net/"
+  whois:
+    io: "whois.nic.io"
+  limits:
+    whois.denic.de: {rate: 1, burst: 3, concurrency: 2}
+    "tld:jp": {rate: 0.5}
+  charsets:
+    cn: gbk
+  queries:
+    whois.jprs.jp: {query: "{domain}/e", name: jprs-en}
+    "tld:zz": {port: 4343, greeting: true, halfClose: true}
+bgp:
+  tableFile: "/var/lib/whois/rib.gz"
+  reloadInterval: 300
+breaker:
+  failures: 3
+  errorRate: 80
+  cooldown: 45
+jobs:
+  enabled: true
+  maxItems: 500
+  retention: 600
+  maxRunning: 3
+  maxRunningPerKey: 1
 auth:
   keys: ["key-one", "key-two"]
 mcp:
@@ -60,6 +100,48 @@ func TestParseConfigYAML(t *testing.T) {
 	if cfg.Bootstrap.Interval != 3600 {
 		t.Errorf("bootstrap.interval: %d", cfg.Bootstrap.Interval)
 	}
+	if cfg.Bootstrap.WhoisInterval != 604800 {
+		t.Errorf("bootstrap.whoisInterval: %d", cfg.Bootstrap.WhoisInterval)
+	}
+	if cfg.Bootstrap.CacheFile != "/var/lib/whois/bootstrap.json" || cfg.Bootstrap.WhoisCacheFile != "/var/lib/whois/whois.json" || !cfg.Bootstrap.CacheRedis {
+		t.Errorf("bootstrap cache: %q, %q, %v", cfg.Bootstrap.CacheFile, cfg.Bootstrap.WhoisCacheFile, cfg.Bootstrap.CacheRedis)
+	}
+	if cfg.Bootstrap.WarnAge != 172800 || cfg.Bootstrap.FailAge != 604800 {
+		t.Errorf("bootstrap ages: %d, %d", cfg.Bootstrap.WarnAge, cfg.Bootstrap.FailAge)
+	}
+	if !cfg.RDAP.FollowRegistrar {
+		t.Errorf("rdap.followRegistrar: false")
+	}
+	if !cfg.RDAP.WhoisFallback {
+		t.Errorf("rdap.whoisFallback: false")
+	}
+	if cfg.RDAP.HedgeDelay != 800 {
+		t.Errorf("rdap.hedgeDelay: %d", cfg.RDAP.HedgeDelay)
+	}
+	if len(cfg.Servers.RDAP) != 3 || cfg.Servers.RDAP["192.0.2.0/24"] != "https://rdap.example.net/" || cfg.Servers.Whois["io"] != "whois.nic.io" {
+		t.Errorf("servers: %+v", cfg.Servers)
+	}
+	if l := cfg.Servers.Limits["whois.denic.de"]; l.Rate != 1 || l.Burst != 3 || l.Concurrency != 2 || cfg.Servers.Limits["tld:jp"].Rate != 0.5 {
+		t.Errorf("servers.limits: %+v", cfg.Servers.Limits)
+	}
+	if cfg.Servers.Charsets["cn"] != "gbk" {
+		t.Errorf("servers.charsets: %+v", cfg.Servers.Charsets)
+	}
+	if q := cfg.Servers.Queries["whois.jprs.jp"]; q.Query != "{domain}/e" || q.Name != "jprs-en" {
+		t.Errorf("servers.queries: %+v", cfg.Servers.Queries)
+	}
+	if q := cfg.Servers.Queries["tld:zz"]; q.Port != 4343 || !q.Greeting || !q.HalfClose {
+		t.Errorf("servers.queries: %+v", cfg.Servers.Queries)
+	}
+	if cfg.BGP.TableFile != "/var/lib/whois/rib.gz" || cfg.BGP.ReloadInterval != 300 {
+		t.Errorf("bgp: %+v", cfg.BGP)
+	}
+	if cfg.Breaker.Failures != 3 || cfg.Breaker.ErrorRate != 80 || cfg.Breaker.Cooldown != 45 {
+		t.Errorf("breaker: %+v", cfg.Breaker)
+	}
+	if !cfg.Jobs.Enabled || cfg.Jobs.MaxItems != 500 || cfg.Jobs.Retention != 600 || cfg.Jobs.MaxRunning != 3 || cfg.Jobs.MaxRunningPerKey != 1 {
+		t.Errorf("jobs: %+v", cfg.Jobs)
+	}
 	if !cfg.MCP.LocalhostProtection {
 		t.Errorf("mcp.localhostProtection: false")
 	}
@@ -152,6 +234,22 @@ func TestNormalizeAuthClients(t *testing.T) {
 	}
 }
 
+// TestAuthClientID verifies the client ID follows the key, not the name, and
+// does not contain the key.
+func TestAuthClientID(t *testing.T) {
+	a := AuthClient{Key: "key-one", Name: "shared"}
+	b := AuthClient{Key: "key-two", Name: "shared"}
+	if a.ID() == b.ID() {
+		t.Errorf("two keys with the same name share ID %s", a.ID())
+	}
+	if again := (&AuthClient{Key: "key-one", Name: "renamed"}).ID(); again != a.ID() {
+		t.Errorf("ID changed with the name: %s, then %s", a.ID(), again)
+	}
+	if strings.Contains(a.ID(), a.Key) {
+		t.Errorf("ID %s reveals the key", a.ID())
+	}
+}
+
 func TestEnvOverrideAuthKeys(t *testing.T) {
 	t.Setenv("WHOIS_AUTH_KEYS", "k1, k2 ,,k3")
 	var cfg Config
@@ -302,7 +400,13 @@ func TestValidateConfigNegativeValues(t *testing.T) {
 		{"cache.memoryMaxSize", func(c *Config) { c.Cache.MemoryMaxSize = -1 }},
 		{"cache.memoryCleanInterval", func(c *Config) { c.Cache.MemoryCleanInterval = -1 }},
 		{"bootstrap.interval", func(c *Config) { c.Bootstrap.Interval = -1 }},
+		{"bootstrap.whoisInterval", func(c *Config) { c.Bootstrap.WhoisInterval = -1 }},
+		{"bootstrap.warnAge", func(c *Config) { c.Bootstrap.WarnAge = -1 }},
+		{"bootstrap.failAge", func(c *Config) { c.Bootstrap.FailAge = -1 }},
 		{"batch.maxItems", func(c *Config) { c.Batch.MaxItems = -1 }},
+		{"breaker.errorRate", func(c *Config) { c.Breaker.ErrorRate = -1 }},
+		{"breaker.cooldown", func(c *Config) { c.Breaker.Cooldown = -1 }},
+		{"rdap.hedgeDelay", func(c *Config) { c.RDAP.HedgeDelay = -1 }},
 	}
 	for _, tc := range cases {
 		var cfg Config
@@ -333,6 +437,27 @@ func TestValidateConfigDefaultsPass(t *testing.T) {
 	}
 }
 
+func TestValidateConfigBootstrapAges(t *testing.T) {
+	for _, tc := range []struct {
+		warn, fail int
+		ok         bool
+	}{
+		{0, 0, true},
+		{3600, 0, true},
+		{0, 3600, true},
+		{3600, 7200, true},
+		{7200, 7200, false},
+		{7200, 3600, false},
+	} {
+		var cfg Config
+		applyDefaults(&cfg)
+		cfg.Bootstrap.WarnAge, cfg.Bootstrap.FailAge = tc.warn, tc.fail
+		if err := validateConfig(&cfg); (err == nil) != tc.ok {
+			t.Errorf("warnAge=%d failAge=%d: err = %v, want ok=%v", tc.warn, tc.fail, err, tc.ok)
+		}
+	}
+}
+
 func TestApplyDefaults(t *testing.T) {
 	var cfg Config
 	applyDefaults(&cfg)
@@ -394,6 +519,153 @@ func TestValidateConfigProxyServer(t *testing.T) {
 	}
 }
 
+// TestValidateConfigServers verifies servers.rdap and servers.whois entries
+// are checked at startup and normalized: keys lowercased (object tags
+// upper-cased), CIDRs canonicalized, RDAP base URLs given a trailing slash.
+func TestValidateConfigServers(t *testing.T) {
+	rdap := map[string]string{
+		"IO":                    "https://rdap.nic.io",
+		"192.0.2.1/24":          "https://rdap.example.net/rdap/",
+		"2001:db8::/32":         "http://rdap.example.net/",
+		"64496-64511":           "https://rdap.example.net/",
+		"tag:arin":              "https://rdap.arin.net/registry/",
+		"co.jp":                 "https://rdap.example.jp/",
+		"xn--p1ai":              "https://rdap.example.ru/",
+		"4200000000-4200000001": "https://rdap.example.net/",
+	}
+	got, err := normalizeServers("servers.rdap", rdap, serverlist.NormalizeRdapKey, normalizeRdapURL)
+	if err != nil {
+		t.Fatalf("servers.rdap: %v", err)
+	}
+	for key, want := range map[string]string{
+		"io":           "https://rdap.nic.io/",
+		"192.0.2.0/24": "https://rdap.example.net/rdap/",
+		"tag:ARIN":     "https://rdap.arin.net/registry/",
+	} {
+		if got[key] != want {
+			t.Errorf("servers.rdap[%q] = %q, want %q", key, got[key], want)
+		}
+	}
+
+	whois, err := normalizeServers("servers.whois", map[string]string{"IO": "Whois.NIC.io", "example": "whois.example.net:4343"}, serverlist.NormalizeWhoisKey, normalizeWhoisAddr)
+	if err != nil || whois["io"] != "whois.nic.io" || whois["example"] != "whois.example.net:4343" {
+		t.Errorf("servers.whois: %v %v", whois, err)
+	}
+
+	charsets, err := normalizeServers("servers.charsets", map[string]string{"CN": "GBK", "de": "latin1", "jp": " Shift_JIS "}, serverlist.NormalizeWhoisKey, normalizeCharset)
+	if err != nil || charsets["cn"] != "gbk" || charsets["de"] != "windows-1252" || charsets["jp"] != "shift_jis" {
+		t.Errorf("servers.charsets: %v %v", charsets, err)
+	}
+
+	invalid := []struct {
+		section string
+		mutate  func(*Config)
+	}{
+		{"servers.rdap", func(c *Config) { c.Servers.RDAP = map[string]string{"io": "rdap.nic.io"} }},
+		{"servers.rdap", func(c *Config) { c.Servers.RDAP = map[string]string{"io": "ftp://rdap.nic.io/"} }},
+		{"servers.rdap", func(c *Config) { c.Servers.RDAP = map[string]string{"io": ""} }},
+		{"servers.rdap", func(c *Config) { c.Servers.RDAP = map[string]string{"192.0.2.0/33": "https://rdap.example.net/"} }},
+		{"servers.rdap", func(c *Config) { c.Servers.RDAP = map[string]string{"64511-64496": "https://rdap.example.net/"} }},
+		{"servers.rdap", func(c *Config) { c.Servers.RDAP = map[string]string{"bad key": "https://rdap.example.net/"} }},
+		{"servers.rdap", func(c *Config) {
+			c.Servers.RDAP = map[string]string{"io": "https://a.example/", "IO": "https://b.example/"}
+		}},
+		{"servers.whois", func(c *Config) { c.Servers.Whois = map[string]string{"io": "whois://whois.nic.io"} }},
+		{"servers.whois", func(c *Config) { c.Servers.Whois = map[string]string{"io": "whois.nic.io:99999"} }},
+		{"servers.whois", func(c *Config) { c.Servers.Whois = map[string]string{"192.0.2.0/24": "whois.example.net"} }},
+		{"servers.charsets", func(c *Config) { c.Servers.Charsets = map[string]string{"cn": "gb2312-ish"} }},
+	}
+	for _, tc := range invalid {
+		var cfg Config
+		applyDefaults(&cfg)
+		tc.mutate(&cfg)
+		err := validateConfig(&cfg)
+		if err == nil {
+			t.Errorf("%+v / %+v: expected error", cfg.Servers.RDAP, cfg.Servers.Whois)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.section) {
+			t.Errorf("error %q does not name %s", err, tc.section)
+		}
+	}
+}
+
+// TestValidateConfigServerLimits verifies servers.limits keys are normalized
+// and entries checked, with burst defaulting to 1 under a rate.
+func TestValidateConfigServerLimits(t *testing.T) {
+	got, err := normalizeServerLimits(map[string]ServerLimit{
+		"WHOIS.DENIC.DE": {Rate: 1},
+		"tld:CO.JP":      {Concurrency: 1},
+		"192.0.2.1":      {Rate: 2, Burst: 4},
+	})
+	if err != nil {
+		t.Fatalf("normalizeServerLimits: %v", err)
+	}
+	for key, want := range map[string]ServerLimit{
+		"whois.denic.de": {Rate: 1, Burst: 1},
+		"tld:co.jp":      {Concurrency: 1},
+		"192.0.2.1":      {Rate: 2, Burst: 4},
+	} {
+		if got[key] != want {
+			t.Errorf("servers.limits[%q] = %+v, want %+v", key, got[key], want)
+		}
+	}
+
+	for _, limits := range []map[string]ServerLimit{
+		{"whois.denic.de:43": {Rate: 1}},
+		{"https://rdap.denic.de/": {Rate: 1}},
+		{"tld:": {Rate: 1}},
+		{"whois.denic.de": {}},
+		{"whois.denic.de": {Rate: -1}},
+		{"whois.denic.de": {Concurrency: 1, Burst: -1}},
+		{"whois.denic.de": {Rate: 1}, "WHOIS.denic.de": {Rate: 2}},
+	} {
+		var cfg Config
+		applyDefaults(&cfg)
+		cfg.Servers.Limits = limits
+		if err := validateConfig(&cfg); err == nil || !strings.Contains(err.Error(), "servers.limits") {
+			t.Errorf("%+v: expected a servers.limits error, got %v", limits, err)
+		}
+	}
+}
+
+// TestValidateConfigWhoisQueries verifies servers.queries keys are normalized
+// like servers.limits keys and entries checked, with the query defaulting to
+// the plain domain.
+func TestValidateConfigWhoisQueries(t *testing.T) {
+	got, err := normalizeWhoisQueries(map[string]WhoisQuery{
+		"WHOIS.DENIC.DE": {Query: "-T dn,ace {domain}", Name: " DENIC "},
+		"tld:CO.JP":      {Port: 4343},
+	})
+	if err != nil {
+		t.Fatalf("normalizeWhoisQueries: %v", err)
+	}
+	for key, want := range map[string]WhoisQuery{
+		"whois.denic.de": {Query: "-T dn,ace {domain}", Name: "denic"},
+		"tld:co.jp":      {Query: "{domain}", Port: 4343},
+	} {
+		if got[key] != want {
+			t.Errorf("servers.queries[%q] = %+v, want %+v", key, got[key], want)
+		}
+	}
+
+	for _, queries := range []map[string]WhoisQuery{
+		{"whois.denic.de:43": {}},
+		{"tld:": {}},
+		{"whois.denic.de": {Query: "-T dn,ace"}},
+		{"whois.denic.de": {Query: "{domain}\r\nsecond"}},
+		{"whois.denic.de": {Port: 70000}},
+		{"whois.denic.de": {}, "WHOIS.denic.de": {}},
+	} {
+		var cfg Config
+		applyDefaults(&cfg)
+		cfg.Servers.Queries = queries
+		if err := validateConfig(&cfg); err == nil || !strings.Contains(err.Error(), "servers.queries") {
+			t.Errorf("%+v: expected a servers.queries error, got %v", queries, err)
+		}
+	}
+}
+
 // TestProxySuffixesLowercased verifies configured suffixes are normalized to
 // lowercase: the lookup side lowercases every queried resource, so an
 // uppercase suffix would never match.
@@ -0,0 +1,352 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/KincaidYang/whois/internal/metrics"
+	"github.com/KincaidYang/whois/internal/serverlist"
+)
+
+// Settings is the part of the configuration a reload may change. Each
+// successful load builds a new value and swaps it in whole, so a request
+// sees either the old settings or the new ones, never a mix. A Settings is
+// never modified once installed; treat what Current returns as read-only.
+type Settings struct {
+	// AuthClients is the list of accepted API clients (key + display name +
+	// optional rate limit). Empty leaves the service open; non-empty enables
+	// authentication on every endpoint except /health and /ready.
+	AuthClients []AuthClient
+	// CacheExpiration is the cache duration.
+	CacheExpiration time.Duration
+	// NegativeCacheExpiration is how long not-found/denied results are cached.
+	NegativeCacheExpiration time.Duration
+	// ProxyServer, ProxyUsername and ProxyPassword configure the proxy RDAP
+	// queries for ProxySuffixes (lowercased; "all" for every TLD) go through.
+	ProxyServer   string
+	ProxyUsername string
+	ProxyPassword string
+	ProxySuffixes []string
+	// BatchEnabled turns on the POST /batch bulk-query endpoint and the MCP
+	// batch tool (default: false).
+	BatchEnabled bool
+	// BatchMaxItems caps how many queries one batch request may carry.
+	BatchMaxItems int
+	// JobsEnabled turns on the asynchronous POST /jobs batch endpoint
+	// (default: false).
+	JobsEnabled bool
+	// JobsMaxItems caps how many queries one job may carry.
+	JobsMaxItems int
+	// JobsRetention is how long a job's state is kept after its last update.
+	JobsRetention time.Duration
+	// JobsMaxRunning and JobsMaxRunningPerKey cap how many jobs run on this
+	// instance at once, in total and per API key.
+	JobsMaxRunning       int
+	JobsMaxRunningPerKey int
+	// RDAPServers and WhoisServers are the operator's servers.rdap and
+	// servers.whois overrides, keys normalized (see serverlist.NormalizeRdapKey).
+	RDAPServers  map[string]string
+	WhoisServers map[string]string
+	// ServerLimits is servers.limits, normalized (see normalizeServerLimits).
+	ServerLimits map[string]ServerLimit
+	// WhoisCharsets is servers.charsets, keys normalized like servers.whois
+	// and charsets by their canonical name.
+	WhoisCharsets map[string]string
+	// WhoisQueries is servers.queries, normalized (see normalizeWhoisQueries).
+	WhoisQueries map[string]WhoisQuery
+}
+
+// settings holds the Settings in effect. It starts out empty so packages
+// used without Load (unit tests) see zero values rather than nil.
+var settings atomic.Pointer[Settings]
+
+func init() {
+	settings.Store(&Settings{})
+}
+
+// Current returns the settings in effect.
+func Current() *Settings {
+	return settings.Load()
+}
+
+// SetCurrent installs s as the settings in effect without touching the
+// server overrides; tests use it to change one setting for their duration.
+func SetCurrent(s *Settings) {
+	settings.Store(s)
+}
+
+var (
+	// reloadMu serializes loads, so a SIGHUP and a file change arriving
+	// together cannot interleave; it guards loaded and loadedFile.
+	reloadMu sync.Mutex
+	// loaded is the configuration last installed, for the reload diff.
+	loaded Config
+	// loadedFile is the file it was read from, and loadedStat that file's
+	// modification time and size, which the watcher compares against.
+	loadedFile string
+	loadedStat fileStamp
+)
+
+// fileStamp identifies one version of the configuration file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func stampOf(name string) fileStamp {
+	info, err := os.Stat(name)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
+
+// newSettings builds the reloadable settings from a validated configuration.
+// A client whose key and rate limit are unchanged from prev keeps its
+// limiter, so a reload does not hand every caller a fresh bucket.
+func newSettings(config *Config, prev *Settings) (*Settings, error) {
+	clients, err := normalizeAuthClients(config.Auth.Keys)
+	if err != nil {
+		return nil, err
+	}
+	if prev != nil {
+		limiters := make(map[string]*AuthClient, len(prev.AuthClients))
+		for i := range prev.AuthClients {
+			limiters[prev.AuthClients[i].Key] = &prev.AuthClients[i]
+		}
+		for i := range clients {
+			if old, ok := limiters[clients[i].Key]; ok && old.RateLimit == clients[i].RateLimit {
+				clients[i].Limiter = old.Limiter
+			}
+		}
+	}
+
+	// validateConfig has already checked the server overrides.
+	rdapServers, _ := normalizeServers("servers.rdap", config.Servers.RDAP, serverlist.NormalizeRdapKey, normalizeRdapURL)
+	whoisServers, _ := normalizeServers("servers.whois", config.Servers.Whois, serverlist.NormalizeWhoisKey, normalizeWhoisAddr)
+	serverLimits, _ := normalizeServerLimits(config.Servers.Limits)
+	whoisCharsets, _ := normalizeServers("servers.charsets", config.Servers.Charsets, serverlist.NormalizeWhoisKey, normalizeCharset)
+	whoisQueries, _ := normalizeWhoisQueries(config.Servers.Queries)
+
+	// Proxy suffixes are lowercased to match the lookup side, which
+	// normalizes every queried resource to lowercase — an uppercase suffix
+	// in the config would otherwise never match.
+	return &Settings{
+		AuthClients:             clients,
+		CacheExpiration:         time.Duration(config.Cache.Expiration) * time.Second,
+		NegativeCacheExpiration: time.Duration(config.Cache.NegativeExpiration) * time.Second,
+		ProxyServer:             config.Proxy.Server,
+		ProxyUsername:           config.Proxy.Username,
+		ProxyPassword:           config.Proxy.Password,
+		ProxySuffixes:           lowercaseAll(config.Proxy.Suffixes),
+		BatchEnabled:            config.Batch.Enabled,
+		BatchMaxItems:           config.Batch.MaxItems,
+		JobsEnabled:             config.Jobs.Enabled,
+		JobsMaxItems:            config.Jobs.MaxItems,
+		JobsRetention:           time.Duration(config.Jobs.Retention) * time.Second,
+		JobsMaxRunning:          config.Jobs.MaxRunning,
+		JobsMaxRunningPerKey:    config.Jobs.MaxRunningPerKey,
+		RDAPServers:             rdapServers,
+		WhoisServers:            whoisServers,
+		ServerLimits:            serverLimits,
+		WhoisCharsets:           whoisCharsets,
+		WhoisQueries:            whoisQueries,
+	}, nil
+}
+
+// install makes s the settings in effect and layers its server overrides
+// over the compiled and IANA server data.
+func install(s *Settings) {
+	settings.Store(s)
+	serverlist.SetRdapOverrides(s.RDAPServers)
+	serverlist.SetWhoisOverrides(s.WhoisServers)
+}
+
+// readConfig reads, parses, overrides from the environment, defaults and
+// validates the configuration file, returning it with the file's name.
+func readConfig() (Config, string, error) {
+	data, name, err := readConfigFile()
+	if err != nil {
+		return Config{}, "", fmt.Errorf("failed to open configuration file: %w", err)
+	}
+	config, err := parseConfig(data, strings.ToLower(filepath.Ext(name)))
+	if err != nil {
+		return Config{}, "", err
+	}
+	overrideConfigWithEnv(&config)
+	applyDefaults(&config)
+	if err := validateConfig(&config); err != nil {
+		return Config{}, "", err
+	}
+	return config, name, nil
+}
+
+// Reload re-reads the configuration file and environment and swaps in the
+// reloadable settings: auth keys and per-key limits, proxy settings, cache
+// TTLs, batch and job limits, and server overrides. Every changed setting is
+// logged; changes to the rest (port, Redis, log level, …) are logged as
+// needing a restart and otherwise ignored. An invalid file leaves the
+// running configuration untouched and returns the error.
+func Reload() error {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	s, config, name, err := loadSettings()
+	if err != nil {
+		metrics.ConfigReloadsTotal.WithLabelValues("failure").Inc()
+		slog.Error("configuration reload rejected; keeping the running configuration", "err", err)
+		return err
+	}
+	applied, ignored := configDiff(loaded, config)
+	install(s)
+	loaded, loadedFile, loadedStat = config, name, stampOf(name)
+	metrics.ConfigReloadsTotal.WithLabelValues("success").Inc()
+	slog.Info("configuration reloaded", "file", name, "changed", applied)
+	if len(ignored) > 0 {
+		slog.Warn("configuration changes that need a restart to take effect", "settings", ignored)
+	}
+	return nil
+}
+
+// loadSettings reads the configuration and builds its settings on top of the
+// current ones, without installing anything.
+func loadSettings() (*Settings, Config, string, error) {
+	config, name, err := readConfig()
+	if err != nil {
+		return nil, Config{}, "", err
+	}
+	s, err := newSettings(&config, Current())
+	if err != nil {
+		return nil, Config{}, "", err
+	}
+	return s, config, name, nil
+}
+
+// StartWatcher reloads the configuration whenever the file it was loaded
+// from changes, checking every interval. A rejected file is not retried
+// until it changes again. Stops when ctx is cancelled.
+func StartWatcher(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				reloadMu.Lock()
+				name, last := loadedFile, loadedStat
+				reloadMu.Unlock()
+				if name == "" {
+					continue
+				}
+				if stamp := stampOf(name); stamp != last && stamp != (fileStamp{}) {
+					reloadMu.Lock()
+					loadedStat = stamp
+					reloadMu.Unlock()
+					_ = Reload()
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// reloadablePrefixes are the dotted settings Reload applies.
+var reloadablePrefixes = []string{
+	"auth.", "proxy.", "cache.expiration", "cache.negativeExpiration",
+	"batch.", "jobs.", "servers.",
+}
+
+// secretSettings are never logged by value.
+var secretSettings = map[string]bool{
+	"auth.keys": true, "redis.password": true, "proxy.password": true,
+}
+
+// configDiff describes each setting that differs between two
+// configurations as "key: old → new", split into those Reload applies and
+// those that need a restart. Secrets are reported as changed, without values.
+func configDiff(old, new Config) (applied, ignored []string) {
+	before, after := flattenConfig(old), flattenConfig(new)
+	keys := make(map[string]bool, len(before)+len(after))
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	for _, k := range sorted {
+		if before[k] == after[k] {
+			continue
+		}
+		change := fmt.Sprintf("%s: %s → %s", k, orUnset(before[k]), orUnset(after[k]))
+		if secretSettings[k] {
+			change = k + ": changed"
+		}
+		if isReloadable(k) {
+			applied = append(applied, change)
+		} else {
+			ignored = append(ignored, change)
+		}
+	}
+	return applied, ignored
+}
+
+func isReloadable(key string) bool {
+	for _, prefix := range reloadablePrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func orUnset(s string) string {
+	if s == "" {
+		return "(unset)"
+	}
+	return s
+}
+
+// flattenConfig renders a configuration as dotted keys ("cache.expiration",
+// "servers.rdap.io") to compact JSON values. Lists are kept whole.
+func flattenConfig(config Config) map[string]string {
+	data, _ := json.Marshal(config)
+	var tree map[string]any
+	_ = json.Unmarshal(data, &tree)
+	out := make(map[string]string)
+	var walk func(prefix string, v any)
+	walk = func(prefix string, v any) {
+		if m, ok := v.(map[string]any); ok {
+			for k, child := range m {
+				key := k
+				if prefix != "" {
+					key = prefix + "." + k
+				}
+				walk(key, child)
+			}
+			return
+		}
+		if v == nil {
+			return
+		}
+		b, _ := json.Marshal(v)
+		out[prefix] = string(b)
+	}
+	walk("", tree)
+	return out
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// withReloadState runs a test from an empty directory and restores the
+// installed settings and reload bookkeeping afterwards.
+func withReloadState(t *testing.T) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	oldSettings := Current()
+	reloadMu.Lock()
+	oldLoaded, oldFile, oldStat := loaded, loadedFile, loadedStat
+	reloadMu.Unlock()
+	t.Cleanup(func() {
+		install(oldSettings)
+		reloadMu.Lock()
+		loaded, loadedFile, loadedStat = oldLoaded, oldFile, oldStat
+		reloadMu.Unlock()
+	})
+}
+
+func writeConfigFile(t *testing.T, content string) {
+	t.Helper()
+	if err := os.WriteFile("config.yaml", []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+}
+
+const reloadYAML = `
+cache:
+  expiration: 600
+auth:
+  keys:
+    - key: "kept-key"
+      name: "kept"
+      rateLimit: 60
+    - key: "changed-key"
+      name: "changed"
+      rateLimit: 60
+batch:
+  enabled: false
+`
+
+func TestReload(t *testing.T) {
+	withReloadState(t)
+	writeConfigFile(t, reloadYAML)
+	if err := Reload(); err != nil {
+		t.Fatalf("initial reload: %v", err)
+	}
+	before := Current()
+	if before.CacheExpiration != 600*time.Second || len(before.AuthClients) != 2 || before.BatchEnabled {
+		t.Fatalf("initial settings: %+v", before)
+	}
+
+	writeConfigFile(t, strings.NewReplacer(
+		"expiration: 600", "expiration: 120",
+		"rateLimit: 60\nbatch", "rateLimit: 30\nbatch",
+		"enabled: false", "enabled: true",
+	).Replace(reloadYAML))
+	if err := Reload(); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	after := Current()
+	if after.CacheExpiration != 120*time.Second || !after.BatchEnabled {
+		t.Errorf("reloaded settings: %+v", after)
+	}
+	// An unchanged client keeps its bucket; a changed limit gets a new one.
+	if after.AuthClients[0].Limiter != before.AuthClients[0].Limiter {
+		t.Error("unchanged client was given a new limiter")
+	}
+	if after.AuthClients[1].Limiter == before.AuthClients[1].Limiter || after.AuthClients[1].RateLimit != 30 {
+		t.Errorf("changed client kept its old limiter: %+v", after.AuthClients[1])
+	}
+	// The replaced snapshot is left as it was for requests still holding it.
+	if before.CacheExpiration != 600*time.Second || before.BatchEnabled {
+		t.Errorf("old settings were modified: %+v", before)
+	}
+}
+
+func TestReloadRejectsInvalidFile(t *testing.T) {
+	withReloadState(t)
+	writeConfigFile(t, reloadYAML)
+	if err := Reload(); err != nil {
+		t.Fatalf("initial reload: %v", err)
+	}
+	before := Current()
+
+	for name, content := range map[string]string{
+		"syntax":      "cache: [",
+		"unknown key": "cache:\n  expiry: 5\n",
+		"invalid":     "cache:\n  expiration: -1\n",
+	} {
+		writeConfigFile(t, content)
+		if err := Reload(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+		if Current() != before {
+			t.Errorf("%s: running settings replaced", name)
+		}
+	}
+}
+
+func TestConfigDiff(t *testing.T) {
+	var old, changed Config
+	applyDefaults(&old)
+	applyDefaults(&changed)
+	changed.Cache.Expiration = 120
+	changed.Server.Port = 9090
+	changed.Auth.Keys = []AuthKeySpec{{Key: "secret-value"}}
+	changed.Servers.RDAP = map[string]string{"io": "https://rdap.example/"}
+
+	applied, ignored := configDiff(old, changed)
+	wantApplied := []string{
+		"auth.keys: changed",
+		"cache.expiration: 3600 → 120",
+		`servers.rdap.io: (unset) → "https://rdap.example/"`,
+	}
+	if !slices.Equal(applied, wantApplied) {
+		t.Errorf("applied = %q, want %q", applied, wantApplied)
+	}
+	if want := []string{"server.port: 8043 → 9090"}; !slices.Equal(ignored, want) {
+		t.Errorf("ignored = %q, want %q", ignored, want)
+	}
+	for _, change := range applied {
+		if strings.Contains(change, "secret-value") {
+			t.Errorf("secret logged: %q", change)
+		}
+	}
+}
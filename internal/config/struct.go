@@ -67,6 +67,44 @@ func (s *AuthKeySpec) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// ServerLimit is one entry of servers.limits: the pace outbound queries to an
+// upstream server are held to. At least one of Rate and Concurrency is set.
+type ServerLimit struct {
+	// Rate is the sustained query rate in queries per second; fractions are
+	// allowed ("0.5" is one query every two seconds). 0 = unlimited.
+	Rate float64 `json:"rate" yaml:"rate"`
+	// Burst is how many queries may go out back to back before Rate applies
+	// (default: 1).
+	Burst int `json:"burst" yaml:"burst"`
+	// Concurrency caps the queries in flight to the server at once.
+	// 0 = unlimited.
+	Concurrency int `json:"concurrency" yaml:"concurrency"`
+}
+
+// WhoisQueryDomain stands for the domain name in a WhoisQuery's Query.
+const WhoisQueryDomain = "{domain}"
+
+// WhoisQuery is one entry of servers.queries: how a WHOIS server is asked for
+// a domain.
+type WhoisQuery struct {
+	// Query is the line sent, with WhoisQueryDomain standing for the domain
+	// ("-T dn,ace {domain}", "{domain}/e"). Default: "{domain}".
+	Query string `json:"query" yaml:"query"`
+	// Port is the port to connect to when the server address names none
+	// (default: 43).
+	Port int `json:"port" yaml:"port"`
+	// Greeting is set for servers that send a line on connect; it is read
+	// and discarded before the query is sent.
+	Greeting bool `json:"greeting" yaml:"greeting"`
+	// HalfClose shuts down the sending side of the connection after the
+	// query, for servers that only answer once they see the end of input.
+	HalfClose bool `json:"halfClose" yaml:"halfClose"`
+	// Name tells the parsers which output format the query asks for
+	// ("jprs-en"). Answers to an unnamed query are parsed like the plain
+	// query's.
+	Name string `json:"name" yaml:"name"`
+}
+
 // Config represents the configuration for the application. YAML and JSON tags
 // are identical camelCase; keys from the pre-v0.9 flat layout are rejected at
 // load time with a migration hint (see legacyKeys in config.go).
@@ -129,7 +167,94 @@ type Config struct {
 		// Interval is how often (in seconds) to refresh. 0 or unset disables
 		// all fetching; the default config.yaml sets 86400 (24 hours).
 		Interval int `json:"interval" yaml:"interval"`
+		// WhoisInterval is how often (in seconds) to refresh the whole WHOIS
+		// server table, one whois.iana.org query per root-zone TLD. 0 or
+		// unset (the default) leaves it to on-demand discovery.
+		WhoisInterval int `json:"whoisInterval" yaml:"whoisInterval"`
+		// CacheFile is where the last successfully fetched IANA data is
+		// kept, and restored from on startup before the first fetch. Empty
+		// disables the file.
+		CacheFile string `json:"cacheFile" yaml:"cacheFile"`
+		// WhoisCacheFile is where the discovered WHOIS server table is kept,
+		// and restored from on startup. Empty disables the file.
+		WhoisCacheFile string `json:"whoisCacheFile" yaml:"whoisCacheFile"`
+		// CacheRedis also keeps both in Redis, shared by every replica
+		// using the same Redis. Ignored without Redis.
+		CacheRedis bool `json:"cacheRedis" yaml:"cacheRedis"`
+		// WarnAge and FailAge (seconds) are how old any category of the
+		// RDAP bootstrap data may get before the /ready bootstrap check
+		// warns, or fails and takes the instance out of service. A category
+		// never fetched counts from startup. 0 disables each; with both 0
+		// /ready has no bootstrap check.
+		WarnAge int `json:"warnAge" yaml:"warnAge"`
+		FailAge int `json:"failAge" yaml:"failAge"`
 	} `json:"bootstrap" yaml:"bootstrap"`
+	// Servers overrides the compiled-in and IANA-fetched upstream servers
+	// without a rebuild, e.g. when a TLD moves registry backend.
+	Servers struct {
+		// RDAP maps a domain suffix ("io"), CIDR block ("192.0.2.0/24"), ASN
+		// range ("64496-64511") or object tag ("tag:ARIN") to an RDAP base
+		// URL. Entries take precedence over compiled and IANA data.
+		RDAP map[string]string `json:"rdap" yaml:"rdap"`
+		// Whois maps a domain suffix to a WHOIS server host, optionally with
+		// a port ("whois.nic.io", "whois.example.net:4343").
+		Whois map[string]string `json:"whois" yaml:"whois"`
+		// Limits paces outbound queries per upstream server, keyed by the
+		// server's host name ("whois.denic.de", "rdap.denic.de") or by
+		// "tld:" and a domain suffix ("tld:jp") for whichever RDAP and
+		// WHOIS servers currently serve it. Shared across replicas through
+		// Redis when it is configured.
+		Limits map[string]ServerLimit `json:"limits" yaml:"limits"`
+		// Charsets maps a domain suffix to the charset its WHOIS server
+		// answers in when not UTF-8 ("gbk", "euc-kr", "shift_jis",
+		// "koi8-r", "iso-8859-1", ...), overriding the built-in hints.
+		Charsets map[string]string `json:"charsets" yaml:"charsets"`
+		// Queries sets how WHOIS servers are queried, keyed like Limits by
+		// server host name or "tld:" and a domain suffix. An entry replaces
+		// the built-in one for the same key.
+		Queries map[string]WhoisQuery `json:"queries" yaml:"queries"`
+	} `json:"servers" yaml:"servers"`
+	// RDAP holds settings for RDAP domain queries.
+	RDAP struct {
+		// FollowRegistrar fetches the registrar's RDAP record linked from a
+		// registry answer (rel "related") for every domain query, merging in
+		// registrar-only data such as the abuse contact. Off by default: it
+		// adds an upstream request per query; clients can opt in per request
+		// with ?follow=registrar.
+		FollowRegistrar bool `json:"followRegistrar" yaml:"followRegistrar"`
+		// WhoisFallback retries a domain query over WHOIS when the RDAP
+		// server fails transiently (network error, timeout, 5xx, 429 or an
+		// unparseable answer). A not-found or denied answer never falls back.
+		WhoisFallback bool `json:"whoisFallback" yaml:"whoisFallback"`
+		// HedgeDelay is how long (in milliseconds) a query to an RDAP
+		// service with several base URLs waits for the first before also
+		// sending it to the next. 0 (the default) disables hedging; the
+		// next URL is then tried only after the first fails.
+		HedgeDelay int `json:"hedgeDelay" yaml:"hedgeDelay"`
+	} `json:"rdap" yaml:"rdap"`
+	// Breaker holds the thresholds of the per-upstream circuit breakers.
+	Breaker struct {
+		// Failures is how many consecutive failures open an upstream's
+		// breaker (default: 5). Negative disables the breakers.
+		Failures int `json:"failures" yaml:"failures"`
+		// ErrorRate is the failure percentage over an upstream's last 20
+		// queries that opens its breaker (default: 50).
+		ErrorRate int `json:"errorRate" yaml:"errorRate"`
+		// Cooldown is how long (in seconds) an open breaker rejects queries
+		// before letting one probe through (default: 30).
+		Cooldown int `json:"cooldown" yaml:"cooldown"`
+	} `json:"breaker" yaml:"breaker"`
+	// BGP holds settings for the local BGP RIB dump IP and ASN responses
+	// are enriched from.
+	BGP struct {
+		// TableFile is the path of an MRT RIB dump or its bgpdump -m text
+		// (optionally gzip or bzip2 compressed). Empty (the default)
+		// disables the enrichment.
+		TableFile string `json:"tableFile" yaml:"tableFile"`
+		// ReloadInterval is how often (in seconds) the file is checked for
+		// changes and reloaded when it has (default: 60).
+		ReloadInterval int `json:"reloadInterval" yaml:"reloadInterval"`
+	} `json:"bgp" yaml:"bgp"`
 	// Auth holds API authentication settings.
 	Auth struct {
 		// Keys is the list of accepted API keys. Empty (the default) leaves
@@ -150,6 +275,24 @@ type Config struct {
 		// request (default: 10).
 		MaxItems int `json:"maxItems" yaml:"maxItems"`
 	} `json:"batch" yaml:"batch"`
+	// Jobs holds settings for the asynchronous POST /jobs batch endpoint.
+	Jobs struct {
+		// Enabled turns the endpoint on (default: false). Like batch.enabled,
+		// best combined with auth.keys.
+		Enabled bool `json:"enabled" yaml:"enabled"`
+		// MaxItems is the maximum number of queries accepted in one job
+		// (default: 10000).
+		MaxItems int `json:"maxItems" yaml:"maxItems"`
+		// Retention is how long (in seconds) a job's progress and results
+		// stay available for polling after its last update (default: 86400).
+		Retention int `json:"retention" yaml:"retention"`
+		// MaxRunning is how many jobs may run on one instance at the same
+		// time (default: 8); MaxRunningPerKey is how many of those one API
+		// key may run (default: 2). A job submitted beyond them is refused
+		// rather than queued.
+		MaxRunning       int `json:"maxRunning" yaml:"maxRunning"`
+		MaxRunningPerKey int `json:"maxRunningPerKey" yaml:"maxRunningPerKey"`
+	} `json:"jobs" yaml:"jobs"`
 	// MCP holds settings for the MCP Streamable HTTP endpoint (/mcp).
 	MCP struct {
 		// LocalhostProtection enables DNS-rebinding protection, which rejects
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/KincaidYang/whois/internal/model"
+	"github.com/KincaidYang/whois/internal/utils"
+)
+
+// HandleAbuse handles the HTTP request for the abuse contact of a domain, IP
+// address or prefix, or ASN (kind is the utils.Kind* of resource). The
+// registration data is looked up through the regular handler for the kind,
+// so the cache, request deduplication and negative cache all apply and no
+// entry of its own is kept; the abuse contact is then picked from it:
+//   - domains: the registrar's abuse contact (ICANN gTLD profile), else any
+//     other abuse entity in the registry's answer;
+//   - IPs and ASNs: the RIR's abuse entity, wherever it is nested.
+//
+// Failures of the lookup are passed through unchanged; registration data
+// without an abuse contact is a 404.
+func HandleAbuse(ctx context.Context, w http.ResponseWriter, kind, resource string, cacheKeyPrefix string, format Format, refresh, followRegistrar bool) {
+	rc := NewResponseCapture()
+	switch kind {
+	case utils.KindIP:
+		HandleIP(ctx, rc, resource, cacheKeyPrefix, FormatParsed, refresh)
+	case utils.KindASN:
+		HandleASN(ctx, rc, resource, cacheKeyPrefix, FormatParsed, refresh)
+	case utils.KindDomain:
+		HandleDomain(ctx, rc, resource, cacheKeyPrefix, FormatParsed, refresh, followRegistrar, false)
+	default:
+		utils.HandleHTTPError(w, utils.ErrorTypeBadRequest, "The /abuse/ path requires a domain, IP address, CIDR prefix or AS number.")
+		return
+	}
+
+	if rc.StatusCode() != http.StatusOK {
+		for k, v := range rc.Header() {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(rc.StatusCode())
+		_, _ = w.Write(rc.Body())
+		return
+	}
+
+	info, err := abuseContact(kind, resource, rc.Body())
+	if err != nil {
+		utils.HandleInternalError(ctx, w, err)
+		return
+	}
+	if info == nil {
+		utils.HandleHTTPError(w, utils.ErrorTypeNotFound, "No abuse contact published for: "+resource)
+		return
+	}
+
+	outcome, err := encodeOutcome(format, info)
+	if err != nil {
+		utils.HandleInternalError(ctx, w, err)
+		return
+	}
+	for _, k := range []string{"X-Cache", "Cache-Control"} {
+		if v := rc.Header().Get(k); v != "" {
+			w.Header().Set(k, v)
+		}
+	}
+	w.Header().Set("Content-Type", outcome.contentType)
+	_, _ = fmt.Fprint(w, outcome.body)
+}
+
+// abuseContact picks the abuse contact out of a handler's JSON answer for
+// kind. Returns nil when there is none.
+func abuseContact(kind, resource string, body []byte) (*model.AbuseInfo, error) {
+	info := &model.AbuseInfo{Query: resource, ResourceType: kind}
+
+	var contacts []model.Contact
+	switch kind {
+	case utils.KindDomain:
+		var domain model.DomainInfo
+		if err := json.Unmarshal(body, &domain); err != nil {
+			return nil, err
+		}
+		info.Query = domain.LdhName
+		if c := domain.RegistrarAbuseContact; c != nil && (c.Email != "" || c.Phone != "") {
+			info.Email, info.Phone = c.Email, c.Phone
+			info.Name = domain.Registrar
+			info.Source = model.AbuseSourceRegistrar
+			return info, nil
+		}
+		contacts = domain.Contacts
+		info.Source = model.AbuseSourceRegistry
+	case utils.KindIP:
+		var ip model.IPInfo
+		if err := json.Unmarshal(body, &ip); err != nil {
+			return nil, err
+		}
+		contacts = ip.Contacts
+		info.Source = model.AbuseSourceRIR
+	case utils.KindASN:
+		var asn model.ASNInfo
+		if err := json.Unmarshal(body, &asn); err != nil {
+			return nil, err
+		}
+		contacts = asn.Contacts
+		info.Source = model.AbuseSourceRIR
+	}
+
+	c := bestAbuseContact(contacts)
+	if c == nil {
+		return nil, nil
+	}
+	info.Email, info.Phone, info.Handle = c.Email, c.Phone, c.Handle
+	info.Name = c.Name
+	if info.Name == "" {
+		info.Name = c.Organization
+	}
+	return info, nil
+}
+
+// bestAbuseContact returns the first abuse contact with a mailbox — reports
+// are sent by email — else the first one with a phone number, else nil.
+func bestAbuseContact(contacts []model.Contact) *model.Contact {
+	var withPhone *model.Contact
+	for i := range contacts {
+		c := &contacts[i]
+		if c.Role != "abuse" {
+			continue
+		}
+		if c.Email != "" {
+			return c
+		}
+		if c.Phone != "" && withPhone == nil {
+			withPhone = c
+		}
+	}
+	return withPhone
+}
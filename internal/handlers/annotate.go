@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/utils"
+)
+
+// serveAnnotated answers a query in a format rendered from the normalized
+// model, adding data from local tables (the BGP table) to the model at
+// response time. The cache and the shared flight hold the registry's answer
+// alone, as JSON, so a cached entry never carries annotations from a table
+// that has since been reloaded; every response, cached or not, is annotated
+// from the table loaded now and then rendered in format.
+func serveAnnotated[T any](ctx context.Context, w http.ResponseWriter, key string, format Format, refresh bool, query func(context.Context) (T, error), annotate func(*T)) {
+	if !refresh {
+		result, err := utils.GetFromCache(ctx, config.CacheManager, key)
+		if err != nil {
+			utils.HandleInternalError(ctx, w, err)
+			return
+		}
+		if result.Found {
+			w.Header().Set("X-Cache", "HIT")
+			if utils.IsNegativeCacheHit(w, result.Data) {
+				return
+			}
+			if outcome, err := annotateOutcome(format, result.Data, annotate); err == nil {
+				setCacheControl(w)
+				utils.HandleCacheResponse(w, outcome.body, outcome.contentType)
+				return
+			}
+			// Entries cached before annotations moved out of the cache hold
+			// a rendering; query afresh and replace them.
+			slog.DebugContext(ctx, "cached entry is not the JSON model, querying afresh", "key", key)
+		}
+	}
+
+	outcome, err := dedupedQuery(ctx, key, refresh, func(qctx context.Context) (queryOutcome, error) {
+		v, err := query(qctx)
+		if err != nil {
+			return queryOutcome{}, err
+		}
+		return encodeOutcome(FormatParsed, v)
+	})
+	if err != nil {
+		utils.HandleQueryError(ctx, w, err)
+		return
+	}
+	if outcome, err = annotateOutcome(format, outcome.body, annotate); err != nil {
+		utils.HandleInternalError(ctx, w, err)
+		return
+	}
+	writeUpstreamResult(w, outcome, refresh)
+}
+
+// annotateOutcome decodes body, the JSON model, annotates it and encodes it
+// in format.
+func annotateOutcome[T any](format Format, body string, annotate func(*T)) (queryOutcome, error) {
+	var v T
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return queryOutcome{}, err
+	}
+	annotate(&v)
+	return encodeOutcome(format, v)
+}
@@ -2,21 +2,26 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/KincaidYang/whois/internal/bgp"
+	"github.com/KincaidYang/whois/internal/model"
 	"github.com/KincaidYang/whois/internal/rdap"
 	"github.com/KincaidYang/whois/internal/serverlist"
 	"github.com/KincaidYang/whois/internal/utils"
 )
 
 // HandleASN function is used to handle the HTTP request for querying the RDAP information for a given ASN (Autonomous System Number).
+// The answer is rendered in format; every format but the default is cached
+// under its own key namespace ("rdap:", "text:", ...), and FormatRDAP is the
+// registry's answer unmodified.
 // When refresh is true the cache read is skipped: the query goes upstream and
-// its result overwrites the cached entry (X-Cache: REFRESH).
-func HandleASN(ctx context.Context, w http.ResponseWriter, resource string, cacheKeyPrefix string, refresh bool) {
+// its result overwrites the cached entry (X-Cache: REFRESH). The prefixes
+// from the local BGP table are added per response and never cached.
+func HandleASN(ctx context.Context, w http.ResponseWriter, resource string, cacheKeyPrefix string, format Format, refresh bool) {
 	// Parse the ASN
 	asn := strings.TrimPrefix(resource, "asn")
 	if asn == resource {
@@ -28,33 +33,41 @@ func HandleASN(ctx context.Context, w http.ResponseWriter, resource string, cach
 		return
 	}
 
-	// Check cache first before doing any lookups
-	key := fmt.Sprintf("%s%s", cacheKeyPrefix, asn)
-	if serveFromCache(ctx, w, key, refresh) != cacheMiss {
-		return
-	}
+	key := fmt.Sprintf("%s%s%s", cacheKeyPrefix, format.keyNamespace(), asn)
 
 	// Find the RDAP server URL via pre-built sorted ASN range index
-	serverURL, _ := serverlist.LookupASNKey(asnInt)
+	serverURLs, _ := serverlist.LookupASNServers(asnInt)
 
-	// Query and parse the RDAP information, deduplicating concurrent misses
-	outcome, err := dedupedQuery(ctx, key, refresh, func(qctx context.Context) (queryOutcome, error) {
-		queryResult, err := rdap.RDAPQueryASN(qctx, asn, serverURL)
-		if err != nil {
-			return queryOutcome{}, err
-		}
+	if format != FormatRDAP {
+		serveAnnotated(ctx, w, key, format, refresh, func(qctx context.Context) (model.ASNInfo, error) {
+			queryResult, err := rdap.RDAPQueryASN(qctx, asn, serverURLs)
+			if err != nil {
+				return model.ASNInfo{}, err
+			}
+			return rdap.ParseRDAPResponseforASN(queryResult)
+		}, func(info *model.ASNInfo) {
+			// Announced prefixes come from the local BGP table, when one
+			// is loaded.
+			info.Prefixes = nil
+			for _, p := range bgp.Active().Prefixes(uint32(asnInt)) {
+				info.Prefixes = append(info.Prefixes, p.String())
+			}
+		})
+		return
+	}
 
-		asnInfo, err := rdap.ParseRDAPResponseforASN(queryResult)
-		if err != nil {
-			return queryOutcome{}, err
-		}
+	// Check cache first before querying
+	if serveFromCache(ctx, w, key, format, refresh) != cacheMiss {
+		return
+	}
 
-		resultBytes, err := json.Marshal(asnInfo)
+	// Query the RDAP information, deduplicating concurrent misses
+	outcome, err := dedupedQuery(ctx, key, refresh, func(qctx context.Context) (queryOutcome, error) {
+		queryResult, err := rdap.RDAPQueryASN(qctx, asn, serverURLs)
 		if err != nil {
 			return queryOutcome{}, err
 		}
-
-		return queryOutcome{body: string(resultBytes), contentType: "application/json"}, nil
+		return passthrough(queryResult), nil
 	})
 	if err != nil {
 		utils.HandleQueryError(ctx, w, err)
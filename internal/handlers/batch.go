@@ -77,41 +77,32 @@ type BatchResponse struct {
 // HandleBatch serves POST /batch: a list of mixed domain/IP/ASN queries
 // answered item by item. The response is always 200 with per-item statuses;
 // request-level failures (disabled, oversized, malformed, over budget) are
-// problem responses.
+// problem responses. Like single queries, the results can be rendered as
+// text, CSV or YAML (?format= or Accept), or streamed as NDJSON (see
+// streamBatch).
 func HandleBatch(ctx context.Context, w http.ResponseWriter, r *http.Request) {
-	if !config.BatchEnabled {
+	settings := config.Current()
+	if !settings.BatchEnabled {
 		utils.WriteBatchDisabled(w)
 		return
 	}
-
-	var req BatchRequest
-	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBody))
-	dec.DisallowUnknownFields()
-	if err := dec.Decode(&req); err != nil {
-		utils.HandleHTTPError(w, utils.ErrorTypeBadRequest, "Invalid request body: expected {\"queries\": [\"example.com\", ...]}.")
-		return
-	}
-	// Decode only reads the first JSON value; reject trailing data so a body
-	// like `{"queries":[...]}{"queries":[...]}` is not silently half-read.
-	if err := dec.Decode(&struct{}{}); err != io.EOF {
-		utils.HandleHTTPError(w, utils.ErrorTypeBadRequest, "Invalid request body: unexpected data after the JSON object.")
-		return
-	}
-	if len(req.Queries) == 0 {
-		utils.HandleHTTPError(w, utils.ErrorTypeBadRequest, "The queries list must not be empty.")
+	w.Header().Add("Vary", "Accept")
+	format, ok := negotiate(r.URL.Query(), r.Header.Get("Accept"), batchFormatNames, batchAcceptTypes)
+	if !ok || format == FormatRDAP {
+		utils.HandleHTTPError(w, utils.ErrorTypeBadRequest, `The /batch format parameter only accepts "json", "ndjson", "text", "csv" or "yaml".`)
 		return
 	}
-	if len(req.Queries) > config.BatchMaxItems {
-		utils.HandleHTTPError(w, utils.ErrorTypeBadRequest,
-			"Too many queries in one batch: the limit on this instance is "+strconv.Itoa(config.BatchMaxItems)+".")
+
+	queries, ok := decodeQueries(w, r, maxBatchBody, settings.BatchMaxItems, "batch")
+	if !ok {
 		return
 	}
 
 	// The auth middleware charged one rate-limit token for the request;
 	// charge the remaining N-1 so a batch costs as many tokens as the same
 	// queries sent one by one, and the per-key limit cannot be bypassed.
-	if client := config.AuthClientFromContext(ctx); client != nil && client.Limiter != nil && len(req.Queries) > 1 {
-		reservation := client.Limiter.ReserveN(time.Now(), len(req.Queries)-1)
+	if client := config.AuthClientFromContext(ctx); client != nil && client.Limiter != nil && len(queries) > 1 {
+		reservation := client.Limiter.ReserveN(time.Now(), len(queries)-1)
 		if !reservation.OK() {
 			utils.WriteRateLimitedBatch(w)
 			return
@@ -123,11 +114,56 @@ func HandleBatch(ctx context.Context, w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	results := RunBatch(ctx, req.Queries)
+	if format == formatNDJSON {
+		streamBatch(ctx, w, queries)
+		return
+	}
+
+	results := RunBatch(ctx, queries)
+	if format.rendered() {
+		body, err := renderBatch(format, results)
+		if err != nil {
+			utils.HandleInternalError(ctx, w, err)
+			return
+		}
+		w.Header().Set("Content-Type", format.contentType())
+		_, _ = io.WriteString(w, body)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(BatchResponse{Results: results})
 }
 
+// decodeQueries reads a {"queries": [...]} request body of at most maxBody
+// bytes carrying 1 to maxItems queries; otherwise it writes the 400 response
+// itself and reports false. what names the request in the size error
+// ("batch", "job").
+func decodeQueries(w http.ResponseWriter, r *http.Request, maxBody int64, maxItems int, what string) ([]string, bool) {
+	var req BatchRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		utils.HandleHTTPError(w, utils.ErrorTypeBadRequest, "Invalid request body: expected {\"queries\": [\"example.com\", ...]}.")
+		return nil, false
+	}
+	// Decode only reads the first JSON value; reject trailing data so a body
+	// like `{"queries":[...]}{"queries":[...]}` is not silently half-read.
+	if err := dec.Decode(&struct{}{}); err != io.EOF {
+		utils.HandleHTTPError(w, utils.ErrorTypeBadRequest, "Invalid request body: unexpected data after the JSON object.")
+		return nil, false
+	}
+	if len(req.Queries) == 0 {
+		utils.HandleHTTPError(w, utils.ErrorTypeBadRequest, "The queries list must not be empty.")
+		return nil, false
+	}
+	if len(req.Queries) > maxItems {
+		utils.HandleHTTPError(w, utils.ErrorTypeBadRequest,
+			"Too many queries in one "+what+": the limit on this instance is "+strconv.Itoa(maxItems)+".")
+		return nil, false
+	}
+	return req.Queries, true
+}
+
 // RunBatch answers each query with bounded concurrency. Items share the
 // caller's context: when the request deadline expires, unfinished items
 // report their individual timeout errors. Duplicate in-flight queries are
@@ -135,6 +171,14 @@ func HandleBatch(ctx context.Context, w http.ResponseWriter, r *http.Request) {
 // the HTTP /batch endpoint and the MCP whois_batch_lookup tool.
 func RunBatch(ctx context.Context, queries []string) []BatchItem {
 	results := make([]BatchItem, len(queries))
+	runBatch(ctx, queries, func(i int, item BatchItem) { results[i] = item })
+	return results
+}
+
+// runBatch answers each query like RunBatch, handing every item to done as
+// soon as it completes. done is called from the items' goroutines, possibly
+// concurrently.
+func runBatch(ctx context.Context, queries []string, done func(i int, item BatchItem)) {
 	sem := make(chan struct{}, batchConcurrency)
 	var wg sync.WaitGroup
 	for i, query := range queries {
@@ -148,19 +192,92 @@ func RunBatch(ctx context.Context, queries []string) []BatchItem {
 			select {
 			case sem <- struct{}{}:
 			case <-ctx.Done():
-				results[i] = batchDeadlineItem(query)
+				done(i, batchDeadlineItem(query))
 				return
 			}
 			defer func() { <-sem }()
 			if ctx.Err() != nil {
-				results[i] = batchDeadlineItem(query)
+				done(i, batchDeadlineItem(query))
 				return
 			}
-			results[i] = runBatchItem(ctx, query)
+			done(i, runBatchItem(ctx, query))
 		}(i, query)
 	}
 	wg.Wait()
-	return results
+}
+
+// formatNDJSON streams batch items as newline-delimited JSON; /batch is the
+// only endpoint offering it.
+const formatNDJSON Format = "ndjson"
+
+// batchFormatNames and batchAcceptTypes extend the formats every endpoint
+// negotiates with NDJSON.
+var (
+	batchFormatNames = withFormat(formatNames, "ndjson", formatNDJSON)
+	batchAcceptTypes = withFormat(acceptTypes, "application/x-ndjson", formatNDJSON)
+)
+
+// withFormat returns a copy of m with name mapped to f.
+func withFormat(m map[string]Format, name string, f Format) map[string]Format {
+	out := make(map[string]Format, len(m)+1)
+	for k, v := range m {
+		out[k] = v
+	}
+	out[name] = f
+	return out
+}
+
+// StreamedBatchItem is one line of an NDJSON batch response: an item plus
+// its position in the request, since lines arrive in completion order.
+type StreamedBatchItem struct {
+	Index int `json:"index"`
+	BatchItem
+}
+
+// BatchSummary is the final line of an NDJSON batch response.
+type BatchSummary struct {
+	Summary struct {
+		Total     int   `json:"total"`
+		Succeeded int   `json:"succeeded"`
+		Failed    int   `json:"failed"`
+		ElapsedMs int64 `json:"elapsedMs"`
+	} `json:"summary"`
+}
+
+// streamBatch answers a batch as NDJSON: one StreamedBatchItem line per query,
+// written and flushed as soon as the query completes, so fast cache hits do
+// not wait for slow registries, then a BatchSummary line. The status is
+// committed with the first line, so per-item failures only show in the
+// items, as in the JSON response.
+func streamBatch(ctx context.Context, w http.ResponseWriter, queries []string) {
+	start := time.Now()
+	lines := make(chan StreamedBatchItem)
+	go func() {
+		runBatch(ctx, queries, func(i int, item BatchItem) {
+			lines <- StreamedBatchItem{Index: i, BatchItem: item}
+		})
+		close(lines)
+	}()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	rc := http.NewResponseController(w)
+	enc := json.NewEncoder(w)
+	var summary BatchSummary
+	for line := range lines {
+		if line.Status < 400 {
+			summary.Summary.Succeeded++
+		} else {
+			summary.Summary.Failed++
+		}
+		// A failed write means the client went away; keep draining so the
+		// batch's goroutines are not left blocked on the channel.
+		if enc.Encode(line) == nil {
+			_ = rc.Flush()
+		}
+	}
+	summary.Summary.Total = len(queries)
+	summary.Summary.ElapsedMs = time.Since(start).Milliseconds()
+	_ = enc.Encode(summary)
 }
 
 // batchDeadlineItem reports a query that never ran because the batch's
@@ -181,11 +298,11 @@ func runBatchItem(ctx context.Context, query string) BatchItem {
 	rc := NewResponseCapture()
 	switch kind {
 	case utils.KindIP:
-		HandleIP(ctx, rc, resource, CacheKeyPrefix, false)
+		HandleIP(ctx, rc, resource, CacheKeyPrefix, FormatParsed, false)
 	case utils.KindASN:
-		HandleASN(ctx, rc, resource, CacheKeyPrefix, false)
+		HandleASN(ctx, rc, resource, CacheKeyPrefix, FormatParsed, false)
 	case utils.KindDomain:
-		HandleDomain(ctx, rc, resource, CacheKeyPrefix, false, false)
+		HandleDomain(ctx, rc, resource, CacheKeyPrefix, FormatParsed, false, false, false)
 	default:
 		utils.HandleHTTPError(rc, utils.ErrorTypeBadRequest, "Invalid input. Please provide a valid domain, IP, or ASN.")
 	}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/KincaidYang/whois/internal/breaker"
+)
+
+// BreakerReport is the /breakers response: every upstream queried since
+// startup, with its circuit breaker state.
+type BreakerReport struct {
+	Timestamp string           `json:"timestamp"`
+	Breakers  []breaker.Status `json:"breakers"`
+}
+
+// HandleBreakers handles the /breakers endpoint, listing open breakers
+// first. Unlike /health and /ready it sits behind API key authentication:
+// it names every upstream this instance has queried.
+func HandleBreakers(w http.ResponseWriter, r *http.Request) {
+	report := BreakerReport{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Breakers:  breaker.Snapshot(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -2,11 +2,13 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
 
+	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/metrics"
 	"github.com/KincaidYang/whois/internal/model"
 	"github.com/KincaidYang/whois/internal/rdap"
 	"github.com/KincaidYang/whois/internal/serverlist"
@@ -70,13 +72,59 @@ var whoisParsers = map[string]func(string, string) (model.DomainInfo, error){
 	"xn--3e0b707e": whois.ParseWhoisResponseKR,
 }
 
+// templateParsers maps the name of a WHOIS query template (see
+// servers.queries) to the parser for the answers it produces, for registries
+// whose answer format depends on the query. They take precedence over
+// whoisParsers.
+var templateParsers = map[string]func(string, string) (model.DomainInfo, error){
+	"jprs-en": whois.ParseWhoisResponseJPEnglish,
+}
+
+// whoisParser returns the parser for the registry's answer to a query for a
+// domain under tld, sent with the named query template.
+func whoisParser(tld, template string) (func(string, string) (model.DomainInfo, error), bool) {
+	if parseFunc, ok := templateParsers[template]; ok {
+		return parseFunc, true
+	}
+	parseFunc, ok := whoisParsers[tld]
+	return parseFunc, ok
+}
+
+// lookupTLD returns the suffix a name is routed by: its public suffix when
+// that is compound ("co.jp") and has a dedicated parser or server, otherwise
+// the root TLD ("jp").
+func lookupTLD(name string) string {
+	tld, _ := publicsuffix.PublicSuffix(name)
+	if strings.Contains(tld, ".") {
+		_, hasParser := whoisParsers[tld]
+		_, hasWhoisServer := serverlist.LookupWhoisServer(tld)
+		_, hasRdapServer := serverlist.LookupRdapServer(tld)
+		if !hasParser && !hasWhoisServer && !hasRdapServer {
+			parts := strings.Split(tld, ".")
+			tld = parts[len(parts)-1]
+		}
+	}
+	return tld
+}
+
 // HandleDomain function is used to handle the HTTP request for querying the RDAP (Registration Data Access Protocol) or WHOIS information for a given domain.
-// When raw is true, the unparsed WHOIS response is returned as text/plain
+// With FormatWhois, the unparsed WHOIS response is returned as text/plain
 // (RDAP is skipped, since RDAP has no raw-text form), cached under a separate
-// "raw:" key namespace so parsed and raw results never mix.
+// "raw:" key namespace so parsed and raw results never mix. With FormatRDAP,
+// the registry's RDAP answer is returned unmodified (WHOIS is never used),
+// cached under "rdap:". The text, CSV and YAML renderings are cached under
+// "text:", "csv:" and "yaml:".
 // When refresh is true the cache read is skipped: the query goes upstream and
 // its result overwrites the cached entry (X-Cache: REFRESH).
-func HandleDomain(ctx context.Context, w http.ResponseWriter, resource string, cacheKeyPrefix string, raw, refresh bool) {
+// When followRegistrar is true, or rdap.followRegistrar is set, an RDAP answer
+// is supplemented with the registrar's own RDAP record; for TLDs with an RDAP
+// server that result is cached under a separate "registrar:" key namespace.
+// With rdap.whoisFallback set, a transient RDAP failure is retried over WHOIS
+// (see fallBackToWhois); the answer's source field says which one answered.
+// When merged is true, RDAP and WHOIS are queried together and the fields
+// the RDAP answer leaves empty are filled from WHOIS (see mergeDomainInfo);
+// that result is cached under a separate "merged:" key namespace.
+func HandleDomain(ctx context.Context, w http.ResponseWriter, resource string, cacheKeyPrefix string, format Format, refresh, followRegistrar, merged bool) {
 	// Convert the domain to Punycode encoding (supports IDN domains)
 	punycodeDomain, err := idna.ToASCII(resource)
 	if err != nil {
@@ -86,19 +134,7 @@ func HandleDomain(ctx context.Context, w http.ResponseWriter, resource string, c
 	resource = punycodeDomain
 
 	// Get the TLD (Top-Level Domain) of the domain
-	tld, _ := publicsuffix.PublicSuffix(resource)
-
-	// For compound TLDs like "co.jp", check if we have a dedicated parser or server.
-	// Otherwise, fall back to the root TLD (e.g., "jp").
-	if strings.Contains(tld, ".") {
-		_, hasParser := whoisParsers[tld]
-		_, hasWhoisServer := serverlist.TLDToWhoisServer[tld]
-		_, hasRdapServer := serverlist.LookupRdapServer(tld)
-		if !hasParser && !hasWhoisServer && !hasRdapServer {
-			parts := strings.Split(tld, ".")
-			tld = parts[len(parts)-1]
-		}
-	}
+	tld := lookupTLD(resource)
 
 	// Get the main domain
 	mainDomain, _ := publicsuffix.EffectiveTLDPlusOne(resource)
@@ -107,35 +143,70 @@ func HandleDomain(ctx context.Context, w http.ResponseWriter, resource string, c
 	}
 	resource = mainDomain
 	domain := resource
-	key := fmt.Sprintf("%s%s", cacheKeyPrefix, domain)
-	if raw {
-		key = fmt.Sprintf("%sraw:%s", cacheKeyPrefix, domain)
+	// Only an RDAP answer links to the registrar: a TLD answered over WHOIS
+	// shares the plain entries.
+	_, hasRDAP := serverlist.LookupRdapServer(tld)
+	followRegistrar = (followRegistrar || config.RDAPFollowRegistrar) && hasRDAP
+	namespace := format.keyNamespace()
+	if followRegistrar && (format == FormatParsed || format.rendered()) {
+		namespace += "registrar:"
 	}
+	if merged {
+		namespace += "merged:"
+	}
+	key := fmt.Sprintf("%s%s%s", cacheKeyPrefix, namespace, domain)
 
 	// Check if the RDAP or WHOIS information for the domain is cached
-	if serveFromCache(ctx, w, key, refresh) != cacheMiss {
+	if serveFromCache(ctx, w, key, format, refresh) != cacheMiss {
 		return
 	}
 
-	// Select the query path: RDAP preferred, WHOIS as fallback (raw output
-	// always queries WHOIS). The query itself runs deduplicated, so
-	// concurrent misses on the same domain share one upstream request.
+	// Select the query path: RDAP preferred, WHOIS for TLDs without RDAP or,
+	// with rdap.whoisFallback, when RDAP fails transiently (raw output always
+	// queries WHOIS, RDAP passthrough always RDAP). The query itself
+	// runs deduplicated, so concurrent misses on the same domain share one
+	// upstream request.
 	var query func(context.Context) (queryOutcome, error)
-	if raw {
-		if _, ok := serverlist.TLDToWhoisServer[tld]; !ok {
+	if format == FormatWhois {
+		if _, ok := serverlist.DiscoverWhoisServer(ctx, tld); !ok {
 			utils.HandleHTTPError(w, utils.ErrorTypeNotFound, "No WHOIS server known for TLD: "+tld)
 			return
 		}
 		query = func(qctx context.Context) (queryOutcome, error) {
 			return queryWhoisRaw(qctx, domain, tld)
 		}
+	} else if format == FormatRDAP {
+		if _, ok := serverlist.LookupRdapServer(tld); !ok {
+			utils.HandleHTTPError(w, utils.ErrorTypeNotFound, "No RDAP server known for TLD: "+tld)
+			return
+		}
+		query = func(qctx context.Context) (queryOutcome, error) {
+			queryResult, err := rdap.RDAPQuery(qctx, domain, tld)
+			if err != nil {
+				return queryOutcome{}, err
+			}
+			return passthrough(queryResult), nil
+		}
+	} else if merged {
+		_, hasRDAP := serverlist.LookupRdapServer(tld)
+		if _, hasWhois := serverlist.DiscoverWhoisServer(ctx, tld); !hasRDAP && !hasWhois {
+			utils.HandleHTTPError(w, utils.ErrorTypeNotFound, "No WHOIS or RDAP server known for TLD: "+tld)
+			return
+		}
+		query = func(qctx context.Context) (queryOutcome, error) {
+			return queryMergedDomain(qctx, domain, tld, format, followRegistrar)
+		}
 	} else if _, ok := serverlist.LookupRdapServer(tld); ok {
 		query = func(qctx context.Context) (queryOutcome, error) {
-			return queryRDAPDomain(qctx, domain, tld)
+			outcome, err := queryRDAPDomain(qctx, domain, tld, format, followRegistrar)
+			if err != nil && config.RDAPWhoisFallback && rdap.IsTransient(err) {
+				return fallBackToWhois(qctx, domain, tld, format, err)
+			}
+			return outcome, err
 		}
-	} else if _, ok := serverlist.TLDToWhoisServer[tld]; ok {
+	} else if _, ok := serverlist.DiscoverWhoisServer(ctx, tld); ok {
 		query = func(qctx context.Context) (queryOutcome, error) {
-			return queryWhoisDomain(qctx, domain, tld)
+			return queryWhoisDomain(qctx, domain, tld, format)
 		}
 	} else {
 		// Nothing to query for this TLD: that is an answer about the requested
@@ -154,76 +225,147 @@ func HandleDomain(ctx context.Context, w http.ResponseWriter, resource string, c
 	writeUpstreamResult(w, outcome, refresh)
 }
 
-// queryRDAPDomain queries RDAP for a domain and parses the response.
-func queryRDAPDomain(ctx context.Context, domain, tld string) (queryOutcome, error) {
-	queryResult, err := rdap.RDAPQuery(ctx, domain, tld)
+// queryRDAPDomain queries RDAP for a domain and parses the response into
+// format, following the registry's link to the registrar's record when
+// followRegistrar is set.
+func queryRDAPDomain(ctx context.Context, domain, tld string, format Format, followRegistrar bool) (queryOutcome, error) {
+	domainInfo, err := rdapDomainInfo(ctx, domain, tld, followRegistrar)
 	if err != nil {
 		return queryOutcome{}, err
 	}
+	return encodeOutcome(format, domainInfo)
+}
+
+// rdapDomainInfo is queryRDAPDomain before encoding.
+func rdapDomainInfo(ctx context.Context, domain, tld string, followRegistrar bool) (model.DomainInfo, error) {
+	queryResult, err := rdap.RDAPQuery(ctx, domain, tld)
+	if err != nil {
+		return model.DomainInfo{}, err
+	}
 
 	domainInfo, err := rdap.ParseRDAPResponseforDomain(queryResult)
 	if err != nil {
-		return queryOutcome{}, err
+		return model.DomainInfo{}, err
+	}
+	domainInfo.Source = model.DomainSourceRDAP
+	if followRegistrar {
+		domainInfo.RegistrarLookup = followRegistrarLink(ctx, &domainInfo, queryResult)
 	}
 	finalizeDomainInfo(&domainInfo, domain)
+	return domainInfo, nil
+}
+
+// fallBackToWhois answers a domain query over WHOIS after RDAP failed
+// transiently with rdapErr. When there is no WHOIS server, no time left, or
+// WHOIS fails too, rdapErr is returned: a WHOIS "not found" is not trusted
+// here, since a parser missing a record would otherwise be negatively cached
+// while the registry's RDAP server was merely down.
+func fallBackToWhois(ctx context.Context, domain, tld string, format Format, rdapErr error) (queryOutcome, error) {
+	if ctx.Err() != nil {
+		return queryOutcome{}, rdapErr
+	}
+	if _, ok := serverlist.DiscoverWhoisServer(ctx, tld); !ok {
+		return queryOutcome{}, rdapErr
+	}
+	slog.WarnContext(ctx, "RDAP query failed, falling back to WHOIS", "domain", domain, "tld", tld, "err", rdapErr)
 
-	resultBytes, err := json.Marshal(domainInfo)
+	outcome, err := queryWhoisDomain(ctx, domain, tld, format)
 	if err != nil {
-		return queryOutcome{}, err
+		metrics.RDAPFallbackTotal.WithLabelValues(tld, "failure").Inc()
+		slog.WarnContext(ctx, "WHOIS fallback failed", "domain", domain, "tld", tld, "err", err)
+		return queryOutcome{}, rdapErr
 	}
+	metrics.RDAPFallbackTotal.WithLabelValues(tld, "success").Inc()
+	return outcome, nil
+}
 
-	return queryOutcome{body: string(resultBytes), contentType: "application/json"}, nil
+// followRegistrarLink fetches the registrar's RDAP record linked from the
+// registry's answer and merges it into info. A failed hop is reported in the
+// returned lookup, never as an error: the registry has already answered.
+func followRegistrarLink(ctx context.Context, info *model.DomainInfo, registryResponse string) *model.RegistrarLookup {
+	link := rdap.RegistrarLink(registryResponse)
+	if link == "" {
+		return &model.RegistrarLookup{Status: model.RegistrarLookupNoLink}
+	}
+	lookup := &model.RegistrarLookup{Status: model.RegistrarLookupFailed, URL: link}
+
+	response, err := rdap.RDAPQueryRegistrar(ctx, link)
+	if err != nil {
+		slog.WarnContext(ctx, "registrar RDAP lookup failed", "url", link, "err", err)
+		return lookup
+	}
+	registrar, err := rdap.ParseRDAPResponseforDomain(response)
+	if err != nil {
+		slog.WarnContext(ctx, "registrar RDAP response unparseable", "url", link, "err", err)
+		return lookup
+	}
+	rdap.MergeRegistrar(info, registrar)
+	lookup.Status = model.RegistrarLookupOK
+	return lookup
 }
 
 // queryWhoisRaw queries WHOIS for a domain and returns the unparsed response
-// as text/plain.
+// as text/plain. When the registry refers to a registrar's server, that
+// answer follows the registry's (see whois.JoinHops).
 func queryWhoisRaw(ctx context.Context, domain, tld string) (queryOutcome, error) {
-	queryResult, err := whois.Whois(ctx, domain, tld)
+	hops, err := whois.WhoisWithReferrals(ctx, domain, tld)
 	if err != nil {
 		return queryOutcome{}, err
 	}
 
-	return queryOutcome{body: queryResult, contentType: "text/plain; charset=utf-8"}, nil
+	return queryOutcome{body: whois.JoinHops(hops), contentType: "text/plain; charset=utf-8"}, nil
 }
 
-// queryWhoisDomain queries WHOIS for a domain, parsing the response when a
-// parser exists for the TLD (raw text otherwise).
-func queryWhoisDomain(ctx context.Context, domain, tld string) (queryOutcome, error) {
-	queryResult, err := whois.Whois(ctx, domain, tld)
+// queryWhoisDomain queries WHOIS for a domain, parsing the response into
+// format when a parser exists for the TLD (raw text otherwise). Referrals to
+// registrar servers are followed, and their answers fill what the registry
+// left out.
+func queryWhoisDomain(ctx context.Context, domain, tld string, format Format) (queryOutcome, error) {
+	domainInfo, err := whoisDomainInfo(ctx, domain, tld)
 	if err != nil {
 		return queryOutcome{}, err
 	}
+	return encodeOutcome(format, domainInfo)
+}
 
-	parseFunc, ok := whoisParsers[tld]
-	if !ok {
-		// No parser for this TLD: wrap the raw WHOIS text in the regular JSON
-		// object (unparsed=true) so the endpoint's content type stays stable.
-		// Clients that want the bare text use ?raw=1.
-		info := model.DomainInfo{
-			ObjectClassName: model.ObjectClassDomain,
-			Unparsed:        true,
-			RawText:         queryResult,
-		}
-		finalizeDomainInfo(&info, domain)
-		resultBytes, err := json.Marshal(info)
-		if err != nil {
-			return queryOutcome{}, err
-		}
-		return queryOutcome{body: string(resultBytes), contentType: "application/json"}, nil
+// unparsedDomainInfo answers for a TLD without a parser: the raw WHOIS text
+// wrapped in the regular JSON object (unparsed=true), so the endpoint's
+// content type stays stable. The text is the whole referral chain, as for
+// ?raw=1, so the registrar's answer a referral fetched is not dropped.
+// Clients that want the bare text use ?raw=1.
+func unparsedDomainInfo(hops []whois.Hop, domain string) model.DomainInfo {
+	info := model.DomainInfo{
+		ObjectClassName: model.ObjectClassDomain,
+		Source:          model.DomainSourceWhois,
+		Charset:         hops[0].Charset,
+		Unparsed:        true,
+		RawText:         whois.JoinHops(hops),
 	}
+	finalizeDomainInfo(&info, domain)
+	return info
+}
 
-	var domainInfo model.DomainInfo
-	domainInfo, err = parseFunc(queryResult, domain)
+// whoisDomainInfo is queryWhoisDomain before encoding.
+func whoisDomainInfo(ctx context.Context, domain, tld string) (model.DomainInfo, error) {
+	hops, err := whois.WhoisWithReferrals(ctx, domain, tld)
 	if err != nil {
-		// "resource not found" or other parsing error during the WHOIS parsing
-		return queryOutcome{}, err
+		return model.DomainInfo{}, err
 	}
-	finalizeDomainInfo(&domainInfo, domain)
+	queryResult := hops[0].Text
 
-	resultBytes, err := json.Marshal(domainInfo)
-	if err != nil {
-		return queryOutcome{}, err
+	parseFunc, ok := whoisParser(tld, hops[0].Template)
+	if !ok {
+		return unparsedDomainInfo(hops, domain), nil
 	}
 
-	return queryOutcome{body: string(resultBytes), contentType: "application/json"}, nil
+	domainInfo, err := parseFunc(queryResult, domain)
+	if err != nil {
+		// "resource not found" or other parsing error during the WHOIS parsing
+		return model.DomainInfo{}, err
+	}
+	domainInfo.Source = model.DomainSourceWhois
+	domainInfo.Charset = hops[0].Charset
+	whois.MergeReferrals(&domainInfo, hops[1:], domain)
+	finalizeDomainInfo(&domainInfo, domain)
+	return domainInfo, nil
 }
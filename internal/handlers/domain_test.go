@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/KincaidYang/whois/internal/whois"
+)
+
+// TestUnparsedDomainInfoKeepsReferrals verifies the answer for a TLD without
+// a parser carries the registrar's answer a referral fetched, not just the
+// registry's.
+func TestUnparsedDomainInfoKeepsReferrals(t *testing.T) {
+	info := unparsedDomainInfo([]whois.Hop{
+		{Server: "whois.registry.test", Text: "Domain Name: example.zz\nRegistrar WHOIS Server: whois.registrar.test\n", Charset: "utf-8"},
+		{Server: "whois.registrar.test", Text: "Registrar: Example Registrar, Inc.\n"},
+	}, "example.zz")
+
+	if !info.Unparsed || info.Charset != "utf-8" {
+		t.Errorf("unparsed answer: %+v", info)
+	}
+	for _, want := range []string{"Registrar WHOIS Server: whois.registrar.test", "# whois.registrar.test", "Registrar: Example Registrar, Inc."} {
+		if !strings.Contains(info.RawText, want) {
+			t.Errorf("rawText lacks %q: %q", want, info.RawText)
+		}
+	}
+}
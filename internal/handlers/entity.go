@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/KincaidYang/whois/internal/model"
+	"github.com/KincaidYang/whois/internal/rdap"
+	"github.com/KincaidYang/whois/internal/serverlist"
+	"github.com/KincaidYang/whois/internal/utils"
+)
+
+// HandleEntity handles the HTTP request for querying an RDAP entity (a
+// registrar, registrant, abuse or other contact) by handle. The server is
+// chosen by the handle's object tag (RFC 8521): "ABC123-ARIN" is asked of
+// ARIN. Registries differ on whether handles are case-sensitive, so the
+// handle is passed upstream and keyed in the cache exactly as given, under
+// a separate "entity:" key namespace. Other formats prefix their own
+// namespace ("rdap:entity:"); FormatRDAP is the registry's answer
+// unmodified.
+// When refresh is true the cache read is skipped: the query goes upstream and
+// its result overwrites the cached entry (X-Cache: REFRESH).
+func HandleEntity(ctx context.Context, w http.ResponseWriter, handle string, cacheKeyPrefix string, format Format, refresh bool) {
+	key := fmt.Sprintf("%s%sentity:%s", cacheKeyPrefix, format.keyNamespace(), handle)
+	if serveFromCache(ctx, w, key, format, refresh) != cacheMiss {
+		return
+	}
+
+	serverURLs, tag, ok := serverlist.LookupEntityServers(handle)
+	if !ok {
+		if tag == "" {
+			utils.HandleHTTPError(w, utils.ErrorTypeNotFound, "Entity handle has no object tag: "+handle)
+		} else {
+			utils.HandleHTTPError(w, utils.ErrorTypeNotFound, "No RDAP server known for object tag: "+tag)
+		}
+		return
+	}
+
+	outcome, err := dedupedQuery(ctx, key, refresh, func(qctx context.Context) (queryOutcome, error) {
+		queryResult, err := rdap.RDAPQueryEntity(qctx, handle, serverURLs)
+		if err != nil {
+			return queryOutcome{}, err
+		}
+		if format == FormatRDAP {
+			return passthrough(queryResult), nil
+		}
+
+		entityInfo, err := rdap.ParseRDAPResponseforEntity(queryResult)
+		if err != nil {
+			return queryOutcome{}, err
+		}
+		finalizeEntityInfo(&entityInfo, handle)
+
+		return encodeOutcome(format, entityInfo)
+	})
+	if err != nil {
+		utils.HandleQueryError(ctx, w, err)
+		return
+	}
+
+	writeUpstreamResult(w, outcome, refresh)
+}
+
+// finalizeEntityInfo fills what the registry may leave out, mirroring
+// finalizeNameserverInfo: the queried handle and non-nil lists.
+func finalizeEntityInfo(info *model.EntityInfo, handle string) {
+	if info.Handle == "" {
+		info.Handle = handle
+	}
+	if info.Email == nil {
+		info.Email = []string{}
+	}
+	if info.Tel == nil {
+		info.Tel = []string{}
+	}
+	if info.Status == nil {
+		info.Status = []string{}
+	}
+}
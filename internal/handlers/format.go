@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"encoding/json"
+	"mime"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Format selects the representation a handler answers with. Each
+// non-default format is cached under its own key namespace, so one resource
+// in two representations never shares an entry.
+type Format string
+
+const (
+	// FormatParsed is the normalized JSON model (the default).
+	FormatParsed Format = ""
+	// FormatWhois is the unparsed WHOIS text (?raw); domains only.
+	FormatWhois Format = "whois"
+	// FormatRDAP is the upstream RDAP JSON, unmodified (?format=rdap).
+	FormatRDAP Format = "rdap"
+	// FormatText, FormatCSV and FormatYAML render the normalized model as
+	// aligned whois-style text, a CSV header and row, and YAML.
+	FormatText Format = "text"
+	FormatCSV  Format = "csv"
+	FormatYAML Format = "yaml"
+)
+
+// formatNames maps the values ?format= accepts to formats.
+var formatNames = map[string]Format{
+	"json": FormatParsed,
+	"rdap": FormatRDAP,
+	"text": FormatText,
+	"csv":  FormatCSV,
+	"yaml": FormatYAML,
+}
+
+// acceptTypes maps the media types an Accept header can ask for to formats.
+// The RDAP media type is deliberately absent: RDAP clients send it by
+// habit, and the unmodified answer is only returned when asked for by name.
+var acceptTypes = map[string]Format{
+	"application/json":   FormatParsed,
+	"text/plain":         FormatText,
+	"text/csv":           FormatCSV,
+	"application/yaml":   FormatYAML,
+	"application/x-yaml": FormatYAML,
+	"text/yaml":          FormatYAML,
+}
+
+// NegotiateFormat picks the representation a request asks for: ?format= when
+// present, otherwise the Accept header's most preferred supported type.
+// Anything unsupported in Accept falls back to JSON, so clients sending a
+// browser-style header keep working; ok is false only for an unknown
+// ?format= value.
+func NegotiateFormat(query url.Values, accept string) (format Format, ok bool) {
+	return negotiate(query, accept, formatNames, acceptTypes)
+}
+
+// negotiate implements NegotiateFormat over the given ?format= names and
+// Accept media types, so an endpoint can offer formats of its own (see
+// batchFormatNames).
+func negotiate(query url.Values, accept string, names, types map[string]Format) (format Format, ok bool) {
+	if query.Has("format") {
+		format, ok = names[query.Get("format")]
+		return format, ok
+	}
+
+	bestQ := 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		f, supported := types[mediaType]
+		if !supported {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		// Earlier types win ties, as listed.
+		if q > bestQ {
+			format, bestQ = f, q
+		}
+	}
+	return format, true
+}
+
+// rendered reports whether the format is rendered from the normalized model
+// rather than being JSON or an upstream answer.
+func (f Format) rendered() bool {
+	return f == FormatText || f == FormatCSV || f == FormatYAML
+}
+
+// keyNamespace is the cache key segment of the format's entries.
+func (f Format) keyNamespace() string {
+	switch f {
+	case FormatWhois:
+		return "raw:"
+	case FormatParsed:
+		return ""
+	}
+	return string(f) + ":"
+}
+
+// contentType is the Content-Type of the format's responses.
+func (f Format) contentType() string {
+	switch f {
+	case FormatWhois, FormatText:
+		return "text/plain; charset=utf-8"
+	case FormatRDAP:
+		return "application/rdap+json"
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatYAML:
+		return "application/yaml"
+	}
+	return "application/json"
+}
+
+// passthrough wraps an upstream RDAP answer, unparsed, as a FormatRDAP
+// result.
+func passthrough(response string) queryOutcome {
+	return queryOutcome{body: response, contentType: FormatRDAP.contentType()}
+}
+
+// encodeOutcome serializes a response object in format: JSON, or one of the
+// renderings of it.
+func encodeOutcome(format Format, v any) (queryOutcome, error) {
+	resultBytes, err := json.Marshal(v)
+	if err != nil {
+		return queryOutcome{}, err
+	}
+	body := string(resultBytes)
+	if format.rendered() {
+		if body, err = render(format, resultBytes); err != nil {
+			return queryOutcome{}, err
+		}
+	}
+	return queryOutcome{body: body, contentType: format.contentType()}, nil
+}
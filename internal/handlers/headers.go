@@ -14,11 +14,12 @@ import (
 // enabled the response is marked private: a shared cache (CDN) serving it
 // to other clients would bypass the key check and the per-key rate limit.
 func setCacheControl(w http.ResponseWriter) {
+	s := config.Current()
 	scope := "public"
-	if len(config.AuthClients) > 0 {
+	if len(s.AuthClients) > 0 {
 		scope = "private"
 	}
-	w.Header().Set("Cache-Control", fmt.Sprintf("%s, max-age=%d", scope, int(config.CacheExpiration.Seconds())))
+	w.Header().Set("Cache-Control", fmt.Sprintf("%s, max-age=%d", scope, int(s.CacheExpiration.Seconds())))
 }
 
 // missLabel is the X-Cache value for a response that went upstream: REFRESH
@@ -39,11 +40,11 @@ const (
 	cacheFailed                     // the cache backend failed; an error response has been written
 )
 
-// serveFromCache answers a request from the cached entry for key when there is
-// one. A refresh query skips the lookup entirely: it asked for a forced
-// upstream fetch. Anything other than cacheMiss means the response is already
-// written and the handler is done.
-func serveFromCache(ctx context.Context, w http.ResponseWriter, key string, refresh bool) cacheOutcome {
+// serveFromCache answers a request from the cached entry for key, in format,
+// when there is one. A refresh query skips the lookup entirely: it asked for a
+// forced upstream fetch. Anything other than cacheMiss means the response is
+// already written and the handler is done.
+func serveFromCache(ctx context.Context, w http.ResponseWriter, key string, format Format, refresh bool) cacheOutcome {
 	if refresh {
 		return cacheMiss
 	}
@@ -62,7 +63,7 @@ func serveFromCache(ctx context.Context, w http.ResponseWriter, key string, refr
 		return cacheServed
 	}
 	setCacheControl(w)
-	utils.HandleCacheResponse(w, result.Data, contentType(result.Data))
+	utils.HandleCacheResponse(w, result.Data, contentType(format, result.Data))
 	return cacheServed
 }
 
@@ -75,10 +76,13 @@ func writeUpstreamResult(w http.ResponseWriter, outcome queryOutcome, refresh bo
 	_, _ = fmt.Fprint(w, outcome.body)
 }
 
-// contentType reports how a cached body should be typed. Only ?raw responses
-// are not JSON, and they are stored under their own key namespace, so the
-// first byte tells the two apart.
-func contentType(data string) string {
+// contentType reports how a cached body should be typed. Every format other
+// than the default has its own key namespace, so the format decides; under
+// the default namespace the first byte tells JSON from text.
+func contentType(format Format, data string) string {
+	if format != FormatParsed {
+		return format.contentType()
+	}
 	if len(data) == 0 || data[0] != '{' {
 		return "text/plain; charset=utf-8"
 	}
@@ -12,17 +12,21 @@ import (
 // open instance but marked private once API key authentication is enabled,
 // so a shared cache cannot serve authenticated results past the key check.
 func TestSetCacheControlScope(t *testing.T) {
-	oldClients := config.AuthClients
-	t.Cleanup(func() { config.AuthClients = oldClients })
+	old := config.Current()
+	t.Cleanup(func() { config.SetCurrent(old) })
 
-	config.AuthClients = nil
+	open := *old
+	open.AuthClients = nil
+	config.SetCurrent(&open)
 	w := httptest.NewRecorder()
 	setCacheControl(w)
 	if cc := w.Header().Get("Cache-Control"); !strings.HasPrefix(cc, "public, max-age=") {
 		t.Errorf("open instance: Cache-Control = %q, want public, max-age=...", cc)
 	}
 
-	config.AuthClients = []config.AuthClient{{Name: "test", Key: "k"}}
+	authed := *old
+	authed.AuthClients = []config.AuthClient{{Name: "test", Key: "k"}}
+	config.SetCurrent(&authed)
 	w = httptest.NewRecorder()
 	setCacheControl(w)
 	if cc := w.Header().Get("Cache-Control"); !strings.HasPrefix(cc, "private, max-age=") {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/serverlist"
 	"github.com/KincaidYang/whois/internal/utils"
 )
 
@@ -60,6 +61,50 @@ func getCapacityCheck() Check {
 	return Check{Status: "ok", Message: fmt.Sprintf("%d/%d", currentLoad, config.RateLimit)}
 }
 
+// getBootstrapCheck returns the RDAP bootstrap data staleness check, judged
+// by its oldest category against bootstrap.warnAge and bootstrap.failAge. A
+// category's age is the older of its last fetch (from startup when never
+// fetched) and its publication timestamp, when the file carried one, so a
+// mirror that answers but stopped updating is caught too. ok is false when
+// the check fails; present is false when neither threshold is set.
+func getBootstrapCheck() (check Check, ok, present bool) {
+	if config.BootstrapWarnAge <= 0 && config.BootstrapFailAge <= 0 {
+		return Check{}, true, false
+	}
+	var oldest serverlist.BootstrapCategory
+	oldestAge, fetchAge, publishAge := time.Duration(-1), time.Duration(0), time.Duration(0)
+	for _, c := range serverlist.BootstrapCategories() {
+		fetched := time.Since(startTime)
+		if !c.FetchedAt.IsZero() {
+			fetched = time.Since(c.FetchedAt)
+		}
+		age := fetched
+		var published time.Duration
+		if !c.Publication.IsZero() {
+			published = time.Since(c.Publication)
+			age = max(age, published)
+		}
+		if age > oldestAge {
+			oldest, oldestAge, fetchAge, publishAge = c, age, fetched, published
+		}
+	}
+
+	message := fmt.Sprintf("%s fetched %s ago", oldest.Name, fetchAge.Round(time.Second))
+	if oldest.FetchedAt.IsZero() {
+		message = fmt.Sprintf("%s not fetched since startup %s ago", oldest.Name, fetchAge.Round(time.Second))
+	}
+	if !oldest.Publication.IsZero() {
+		message += fmt.Sprintf(", published %s ago", publishAge.Round(time.Second))
+	}
+	switch {
+	case config.BootstrapFailAge > 0 && oldestAge >= config.BootstrapFailAge:
+		return Check{Status: "fail", Message: message}, false, true
+	case config.BootstrapWarnAge > 0 && oldestAge >= config.BootstrapWarnAge:
+		return Check{Status: "warning", Message: message}, true, true
+	}
+	return Check{Status: "ok", Message: message}, true, true
+}
+
 // HandleHealth handles the /health endpoint
 // Returns basic health status - always returns 200 if the server is running
 func HandleHealth(w http.ResponseWriter, r *http.Request) {
@@ -81,7 +126,8 @@ func HandleHealth(w http.ResponseWriter, r *http.Request) {
 
 // HandleReady handles the /ready endpoint
 // Returns 200 if the service is ready to accept requests
-// Returns 503 if dependencies are not available and requireRedis is true
+// Returns 503 if dependencies are not available and requireRedis is true, or
+// if the RDAP bootstrap data is older than bootstrap.failAge
 func HandleReady(w http.ResponseWriter, r *http.Request) {
 	httpStatus := http.StatusOK
 	overallStatus := "ok"
@@ -107,6 +153,13 @@ func HandleReady(w http.ResponseWriter, r *http.Request) {
 			"capacity": getCapacityCheck(),
 		},
 	}
+	if bootstrapCheck, ok, present := getBootstrapCheck(); present {
+		status.Checks["bootstrap"] = bootstrapCheck
+		if !ok {
+			status.Status = "unavailable"
+			httpStatus = http.StatusServiceUnavailable
+		}
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(httpStatus)
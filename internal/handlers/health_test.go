@@ -3,12 +3,16 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/serverlist"
 	"github.com/KincaidYang/whois/internal/utils"
 )
 
@@ -136,6 +140,79 @@ func TestGetCapacityCheckAtLimit(t *testing.T) {
 	}
 }
 
+// TestBootstrapCheck verifies /ready judges the RDAP bootstrap data by its
+// oldest category, counting a category never fetched from startup and a
+// category's publication timestamp when it is older than its fetch.
+func TestBootstrapCheck(t *testing.T) {
+	saveCacheGlobals(t)
+	mc := utils.NewMemoryCache(4, time.Minute)
+	t.Cleanup(func() { _ = mc.Close() })
+	config.CacheManager = mc
+	config.RequireRedis = false
+	oldWarn, oldFail := config.BootstrapWarnAge, config.BootstrapFailAge
+	t.Cleanup(func() { config.BootstrapWarnAge, config.BootstrapFailAge = oldWarn, oldFail })
+
+	ready := func() (int, HealthStatus) {
+		w := httptest.NewRecorder()
+		HandleReady(w, httptest.NewRequest("GET", "/ready", nil))
+		return w.Code, decodeHealth(t, w)
+	}
+
+	config.BootstrapWarnAge, config.BootstrapFailAge = 0, 0
+	if _, status := ready(); status.Checks["bootstrap"] != (Check{}) {
+		t.Errorf("check present with no thresholds: %+v", status.Checks["bootstrap"])
+	}
+
+	// Nothing has been fetched in this process, so every category is as
+	// old as the process.
+	config.BootstrapWarnAge, config.BootstrapFailAge = time.Nanosecond, time.Hour
+	if code, status := ready(); code != http.StatusOK || status.Checks["bootstrap"].Status != "warning" {
+		t.Errorf("past warnAge: %d %+v, want 200 with a warning", code, status.Checks["bootstrap"])
+	}
+	config.BootstrapWarnAge, config.BootstrapFailAge = 0, time.Nanosecond
+	code, status := ready()
+	if code != http.StatusServiceUnavailable || status.Status != "unavailable" || status.Checks["bootstrap"].Status != "fail" {
+		t.Errorf("past failAge: %d %+v, want 503 with a failed check", code, status)
+	}
+
+	// Freshly fetched data in every category passes.
+	cache := serverlist.FileCache(filepath.Join(t.TempDir(), "bootstrap.json"))
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	categories := make([]string, 0, 5)
+	for _, name := range []string{"dns", "ipv4", "ipv6", "asn", "objecttags"} {
+		categories = append(categories, fmt.Sprintf(`%q:{"fetchedAt":%q,"services":{"zzready":["https://ready.example/"]}}`, name, now))
+	}
+	body := `{"version":1,"categories":{` + strings.Join(categories, ",") + `}}`
+	if err := cache.Save(context.Background(), []byte(body)); err != nil {
+		t.Fatal(err)
+	}
+	serverlist.LoadBootstrapCache(context.Background(), cache)
+	t.Cleanup(func() { serverlist.UpdateFromIANA(nil) })
+
+	config.BootstrapWarnAge, config.BootstrapFailAge = time.Hour, 2*time.Hour
+	if code, status := ready(); code != http.StatusOK || status.Checks["bootstrap"].Status != "ok" {
+		t.Errorf("fresh data: %d %+v, want 200 ok", code, status.Checks["bootstrap"])
+	}
+
+	// Freshly fetched data whose publication is old is as stale as its
+	// publication, and the message names both ages.
+	published := time.Now().Add(-3 * time.Hour).UTC().Format(time.RFC3339)
+	categories[0] = fmt.Sprintf(`"dns":{"fetchedAt":%q,"publication":%q,"services":{"zzready":["https://ready.example/"]}}`, now, published)
+	body = `{"version":1,"categories":{` + strings.Join(categories, ",") + `}}`
+	if err := cache.Save(context.Background(), []byte(body)); err != nil {
+		t.Fatal(err)
+	}
+	serverlist.LoadBootstrapCache(context.Background(), cache)
+	code, status = ready()
+	check := status.Checks["bootstrap"]
+	if code != http.StatusServiceUnavailable || check.Status != "fail" {
+		t.Errorf("old publication: %d %+v, want 503 with a failed check", code, check)
+	}
+	if !strings.Contains(check.Message, "dns fetched") || !strings.Contains(check.Message, "published 3h") {
+		t.Errorf("old publication message = %q, want both ages", check.Message)
+	}
+}
+
 func TestHandleInfoIncludesBuildInfo(t *testing.T) {
 	oldBuild, oldCommit := config.BuildTime, config.GitCommit
 	t.Cleanup(func() { config.BuildTime, config.GitCommit = oldBuild, oldCommit })
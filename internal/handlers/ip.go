@@ -2,26 +2,28 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
+	"net/netip"
 	"strings"
 
+	"github.com/KincaidYang/whois/internal/bgp"
+	"github.com/KincaidYang/whois/internal/model"
 	"github.com/KincaidYang/whois/internal/rdap"
 	"github.com/KincaidYang/whois/internal/serverlist"
 	"github.com/KincaidYang/whois/internal/utils"
 )
 
 // HandleIP function is used to handle the HTTP request for querying the RDAP information for a given IP.
+// The answer is rendered in format; every format but the default is cached
+// under its own key namespace ("rdap:", "text:", ...), and FormatRDAP is the
+// registry's answer unmodified.
 // When refresh is true the cache read is skipped: the query goes upstream and
-// its result overwrites the cached entry (X-Cache: REFRESH).
-func HandleIP(ctx context.Context, w http.ResponseWriter, resource string, cacheKeyPrefix string, refresh bool) {
-	// Check cache first before doing any lookups
-	key := fmt.Sprintf("%s%s", cacheKeyPrefix, resource)
-	if serveFromCache(ctx, w, key, refresh) != cacheMiss {
-		return
-	}
+// its result overwrites the cached entry (X-Cache: REFRESH). The route from
+// the local BGP table is added per response and never cached.
+func HandleIP(ctx context.Context, w http.ResponseWriter, resource string, cacheKeyPrefix string, format Format, refresh bool) {
+	key := fmt.Sprintf("%s%s%s", cacheKeyPrefix, format.keyNamespace(), resource)
 
 	// Parse the IP (for CIDR input, the prefix base address) and find the
 	// RDAP server URL
@@ -30,26 +32,31 @@ func HandleIP(ctx context.Context, w http.ResponseWriter, resource string, cache
 		ipStr = resource[:i]
 	}
 	ip := net.ParseIP(ipStr)
-	serverURL, _ := serverlist.LookupIPKey(ip)
+	serverURLs, _ := serverlist.LookupIPServers(ip)
 
-	// Query and parse the RDAP information, deduplicating concurrent misses
-	outcome, err := dedupedQuery(ctx, key, refresh, func(qctx context.Context) (queryOutcome, error) {
-		queryResult, err := rdap.RDAPQueryIP(qctx, resource, serverURL)
-		if err != nil {
-			return queryOutcome{}, err
-		}
+	if format != FormatRDAP {
+		serveAnnotated(ctx, w, key, format, refresh, func(qctx context.Context) (model.IPInfo, error) {
+			queryResult, err := rdap.RDAPQueryIP(qctx, resource, serverURLs)
+			if err != nil {
+				return model.IPInfo{}, err
+			}
+			return rdap.ParseRDAPResponseforIP(queryResult)
+		}, func(info *model.IPInfo) { annotateRoute(info, resource) })
+		return
+	}
 
-		ipInfo, err := rdap.ParseRDAPResponseforIP(queryResult)
-		if err != nil {
-			return queryOutcome{}, err
-		}
+	// Check cache first before querying
+	if serveFromCache(ctx, w, key, format, refresh) != cacheMiss {
+		return
+	}
 
-		resultBytes, err := json.Marshal(ipInfo)
+	// Query the RDAP information, deduplicating concurrent misses
+	outcome, err := dedupedQuery(ctx, key, refresh, func(qctx context.Context) (queryOutcome, error) {
+		queryResult, err := rdap.RDAPQueryIP(qctx, resource, serverURLs)
 		if err != nil {
 			return queryOutcome{}, err
 		}
-
-		return queryOutcome{body: string(resultBytes), contentType: "application/json"}, nil
+		return passthrough(queryResult), nil
 	})
 	if err != nil {
 		utils.HandleQueryError(ctx, w, err)
@@ -59,3 +66,23 @@ func HandleIP(ctx context.Context, w http.ResponseWriter, resource string, cache
 	// Return the RDAP information
 	writeUpstreamResult(w, outcome, refresh)
 }
+
+// annotateRoute sets the announced prefix covering resource (an address or
+// CIDR prefix) and its origin AS from the local BGP table, when one is
+// loaded, and clears them otherwise. The table is local, so this costs no
+// upstream query.
+func annotateRoute(info *model.IPInfo, resource string) {
+	info.AnnouncedPrefix, info.OriginASN = "", 0
+	p, err := netip.ParsePrefix(resource)
+	if err != nil {
+		addr, err := netip.ParseAddr(resource)
+		if err != nil {
+			return
+		}
+		p = netip.PrefixFrom(addr, addr.BitLen())
+	}
+	if prefix, origin, ok := bgp.Active().Lookup(p); ok {
+		info.AnnouncedPrefix = prefix.String()
+		info.OriginASN = origin
+	}
+}
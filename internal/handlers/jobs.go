@@ -0,0 +1,533 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/metrics"
+	"github.com/KincaidYang/whois/internal/utils"
+	"golang.org/x/time/rate"
+)
+
+// jobConcurrency caps how many of one job's queries run at the same time.
+const jobConcurrency = 5
+
+// jobChunkSize is how many results are stored together under one
+// jobChunkKey. A flush rewrites only the chunks that changed, so its cost
+// follows the progress since the last flush, not the size of the job.
+const jobChunkSize = 100
+
+// maxJobBody bounds the POST /jobs request body: room for the default
+// jobs.maxItems of 10000 queries at up to ~400 bytes each.
+const maxJobBody = 4 << 20 // 4 MiB
+
+// jobFlushInterval is how often a running job's progress is written to the
+// cache for pollers, and how often it checks for a cancellation requested on
+// another instance.
+const jobFlushInterval = 2 * time.Second
+
+// Job statuses. A job is running until every query has an answer
+// (completed), a DELETE stops it (cancelled), or the instance running it
+// shuts down first (interrupted).
+const (
+	jobRunning     = "running"
+	jobCompleted   = "completed"
+	jobCancelled   = "cancelled"
+	jobInterrupted = "interrupted"
+)
+
+// Job is the state of an asynchronous batch job, as returned by /jobs/{id}
+// (see jobRecord for how it is stored). Results has one entry per query in
+// request order; entries of queries not answered yet are null.
+type Job struct {
+	ID        string       `json:"id"`
+	Status    string       `json:"status"`
+	Total     int          `json:"total"`
+	Completed int          `json:"completed"`
+	Failed    int          `json:"failed"`
+	CreatedAt time.Time    `json:"createdAt"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+	Client    string       `json:"client,omitempty"`
+	Results   []*BatchItem `json:"results"`
+}
+
+// jobRecord is the header of a job as stored in the cache under jobKey: the
+// Job served to pollers, less its results, plus the ID of the API key that
+// owns it (see config.AuthClient.ID), which is never served. The results
+// are stored apart, jobChunkSize at a time under jobChunkKey.
+type jobRecord struct {
+	Job
+	// Results shadows Job.Results and stays nil, leaving the results out.
+	Results []*BatchItem `json:"results,omitempty"`
+	Owner   string       `json:"owner,omitempty"`
+}
+
+// jobOwner is the stored owner of a job created by client: its ID, or ""
+// without auth.
+func jobOwner(client *config.AuthClient) string {
+	if client == nil {
+		return ""
+	}
+	return client.ID()
+}
+
+// JobManager runs the asynchronous batch jobs of POST /jobs. A job runs on
+// the instance that accepted it, but its state lives in the cache backend
+// (Redis when configured), so any replica sharing that backend can serve
+// polls and cancellations for it.
+type JobManager struct {
+	// ctx is canceled by Shutdown, interrupting every running job.
+	ctx  context.Context
+	stop context.CancelFunc
+	wg   sync.WaitGroup
+
+	mu      sync.Mutex
+	running map[string]*runningJob
+}
+
+// runningJob is the local handle of a job running on this instance.
+type runningJob struct {
+	cancel    context.CancelFunc
+	cancelled atomic.Bool        // a DELETE, not a shutdown, canceled the job
+	client    *config.AuthClient // the API key that created it; nil without auth
+}
+
+// NewJobManager returns a JobManager ready to accept jobs.
+func NewJobManager() *JobManager {
+	ctx, stop := context.WithCancel(context.Background())
+	return &JobManager{ctx: ctx, stop: stop, running: make(map[string]*runningJob)}
+}
+
+// Shutdown interrupts every running job and waits, until ctx ends, for them
+// to record their final state. No job is accepted afterwards.
+func (m *JobManager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	m.stop()
+	m.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// HandleCreate serves POST /jobs: it validates the queries like /batch does
+// (up to jobs.maxItems of them), stores the new job and answers 202 with its
+// state and a Location to poll, then runs it in the background. Jobs pace
+// themselves to the caller's per-key rate limit rather than failing: each
+// query after the first waits for a token, so a job costs as many tokens as
+// the same queries sent one by one. A job beyond jobs.maxRunningPerKey of
+// the caller's, or jobs.maxRunning in all, is refused with 429 or 503.
+func (m *JobManager) HandleCreate(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	settings := config.Current()
+	if !settings.JobsEnabled {
+		utils.WriteJobsDisabled(w)
+		return
+	}
+	queries, ok := decodeQueries(w, r, maxJobBody, settings.JobsMaxItems, "job")
+	if !ok {
+		return
+	}
+
+	now := time.Now().UTC()
+	job := &Job{
+		ID:        rand.Text(),
+		Status:    jobRunning,
+		Total:     len(queries),
+		CreatedAt: now,
+		UpdatedAt: now,
+		Results:   make([]*BatchItem, len(queries)),
+	}
+	client := config.AuthClientFromContext(ctx)
+	if client != nil {
+		job.Client = client.Name
+	}
+	body, err := json.Marshal(job)
+	if err != nil {
+		utils.HandleInternalError(ctx, w, err)
+		return
+	}
+	record, err := json.Marshal(jobRecord{Job: *job, Owner: jobOwner(client)})
+	if err != nil {
+		utils.HandleInternalError(ctx, w, err)
+		return
+	}
+
+	// The job outlives the request: it keeps the request's values (request
+	// ID, client) for its logs, but only Shutdown or a DELETE stop it.
+	jctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	rj := &runningJob{cancel: cancel, client: client}
+	m.mu.Lock()
+	if m.ctx.Err() != nil {
+		m.mu.Unlock()
+		cancel()
+		utils.HandleHTTPError(w, utils.ErrorTypeInternalServer, "The server is shutting down; submit the job again.")
+		return
+	}
+	if status := m.full(settings, client); status != 0 {
+		m.mu.Unlock()
+		cancel()
+		utils.WriteTooManyJobs(w, status)
+		return
+	}
+	m.running[job.ID] = rj
+	m.wg.Add(1)
+	m.mu.Unlock()
+	stopOnShutdown := context.AfterFunc(m.ctx, cancel)
+
+	saveJob(jctx, job.ID, jobKey(job.ID), record)
+	metrics.JobsRunning.Inc()
+	slog.InfoContext(ctx, "job accepted", "job", job.ID, "queries", len(queries))
+	go func() {
+		defer stopOnShutdown()
+		m.run(jctx, rj, job, queries, client)
+	}()
+
+	w.Header().Set("Location", "/jobs/"+job.ID)
+	writeJob(w, http.StatusAccepted, body)
+}
+
+// full reports whether client may not start another job right now: 429 when
+// it runs jobs.maxRunningPerKey jobs already, 503 when the instance runs
+// jobs.maxRunning. Without auth only the instance-wide cap applies. m.mu
+// must be held.
+func (m *JobManager) full(settings *config.Settings, client *config.AuthClient) int {
+	if client != nil {
+		own := 0
+		for _, rj := range m.running {
+			if rj.client != nil && rj.client.Key == client.Key {
+				own++
+			}
+		}
+		if own >= settings.JobsMaxRunningPerKey {
+			return http.StatusTooManyRequests
+		}
+	}
+	if len(m.running) >= settings.JobsMaxRunning {
+		return http.StatusServiceUnavailable
+	}
+	return 0
+}
+
+// HandleJob serves GET and DELETE /jobs/{id}. GET reports the job's progress
+// and the results so far. DELETE cancels a running job and answers 202 with
+// its current state: queries in flight finish, no new ones start, and the
+// status turns to cancelled shortly after. Deleting a finished job changes
+// nothing. A job is only visible to the API key that created it, told
+// apart by its ID rather than its name, which other keys may share; anyone
+// else gets the same 404 as for an unknown ID.
+func (m *JobManager) HandleJob(ctx context.Context, w http.ResponseWriter, r *http.Request, id string) {
+	if !config.Current().JobsEnabled {
+		utils.WriteJobsDisabled(w)
+		return
+	}
+	job, ok := loadJob(ctx, id)
+	if !ok || job.Owner != jobOwner(config.AuthClientFromContext(ctx)) {
+		utils.HandleHTTPError(w, utils.ErrorTypeNotFound, "No such job: "+id)
+		return
+	}
+	body, err := json.Marshal(&job.Job)
+	if err != nil {
+		utils.HandleInternalError(ctx, w, err)
+		return
+	}
+
+	if r.Method == http.MethodDelete && job.Status == jobRunning {
+		m.cancel(ctx, id)
+		writeJob(w, http.StatusAccepted, body)
+		return
+	}
+	writeJob(w, http.StatusOK, body)
+}
+
+// cancel stops job id: directly when it runs on this instance, and through a
+// cache flag every instance's running jobs check (see jobFlushInterval).
+func (m *JobManager) cancel(ctx context.Context, id string) {
+	m.mu.Lock()
+	rj := m.running[id]
+	m.mu.Unlock()
+	if rj != nil {
+		rj.cancelled.Store(true)
+		rj.cancel()
+	}
+	if err := config.CacheManager.Set(ctx, jobCancelKey(id), "1", config.Current().JobsRetention); err != nil {
+		slog.WarnContext(ctx, "failed to store job cancellation", "job", id, "err", err)
+	}
+}
+
+// clientLimiter returns the rate limiter of client, the API key a job was
+// created with, as currently configured, so a reload that changed the key's
+// rate limit paces the rest of the job. A key a reload removed keeps the
+// limiter the job was created with. Nil without auth or rate limit.
+func clientLimiter(client *config.AuthClient) *rate.Limiter {
+	if client == nil {
+		return nil
+	}
+	for _, c := range config.Current().AuthClients {
+		if c.Key == client.Key {
+			return c.Limiter
+		}
+	}
+	return client.Limiter
+}
+
+// run answers the job's queries with bounded concurrency, flushing progress
+// to the cache every jobFlushInterval, and records the final state. A flush
+// writes the result chunks that changed since the last one, and those about
+// to outlive jobs.retention since they were written, then the header. Each
+// query after the first waits for a token of client's current rate limit
+// (see clientLimiter).
+func (m *JobManager) run(ctx context.Context, rj *runningJob, job *Job, queries []string, client *config.AuthClient) {
+	defer m.wg.Done()
+	defer metrics.JobsRunning.Dec()
+	defer func() {
+		m.mu.Lock()
+		delete(m.running, job.ID)
+		m.mu.Unlock()
+		rj.cancel()
+	}()
+
+	owner := jobOwner(client)
+	chunks := (job.Total + jobChunkSize - 1) / jobChunkSize
+	dirty := make([]bool, chunks)        // chunks changed since their last write
+	savedAt := make([]time.Time, chunks) // zero until a chunk is first written
+	var mu sync.Mutex                    // guards job, dirty and savedAt while queries run
+	flush := func() {
+		mu.Lock()
+		now := time.Now()
+		retention := config.Current().JobsRetention
+		bodies := make(map[int][]byte)
+		for c := range dirty {
+			if dirty[c] || (!savedAt[c].IsZero() && now.Sub(savedAt[c]) >= retention/2) {
+				bodies[c], _ = json.Marshal(job.Results[c*jobChunkSize : min((c+1)*jobChunkSize, job.Total)])
+				dirty[c] = false
+			}
+		}
+		job.UpdatedAt = now.UTC()
+		header, _ := json.Marshal(jobRecord{Job: *job, Owner: owner})
+		mu.Unlock()
+
+		for c, body := range bodies {
+			ok := saveJob(ctx, job.ID, jobChunkKey(job.ID, c), body)
+			mu.Lock()
+			if ok {
+				savedAt[c] = now
+			} else {
+				dirty[c] = true // retried by the next flush
+			}
+			mu.Unlock()
+		}
+		saveJob(ctx, job.ID, jobKey(job.ID), header)
+	}
+
+	stopFlush := make(chan struct{})
+	flushed := make(chan struct{})
+	go func() {
+		defer close(flushed)
+		ticker := time.NewTicker(jobFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopFlush:
+				return
+			case <-ticker.C:
+				if result, err := config.CacheManager.Get(ctx, jobCancelKey(job.ID)); err == nil && result.Found {
+					rj.cancelled.Store(true)
+					rj.cancel()
+				}
+				flush()
+			}
+		}
+	}()
+
+	sem := make(chan struct{}, jobConcurrency)
+	var wg sync.WaitGroup
+dispatch:
+	for i, query := range queries {
+		// The first query rides on the POST's own rate-limit token.
+		if limiter := clientLimiter(client); i > 0 && limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				break
+			}
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break dispatch
+		}
+		wg.Add(1)
+		go func(i int, query string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			item, ok := runJobItem(ctx, query)
+			if !ok {
+				return
+			}
+			mu.Lock()
+			job.Results[i] = &item
+			dirty[i/jobChunkSize] = true
+			job.Completed++
+			if item.Status >= 400 {
+				job.Failed++
+			}
+			mu.Unlock()
+		}(i, query)
+	}
+	wg.Wait()
+	close(stopFlush)
+	<-flushed
+
+	switch {
+	case rj.cancelled.Load():
+		job.Status = jobCancelled
+	case ctx.Err() != nil:
+		job.Status = jobInterrupted
+	default:
+		job.Status = jobCompleted
+	}
+	flush()
+	metrics.JobsTotal.WithLabelValues(job.Status).Inc()
+	slog.InfoContext(ctx, "job finished", "job", job.ID, "status", job.Status, "completed", job.Completed, "total", job.Total)
+}
+
+// jobSlots holds the share of config.ConcurrencyLimiter that job queries
+// may occupy together, so running jobs never take every slot from
+// synchronous requests. It follows the limiter it was sized for (tests
+// replace config.ConcurrencyLimiter).
+var jobSlots struct {
+	mu      sync.Mutex
+	limiter chan struct{}
+	slots   chan struct{}
+}
+
+// currentJobSlots returns the job share of the current concurrency limiter:
+// half of its slots, at least one.
+func currentJobSlots() chan struct{} {
+	jobSlots.mu.Lock()
+	defer jobSlots.mu.Unlock()
+	if jobSlots.limiter != config.ConcurrencyLimiter {
+		jobSlots.limiter = config.ConcurrencyLimiter
+		jobSlots.slots = make(chan struct{}, max(cap(config.ConcurrencyLimiter)/2, 1))
+	}
+	return jobSlots.slots
+}
+
+// runJobItem answers one job query like a batch item, under its own request
+// timeout. It waits for a job slot (see jobSlots), then a concurrency slot,
+// instead of failing when the server is busy. ok is false when the job was
+// stopped before the query got an answer: the item then stays pending
+// rather than reporting the cancellation as its result.
+func runJobItem(ctx context.Context, query string) (item BatchItem, ok bool) {
+	slots, limiter := currentJobSlots(), config.ConcurrencyLimiter
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return BatchItem{}, false
+	}
+	defer func() { <-slots }()
+	select {
+	case limiter <- struct{}{}:
+	case <-ctx.Done():
+		return BatchItem{}, false
+	}
+	defer func() { <-limiter }()
+
+	qctx, cancel := context.WithTimeout(ctx, config.RequestTimeout)
+	defer cancel()
+	item = runBatchItem(qctx, query)
+	if ctx.Err() != nil {
+		return BatchItem{}, false
+	}
+	return item, true
+}
+
+// jobKey is the cache key of a job's header (see jobRecord), jobChunkKey of
+// its results from chunk*jobChunkSize on; jobCancelKey flags a requested
+// cancellation. A colon never occurs in a domain name, so none collides with
+// a query's cache key.
+func jobKey(id string) string { return CacheKeyPrefix + "job:" + id }
+func jobChunkKey(id string, chunk int) string {
+	return CacheKeyPrefix + "job:" + id + ":" + strconv.Itoa(chunk)
+}
+func jobCancelKey(id string) string { return CacheKeyPrefix + "job-cancel:" + id }
+
+// saveJob stores part of job id's state under key for jobs.retention and
+// reports whether it did. A failed write is logged and otherwise ignored:
+// the job keeps running and the next flush retries. The write is detached
+// from ctx so a canceled job still records its final state.
+func saveJob(ctx context.Context, id, key string, body []byte) bool {
+	sctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := config.CacheManager.Set(sctx, key, string(body), config.Current().JobsRetention); err != nil {
+		slog.WarnContext(ctx, "failed to store job state", "job", id, "err", err)
+		return false
+	}
+	return true
+}
+
+// loadJob reads a job's stored header and results. IDs are rand.Text
+// strings, so anything else is rejected before reaching the cache. Results
+// of a chunk not stored yet, or no longer, are null.
+func loadJob(ctx context.Context, id string) (*jobRecord, bool) {
+	if !isJobID(id) {
+		return nil, false
+	}
+	result, err := config.CacheManager.Get(ctx, jobKey(id))
+	if err != nil || !result.Found {
+		return nil, false
+	}
+	var job jobRecord
+	if err := json.Unmarshal([]byte(result.Data), &job); err != nil || job.Total < 0 {
+		return nil, false
+	}
+	job.Job.Results = make([]*BatchItem, job.Total)
+	for c := 0; c*jobChunkSize < job.Total; c++ {
+		result, err := config.CacheManager.Get(ctx, jobChunkKey(id, c))
+		if err != nil || !result.Found {
+			continue
+		}
+		var items []*BatchItem
+		if err := json.Unmarshal([]byte(result.Data), &items); err != nil {
+			continue
+		}
+		copy(job.Job.Results[c*jobChunkSize:], items[:min(len(items), jobChunkSize)])
+	}
+	return &job, true
+}
+
+// isJobID reports whether id has the shape of rand.Text output: 26
+// characters of the base32 alphabet.
+func isJobID(id string) bool {
+	if len(id) != 26 {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if c := id[i]; (c < 'A' || c > 'Z') && (c < '2' || c > '7') {
+			return false
+		}
+	}
+	return true
+}
+
+// writeJob writes a job's state as the response body.
+func writeJob(w http.ResponseWriter, status int, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
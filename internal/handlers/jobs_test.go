@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/KincaidYang/whois/internal/config"
+)
+
+// TestCurrentJobSlots verifies jobs get half of the concurrency limiter, at
+// least one slot, and follow a replaced limiter.
+func TestCurrentJobSlots(t *testing.T) {
+	orig := config.ConcurrencyLimiter
+	t.Cleanup(func() { config.ConcurrencyLimiter = orig })
+
+	for _, tt := range []struct{ limit, want int }{{10, 5}, {3, 1}, {1, 1}} {
+		config.ConcurrencyLimiter = make(chan struct{}, tt.limit)
+		slots := currentJobSlots()
+		if cap(slots) != tt.want {
+			t.Errorf("limit %d: %d job slots, want %d", tt.limit, cap(slots), tt.want)
+		}
+		if again := currentJobSlots(); again != slots {
+			t.Errorf("limit %d: job slots rebuilt for the same limiter", tt.limit)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/KincaidYang/whois/internal/model"
+	"github.com/KincaidYang/whois/internal/rdap"
+	"github.com/KincaidYang/whois/internal/serverlist"
+)
+
+// errNoServer stands in for the answer of a protocol the TLD has no server
+// for, so a merged query treats it like a source that failed.
+var errNoServer = errors.New("no server known for TLD")
+
+// queryMergedDomain queries RDAP and WHOIS for a domain concurrently and
+// answers with the RDAP result, its empty fields filled from WHOIS. When
+// only one of them answers, that answer is returned alone, its fields all
+// attributed to it — except that an RDAP not-found or denied answer is
+// final, as it is without merging.
+func queryMergedDomain(ctx context.Context, domain, tld string, format Format, followRegistrar bool) (queryOutcome, error) {
+	var (
+		wg                  sync.WaitGroup
+		rdapInfo, whoisInfo model.DomainInfo
+		rdapErr, whoisErr   = errNoServer, errNoServer
+	)
+	if _, ok := serverlist.LookupRdapServer(tld); ok {
+		wg.Go(func() { rdapInfo, rdapErr = rdapDomainInfo(ctx, domain, tld, followRegistrar) })
+	}
+	if _, ok := serverlist.LookupWhoisServer(tld); ok {
+		wg.Go(func() { whoisInfo, whoisErr = whoisDomainInfo(ctx, domain, tld) })
+	}
+	wg.Wait()
+
+	switch {
+	case rdapErr == nil:
+		if whoisErr == nil {
+			mergeDomainInfo(&rdapInfo, whoisInfo)
+			return encodeOutcome(format, rdapInfo)
+		}
+		if !errors.Is(whoisErr, errNoServer) {
+			slog.WarnContext(ctx, "WHOIS query for merged answer failed", "domain", domain, "tld", tld, "err", whoisErr)
+		}
+		fillDomainInfo(&rdapInfo, model.DomainInfo{}, model.DomainSourceRDAP)
+		return encodeOutcome(format, rdapInfo)
+	case !errors.Is(rdapErr, errNoServer) && !rdap.IsTransient(rdapErr):
+		return queryOutcome{}, rdapErr
+	case whoisErr == nil:
+		fillDomainInfo(&whoisInfo, model.DomainInfo{}, model.DomainSourceWhois)
+		return encodeOutcome(format, whoisInfo)
+	case errors.Is(rdapErr, errNoServer):
+		return queryOutcome{}, whoisErr
+	default:
+		return queryOutcome{}, rdapErr
+	}
+}
+
+// mergeDomainInfo fills the fields info (the RDAP answer) left empty from
+// secondary (the WHOIS answer), recording in info.Provenance where each
+// populated field came from. info.Source becomes DomainSourceMerged when
+// WHOIS filled anything. An unparsed WHOIS answer has no fields to give.
+func mergeDomainInfo(info *model.DomainInfo, secondary model.DomainInfo) {
+	if fillDomainInfo(info, secondary, model.DomainSourceRDAP) {
+		info.Source = model.DomainSourceMerged
+		info.Charset = secondary.Charset
+	}
+}
+
+// fillDomainInfo fills the fields info left empty from secondary, a WHOIS
+// answer, and sets info.Provenance: the fields info had are attributed to
+// source, the ones filled to WHOIS. It reports whether anything was filled.
+// With an empty secondary it only attributes info's fields to source.
+func fillDomainInfo(info *model.DomainInfo, secondary model.DomainInfo, source string) bool {
+	provenance := make(map[string]string)
+	filled := false
+	// fill records field as coming from source when it has a value there, or
+	// copies it from WHOIS when only WHOIS has one.
+	fill := func(field string, empty, secondaryEmpty bool, copyFrom func()) {
+		switch {
+		case !empty:
+			provenance[field] = source
+		case !secondaryEmpty && !secondary.Unparsed:
+			copyFrom()
+			provenance[field] = model.DomainSourceWhois
+			filled = true
+		}
+	}
+
+	fill("registrar", info.Registrar == "", secondary.Registrar == "",
+		func() { info.Registrar = secondary.Registrar })
+	fill("registrarIanaId", info.RegistrarIANAID == "", secondary.RegistrarIANAID == "",
+		func() { info.RegistrarIANAID = secondary.RegistrarIANAID })
+	fill("status", len(info.Status) == 0, len(secondary.Status) == 0,
+		func() { info.Status = secondary.Status })
+	fill("registrationDate", info.RegistrationDate == "", secondary.RegistrationDate == "",
+		func() { info.RegistrationDate = secondary.RegistrationDate })
+	fill("expirationDate", info.ExpirationDate == "", secondary.ExpirationDate == "",
+		func() { info.ExpirationDate = secondary.ExpirationDate })
+	fill("lastChangedDate", info.LastChangedDate == "", secondary.LastChangedDate == "",
+		func() { info.LastChangedDate = secondary.LastChangedDate })
+	fill("nameservers", len(info.Nameservers) == 0, len(secondary.Nameservers) == 0,
+		func() { info.Nameservers = secondary.Nameservers })
+	fill("secureDNS", info.SecureDNS == nil, secondary.SecureDNS == nil,
+		func() { info.SecureDNS = secondary.SecureDNS })
+	fill("lastUpdateOfRdapDb", info.LastUpdateOfRdapDb == "", true, nil)
+	fill("contacts", len(info.Contacts) == 0, len(secondary.Contacts) == 0,
+		func() { info.Contacts = secondary.Contacts })
+	fill("redactions", len(info.Redactions) == 0, true, nil)
+	fill("registrarExpirationDate", info.RegistrarExpirationDate == "", secondary.RegistrarExpirationDate == "",
+		func() { info.RegistrarExpirationDate = secondary.RegistrarExpirationDate })
+	fill("reseller", info.Reseller == "", secondary.Reseller == "",
+		func() { info.Reseller = secondary.Reseller })
+	fill("registrarAbuseContact", info.RegistrarAbuseContact == nil, secondary.RegistrarAbuseContact == nil,
+		func() { info.RegistrarAbuseContact = secondary.RegistrarAbuseContact })
+
+	info.Provenance = provenance
+	return filled
+}
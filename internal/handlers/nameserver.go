@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/KincaidYang/whois/internal/model"
+	"github.com/KincaidYang/whois/internal/rdap"
+	"github.com/KincaidYang/whois/internal/serverlist"
+	"github.com/KincaidYang/whois/internal/utils"
+	"golang.org/x/net/idna"
+)
+
+// HandleNameserver handles the HTTP request for querying the RDAP nameserver
+// object of a host name (its glue addresses and registry status). Nameserver
+// objects only exist in RDAP, so a TLD without an RDAP server is a 404. The
+// result is cached under a separate "nameserver:" key namespace: a host name
+// is also a valid domain name, and the two answers must never mix. Other
+// formats prefix their own namespace ("rdap:nameserver:"); FormatRDAP is
+// the registry's answer unmodified.
+// When refresh is true the cache read is skipped: the query goes upstream and
+// its result overwrites the cached entry (X-Cache: REFRESH).
+func HandleNameserver(ctx context.Context, w http.ResponseWriter, resource string, cacheKeyPrefix string, format Format, refresh bool) {
+	// Convert the host name to Punycode encoding (supports IDN host names).
+	// Unlike a domain query, the name is not reduced to its registrable
+	// domain: ns1.example.com and ns2.example.com are different objects.
+	name, err := idna.ToASCII(strings.TrimSuffix(resource, "."))
+	if err != nil {
+		utils.HandleHTTPError(w, utils.ErrorTypeBadRequest, "Invalid host name: "+resource)
+		return
+	}
+
+	key := fmt.Sprintf("%s%snameserver:%s", cacheKeyPrefix, format.keyNamespace(), name)
+	if serveFromCache(ctx, w, key, format, refresh) != cacheMiss {
+		return
+	}
+
+	tld := lookupTLD(name)
+	if _, ok := serverlist.LookupRdapServer(tld); !ok {
+		utils.HandleHTTPError(w, utils.ErrorTypeNotFound, "No RDAP server known for TLD: "+tld)
+		return
+	}
+
+	outcome, err := dedupedQuery(ctx, key, refresh, func(qctx context.Context) (queryOutcome, error) {
+		queryResult, err := rdap.RDAPQueryNameserver(qctx, name, tld)
+		if err != nil {
+			return queryOutcome{}, err
+		}
+		if format == FormatRDAP {
+			return passthrough(queryResult), nil
+		}
+
+		nsInfo, err := rdap.ParseRDAPResponseforNameserver(queryResult)
+		if err != nil {
+			return queryOutcome{}, err
+		}
+		finalizeNameserverInfo(&nsInfo, name)
+
+		return encodeOutcome(format, nsInfo)
+	})
+	if err != nil {
+		utils.HandleQueryError(ctx, w, err)
+		return
+	}
+
+	writeUpstreamResult(w, outcome, refresh)
+}
+
+// finalizeNameserverInfo fills what the registry may leave out, mirroring
+// finalizeDomainInfo: the queried name, its Unicode form and non-nil status.
+func finalizeNameserverInfo(info *model.NameserverInfo, name string) {
+	if info.LdhName == "" {
+		info.LdhName = name
+	}
+	if info.UnicodeName == "" {
+		if u, err := idna.ToUnicode(info.LdhName); err == nil {
+			info.UnicodeName = u
+		}
+	}
+	if info.Status == nil {
+		info.Status = []string{}
+	}
+}
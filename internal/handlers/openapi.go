@@ -20,18 +20,27 @@ var openAPISpec []byte
 // alternatives (auth is a deployment choice unknown at build time); when this
 // instance enforces authentication, the anonymous alternative is dropped so a
 // generated client knows a credential is required rather than merely
-// possible. Computed once on first use: configuration is loaded before the
-// server accepts requests and cannot change at runtime.
-var servedOpenAPI = sync.OnceValues(func() ([]byte, string) {
+// possible. Both forms are computed once on first use; which one is served
+// follows the current auth.keys, which a configuration reload may change.
+func servedOpenAPI(authEnabled bool) ([]byte, string) {
+	if authEnabled {
+		return authOpenAPI()
+	}
+	return openOpenAPI()
+}
+
+var openOpenAPI = sync.OnceValues(func() ([]byte, string) {
+	return openAPISpec, utils.ETagFor(openAPISpec)
+})
+
+var authOpenAPI = sync.OnceValues(func() ([]byte, string) {
 	spec := openAPISpec
-	if len(config.AuthClients) > 0 {
-		patched, err := withoutAnonymousSecurity(spec)
-		if err != nil {
-			// Serve the embedded document rather than breaking the endpoint.
-			slog.Warn("could not drop anonymous security from OpenAPI spec", "err", err)
-		} else {
-			spec = patched
-		}
+	patched, err := withoutAnonymousSecurity(spec)
+	if err != nil {
+		// Serve the embedded document rather than breaking the endpoint.
+		slog.Warn("could not drop anonymous security from OpenAPI spec", "err", err)
+	} else {
+		spec = patched
 	}
 	return spec, utils.ETagFor(spec)
 })
@@ -72,10 +81,11 @@ func withoutAnonymousSecurity(spec []byte) ([]byte, error) {
 // HandleOpenAPI serves the OpenAPI 3.1 description of this service, honouring
 // If-None-Match revalidation.
 func HandleOpenAPI(w http.ResponseWriter, r *http.Request) {
-	spec, etag := servedOpenAPI()
+	authEnabled := len(config.Current().AuthClients) > 0
+	spec, etag := servedOpenAPI(authEnabled)
 	w.Header().Set("ETag", etag)
 	scope := "public"
-	if len(config.AuthClients) > 0 {
+	if authEnabled {
 		scope = "private"
 	}
 	w.Header().Set("Cache-Control", scope+", max-age=86400")
@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The renderers work on the JSON a handler would have returned, decoded into
+// a yaml.Node: JSON is valid YAML, and the node tree keeps the fields in the
+// order the model declares them, which a map would lose.
+
+// field is one leaf of a response object: its path of JSON keys (1-based
+// indexes for list elements that are objects) and its text.
+type field struct {
+	path  []string
+	value string
+}
+
+// decodeNode decodes a JSON value into its root node.
+func decodeNode(body []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, fmt.Errorf("render: empty document")
+	}
+	return doc.Content[0], nil
+}
+
+// render converts a JSON response object into a rendered format.
+func render(format Format, body []byte) (string, error) {
+	root, err := decodeNode(body)
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case FormatText:
+		return renderText(root), nil
+	case FormatCSV:
+		fields := flatten(root, nil, nil)
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		header, row := csvRow(fields)
+		_ = w.Write(header)
+		_ = w.Write(row)
+		w.Flush()
+		return buf.String(), w.Error()
+	case FormatYAML:
+		return renderYAML(root)
+	}
+	return string(body), nil
+}
+
+// renderBatch renders batch results. YAML renders the JSON response as a
+// whole. CSV has one row per query — its query, status and error detail,
+// then the union of the data columns of every item ("data." and the path, as
+// in the JSON), in order of first appearance. Text has one block per query under a "# <query>" line.
+func renderBatch(format Format, results []BatchItem) (string, error) {
+	if format == FormatYAML {
+		body, err := json.Marshal(BatchResponse{Results: results})
+		if err != nil {
+			return "", err
+		}
+		return render(format, body)
+	}
+
+	var blocks []string
+	header := []string{"query", "status", "error"}
+	column := make(map[string]int)
+	var rows [][]string
+	for _, item := range results {
+		detail := problemDetail(item.Error)
+		var fields []field
+		if item.Data != nil {
+			root, err := decodeNode(item.Data)
+			if err != nil {
+				return "", err
+			}
+			if format == FormatText {
+				blocks = append(blocks, "# "+item.Query+"\n\n"+renderText(root))
+				continue
+			}
+			fields = flatten(root, nil, nil)
+		}
+		if format == FormatText {
+			blocks = append(blocks, fmt.Sprintf("# %s\n\nError: %d %s\n", item.Query, item.Status, detail))
+			continue
+		}
+
+		row := []string{item.Query, strconv.Itoa(item.Status), detail}
+		names, values := csvRow(fields)
+		for i, name := range names {
+			name = "data." + name
+			c, ok := column[name]
+			if !ok {
+				c = len(header)
+				column[name] = c
+				header = append(header, name)
+			}
+			for len(row) <= c {
+				row = append(row, "")
+			}
+			row[c] = values[i]
+		}
+		rows = append(rows, row)
+	}
+	if format == FormatText {
+		return strings.Join(blocks, "\n"), nil
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(header)
+	for _, row := range rows {
+		for len(row) < len(header) {
+			row = append(row, "")
+		}
+		_ = w.Write(row)
+	}
+	w.Flush()
+	return buf.String(), w.Error()
+}
+
+// problemDetail returns the detail of an RFC 9457 problem object, or "".
+func problemDetail(problem json.RawMessage) string {
+	var p struct {
+		Detail string `json:"detail"`
+	}
+	if problem == nil || json.Unmarshal(problem, &p) != nil {
+		return ""
+	}
+	return p.Detail
+}
+
+// flatten appends the leaves of node to out. A list of scalars yields one
+// field per element under the same path; a list of objects numbers them.
+// Nulls, empty lists and empty objects yield nothing.
+func flatten(node *yaml.Node, path []string, out []field) []field {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			out = flatten(node.Content[i+1], appendPath(path, node.Content[i].Value), out)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			if item.Kind == yaml.ScalarNode {
+				out = flatten(item, path, out)
+			} else {
+				out = flatten(item, appendPath(path, strconv.Itoa(i+1)), out)
+			}
+		}
+	case yaml.ScalarNode:
+		if node.Tag != "!!null" {
+			out = append(out, field{path: path, value: node.Value})
+		}
+	}
+	return out
+}
+
+// appendPath returns path+segment without aliasing path's backing array,
+// which sibling fields share.
+func appendPath(path []string, segment string) []string {
+	out := make([]string, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, segment)
+}
+
+// csvRow turns fields into a header of dotted paths and the matching row. A
+// list of scalars shares one column, its values joined with "; ".
+func csvRow(fields []field) (header, row []string) {
+	column := make(map[string]int)
+	for _, f := range fields {
+		key := strings.Join(f.path, ".")
+		if i, ok := column[key]; ok {
+			row[i] += "; " + f.value
+			continue
+		}
+		column[key] = len(header)
+		header = append(header, key)
+		row = append(row, f.value)
+	}
+	return header, row
+}
+
+// textLabels names fields the way a classic WHOIS client would; fields
+// missing here are labelled by splitting their camelCase key.
+var textLabels = map[string]string{
+	"objectClassName":       "Object Class",
+	"registrarIanaId":       "Registrar IANA ID",
+	"nameservers":           "Name Server",
+	"secureDNS":             "DNSSEC",
+	"dsData":                "DS Data",
+	"keyData":               "Key Data",
+	"lastUpdateOfRdapDb":    "Last Update of RDAP Database",
+	"contacts":              "Contact",
+	"redactions":            "Redaction",
+	"remarks":               "Remark",
+	"cidr":                  "CIDR",
+	"url":                   "URL",
+	"ipAddresses":           "IP Address",
+	"registrarAbuseContact": "Registrar Abuse Contact",
+	"originAsn":             "Origin AS",
+}
+
+// ldhNameLabels names the ldhName field by object class.
+var ldhNameLabels = map[string]string{
+	"domain":     "Domain Name",
+	"nameserver": "Host Name",
+}
+
+// renderText renders a response object as "Label: value" lines with the
+// values aligned, one line per list element, like a classic WHOIS client.
+// A domain no parser exists for is its WHOIS text, so that is returned as
+// is.
+func renderText(root *yaml.Node) string {
+	var objectClass string
+	var unparsed bool
+	var rawText string
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		switch root.Content[i].Value {
+		case "objectClassName":
+			objectClass = root.Content[i+1].Value
+		case "unparsed":
+			unparsed = root.Content[i+1].Value == "true"
+		case "rawText":
+			rawText = root.Content[i+1].Value
+		}
+	}
+	if unparsed && rawText != "" {
+		if !strings.HasSuffix(rawText, "\n") {
+			rawText += "\n"
+		}
+		return rawText
+	}
+
+	fields := flatten(root, nil, nil)
+	labels := make([]string, len(fields))
+	width := 0
+	for i, f := range fields {
+		labels[i] = textLabel(f.path, objectClass) + ":"
+		width = max(width, len(labels[i]))
+	}
+
+	var b strings.Builder
+	indent := "\n" + strings.Repeat(" ", width+1)
+	for i, f := range fields {
+		value := strings.ReplaceAll(strings.TrimRight(f.value, "\n"), "\n", indent)
+		fmt.Fprintf(&b, "%-*s %s\n", width, labels[i], value)
+	}
+	return b.String()
+}
+
+// textLabel joins the labels of a field's path segments ("Contact 1 Email").
+func textLabel(path []string, objectClass string) string {
+	words := make([]string, 0, len(path))
+	for i, segment := range path {
+		label, ok := textLabels[segment]
+		switch {
+		case i == 0 && segment == "ldhName" && ldhNameLabels[objectClass] != "":
+			label = ldhNameLabels[objectClass]
+		case !ok:
+			label = splitCamel(segment)
+		}
+		words = append(words, label)
+	}
+	return strings.Join(words, " ")
+}
+
+// splitCamel turns a camelCase key into capitalized words
+// ("registrationDate" → "Registration Date").
+func splitCamel(key string) string {
+	var b strings.Builder
+	for i, r := range key {
+		switch {
+		case i == 0:
+			r = unicode.ToUpper(r)
+		case unicode.IsUpper(r):
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// renderYAML re-encodes the node tree in block style. Decoding JSON leaves
+// every node in flow style with strings double-quoted; clearing the styles
+// lets the encoder quote only what needs it, and multi-line text (rawText)
+// becomes a literal block.
+func renderYAML(root *yaml.Node) (string, error) {
+	var reset func(n *yaml.Node)
+	reset = func(n *yaml.Node) {
+		n.Style = 0
+		if n.Kind == yaml.ScalarNode && n.Tag == "!!str" && strings.Contains(n.Value, "\n") {
+			n.Style = yaml.LiteralStyle
+		}
+		for _, c := range n.Content {
+			reset(c)
+		}
+	}
+	reset(root)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
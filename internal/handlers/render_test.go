@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/KincaidYang/whois/internal/model"
+)
+
+func testDomainInfo() model.DomainInfo {
+	return model.DomainInfo{
+		ObjectClassName: model.ObjectClassDomain,
+		LdhName:         "example.com",
+		Registrar:       "Example Registrar",
+		Status:          []string{"client transfer prohibited", "server delete prohibited"},
+		ExpirationDate:  "2030-01-01T00:00:00Z",
+		Nameservers:     []string{"a.iana-servers.net", "b.iana-servers.net"},
+		SecureDNS:       &model.SecureDNS{DelegationSigned: false},
+		Contacts:        []model.Contact{{Role: "abuse", Email: "abuse@registrar.example"}},
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	outcome, err := encodeOutcome(FormatText, testDomainInfo())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "" +
+		"Object Class:             domain\n" +
+		"Domain Name:              example.com\n" +
+		"Registrar:                Example Registrar\n" +
+		"Status:                   client transfer prohibited\n" +
+		"Status:                   server delete prohibited\n" +
+		"Expiration Date:          2030-01-01T00:00:00Z\n" +
+		"Name Server:              a.iana-servers.net\n" +
+		"Name Server:              b.iana-servers.net\n" +
+		"DNSSEC Delegation Signed: false\n" +
+		"Contact 1 Role:           abuse\n" +
+		"Contact 1 Email:          abuse@registrar.example\n"
+	if outcome.body != want {
+		t.Errorf("text:\n%s\nwant:\n%s", outcome.body, want)
+	}
+	if outcome.contentType != "text/plain; charset=utf-8" {
+		t.Errorf("content type %q", outcome.contentType)
+	}
+}
+
+// TestRenderTextUnparsed verifies a domain without a parser renders as its
+// WHOIS text rather than one long "Raw Text" field.
+func TestRenderTextUnparsed(t *testing.T) {
+	info := model.DomainInfo{ObjectClassName: model.ObjectClassDomain, LdhName: "example.zz", Unparsed: true, RawText: "Domain: example.zz\nStatus: active"}
+	outcome, err := encodeOutcome(FormatText, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outcome.body != "Domain: example.zz\nStatus: active\n" {
+		t.Errorf("text: %q", outcome.body)
+	}
+}
+
+func TestRenderCSV(t *testing.T) {
+	outcome, err := encodeOutcome(FormatCSV, testDomainInfo())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "objectClassName,ldhName,registrar,status,expirationDate,nameservers,secureDNS.delegationSigned,contacts.1.role,contacts.1.email\n" +
+		"domain,example.com,Example Registrar,client transfer prohibited; server delete prohibited,2030-01-01T00:00:00Z,a.iana-servers.net; b.iana-servers.net,false,abuse,abuse@registrar.example\n"
+	if outcome.body != want {
+		t.Errorf("csv:\n%s\nwant:\n%s", outcome.body, want)
+	}
+}
+
+func TestRenderYAML(t *testing.T) {
+	info := testDomainInfo()
+	info.Nameservers = []string{}
+	info.RawText = "line one\nline two"
+	outcome, err := encodeOutcome(FormatYAML, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"objectClassName: domain\nldhName: example.com\n",
+		"status:\n  - client transfer prohibited\n",
+		// Strings a YAML parser would read as something else stay quoted.
+		`expirationDate: "2030-01-01T00:00:00Z"`,
+		"nameservers: []\n",
+		"contacts:\n  - role: abuse\n",
+		"rawText: |-\n  line one\n  line two\n",
+	} {
+		if !strings.Contains(outcome.body, want) {
+			t.Errorf("yaml missing %q:\n%s", want, outcome.body)
+		}
+	}
+}
+
+func TestRenderBatchCSV(t *testing.T) {
+	ip, _ := json.Marshal(model.IPInfo{ObjectClassName: model.ObjectClassIPNetwork, Handle: "NET-1", Status: []string{}})
+	domain, _ := json.Marshal(model.DomainInfo{ObjectClassName: model.ObjectClassDomain, LdhName: "example.com", Status: []string{"active"}})
+	results := []BatchItem{
+		{Query: "example.com", Status: 200, Data: domain},
+		{Query: "bad!", Status: 400, Error: json.RawMessage(`{"status":400,"detail":"Invalid input."}`)},
+		{Query: "192.0.2.1", Status: 200, Data: ip},
+	}
+	body, err := renderBatch(FormatCSV, results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "query,status,error,data.objectClassName,data.ldhName,data.status,data.handle\n" +
+		"example.com,200,,domain,example.com,active,\n" +
+		"bad!,400,Invalid input.,,,,\n" +
+		"192.0.2.1,200,,ip network,,,NET-1\n"
+	if body != want {
+		t.Errorf("csv:\n%s\nwant:\n%s", body, want)
+	}
+
+	text, err := renderBatch(FormatText, results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text, "# bad!\n\nError: 400 Invalid input.\n") || !strings.Contains(text, "# 192.0.2.1\n\nObject Class: ip network\n") {
+		t.Errorf("text:\n%s", text)
+	}
+}
+
+func TestNegotiateFormat(t *testing.T) {
+	tests := []struct {
+		query  string
+		accept string
+		want   Format
+		ok     bool
+	}{
+		{"", "", FormatParsed, true},
+		{"", "*/*", FormatParsed, true},
+		{"", "text/html,application/xhtml+xml,*/*;q=0.8", FormatParsed, true},
+		{"", "text/plain", FormatText, true},
+		{"", "text/csv; charset=utf-8", FormatCSV, true},
+		{"", "application/yaml", FormatYAML, true},
+		{"", "application/json;q=0.5, text/plain;q=0.9", FormatText, true},
+		{"", "text/plain, application/json", FormatText, true},
+		{"", "text/plain;q=0, application/json", FormatParsed, true},
+		{"", "application/rdap+json", FormatParsed, true},
+		{"format=yaml", "text/plain", FormatYAML, true},
+		{"format=json", "text/plain", FormatParsed, true},
+		{"format=rdap", "", FormatRDAP, true},
+		{"format=xml", "", "", false},
+	}
+	for _, tt := range tests {
+		query, _ := url.ParseQuery(tt.query)
+		got, ok := NegotiateFormat(query, tt.accept)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("NegotiateFormat(%q, %q) = %q, %v; want %q, %v", tt.query, tt.accept, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+
+	"github.com/KincaidYang/whois/internal/rdap"
+	"github.com/KincaidYang/whois/internal/serverlist"
+	"github.com/KincaidYang/whois/internal/utils"
+)
+
+// Reverse delegations are looked up from the zone of the queried prefix up
+// to these lengths' zones at most specific: RIRs register reverse
+// delegations for /24s and /16s (IPv4) and for nibble-aligned IPv6 blocks,
+// in practice no smaller than a /48.
+const (
+	reverseMaxBitsV4 = 24
+	reverseMaxBitsV6 = 48
+	// reverseMinBitsV6 stops the walk up at an RIR's smallest allocation.
+	reverseMinBitsV6 = 32
+)
+
+// HandleReverse handles the HTTP request for the reverse DNS delegation of
+// an IP address or prefix: the RDAP domain object of its in-addr.arpa or
+// ip6.arpa zone, as registered with the RIR holding the address space, with
+// the delegated nameservers and DNSSEC state. The zone of the query itself is
+// tried first, then each less specific one, until the RIR has a delegation
+// (see reverseZones). The result is cached under a separate "reverse:" key
+// namespace; other formats prefix their own ("rdap:reverse:").
+// When refresh is true the cache read is skipped: the query goes upstream and
+// its result overwrites the cached entry (X-Cache: REFRESH).
+func HandleReverse(ctx context.Context, w http.ResponseWriter, resource string, cacheKeyPrefix string, format Format, refresh bool) {
+	prefix, err := netip.ParsePrefix(resource)
+	if err != nil {
+		addr, err := netip.ParseAddr(resource)
+		if err != nil {
+			utils.HandleHTTPError(w, utils.ErrorTypeBadRequest, "Invalid IP address or prefix: "+resource)
+			return
+		}
+		prefix = netip.PrefixFrom(addr, addr.BitLen())
+	}
+
+	key := fmt.Sprintf("%s%sreverse:%s", cacheKeyPrefix, format.keyNamespace(), resource)
+	if serveFromCache(ctx, w, key, format, refresh) != cacheMiss {
+		return
+	}
+
+	serverURLs, ok := serverlist.LookupIPServers(net.IP(prefix.Addr().AsSlice()))
+	if !ok {
+		utils.HandleHTTPError(w, utils.ErrorTypeNotFound, "No RDAP server known for: "+resource)
+		return
+	}
+
+	outcome, err := dedupedQuery(ctx, key, refresh, func(qctx context.Context) (queryOutcome, error) {
+		for _, zone := range reverseZones(prefix) {
+			queryResult, err := rdap.RDAPQueryReverse(qctx, zone, serverURLs)
+			if errors.Is(err, utils.ErrResourceNotFound) {
+				continue
+			}
+			if err != nil {
+				return queryOutcome{}, err
+			}
+			if format == FormatRDAP {
+				return passthrough(queryResult), nil
+			}
+
+			info, err := rdap.ParseRDAPResponseforDomain(queryResult)
+			if err != nil {
+				return queryOutcome{}, err
+			}
+			finalizeDomainInfo(&info, zone)
+
+			return encodeOutcome(format, info)
+		}
+		return queryOutcome{}, utils.ErrResourceNotFound
+	})
+	if err != nil {
+		utils.HandleQueryError(ctx, w, err)
+		return
+	}
+
+	writeUpstreamResult(w, outcome, refresh)
+}
+
+// reverseZones lists the reverse zones a delegation covering p can be
+// registered under, most specific first: p's own zone (no more specific
+// than a /24 or /48), then every less specific one down to a /8 for IPv4 and
+// nibble by nibble down to a /32 for IPv6.
+func reverseZones(p netip.Prefix) []string {
+	var zones []string
+	if p.Addr().Unmap().Is4() {
+		bits := p.Bits()
+		if p.Addr().Is4In6() {
+			bits = max(bits-96, 0)
+		}
+		for bits = min(bits, reverseMaxBitsV4) / 8 * 8; bits >= 8; bits -= 8 {
+			zones = append(zones, utils.ReverseZone(netip.PrefixFrom(p.Addr().Unmap(), bits)))
+		}
+		return zones
+	}
+	bits := min(p.Bits(), reverseMaxBitsV6) / 4 * 4
+	for {
+		zones = append(zones, utils.ReverseZone(netip.PrefixFrom(p.Addr(), bits)))
+		if bits -= 4; bits < reverseMinBitsV6 {
+			return zones
+		}
+	}
+}
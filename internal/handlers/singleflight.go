@@ -149,9 +149,9 @@ func (f *flight) run(ctx context.Context, cacheKey, fkey string, fn func(context
 	switch {
 	case superseded:
 	case err != nil:
-		utils.CacheNegativeResult(qctx, config.CacheManager, cacheKey, err, config.NegativeCacheExpiration)
+		utils.CacheNegativeResult(qctx, config.CacheManager, cacheKey, err, config.Current().NegativeCacheExpiration)
 	default:
-		if err := utils.SetToCache(qctx, config.CacheManager, cacheKey, outcome.body, config.CacheExpiration); err != nil {
+		if err := utils.SetToCache(qctx, config.CacheManager, cacheKey, outcome.body, config.Current().CacheExpiration); err != nil {
 			slog.WarnContext(qctx, "cache write error", "key", cacheKey, "err", err)
 		}
 	}
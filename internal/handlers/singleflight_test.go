@@ -352,17 +352,20 @@ func TestRefreshFlightOwnsCacheEntry(t *testing.T) {
 // gone, and restoring config.CacheManager under it would be a data race.
 func setupFlightTest(t *testing.T) {
 	t.Helper()
-	oldCache, oldLimiter, oldTTL := config.CacheManager, config.ConcurrencyLimiter, config.CacheExpiration
+	oldCache, oldLimiter, oldSettings := config.CacheManager, config.ConcurrencyLimiter, config.Current()
+	s := *oldSettings
 	config.CacheManager = utils.NewMemoryCache(10, time.Minute)
 	config.ConcurrencyLimiter = make(chan struct{}, 4)
-	config.CacheExpiration = time.Minute
+	s.CacheExpiration = time.Minute
+	config.SetCurrent(&s)
 	t.Cleanup(func() {
 		waitFor(t, "the flight registry to drain", func() bool {
 			flightsMu.Lock()
 			defer flightsMu.Unlock()
 			return len(flights) == 0
 		})
-		config.CacheManager, config.ConcurrencyLimiter, config.CacheExpiration = oldCache, oldLimiter, oldTTL
+		config.CacheManager, config.ConcurrencyLimiter = oldCache, oldLimiter
+		config.SetCurrent(oldSettings)
 	})
 }
 
@@ -90,11 +90,11 @@ func whoisLookup(ctx context.Context, _ *mcp.CallToolRequest, input *WhoisInput)
 
 	switch kind {
 	case utils.KindIP:
-		handlers.HandleIP(ctx, rc, query, cacheKeyPrefix, false)
+		handlers.HandleIP(ctx, rc, query, cacheKeyPrefix, handlers.FormatParsed, false)
 	case utils.KindASN:
-		handlers.HandleASN(ctx, rc, query, cacheKeyPrefix, false)
+		handlers.HandleASN(ctx, rc, query, cacheKeyPrefix, handlers.FormatParsed, false)
 	case utils.KindDomain:
-		handlers.HandleDomain(ctx, rc, query, cacheKeyPrefix, false, false)
+		handlers.HandleDomain(ctx, rc, query, cacheKeyPrefix, handlers.FormatParsed, false, false, false)
 	default:
 		recordTool(toolTypeLookup, http.StatusBadRequest, start)
 		return errorResult("Invalid input: please provide a valid domain, IP address, or ASN"), nil, nil
@@ -115,7 +115,8 @@ func whoisLookup(ctx context.Context, _ *mcp.CallToolRequest, input *WhoisInput)
 func whoisBatchLookup(ctx context.Context, _ *mcp.CallToolRequest, input *BatchInput) (*mcp.CallToolResult, any, error) {
 	start := time.Now()
 
-	if !config.BatchEnabled {
+	settings := config.Current()
+	if !settings.BatchEnabled {
 		countTool(toolTypeBatch, http.StatusForbidden)
 		return errorResult("Batch queries are disabled on this instance (batch.enabled)"), nil, nil
 	}
@@ -123,9 +124,9 @@ func whoisBatchLookup(ctx context.Context, _ *mcp.CallToolRequest, input *BatchI
 		countTool(toolTypeBatch, http.StatusBadRequest)
 		return errorResult("The queries list must not be empty"), nil, nil
 	}
-	if len(input.Queries) > config.BatchMaxItems {
+	if len(input.Queries) > settings.BatchMaxItems {
 		countTool(toolTypeBatch, http.StatusBadRequest)
-		return errorResult("Too many queries in one batch: the limit on this instance is " + strconv.Itoa(config.BatchMaxItems)), nil, nil
+		return errorResult("Too many queries in one batch: the limit on this instance is " + strconv.Itoa(settings.BatchMaxItems)), nil, nil
 	}
 
 	config.Wg.Add(1)
@@ -198,7 +199,7 @@ const discoveryTTL = time.Hour
 // list to callers that never presented a key; "private" keeps the response in
 // the requesting client. Open instances keep the SDK's "public".
 func cacheScope() string {
-	if len(config.AuthClients) > 0 {
+	if len(config.Current().AuthClients) > 0 {
 		return "private"
 	}
 	return "public"
@@ -23,13 +23,15 @@ import (
 func setupBatchTest(t *testing.T, enabled bool, maxItems int) {
 	t.Helper()
 	oldCache, oldLimiter := config.CacheManager, config.ConcurrencyLimiter
-	oldEnabled, oldMax := config.BatchEnabled, config.BatchMaxItems
+	old := config.Current()
+	s := *old
 	config.CacheManager = utils.NewMemoryCache(100, time.Minute)
 	config.ConcurrencyLimiter = make(chan struct{}, 4)
-	config.BatchEnabled, config.BatchMaxItems = enabled, maxItems
+	s.BatchEnabled, s.BatchMaxItems = enabled, maxItems
+	config.SetCurrent(&s)
 	t.Cleanup(func() {
 		config.CacheManager, config.ConcurrencyLimiter = oldCache, oldLimiter
-		config.BatchEnabled, config.BatchMaxItems = oldEnabled, oldMax
+		config.SetCurrent(old)
 	})
 }
 
@@ -230,9 +232,11 @@ func TestToolListCacheHintAndAnnotations(t *testing.T) {
 // never authenticated — withAuth keeps such an instance unenumerable.
 func TestToolListCacheScopePrivate(t *testing.T) {
 	setupBatchTest(t, false, 10)
-	old := config.AuthClients
-	config.AuthClients = []config.AuthClient{{Name: "test", Key: "k"}}
-	t.Cleanup(func() { config.AuthClients = old })
+	old := config.Current()
+	s := *old
+	s.AuthClients = []config.AuthClient{{Name: "test", Key: "k"}}
+	config.SetCurrent(&s)
+	t.Cleanup(func() { config.SetCurrent(old) })
 
 	srv := httptest.NewServer(NewHandler("test"))
 	defer srv.Close()
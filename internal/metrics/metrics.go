@@ -56,7 +56,8 @@ var (
 	)
 
 	// UpstreamDuration tracks how long upstream RDAP or WHOIS queries take by protocol and TLD.
-	// For IP queries the tld label is "_ip"; for ASN queries it is "_asn".
+	// For IP queries the tld label is "_ip"; for ASN queries it is "_asn"; for
+	// entity queries it is "_entity"; for reverse DNS zones it is "_reverse".
 	UpstreamDuration = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "whois_upstream_duration_seconds",
@@ -66,6 +67,79 @@ var (
 		[]string{"protocol", "tld"},
 	)
 
+	// RDAPFallbackTotal counts domain queries retried over WHOIS after a
+	// transient RDAP failure, by TLD and result (success/failure).
+	RDAPFallbackTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "whois_rdap_whois_fallback_total",
+			Help: "Domain queries retried over WHOIS after a transient RDAP failure, by TLD and result.",
+		},
+		[]string{"tld", "result"},
+	)
+
+	// RDAPBaseURLAnswersTotal counts answers from RDAP services, by the base
+	// URL that answered and how it was reached: "primary", "failover" (after
+	// the URLs before it failed) or "hedge" (sent while an earlier URL was
+	// still slow).
+	RDAPBaseURLAnswersTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "whois_rdap_base_url_answers_total",
+			Help: "Answers from RDAP services, by base URL and how it was reached.",
+		},
+		[]string{"base_url", "attempt"},
+	)
+
+	// CircuitBreakerState is each upstream's circuit breaker state: 0 closed,
+	// 1 half-open, 2 open.
+	CircuitBreakerState = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "whois_circuit_breaker_state",
+			Help: "Circuit breaker state by upstream (0 closed, 1 half-open, 2 open).",
+		},
+		[]string{"upstream"},
+	)
+
+	// CircuitBreakerRejectionsTotal counts queries refused because their
+	// upstream's circuit breaker was open.
+	CircuitBreakerRejectionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "whois_circuit_breaker_rejections_total",
+			Help: "Queries refused by an open circuit breaker, by upstream.",
+		},
+		[]string{"upstream"},
+	)
+
+	// UpstreamThrottleWait is how long queries waited for their upstream's
+	// servers.limits rate and concurrency limits before being sent.
+	UpstreamThrottleWait = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "whois_upstream_throttle_wait_seconds",
+			Help:    "Time queries waited for an upstream's outbound rate limit, by upstream.",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+		},
+		[]string{"upstream"},
+	)
+
+	// UpstreamThrottleRejectionsTotal counts queries refused because their
+	// upstream's limits could not admit them before the request deadline.
+	UpstreamThrottleRejectionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "whois_upstream_throttle_rejections_total",
+			Help: "Queries refused by an upstream's outbound rate limit, by upstream.",
+		},
+		[]string{"upstream"},
+	)
+
+	// WhoisTranscodedTotal counts WHOIS answers that were not UTF-8 and were
+	// transcoded, by the charset they were decoded from.
+	WhoisTranscodedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "whois_response_transcoded_total",
+			Help: "WHOIS answers transcoded to UTF-8, by source charset.",
+		},
+		[]string{"charset"},
+	)
+
 	// BootstrapRefreshTotal counts IANA bootstrap refresh attempts by result (success/failure/partial).
 	BootstrapRefreshTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -83,4 +157,76 @@ var (
 			Help: "Unix timestamp of the last successful IANA bootstrap fetch. 0 if never fetched.",
 		},
 	)
+
+	// WhoisRefreshTotal counts WHOIS table refreshes from whois.iana.org by result (success/failure/partial).
+	WhoisRefreshTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "whois_server_table_refresh_total",
+			Help: "WHOIS server table refresh attempts from IANA by result.",
+		},
+		[]string{"result"},
+	)
+
+	// WhoisLastRefreshTimestamp is the Unix timestamp of the last fully successful WHOIS table refresh.
+	WhoisLastRefreshTimestamp = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "whois_server_table_last_refresh_timestamp_seconds",
+			Help: "Unix timestamp of the last successful WHOIS server table refresh. 0 if never refreshed.",
+		},
+	)
+
+	// WhoisDiscoveryTotal counts on-demand WHOIS server lookups at whois.iana.org
+	// for TLDs missing from the table, by result (found/none/error).
+	WhoisDiscoveryTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "whois_server_discovery_total",
+			Help: "On-demand WHOIS server discoveries at IANA by result.",
+		},
+		[]string{"result"},
+	)
+
+	// ConfigReloadsTotal counts configuration reloads (SIGHUP or a changed
+	// file) by result (success/failure).
+	ConfigReloadsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "whois_config_reloads_total",
+			Help: "Configuration reloads by result.",
+		},
+		[]string{"result"},
+	)
+
+	// BGPTableLoadsTotal counts loads of the BGP RIB dump (bgp.tableFile) by result (success/failure).
+	BGPTableLoadsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "whois_bgp_table_loads_total",
+			Help: "BGP RIB dump loads by result.",
+		},
+		[]string{"result"},
+	)
+
+	// BGPTablePrefixes is the number of prefixes in the active BGP table. 0 if none is loaded.
+	BGPTablePrefixes = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "whois_bgp_table_prefixes",
+			Help: "Number of prefixes in the active BGP table. 0 if none is loaded.",
+		},
+	)
+
+	// JobsTotal counts finished asynchronous batch jobs by final status
+	// (completed/cancelled/interrupted).
+	JobsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "whois_jobs_total",
+			Help: "Finished asynchronous batch jobs by final status.",
+		},
+		[]string{"status"},
+	)
+
+	// JobsRunning is the number of asynchronous batch jobs running on this instance.
+	JobsRunning = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "whois_jobs_running",
+			Help: "Asynchronous batch jobs running on this instance.",
+		},
+	)
 )
@@ -0,0 +1,21 @@
+package model
+
+// Where an abuse contact was found, reported in AbuseInfo.Source.
+const (
+	AbuseSourceRegistrar = "registrar" // the abuse contact the registrar publishes for a domain
+	AbuseSourceRegistry  = "registry"  // another abuse entity in the registry's domain answer
+	AbuseSourceRIR       = "rir"       // the abuse entity of an IP network or autonomous system
+)
+
+// AbuseInfo is where to report abuse of a domain, IP network or autonomous
+// system: the best abuse mailbox and phone number its registration data
+// publishes.
+type AbuseInfo struct {
+	Query        string `json:"query"`
+	ResourceType string `json:"resourceType"` // "domain", "ip" or "asn"
+	Email        string `json:"email,omitempty"`
+	Phone        string `json:"phone,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Handle       string `json:"handle,omitempty"`
+	Source       string `json:"source"`
+}
@@ -10,4 +10,12 @@ type ASNInfo struct {
 	RegistrationDate string   `json:"registrationDate,omitempty"`
 	LastChangedDate  string   `json:"lastChangedDate,omitempty"`
 	Remarks          []Remark `json:"remarks,omitempty"`
+
+	// Contacts lists the autonomous system's entities, nested ones
+	// included, once per role (see DomainInfo.Contacts).
+	Contacts []Contact `json:"contacts,omitempty"`
+
+	// Prefixes lists the prefixes the AS originates in the local BGP table
+	// (bgp.tableFile), IPv4 first; absent without a table.
+	Prefixes []string `json:"prefixes,omitempty"`
 }
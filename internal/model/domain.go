@@ -3,9 +3,11 @@ package model
 // RDAP object class names (RFC 9083 section 4.7) used as the
 // objectClassName discriminator on responses.
 const (
-	ObjectClassDomain    = "domain"
-	ObjectClassIPNetwork = "ip network"
-	ObjectClassAutnum    = "autnum"
+	ObjectClassDomain     = "domain"
+	ObjectClassIPNetwork  = "ip network"
+	ObjectClassAutnum     = "autnum"
+	ObjectClassNameserver = "nameserver"
+	ObjectClassEntity     = "entity"
 )
 
 // DSData is a DNSSEC delegation signer record (RFC 9083 section 5.3).
@@ -32,6 +34,54 @@ type SecureDNS struct {
 	KeyData          []KeyData `json:"keyData,omitempty"`
 }
 
+// AbuseContact is how to report abuse of a domain to its registrar (ICANN
+// gTLD RDAP profile: the abuse entity nested under the registrar).
+type AbuseContact struct {
+	Email string `json:"email,omitempty"`
+	Phone string `json:"phone,omitempty"`
+}
+
+// Redaction methods (RFC 9537 section 3). An entry that names none used
+// RedactionRemoval.
+const (
+	RedactionRemoval          = "removal"
+	RedactionEmptyValue       = "emptyValue"
+	RedactionPartialValue     = "partialValue"
+	RedactionReplacementValue = "replacementValue"
+)
+
+// Redaction is one field the registry withheld from its RDAP answer
+// (RFC 9537). Name is the registered redaction type ("Registrant Email") or
+// the registry's description; Path is the JSONPath of the hidden value.
+type Redaction struct {
+	Name   string `json:"name"`
+	Method string `json:"method"`
+	Reason string `json:"reason,omitempty"`
+	Path   string `json:"path,omitempty"`
+}
+
+// Registrar lookup outcomes reported in RegistrarLookup.Status.
+const (
+	RegistrarLookupOK     = "ok"      // the registrar's record was fetched and merged
+	RegistrarLookupFailed = "failed"  // the link was followed but the fetch or parse failed
+	RegistrarLookupNoLink = "no-link" // the registry's answer links to no registrar record
+)
+
+// Protocols a domain answer came from, reported in DomainInfo.Source and,
+// per field, in DomainInfo.Provenance.
+const (
+	DomainSourceRDAP   = "rdap"
+	DomainSourceWhois  = "whois"
+	DomainSourceMerged = "merged" // RDAP with fields filled from WHOIS (?source=merged)
+)
+
+// RegistrarLookup reports the hop to the registrar's RDAP record. It is only
+// present when the hop was requested.
+type RegistrarLookup struct {
+	Status string `json:"status"`
+	URL    string `json:"url,omitempty"`
+}
+
 // DomainInfo is the API representation of a domain. Field names follow the
 // RDAP JSON vocabulary (RFC 9083); dates are RFC 3339 UTC (date-only when the
 // registry provides no time of day).
@@ -48,6 +98,33 @@ type DomainInfo struct {
 	Nameservers        []string   `json:"nameservers"`
 	SecureDNS          *SecureDNS `json:"secureDNS,omitempty"`
 	LastUpdateOfRdapDb string     `json:"lastUpdateOfRdapDb,omitempty"`
+	// Source is the protocol that answered: DomainSourceRDAP or
+	// DomainSourceWhois (also when RDAP failed and WHOIS was used instead),
+	// or DomainSourceMerged when WHOIS filled fields RDAP left empty.
+	Source string `json:"source,omitempty"`
+	// Provenance maps each populated field (by JSON name) of a merged answer
+	// to the source it came from, also when only one source answered. Only
+	// present with ?source=merged.
+	Provenance map[string]string `json:"provenance,omitempty"`
+	// Charset is the character set the registry's WHOIS answer was decoded
+	// from ("utf-8", "gb18030", ...); every answer is returned as UTF-8.
+	// Only present when WHOIS answered or, merged, filled a field.
+	Charset string `json:"charset,omitempty"`
+
+	// Contacts lists every entity in the RDAP answer, nested ones included,
+	// once per role.
+	Contacts []Contact `json:"contacts,omitempty"`
+
+	// Redactions lists the fields the registry withheld (RFC 9537).
+	Redactions []Redaction `json:"redactions,omitempty"`
+
+	// Registrar-level data: published by the registrar rather than the
+	// registry, so mostly only present when the registrar's RDAP record was
+	// followed (RegistrarLookup).
+	RegistrarExpirationDate string           `json:"registrarExpirationDate,omitempty"`
+	Reseller                string           `json:"reseller,omitempty"`
+	RegistrarAbuseContact   *AbuseContact    `json:"registrarAbuseContact,omitempty"`
+	RegistrarLookup         *RegistrarLookup `json:"registrarLookup,omitempty"`
 
 	// Unparsed and RawText are set when no parser exists for the TLD: the
 	// registry's WHOIS text is returned verbatim instead of parsed fields.
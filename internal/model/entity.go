@@ -0,0 +1,52 @@
+package model
+
+// Address is a vCard postal address (RFC 6350 section 6.3.1). Registries
+// fill the structured components, or only Label when they publish the
+// address as one preformatted block.
+type Address struct {
+	Street     []string `json:"street,omitempty"`
+	Locality   string   `json:"locality,omitempty"`
+	Region     string   `json:"region,omitempty"`
+	PostalCode string   `json:"postalCode,omitempty"`
+	Country    string   `json:"country,omitempty"`
+	Label      string   `json:"label,omitempty"`
+}
+
+// EntityInfo is the API representation of an RDAP entity (RFC 9083 section
+// 5.1): a registrar, registrant, abuse desk or other contact. The jCard in
+// the entity's vcardArray is flattened into the fields below, keeping the
+// vCard property names.
+type EntityInfo struct {
+	ObjectClassName  string   `json:"objectClassName"` // always ObjectClassEntity
+	Handle           string   `json:"handle"`
+	Roles            []string `json:"roles"`
+	FN               string   `json:"fn,omitempty"`
+	Org              string   `json:"org,omitempty"`
+	Email            []string `json:"email"`
+	Tel              []string `json:"tel"`
+	Adr              *Address `json:"adr,omitempty"`
+	Status           []string `json:"status"`
+	RegistrationDate string   `json:"registrationDate,omitempty"`
+	LastChangedDate  string   `json:"lastChangedDate,omitempty"`
+}
+
+// Contact is one role an entity plays for a domain — registrant,
+// administrative, technical, abuse, registrar and so on — with its vCard
+// reduced to the first email and phone number. An entity holding several
+// roles is listed once per role, so consumers can pick contacts by role
+// alone. Country repeats Address.Country for callers that need nothing else
+// from the address.
+type Contact struct {
+	Role         string   `json:"role"`
+	Handle       string   `json:"handle,omitempty"`
+	Name         string   `json:"name,omitempty"`
+	Organization string   `json:"organization,omitempty"`
+	Email        string   `json:"email,omitempty"`
+	Phone        string   `json:"phone,omitempty"`
+	Country      string   `json:"country,omitempty"`
+	Address      *Address `json:"address,omitempty"`
+
+	// Redacted names the fields above the registry withheld (RFC 9537), so
+	// an empty field can be told apart from a hidden one.
+	Redacted []string `json:"redacted,omitempty"`
+}
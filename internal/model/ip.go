@@ -15,6 +15,16 @@ type IPInfo struct {
 	RegistrationDate string   `json:"registrationDate,omitempty"`
 	LastChangedDate  string   `json:"lastChangedDate,omitempty"`
 	Remarks          []Remark `json:"remarks,omitempty"`
+
+	// Contacts lists the network's entities, nested ones included, once per
+	// role (see DomainInfo.Contacts).
+	Contacts []Contact `json:"contacts,omitempty"`
+
+	// AnnouncedPrefix is the most specific prefix covering the query in the
+	// local BGP table (bgp.tableFile), and OriginASN the AS originating it.
+	// Both are absent without a table or a covering route.
+	AnnouncedPrefix string `json:"announcedPrefix,omitempty"`
+	OriginASN       uint32 `json:"originAsn,omitempty"`
 }
 
 // Remark is additional registry-provided information (RFC 9083 section 4.3).
@@ -0,0 +1,23 @@
+package model
+
+// IPAddresses lists a nameserver's glue addresses by family (RFC 9083
+// section 5.2).
+type IPAddresses struct {
+	V4 []string `json:"v4"`
+	V6 []string `json:"v6"`
+}
+
+// NameserverInfo is the API representation of a nameserver host object.
+// Field names follow the RDAP nameserver object (RFC 9083 section 5.2); the
+// registry's events are flattened into dates like DomainInfo's.
+type NameserverInfo struct {
+	ObjectClassName    string      `json:"objectClassName"` // always ObjectClassNameserver
+	LdhName            string      `json:"ldhName"`
+	UnicodeName        string      `json:"unicodeName,omitempty"`
+	Handle             string      `json:"handle,omitempty"`
+	IPAddresses        IPAddresses `json:"ipAddresses"`
+	Status             []string    `json:"status"`
+	RegistrationDate   string      `json:"registrationDate,omitempty"`
+	LastChangedDate    string      `json:"lastChangedDate,omitempty"`
+	LastUpdateOfRdapDb string      `json:"lastUpdateOfRdapDb,omitempty"`
+}
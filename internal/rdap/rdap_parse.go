@@ -3,6 +3,8 @@ package rdap
 import (
 	"encoding/json"
 	"fmt"
+	"net"
+	"slices"
 	"strings"
 	"time"
 
@@ -23,15 +25,33 @@ type rdapPublicId struct {
 	Identifier string `json:"identifier"`
 }
 
+// rdapEntity is both an entry of another object's entities array and the
+// top-level object of an entity lookup.
 type rdapEntity struct {
+	Handle     string            `json:"handle"`
 	Roles      []string          `json:"roles"`
 	VcardArray []json.RawMessage `json:"vcardArray"`
 	PublicIds  []rdapPublicId    `json:"publicIds"`
 	Entities   []rdapEntity      `json:"entities"`
+	Status     []string          `json:"status"`
+	Events     []rdapEvent       `json:"events"`
 }
 
+type rdapIPAddresses struct {
+	V4 []string `json:"v4"`
+	V6 []string `json:"v6"`
+}
+
+// rdapNameserver is both an entry of a domain's nameservers array and the
+// top-level object of a nameserver lookup; domain responses usually carry
+// only ldhName.
 type rdapNameserver struct {
-	LdhName string `json:"ldhName"`
+	Handle      string          `json:"handle"`
+	LdhName     string          `json:"ldhName"`
+	UnicodeName string          `json:"unicodeName"`
+	IPAddresses rdapIPAddresses `json:"ipAddresses"`
+	Status      []string        `json:"status"`
+	Events      []rdapEvent     `json:"events"`
 }
 
 type rdapDsData struct {
@@ -54,14 +74,22 @@ type rdapSecureDNS struct {
 	KeyData          []rdapKeyData `json:"keyData"`
 }
 
+type rdapLink struct {
+	Rel  string `json:"rel"`
+	Href string `json:"href"`
+	Type string `json:"type"`
+}
+
 type rdapDomainResponse struct {
-	LdhName     string           `json:"ldhName"`
-	UnicodeName string           `json:"unicodeName"`
-	Status      []string         `json:"status"`
-	Entities    []rdapEntity     `json:"entities"`
-	Events      []rdapEvent      `json:"events"`
-	Nameservers []rdapNameserver `json:"nameservers"`
-	SecureDNS   *rdapSecureDNS   `json:"secureDNS"`
+	LdhName     string            `json:"ldhName"`
+	UnicodeName string            `json:"unicodeName"`
+	Status      []string          `json:"status"`
+	Entities    []rdapEntity      `json:"entities"`
+	Events      []rdapEvent       `json:"events"`
+	Nameservers []rdapNameserver  `json:"nameservers"`
+	SecureDNS   *rdapSecureDNS    `json:"secureDNS"`
+	Links       []rdapLink        `json:"links"`
+	Redacted    []json.RawMessage `json:"redacted"`
 }
 
 type rdapCIDR struct {
@@ -86,45 +114,16 @@ type rdapIPResponse struct {
 	Status       []string     `json:"status"`
 	Events       []rdapEvent  `json:"events"`
 	Remarks      []rdapRemark `json:"remarks"`
+	Entities     []rdapEntity `json:"entities"`
 }
 
 type rdapASNResponse struct {
-	Handle  string       `json:"handle"`
-	Name    string       `json:"name"`
-	Status  []string     `json:"status"`
-	Events  []rdapEvent  `json:"events"`
-	Remarks []rdapRemark `json:"remarks"`
-}
-
-// extractRegistrarName extracts the "fn" (full name) property from a vCard array.
-// The vCard format per RFC 7095 is: ["vcard", [["fn", {}, "text", "Name"], ...]]
-func extractRegistrarName(vcardArray []json.RawMessage) string {
-	if len(vcardArray) < 2 {
-		return ""
-	}
-	var properties []json.RawMessage
-	if err := json.Unmarshal(vcardArray[1], &properties); err != nil {
-		return ""
-	}
-	for _, prop := range properties {
-		var fields []json.RawMessage
-		if err := json.Unmarshal(prop, &fields); err != nil {
-			continue
-		}
-		if len(fields) < 4 {
-			continue
-		}
-		var propName string
-		if err := json.Unmarshal(fields[0], &propName); err != nil || propName != "fn" {
-			continue
-		}
-		var value string
-		if err := json.Unmarshal(fields[3], &value); err != nil {
-			continue
-		}
-		return value
-	}
-	return ""
+	Handle   string       `json:"handle"`
+	Name     string       `json:"name"`
+	Status   []string     `json:"status"`
+	Events   []rdapEvent  `json:"events"`
+	Remarks  []rdapRemark `json:"remarks"`
+	Entities []rdapEntity `json:"entities"`
 }
 
 // ParseRDAPResponseforDomain parses the RDAP response for a domain and returns a DomainInfo structure.
@@ -146,15 +145,40 @@ func ParseRDAPResponseforDomain(response string) (model.DomainInfo, error) {
 	// search recurses. Only a public ID typed "IANA Registrar ID" is the IANA
 	// ID — registries also attach other IDs (e.g. Nominet's
 	// "Registry Identifier: NOMINET") that must not be mistaken for it.
-	if registrar := findRegistrarEntity(rdap.Entities); registrar != nil {
-		info.Registrar = extractRegistrarName(registrar.VcardArray)
+	if registrar := findEntityWithRole(rdap.Entities, "registrar"); registrar != nil {
+		info.Registrar = parseVCard(registrar.VcardArray).fn
 		for _, id := range registrar.PublicIds {
 			if strings.EqualFold(id.Type, "IANA Registrar ID") {
 				info.RegistrarIANAID = id.Identifier
 				break
 			}
 		}
+		// The ICANN gTLD profile nests the registrar's abuse contact inside
+		// the registrar entity; registry and registrar both publish it.
+		if abuse := findEntityWithRole(registrar.Entities, "abuse"); abuse != nil {
+			card := parseVCard(abuse.VcardArray)
+			contact := model.AbuseContact{}
+			if len(card.email) > 0 {
+				contact.Email = card.email[0]
+			}
+			if len(card.tel) > 0 {
+				contact.Phone = card.tel[0]
+			}
+			if contact != (model.AbuseContact{}) {
+				info.RegistrarAbuseContact = &contact
+			}
+		}
 	}
+	if reseller := findEntityWithRole(rdap.Entities, "reseller"); reseller != nil {
+		card := parseVCard(reseller.VcardArray)
+		info.Reseller = card.fn
+		if info.Reseller == "" {
+			info.Reseller = card.org
+		}
+	}
+
+	info.Redactions = parseRedactions(rdap.Redacted)
+	info.Contacts = flagRedactedContacts(collectContacts(rdap.Entities, nil), info.Redactions)
 
 	// Extract event dates, normalized to RFC 3339 UTC (unparseable values
 	// are passed through unchanged).
@@ -169,6 +193,8 @@ func ParseRDAPResponseforDomain(response string) (model.DomainInfo, error) {
 			info.LastChangedDate = date
 		case "last update of RDAP database":
 			info.LastUpdateOfRdapDb = date
+		case "registrar expiration":
+			info.RegistrarExpirationDate = date
 		}
 	}
 
@@ -208,24 +234,219 @@ func ParseRDAPResponseforDomain(response string) (model.DomainInfo, error) {
 	return info, nil
 }
 
-// findRegistrarEntity returns the first entity whose roles include
-// "registrar", searching nested entities depth-first. Returns nil when the
-// response has none (registry-operated TLDs like .br carry no registrar
-// entity at all).
-func findRegistrarEntity(entities []rdapEntity) *rdapEntity {
+// findEntityWithRole returns the first entity whose roles include role,
+// searching nested entities depth-first. Returns nil when the response has
+// none (registry-operated TLDs like .br carry no registrar entity at all).
+func findEntityWithRole(entities []rdapEntity, role string) *rdapEntity {
 	for i := range entities {
-		for _, role := range entities[i].Roles {
-			if role == "registrar" {
+		for _, r := range entities[i].Roles {
+			if strings.EqualFold(r, role) {
 				return &entities[i]
 			}
 		}
-		if nested := findRegistrarEntity(entities[i].Entities); nested != nil {
+		if nested := findEntityWithRole(entities[i].Entities, role); nested != nil {
 			return nested
 		}
 	}
 	return nil
 }
 
+// collectContacts appends a Contact for each role of each entity, walking
+// nested entities depth-first so the registrar's abuse desk follows the
+// registrar. Entities with nothing to report beyond their role (fully
+// redacted ones, for instance) are skipped.
+func collectContacts(entities []rdapEntity, out []model.Contact) []model.Contact {
+	for _, entity := range entities {
+		card := parseVCard(entity.VcardArray)
+		contact := model.Contact{
+			Handle:       entity.Handle,
+			Name:         card.fn,
+			Organization: card.org,
+			Address:      card.adr,
+		}
+		if len(card.email) > 0 {
+			contact.Email = card.email[0]
+		}
+		if len(card.tel) > 0 {
+			contact.Phone = card.tel[0]
+		}
+		if card.adr != nil {
+			contact.Country = card.adr.Country
+		}
+		if contact.Handle != "" || contact.Name != "" || contact.Organization != "" ||
+			contact.Email != "" || contact.Phone != "" || contact.Address != nil {
+			for _, role := range entity.Roles {
+				contact.Role = strings.ToLower(role)
+				out = append(out, contact)
+			}
+		}
+		out = collectContacts(entity.Entities, out)
+	}
+	return out
+}
+
+// RegistrarLink returns the URL of the registrar's RDAP record for the
+// domain, as linked from a registry answer: a "related" link of type
+// application/rdap+json (ICANN gTLD RDAP profile). Only HTTPS links are
+// returned, since the hop is made on the strength of an upstream answer.
+// Returns "" when the answer carries no such link or is not valid JSON.
+func RegistrarLink(response string) string {
+	var rdap rdapDomainResponse
+	if err := json.Unmarshal([]byte(response), &rdap); err != nil {
+		return ""
+	}
+	for _, link := range rdap.Links {
+		if !strings.EqualFold(link.Rel, "related") || !strings.HasPrefix(link.Href, "https://") {
+			continue
+		}
+		// Some registries leave the type out; the domain path still
+		// identifies an RDAP domain record rather than a web page.
+		if strings.EqualFold(link.Type, "application/rdap+json") ||
+			(link.Type == "" && strings.Contains(link.Href, "/domain/")) {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+// MergeRegistrar fills info with what the registrar's own record adds to the
+// registry's: the registrar expiration date, the reseller and the abuse
+// contact. Registry fields are authoritative and never overwritten; the
+// registrar's name and IANA ID only fill gaps.
+func MergeRegistrar(info *model.DomainInfo, registrar model.DomainInfo) {
+	if info.RegistrarExpirationDate == "" {
+		info.RegistrarExpirationDate = registrar.RegistrarExpirationDate
+	}
+	if info.Reseller == "" {
+		info.Reseller = registrar.Reseller
+	}
+	if registrar.RegistrarAbuseContact != nil {
+		if info.RegistrarAbuseContact == nil {
+			info.RegistrarAbuseContact = &model.AbuseContact{}
+		}
+		if info.RegistrarAbuseContact.Email == "" {
+			info.RegistrarAbuseContact.Email = registrar.RegistrarAbuseContact.Email
+		}
+		if info.RegistrarAbuseContact.Phone == "" {
+			info.RegistrarAbuseContact.Phone = registrar.RegistrarAbuseContact.Phone
+		}
+	}
+	// Thin registries publish no contacts of their own; the registrar's
+	// record supplies any role the registry's answer lacks.
+	have := make(map[string]bool, len(info.Contacts))
+	for _, c := range info.Contacts {
+		have[c.Role] = true
+	}
+	for _, c := range registrar.Contacts {
+		if !have[c.Role] {
+			info.Contacts = append(info.Contacts, c)
+		}
+	}
+	for _, r := range registrar.Redactions {
+		if !slices.ContainsFunc(info.Redactions, func(have model.Redaction) bool { return have.Name == r.Name }) {
+			info.Redactions = append(info.Redactions, r)
+		}
+	}
+	if info.Registrar == "" {
+		info.Registrar = registrar.Registrar
+	}
+	if info.RegistrarIANAID == "" {
+		info.RegistrarIANAID = registrar.RegistrarIANAID
+	}
+}
+
+// ParseRDAPResponseforNameserver parses the RDAP response for a nameserver
+// host object and returns a NameserverInfo structure.
+func ParseRDAPResponseforNameserver(response string) (model.NameserverInfo, error) {
+	var rdap rdapNameserver
+	if err := json.Unmarshal([]byte(response), &rdap); err != nil {
+		return model.NameserverInfo{}, err
+	}
+
+	// Hostnames are normalized the same way as a domain's nameserver list:
+	// lowercase, without the trailing dot some registries send.
+	info := model.NameserverInfo{
+		ObjectClassName: model.ObjectClassNameserver,
+		LdhName:         strings.TrimSuffix(strings.ToLower(rdap.LdhName), "."),
+		UnicodeName:     strings.TrimSuffix(rdap.UnicodeName, "."),
+		Handle:          rdap.Handle,
+		IPAddresses: model.IPAddresses{
+			V4: cleanAddresses(rdap.IPAddresses.V4),
+			V6: cleanAddresses(rdap.IPAddresses.V6),
+		},
+		Status: model.CleanStatus(rdap.Status),
+	}
+
+	for _, event := range rdap.Events {
+		date, _ := model.NormalizeDate(event.EventDate, time.UTC)
+		switch event.EventAction {
+		case "registration":
+			info.RegistrationDate = date
+		case "last changed":
+			info.LastChangedDate = date
+		case "last update of RDAP database":
+			info.LastUpdateOfRdapDb = date
+		}
+	}
+
+	return info, nil
+}
+
+// ParseRDAPResponseforEntity parses the RDAP response for an entity and
+// returns an EntityInfo structure with its vCard flattened.
+func ParseRDAPResponseforEntity(response string) (model.EntityInfo, error) {
+	var rdap rdapEntity
+	if err := json.Unmarshal([]byte(response), &rdap); err != nil {
+		return model.EntityInfo{}, err
+	}
+
+	card := parseVCard(rdap.VcardArray)
+	info := model.EntityInfo{
+		ObjectClassName: model.ObjectClassEntity,
+		Handle:          rdap.Handle,
+		Roles:           make([]string, 0, len(rdap.Roles)),
+		FN:              card.fn,
+		Org:             card.org,
+		Email:           card.email,
+		Tel:             card.tel,
+		Adr:             card.adr,
+		Status:          model.CleanStatus(rdap.Status),
+	}
+	for _, role := range rdap.Roles {
+		info.Roles = append(info.Roles, strings.ToLower(role))
+	}
+
+	for _, event := range rdap.Events {
+		date, _ := model.NormalizeDate(event.EventDate, time.UTC)
+		switch event.EventAction {
+		case "registration":
+			info.RegistrationDate = date
+		case "last changed":
+			info.LastChangedDate = date
+		}
+	}
+
+	return info, nil
+}
+
+// cleanAddresses canonicalizes glue addresses ("2001:DB8:0::1" →
+// "2001:db8::1") so they compare equal to the IPs this service is queried
+// with. Unparseable entries are passed through unchanged; nil becomes an
+// empty slice so the JSON contains [] instead of null.
+func cleanAddresses(in []string) []string {
+	out := make([]string, 0, len(in))
+	for _, s := range in {
+		s = strings.TrimSpace(s)
+		if ip := net.ParseIP(s); ip != nil {
+			s = ip.String()
+		}
+		if s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
 // ParseRDAPResponseforIP parses the RDAP response for an IP address.
 func ParseRDAPResponseforIP(response string) (model.IPInfo, error) {
 	var rdap rdapIPResponse
@@ -272,6 +493,9 @@ func ParseRDAPResponseforIP(response string) (model.IPInfo, error) {
 		})
 	}
 
+	// RIRs nest the abuse contact under the network's organization.
+	info.Contacts = collectContacts(rdap.Entities, nil)
+
 	return info, nil
 }
 
@@ -306,5 +530,7 @@ func ParseRDAPResponseforASN(response string) (model.ASNInfo, error) {
 		})
 	}
 
+	info.Contacts = collectContacts(rdap.Entities, nil)
+
 	return info, nil
 }
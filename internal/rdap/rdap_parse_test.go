@@ -3,6 +3,7 @@ package rdap
 import (
 	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/KincaidYang/whois/internal/model"
@@ -56,6 +57,7 @@ func TestParseRDAPDomainRegistrar(t *testing.T) {
 				Digest: "BE74359954660069D5C63D200C39F5603827D7DD02B56F120EE9F3A86764247C",
 			}},
 		},
+		Contacts: []model.Contact{{Role: "registrar", Name: "RESERVED-Internet Assigned Numbers Authority"}},
 	}
 	if !reflect.DeepEqual(info, expected) {
 		t.Errorf("expected %+v, got %+v", expected, info)
@@ -188,9 +190,192 @@ func TestParseRDAPDomainMalformed(t *testing.T) {
 	}
 }
 
-// TestExtractRegistrarName covers the malformed-vCard branches: the function
-// must return "" rather than fail on any shape a misbehaving server sends.
-func TestExtractRegistrarName(t *testing.T) {
+// TestParseRDAPDomainRegistrarRecord covers a registrar's own record (the
+// target of a registry's related link): the abuse contact nested in the
+// registrar entity, the reseller and the registrar expiration event.
+func TestParseRDAPDomainRegistrarRecord(t *testing.T) {
+	response := `{
+		"objectClassName": "domain",
+		"ldhName": "example.com",
+		"entities": [{
+			"objectClassName": "entity",
+			"roles": ["registrar"],
+			"vcardArray": ["vcard", [["fn", {}, "text", "Example Registrar, Inc."]]],
+			"entities": [{
+				"objectClassName": "entity",
+				"roles": ["abuse"],
+				"vcardArray": ["vcard", [
+					["email", {}, "text", "abuse@registrar.example"],
+					["email", {}, "text", "second@registrar.example"],
+					["tel", {"type": "voice"}, "uri", "tel:+1.5555550100"]
+				]]
+			}]
+		}, {
+			"objectClassName": "entity",
+			"roles": ["reseller"],
+			"vcardArray": ["vcard", [["org", {}, "text", "Example Reseller LLC"]]]
+		}],
+		"events": [
+			{"eventAction": "expiration", "eventDate": "2030-01-01T00:00:00Z"},
+			{"eventAction": "registrar expiration", "eventDate": "2029-12-31T00:00:00Z"}
+		]
+	}`
+
+	info, err := ParseRDAPResponseforDomain(response)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.ExpirationDate != "2030-01-01T00:00:00Z" {
+		t.Errorf("ExpirationDate = %q", info.ExpirationDate)
+	}
+	if info.RegistrarExpirationDate != "2029-12-31T00:00:00Z" {
+		t.Errorf("RegistrarExpirationDate = %q", info.RegistrarExpirationDate)
+	}
+	if info.Reseller != "Example Reseller LLC" {
+		t.Errorf("Reseller = %q, want org fallback", info.Reseller)
+	}
+	want := &model.AbuseContact{Email: "abuse@registrar.example", Phone: "+1.5555550100"}
+	if !reflect.DeepEqual(info.RegistrarAbuseContact, want) {
+		t.Errorf("RegistrarAbuseContact = %+v, want %+v", info.RegistrarAbuseContact, want)
+	}
+}
+
+// TestParseRDAPDomainContacts covers contact collection: every entity,
+// nested ones included, once per role, with the first email and phone and
+// the address's country; entities with nothing but a role are skipped.
+func TestParseRDAPDomainContacts(t *testing.T) {
+	response := `{
+		"objectClassName": "domain",
+		"ldhName": "example.com",
+		"entities": [{
+			"objectClassName": "entity",
+			"handle": "C123-EXAMPLE",
+			"roles": ["Administrative", "technical"],
+			"vcardArray": ["vcard", [
+				["fn", {}, "text", "Jane Doe"],
+				["org", {}, "text", "Example Org"],
+				["email", {}, "text", "jane@example.com"],
+				["email", {}, "text", "other@example.com"],
+				["tel", {}, "uri", "tel:+1.5555550123"],
+				["adr", {"cc": "US"}, "text", ["", "", "1 Main St", "Springfield", "IL", "62701", ""]]
+			]]
+		}, {
+			"objectClassName": "entity",
+			"roles": ["registrant"],
+			"vcardArray": ["vcard", [["version", {}, "text", "4.0"]]]
+		}, {
+			"objectClassName": "entity",
+			"handle": "292",
+			"roles": ["registrar"],
+			"vcardArray": ["vcard", [["fn", {}, "text", "Example Registrar"]]],
+			"entities": [{
+				"objectClassName": "entity",
+				"roles": ["abuse"],
+				"vcardArray": ["vcard", [["email", {}, "text", "abuse@registrar.example"]]]
+			}]
+		}]
+	}`
+
+	info, err := ParseRDAPResponseforDomain(response)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	adr := &model.Address{Street: []string{"1 Main St"}, Locality: "Springfield", Region: "IL", PostalCode: "62701", Country: "US"}
+	person := model.Contact{
+		Handle: "C123-EXAMPLE", Name: "Jane Doe", Organization: "Example Org",
+		Email: "jane@example.com", Phone: "+1.5555550123", Country: "US", Address: adr,
+	}
+	admin, tech := person, person
+	admin.Role, tech.Role = "administrative", "technical"
+	want := []model.Contact{
+		admin,
+		tech,
+		{Role: "registrar", Handle: "292", Name: "Example Registrar"},
+		{Role: "abuse", Email: "abuse@registrar.example"},
+	}
+	if !reflect.DeepEqual(info.Contacts, want) {
+		t.Errorf("Contacts = %+v, want %+v", info.Contacts, want)
+	}
+}
+
+func TestRegistrarLink(t *testing.T) {
+	tests := []struct {
+		name  string
+		links string
+		want  string
+	}{
+		{"rdap type", `[{"rel":"self","href":"https://rdap.registry.example/domain/example.com","type":"application/rdap+json"},
+			{"rel":"related","href":"https://rdap.registrar.example/domain/example.com","type":"application/rdap+json"}]`,
+			"https://rdap.registrar.example/domain/example.com"},
+		{"typeless domain path", `[{"rel":"related","href":"https://rdap.registrar.example/domain/example.com"}]`,
+			"https://rdap.registrar.example/domain/example.com"},
+		{"web page", `[{"rel":"related","href":"https://registrar.example/whois","type":"text/html"}]`, ""},
+		{"typeless non-domain path", `[{"rel":"related","href":"https://registrar.example/"}]`, ""},
+		{"plain http", `[{"rel":"related","href":"http://rdap.registrar.example/domain/example.com","type":"application/rdap+json"}]`, ""},
+		{"no links", `[]`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := `{"objectClassName":"domain","ldhName":"example.com","links":` + tt.links + `}`
+			if got := RegistrarLink(response); got != tt.want {
+				t.Errorf("RegistrarLink() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if got := RegistrarLink(`not json`); got != "" {
+		t.Errorf("RegistrarLink(invalid) = %q, want empty", got)
+	}
+}
+
+func TestMergeRegistrar(t *testing.T) {
+	info := model.DomainInfo{
+		Registrar:             "Registry-Side Name",
+		RegistrarAbuseContact: &model.AbuseContact{Email: "abuse@registry.example"},
+	}
+	MergeRegistrar(&info, model.DomainInfo{
+		Registrar:               "Registrar-Side Name",
+		RegistrarIANAID:         "9999",
+		RegistrarExpirationDate: "2029-12-31T00:00:00Z",
+		Reseller:                "Example Reseller",
+		RegistrarAbuseContact:   &model.AbuseContact{Email: "abuse@registrar.example", Phone: "+1.5555550100"},
+	})
+
+	if info.Registrar != "Registry-Side Name" {
+		t.Errorf("Registrar overwritten: %q", info.Registrar)
+	}
+	if info.RegistrarIANAID != "9999" {
+		t.Errorf("RegistrarIANAID gap not filled: %q", info.RegistrarIANAID)
+	}
+	if info.RegistrarExpirationDate != "2029-12-31T00:00:00Z" || info.Reseller != "Example Reseller" {
+		t.Errorf("registrar fields not merged: %+v", info)
+	}
+	want := &model.AbuseContact{Email: "abuse@registry.example", Phone: "+1.5555550100"}
+	if !reflect.DeepEqual(info.RegistrarAbuseContact, want) {
+		t.Errorf("RegistrarAbuseContact = %+v, want %+v", info.RegistrarAbuseContact, want)
+	}
+}
+
+// TestMergeRegistrarContacts verifies the registrar's contacts only fill
+// roles the registry's answer lacks.
+func TestMergeRegistrarContacts(t *testing.T) {
+	info := model.DomainInfo{Contacts: []model.Contact{{Role: "registrar", Name: "Registry-Side Name"}}}
+	MergeRegistrar(&info, model.DomainInfo{Contacts: []model.Contact{
+		{Role: "registrar", Name: "Registrar-Side Name"},
+		{Role: "registrant", Organization: "Example Org"},
+	}})
+	want := []model.Contact{
+		{Role: "registrar", Name: "Registry-Side Name"},
+		{Role: "registrant", Organization: "Example Org"},
+	}
+	if !reflect.DeepEqual(info.Contacts, want) {
+		t.Errorf("Contacts = %+v, want %+v", info.Contacts, want)
+	}
+}
+
+// TestParseVCardMalformed covers the malformed-vCard branches: the parser
+// must return an empty fn rather than fail on any shape a misbehaving server
+// sends.
+func TestParseVCardMalformed(t *testing.T) {
 	raw := func(parts ...string) []json.RawMessage {
 		out := make([]json.RawMessage, len(parts))
 		for i, p := range parts {
@@ -215,7 +400,7 @@ func TestExtractRegistrarName(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := extractRegistrarName(tt.vcard); got != tt.want {
+			if got := parseVCard(tt.vcard).fn; got != tt.want {
 				t.Errorf("got %q, want %q", got, tt.want)
 			}
 		})
@@ -282,6 +467,48 @@ func TestParseRDAPIPV6Prefix(t *testing.T) {
 	}
 }
 
+// TestParseRDAPIPContacts verifies an RIR's abuse entity, nested under the
+// network's organization, is reported alongside its parent.
+func TestParseRDAPIPContacts(t *testing.T) {
+	response := `{
+		"handle": "NET-192-0-2-0-1",
+		"entities": [{
+			"handle": "EXAMPLE-ORG",
+			"roles": ["registrant"],
+			"vcardArray": ["vcard", [["fn", {}, "text", "Example Org"]]],
+			"entities": [{
+				"handle": "ABUSE-ARIN",
+				"roles": ["abuse"],
+				"vcardArray": ["vcard", [
+					["fn", {}, "text", "Abuse Desk"],
+					["email", {}, "text", "abuse@example.net"],
+					["tel", {"type": "work"}, "text", "+1-555-555-0100"]
+				]]
+			}]
+		}]
+	}`
+
+	info, err := ParseRDAPResponseforIP(response)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []model.Contact{
+		{Role: "registrant", Handle: "EXAMPLE-ORG", Name: "Example Org"},
+		{Role: "abuse", Handle: "ABUSE-ARIN", Name: "Abuse Desk", Email: "abuse@example.net", Phone: "+1-555-555-0100"},
+	}
+	if !reflect.DeepEqual(info.Contacts, expected) {
+		t.Errorf("expected %+v, got %+v", expected, info.Contacts)
+	}
+
+	asn, err := ParseRDAPResponseforASN(response)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(asn.Contacts, expected) {
+		t.Errorf("ASN: expected %+v, got %+v", expected, asn.Contacts)
+	}
+}
+
 func TestParseRDAPIPMalformed(t *testing.T) {
 	if _, err := ParseRDAPResponseforIP(`not json`); err == nil {
 		t.Fatal("expected error for malformed JSON")
@@ -325,3 +552,155 @@ func TestParseRDAPASNMalformed(t *testing.T) {
 		t.Fatal("expected error for malformed JSON")
 	}
 }
+
+// TestParseRDAPNameserver covers a registry nameserver object with glue in
+// both families; addresses are canonicalized and the hostname normalized.
+func TestParseRDAPNameserver(t *testing.T) {
+	response := `{
+		"objectClassName": "nameserver",
+		"handle": "NS1_EXAMPLE-VRSN",
+		"ldhName": "NS1.EXAMPLE.COM.",
+		"ipAddresses": {
+			"v4": ["192.0.2.53"],
+			"v6": ["2001:DB8:0:0::53"]
+		},
+		"status": ["active"],
+		"events": [
+			{"eventAction": "registration", "eventDate": "2001-05-01T00:00:00Z"},
+			{"eventAction": "last changed", "eventDate": "2024-02-03T10:00:00Z"},
+			{"eventAction": "last update of RDAP database", "eventDate": "2026-01-16T10:26:40Z"}
+		]
+	}`
+
+	info, err := ParseRDAPResponseforNameserver(response)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := model.NameserverInfo{
+		ObjectClassName: model.ObjectClassNameserver,
+		LdhName:         "ns1.example.com",
+		Handle:          "NS1_EXAMPLE-VRSN",
+		IPAddresses: model.IPAddresses{
+			V4: []string{"192.0.2.53"},
+			V6: []string{"2001:db8::53"},
+		},
+		Status:             []string{"active"},
+		RegistrationDate:   "2001-05-01T00:00:00Z",
+		LastChangedDate:    "2024-02-03T10:00:00Z",
+		LastUpdateOfRdapDb: "2026-01-16T10:26:40Z",
+	}
+	if !reflect.DeepEqual(info, expected) {
+		t.Errorf("expected %+v, got %+v", expected, info)
+	}
+}
+
+// TestParseRDAPNameserverNoGlue verifies a host outside the registry's zone
+// (no glue) still reports both address lists, empty rather than null.
+func TestParseRDAPNameserverNoGlue(t *testing.T) {
+	info, err := ParseRDAPResponseforNameserver(`{"ldhName": "ns.example.net"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.IPAddresses.V4 == nil || info.IPAddresses.V6 == nil {
+		t.Errorf("address lists should be empty, not nil: %+v", info.IPAddresses)
+	}
+	b, _ := json.Marshal(info)
+	if !strings.Contains(string(b), `"ipAddresses":{"v4":[],"v6":[]}`) {
+		t.Errorf("JSON: %s", b)
+	}
+}
+
+func TestParseRDAPNameserverMalformed(t *testing.T) {
+	if _, err := ParseRDAPResponseforNameserver(`{`); err == nil {
+		t.Fatal("expected error for malformed JSON")
+	}
+}
+
+// TestParseRDAPEntity covers an ARIN-shaped entity: tel typed as a URI,
+// an address with a label parameter and structured components, and
+// upper-case roles.
+func TestParseRDAPEntity(t *testing.T) {
+	response := `{
+		"objectClassName": "entity",
+		"handle": "ABC123-ARIN",
+		"roles": ["Abuse", "technical"],
+		"vcardArray": ["vcard", [
+			["version", {}, "text", "4.0"],
+			["fn", {}, "text", "Example Abuse Desk"],
+			["org", {}, "text", ["Example Networks", "Abuse"]],
+			["kind", {}, "text", "group"],
+			["adr", {"label": "1 Main St\nAnytown\nVA\n20001\nUnited States"}, "text",
+				["", "Suite 100", "1 Main St", "Anytown", "VA", "20001", "United States"]],
+			["tel", {"type": ["work", "voice"]}, "uri", "tel:+1-555-555-0100"],
+			["tel", {"type": "fax"}, "text", "+1-555-555-0199"],
+			["email", {}, "text", "abuse@example.net"]
+		]],
+		"status": ["validated"],
+		"events": [
+			{"eventAction": "registration", "eventDate": "2003-03-01T00:00:00-05:00"},
+			{"eventAction": "last changed", "eventDate": "2024-02-03T10:00:00Z"}
+		]
+	}`
+
+	info, err := ParseRDAPResponseforEntity(response)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := model.EntityInfo{
+		ObjectClassName: model.ObjectClassEntity,
+		Handle:          "ABC123-ARIN",
+		Roles:           []string{"abuse", "technical"},
+		FN:              "Example Abuse Desk",
+		Org:             "Example Networks, Abuse",
+		Email:           []string{"abuse@example.net"},
+		Tel:             []string{"+1-555-555-0100", "+1-555-555-0199"},
+		Adr: &model.Address{
+			Street:     []string{"Suite 100", "1 Main St"},
+			Locality:   "Anytown",
+			Region:     "VA",
+			PostalCode: "20001",
+			Country:    "United States",
+			Label:      "1 Main St\nAnytown\nVA\n20001\nUnited States",
+		},
+		Status:           []string{"validated"},
+		RegistrationDate: "2003-03-01T05:00:00Z",
+		LastChangedDate:  "2024-02-03T10:00:00Z",
+	}
+	if !reflect.DeepEqual(info, expected) {
+		t.Errorf("expected %+v, got %+v", expected, info)
+	}
+}
+
+// TestParseVCardAddress covers the address shapes registries send besides the
+// full structured form.
+func TestParseVCardAddress(t *testing.T) {
+	tests := []struct {
+		name string
+		prop string
+		want *model.Address
+	}{
+		{"label only", `["adr", {"label": "Box 1, Berlin"}, "text", ""]`, &model.Address{Label: "Box 1, Berlin"}},
+		{"country code parameter", `["adr", {"cc": "DE"}, "text", ["", "", "", "Berlin", "", "", ""]]`,
+			&model.Address{Locality: "Berlin", Country: "DE"}},
+		{"multi-line street", `["adr", {}, "text", ["", "", ["Line 1", "Line 2"], "", "", "", "JP"]]`,
+			&model.Address{Street: []string{"Line 1", "Line 2"}, Country: "JP"}},
+		{"all empty", `["adr", {}, "text", ["", "", "", "", "", "", ""]]`, nil},
+		{"short component list", `["adr", {}, "text", ["", "", "Main St"]]`, &model.Address{Street: []string{"Main St"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := parseVCard([]json.RawMessage{json.RawMessage(`"vcard"`), json.RawMessage(`[` + tt.prop + `]`)})
+			if !reflect.DeepEqual(card.adr, tt.want) {
+				t.Errorf("got %+v, want %+v", card.adr, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRDAPEntityMalformed(t *testing.T) {
+	if _, err := ParseRDAPResponseforEntity(`{"handle": 1}`); err == nil {
+		t.Fatal("expected error for malformed JSON")
+	}
+}
@@ -2,18 +2,22 @@ package rdap
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
-	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/KincaidYang/whois/internal/breaker"
 	"github.com/KincaidYang/whois/internal/config"
 	"github.com/KincaidYang/whois/internal/metrics"
 	"github.com/KincaidYang/whois/internal/serverlist"
+	"github.com/KincaidYang/whois/internal/throttle"
 	"github.com/KincaidYang/whois/internal/utils"
 )
 
@@ -21,30 +25,51 @@ import (
 // a misbehaving or malicious server exhausting memory.
 const maxResponseSize = 2 << 20 // 2 MiB
 
-// proxyClient is a pre-built HTTP client for proxied RDAP requests.
-// Built once on first use (after config.Load has run) to enable connection
-// pool reuse across requests.
-var proxyClient *http.Client
-
-// proxySuffixSet is a set built from config.ProxySuffixes for O(1) lookup.
-var proxySuffixSet map[string]struct{}
-
-// proxyOnce defers proxy setup until the first query, because the config
-// package no longer initializes itself in init(); proxy settings only exist
-// once config.Load has been called.
-var proxyOnce sync.Once
-
-func initProxy() {
-	if config.ProxyServer != "" {
-		// config.Load validated the URL at startup (an invalid proxy.server
-		// fails the process rather than silently sending traffic direct), so
-		// the error branch here is purely defensive.
-		proxyURL, err := url.Parse(config.ProxyServer)
+// proxyState is the HTTP client for proxied RDAP requests, built from one
+// config.Settings and reused for as long as those settings are in effect,
+// so proxied requests share a connection pool.
+type proxyState struct {
+	settings *config.Settings
+	// client is nil when no proxy is configured.
+	client *http.Client
+	// suffixes is a set built from the settings' ProxySuffixes for O(1)
+	// lookup.
+	suffixes map[string]struct{}
+}
+
+// proxy caches the proxyState for the current settings. It is rebuilt on
+// first use after a configuration reload swaps the settings.
+var proxy atomic.Pointer[proxyState]
+
+// currentProxy returns the proxyState for the settings in effect. A reload
+// that leaves the proxy server and credentials unchanged keeps the old
+// client, and with it its pooled connections.
+func currentProxy() *proxyState {
+	s := config.Current()
+	old := proxy.Load()
+	if old != nil && old.settings == s {
+		return old
+	}
+
+	p := &proxyState{settings: s, suffixes: make(map[string]struct{}, len(s.ProxySuffixes))}
+	for _, suffix := range s.ProxySuffixes {
+		p.suffixes[suffix] = struct{}{}
+	}
+	switch {
+	case s.ProxyServer == "":
+	case old != nil && old.client != nil && old.settings.ProxyServer == s.ProxyServer &&
+		old.settings.ProxyUsername == s.ProxyUsername && old.settings.ProxyPassword == s.ProxyPassword:
+		p.client = old.client
+	default:
+		// config validated the URL on load (an invalid proxy.server is
+		// rejected rather than silently sending traffic direct), so the
+		// error branch here is purely defensive.
+		proxyURL, err := url.Parse(s.ProxyServer)
 		if err == nil {
-			if config.ProxyUsername != "" && config.ProxyPassword != "" {
-				proxyURL.User = url.UserPassword(config.ProxyUsername, config.ProxyPassword)
+			if s.ProxyUsername != "" && s.ProxyPassword != "" {
+				proxyURL.User = url.UserPassword(s.ProxyUsername, s.ProxyPassword)
 			}
-			proxyClient = &http.Client{
+			p.client = &http.Client{
 				Timeout: config.HttpClient.Timeout,
 				Transport: &http.Transport{
 					Proxy:               http.ProxyURL(proxyURL),
@@ -55,35 +80,124 @@ func initProxy() {
 			}
 		}
 	}
-
-	proxySuffixSet = make(map[string]struct{}, len(config.ProxySuffixes))
-	for _, s := range config.ProxySuffixes {
-		proxySuffixSet[s] = struct{}{}
-	}
+	proxy.Store(p)
+	return p
 }
 
 // getHTTPClient returns an HTTP client with appropriate proxy settings.
-// Returns the pre-built proxyClient for proxied TLDs, or config.HttpClient otherwise.
+// Returns the proxy client for proxied TLDs, or config.HttpClient otherwise.
 func getHTTPClient(tld string) *http.Client {
-	proxyOnce.Do(initProxy)
-	if proxyClient != nil {
-		_, matchTLD := proxySuffixSet[tld]
-		_, matchAll := proxySuffixSet["all"]
+	p := currentProxy()
+	if p.client != nil {
+		_, matchTLD := p.suffixes[tld]
+		_, matchAll := p.suffixes["all"]
 		if matchTLD || matchAll {
-			return proxyClient
+			return p.client
 		}
 	}
 	return config.HttpClient
 }
 
-// doRDAPRequest performs the common RDAP HTTP request logic
-func doRDAPRequest(ctx context.Context, client *http.Client, url string) (result string, err error) {
+// doRDAPRequest queries path under bases, the base URLs of an RDAP service,
+// primary first. A transient failure (see IsTransient) moves on to the next
+// one, and with config.RDAPHedgeDelay set the next one is also queried once
+// the current one has been slow for that long. The first answer that is not
+// a transient failure wins and the other requests are cancelled; when every
+// URL fails, the last failure is returned.
+func doRDAPRequest(ctx context.Context, client *http.Client, bases []string, path string) (string, error) {
+	if len(bases) == 1 {
+		result, err := queryRDAPURL(ctx, client, bases[0]+path)
+		if !IsTransient(err) {
+			slog.DebugContext(ctx, "RDAP answered", "server", bases[0], "attempt", "primary")
+			metrics.RDAPBaseURLAnswersTotal.WithLabelValues(bases[0], "primary").Inc()
+		}
+		return result, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type answer struct {
+		base, attempt, result string
+		err                   error
+	}
+	answers := make(chan answer, len(bases))
+	next, pending := 0, 0
+	launch := func(attempt string) {
+		b := bases[next]
+		next++
+		pending++
+		go func() {
+			result, err := queryRDAPURL(ctx, client, b+path)
+			answers <- answer{b, attempt, result, err}
+		}()
+	}
+
+	launch("primary")
+	var hedge <-chan time.Time
+	if config.RDAPHedgeDelay > 0 {
+		timer := time.NewTimer(config.RDAPHedgeDelay)
+		defer timer.Stop()
+		hedge = timer.C
+	}
+	var lastErr error
+	for pending > 0 {
+		select {
+		case a := <-answers:
+			pending--
+			if !IsTransient(a.err) {
+				slog.DebugContext(ctx, "RDAP answered", "server", a.base, "attempt", a.attempt)
+				metrics.RDAPBaseURLAnswersTotal.WithLabelValues(a.base, a.attempt).Inc()
+				return a.result, a.err
+			}
+			lastErr = a.err
+			if next < len(bases) && ctx.Err() == nil {
+				slog.WarnContext(ctx, "RDAP server failed, trying the next base URL", "server", a.base, "next", bases[next], "err", a.err)
+				launch("failover")
+			}
+		case <-hedge:
+			hedge = nil
+			if next < len(bases) {
+				slog.DebugContext(ctx, "RDAP server slow, hedging to the next base URL", "server", bases[next-1], "next", bases[next])
+				launch("hedge")
+			}
+		}
+	}
+	return "", lastErr
+}
+
+// queryRDAPURL performs one RDAP request through the host's circuit breaker,
+// paced by its servers.limits entry. The breaker is asked first, so a request
+// to a server that is down fails fast without waiting for a token.
+func queryRDAPURL(ctx context.Context, client *http.Client, url string) (result string, err error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return "", err
 	}
 	req.Header.Set("Accept", "application/rdap+json")
 
+	done, err := breaker.Allow(req.URL.Host)
+	if err != nil {
+		return "", err
+	}
+	release, err := throttle.Acquire(ctx, req.URL.Host)
+	if err != nil {
+		done(breaker.ErrNotSent)
+		return "", err
+	}
+	defer release()
+	defer func() {
+		if IsTransient(err) {
+			done(err)
+		} else {
+			done(nil)
+		}
+	}()
+	return sendRDAPRequest(client, req)
+}
+
+// sendRDAPRequest sends an RDAP request and reads the answer.
+func sendRDAPRequest(client *http.Client, req *http.Request) (string, error) {
+
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", err
@@ -99,7 +213,7 @@ func doRDAPRequest(ctx context.Context, client *http.Client, url string) (result
 			return "", err
 		}
 		if len(body) > maxResponseSize {
-			return "", fmt.Errorf("RDAP response from %s exceeds %d bytes", url, maxResponseSize)
+			return "", fmt.Errorf("RDAP response from %s exceeds %d bytes", req.URL, maxResponseSize)
 		}
 		return string(body), nil
 	case http.StatusNotFound:
@@ -107,18 +221,43 @@ func doRDAPRequest(ctx context.Context, client *http.Client, url string) (result
 	case http.StatusForbidden:
 		return "", utils.ErrQueryDenied
 	default:
-		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return "", &StatusError{Code: resp.StatusCode}
 	}
 }
 
+// StatusError is an RDAP answer with a status code other than 200, 403 or
+// 404, which map to the utils sentinel errors.
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.Code)
+}
+
+// IsTransient reports whether an RDAP query error says nothing about the
+// queried object — the server was unreachable, slow, overloaded or answered
+// with something unparseable — so the same query may succeed elsewhere or
+// later. Not-found and denied answers, and other 4xx statuses, are answers.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, utils.ErrResourceNotFound) || errors.Is(err, utils.ErrQueryDenied) {
+		return false
+	}
+	var status *StatusError
+	if errors.As(err, &status) {
+		return status.Code >= 500 || status.Code == http.StatusTooManyRequests
+	}
+	return true
+}
+
 // RDAPQuery function is used to query the RDAP information for a given domain.
 func RDAPQuery(ctx context.Context, domain, tld string) (string, error) {
-	rdapServer, ok := serverlist.LookupRdapServer(tld)
+	rdapServers, ok := serverlist.LookupRdapServers(tld)
 	if !ok {
 		return "", fmt.Errorf("no RDAP server known for TLD: %s", tld)
 	}
 
-	slog.DebugContext(ctx, "querying RDAP", "type", "domain", "query", domain, "tld", tld, "server", rdapServer)
+	slog.DebugContext(ctx, "querying RDAP", "type", "domain", "query", domain, "tld", tld, "server", rdapServers[0])
 
 	start := time.Now()
 	defer func() {
@@ -127,16 +266,115 @@ func RDAPQuery(ctx context.Context, domain, tld string) (string, error) {
 	client := getHTTPClient(tld)
 	// PathEscape is defence in depth: entry-point validation already rejects
 	// URL metacharacters, but the query value must never rewrite the URL path.
-	return doRDAPRequest(ctx, client, rdapServer+"domain/"+url.PathEscape(domain))
+	return doRDAPRequest(ctx, client, rdapServers, "domain/"+url.PathEscape(domain))
+}
+
+// registrarTimeout is the budget of the hop to a registrar's RDAP record.
+// The registry has already answered by then, so a slow registrar costs only
+// its own data and must not spend the whole request timeout.
+const registrarTimeout = 5 * time.Second
+
+// maxRegistrarRedirects bounds the redirects one registrar hop follows.
+const maxRegistrarRedirects = 3
+
+// ResolveRegistrarHost vets the host of a registrar link, or of a redirect
+// from it, and returns the address to connect to: the link comes from an
+// upstream answer, so a host resolving to a loopback, private or link-local
+// address is refused (see utils.ResolvePublic). Tests replace it to reach
+// loopback servers.
+var ResolveRegistrarHost = utils.ResolvePublic
+
+// registrarClient returns a client for registrar links derived from base.
+// It connects only to the address ResolveRegistrarHost vetted, so the name
+// cannot resolve elsewhere between the check and the dial, and follows only
+// HTTPS redirects whose host passes the same check. It never goes through a
+// proxy, which would resolve the name itself, and keeps no idle connections,
+// since registrar hosts are many and each is rarely asked twice.
+func registrarClient(base *http.Client) *http.Client {
+	transport := &http.Transport{}
+	if t, ok := base.Transport.(*http.Transport); ok {
+		transport = t.Clone()
+	}
+	transport.Proxy = nil
+	transport.DisableKeepAlives = true
+	dialer := &net.Dialer{Timeout: registrarTimeout}
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		ip, err := ResolveRegistrarHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		return dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+	}
+	return &http.Client{
+		Timeout:   base.Timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxRegistrarRedirects {
+				return fmt.Errorf("registrar link redirected more than %d times", maxRegistrarRedirects)
+			}
+			if req.URL.Scheme != "https" {
+				return fmt.Errorf("registrar link redirected to non-HTTPS %s", req.URL.Redacted())
+			}
+			_, err := ResolveRegistrarHost(req.Context(), req.URL.Hostname())
+			return err
+		},
+	}
+}
+
+// RDAPQueryRegistrar fetches the registrar's RDAP record for a domain from
+// the URL the registry linked to (see RegistrarLink).
+func RDAPQueryRegistrar(ctx context.Context, link string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, registrarTimeout)
+	defer cancel()
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", err
+	}
+	if _, err := ResolveRegistrarHost(ctx, u.Hostname()); err != nil {
+		return "", err
+	}
+
+	slog.DebugContext(ctx, "querying RDAP", "type", "registrar", "server", link)
+	start := time.Now()
+	defer func() {
+		metrics.UpstreamDuration.WithLabelValues("rdap", "_registrar").Observe(time.Since(start).Seconds())
+	}()
+	// The link is a record, not a service's base URL: there is nothing to
+	// fail over to, and it stays out of the per-base-URL metric.
+	return queryRDAPURL(ctx, registrarClient(config.HttpClient), link)
+}
+
+// RDAPQueryNameserver queries the RDAP nameserver object for a host name
+// (RFC 9082 section 3.1.4). Nameserver objects live with the registry of the
+// zone the host name belongs to, so the server is the one for its TLD.
+func RDAPQueryNameserver(ctx context.Context, name, tld string) (string, error) {
+	rdapServers, ok := serverlist.LookupRdapServers(tld)
+	if !ok {
+		return "", fmt.Errorf("no RDAP server known for TLD: %s", tld)
+	}
+
+	slog.DebugContext(ctx, "querying RDAP", "type", "nameserver", "query", name, "tld", tld, "server", rdapServers[0])
+
+	start := time.Now()
+	defer func() {
+		metrics.UpstreamDuration.WithLabelValues("rdap", tld).Observe(time.Since(start).Seconds())
+	}()
+	client := getHTTPClient(tld)
+	return doRDAPRequest(ctx, client, rdapServers, "nameserver/"+url.PathEscape(name))
 }
 
 // RDAPQueryIP queries the RDAP information for a given IP address.
-// serverURL is obtained by the caller via serverlist.LookupIPKey.
-func RDAPQueryIP(ctx context.Context, ip, serverURL string) (string, error) {
-	if serverURL == "" {
+// serverURLs are obtained by the caller via serverlist.LookupIPServers.
+func RDAPQueryIP(ctx context.Context, ip string, serverURLs []string) (string, error) {
+	if len(serverURLs) == 0 {
 		return "", fmt.Errorf("no RDAP server known for IP: %s", ip)
 	}
-	slog.DebugContext(ctx, "querying RDAP", "type", "ip", "query", ip, "server", serverURL)
+	slog.DebugContext(ctx, "querying RDAP", "type", "ip", "query", ip, "server", serverURLs[0])
 	start := time.Now()
 	defer func() {
 		metrics.UpstreamDuration.WithLabelValues("rdap", "_ip").Observe(time.Since(start).Seconds())
@@ -148,19 +386,49 @@ func RDAPQueryIP(ctx context.Context, ip, serverURL string) (string, error) {
 	for i := range segments {
 		segments[i] = url.PathEscape(segments[i])
 	}
-	return doRDAPRequest(ctx, config.HttpClient, serverURL+"ip/"+strings.Join(segments, "/"))
+	return doRDAPRequest(ctx, config.HttpClient, serverURLs, "ip/"+strings.Join(segments, "/"))
+}
+
+// RDAPQueryReverse queries the RDAP domain object of a reverse DNS zone
+// ("2.0.192.in-addr.arpa"). Reverse zones are registered with the RIR that
+// holds the address space, so serverURLs are the ones
+// serverlist.LookupIPServers returns for it, not a TLD's.
+func RDAPQueryReverse(ctx context.Context, zone string, serverURLs []string) (string, error) {
+	if len(serverURLs) == 0 {
+		return "", fmt.Errorf("no RDAP server known for reverse zone: %s", zone)
+	}
+	slog.DebugContext(ctx, "querying RDAP", "type", "reverse", "query", zone, "server", serverURLs[0])
+	start := time.Now()
+	defer func() {
+		metrics.UpstreamDuration.WithLabelValues("rdap", "_reverse").Observe(time.Since(start).Seconds())
+	}()
+	return doRDAPRequest(ctx, config.HttpClient, serverURLs, "domain/"+url.PathEscape(zone))
 }
 
 // RDAPQueryASN queries the RDAP information for a given ASN.
-// serverURL is obtained by the caller via serverlist.LookupASNKey.
-func RDAPQueryASN(ctx context.Context, as, serverURL string) (string, error) {
-	if serverURL == "" {
+// serverURLs are obtained by the caller via serverlist.LookupASNServers.
+func RDAPQueryASN(ctx context.Context, as string, serverURLs []string) (string, error) {
+	if len(serverURLs) == 0 {
 		return "", fmt.Errorf("no RDAP server known for ASN: %s", as)
 	}
-	slog.DebugContext(ctx, "querying RDAP", "type", "asn", "query", as, "server", serverURL)
+	slog.DebugContext(ctx, "querying RDAP", "type", "asn", "query", as, "server", serverURLs[0])
 	start := time.Now()
 	defer func() {
 		metrics.UpstreamDuration.WithLabelValues("rdap", "_asn").Observe(time.Since(start).Seconds())
 	}()
-	return doRDAPRequest(ctx, config.HttpClient, serverURL+"autnum/"+url.PathEscape(as))
+	return doRDAPRequest(ctx, config.HttpClient, serverURLs, "autnum/"+url.PathEscape(as))
+}
+
+// RDAPQueryEntity queries the RDAP information for an entity handle.
+// serverURLs are obtained by the caller via serverlist.LookupEntityServers.
+func RDAPQueryEntity(ctx context.Context, handle string, serverURLs []string) (string, error) {
+	if len(serverURLs) == 0 {
+		return "", fmt.Errorf("no RDAP server known for entity: %s", handle)
+	}
+	slog.DebugContext(ctx, "querying RDAP", "type", "entity", "query", handle, "server", serverURLs[0])
+	start := time.Now()
+	defer func() {
+		metrics.UpstreamDuration.WithLabelValues("rdap", "_entity").Observe(time.Since(start).Seconds())
+	}()
+	return doRDAPRequest(ctx, config.HttpClient, serverURLs, "entity/"+url.PathEscape(handle))
 }
@@ -3,41 +3,69 @@ package rdap
 import (
 	"context"
 	"errors"
+	"net"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/metrics"
+	"github.com/KincaidYang/whois/internal/serverlist"
 	"github.com/KincaidYang/whois/internal/utils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
-// TestMain seeds proxy settings before any test runs: initProxy is guarded by
-// a package-level sync.Once, so the configuration must be in place before the
-// first getHTTPClient call anywhere in this test binary.
-func TestMain(m *testing.M) {
-	config.ProxyServer = "http://proxy.invalid:3128"
-	config.ProxyUsername = "user"
-	config.ProxyPassword = "pass"
-	config.ProxySuffixes = []string{"proxied"}
-	os.Exit(m.Run())
+// withProxySettings installs proxy settings for the duration of a test.
+func withProxySettings(t *testing.T, server, username, password string, suffixes ...string) {
+	t.Helper()
+	old := config.Current()
+	s := *old
+	s.ProxyServer, s.ProxyUsername, s.ProxyPassword, s.ProxySuffixes = server, username, password, suffixes
+	config.SetCurrent(&s)
+	t.Cleanup(func() { config.SetCurrent(old) })
 }
 
 func TestGetHTTPClientProxySelection(t *testing.T) {
+	withProxySettings(t, "http://proxy.invalid:3128", "user", "pass", "proxied")
+
 	proxied := getHTTPClient("proxied")
 	if proxied == config.HttpClient {
 		t.Fatal("TLD in proxy.suffixes should get the proxy client, got the default client")
 	}
-	if proxied != proxyClient {
-		t.Fatalf("expected the shared proxy client, got %+v", proxied)
+	if again := getHTTPClient("proxied"); again != proxied {
+		t.Fatalf("expected the shared proxy client, got %+v", again)
 	}
 	if direct := getHTTPClient("com"); direct != config.HttpClient {
 		t.Fatalf("TLD outside proxy.suffixes should get config.HttpClient, got %+v", direct)
 	}
 }
 
+// TestGetHTTPClientFollowsReload verifies swapped settings take effect on
+// the next request: new suffixes apply while an unchanged proxy keeps its
+// client (and connection pool), and a changed or removed proxy does not.
+func TestGetHTTPClientFollowsReload(t *testing.T) {
+	withProxySettings(t, "http://proxy.invalid:3128", "user", "pass", "proxied")
+	first := getHTTPClient("proxied")
+
+	withProxySettings(t, "http://proxy.invalid:3128", "user", "pass", "proxied", "other")
+	if got := getHTTPClient("other"); got != first {
+		t.Errorf("unchanged proxy: expected the same client, got %+v", got)
+	}
+
+	withProxySettings(t, "http://proxy2.invalid:3128", "user", "pass", "proxied")
+	if got := getHTTPClient("proxied"); got == first || got == config.HttpClient {
+		t.Errorf("changed proxy: expected a new proxy client, got %+v", got)
+	}
+
+	withProxySettings(t, "", "", "", "proxied")
+	if got := getHTTPClient("proxied"); got != config.HttpClient {
+		t.Errorf("proxy removed: expected config.HttpClient, got %+v", got)
+	}
+}
+
 func TestDoRDAPRequestStatusMapping(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -54,7 +82,7 @@ func TestDoRDAPRequestStatusMapping(t *testing.T) {
 			}))
 			defer srv.Close()
 
-			_, err := doRDAPRequest(context.Background(), config.HttpClient, srv.URL)
+			_, err := doRDAPRequest(context.Background(), config.HttpClient, []string{srv.URL}, "")
 			if !errors.Is(err, tt.wantErr) {
 				t.Fatalf("status %d: expected %v, got %v", tt.status, tt.wantErr, err)
 			}
@@ -68,12 +96,32 @@ func TestDoRDAPRequestUnexpectedStatus(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	_, err := doRDAPRequest(context.Background(), config.HttpClient, srv.URL)
+	_, err := doRDAPRequest(context.Background(), config.HttpClient, []string{srv.URL}, "")
 	if err == nil || !strings.Contains(err.Error(), "unexpected status code: 502") {
 		t.Fatalf("expected unexpected-status error, got %v", err)
 	}
 }
 
+func TestIsTransient(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{utils.ErrResourceNotFound, false},
+		{utils.ErrQueryDenied, false},
+		{&StatusError{Code: http.StatusBadRequest}, false},
+		{&StatusError{Code: http.StatusTooManyRequests}, true},
+		{&StatusError{Code: http.StatusBadGateway}, true},
+		{context.DeadlineExceeded, true},
+		{errors.New("invalid character '<' looking for beginning of value"), true},
+	} {
+		if got := IsTransient(tc.err); got != tc.want {
+			t.Errorf("IsTransient(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
+
 func TestDoRDAPRequestOversizedResponse(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		chunk := make([]byte, 64<<10)
@@ -85,30 +133,90 @@ func TestDoRDAPRequestOversizedResponse(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	_, err := doRDAPRequest(context.Background(), config.HttpClient, srv.URL)
+	_, err := doRDAPRequest(context.Background(), config.HttpClient, []string{srv.URL}, "")
 	if err == nil || !strings.Contains(err.Error(), "exceeds") {
 		t.Fatalf("expected oversized-response error, got %v", err)
 	}
 }
 
 func TestDoRDAPRequestInvalidURL(t *testing.T) {
-	if _, err := doRDAPRequest(context.Background(), config.HttpClient, "://bad"); err == nil {
+	if _, err := doRDAPRequest(context.Background(), config.HttpClient, []string{"://bad"}, ""); err == nil {
 		t.Fatal("expected error for unparseable URL")
 	}
 }
 
 func TestRDAPQueryIPNoServer(t *testing.T) {
-	if _, err := RDAPQueryIP(context.Background(), "192.0.2.1", ""); err == nil {
+	if _, err := RDAPQueryIP(context.Background(), "192.0.2.1", nil); err == nil {
 		t.Fatal("expected error when no RDAP server is known for the IP")
 	}
 }
 
 func TestRDAPQueryASNNoServer(t *testing.T) {
-	if _, err := RDAPQueryASN(context.Background(), "64500", ""); err == nil {
+	if _, err := RDAPQueryASN(context.Background(), "64500", nil); err == nil {
 		t.Fatal("expected error when no RDAP server is known for the ASN")
 	}
 }
 
+// TestRDAPQueryRegistrarRefusesInternal verifies a registrar link whose host
+// resolves to a loopback address is refused without being fetched.
+func TestRDAPQueryRegistrarRefusesInternal(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer srv.Close()
+
+	link := strings.Replace(srv.URL, "127.0.0.1", "localhost", 1) + "/domain/example.com"
+	if _, err := RDAPQueryRegistrar(context.Background(), link); err == nil || !strings.Contains(err.Error(), "non-public") {
+		t.Errorf("err = %v, want the link refused", err)
+	}
+	if n := hits.Load(); n != 0 {
+		t.Errorf("registrar fetched %d times, want 0", n)
+	}
+}
+
+// TestRDAPQueryRegistrarRedirectToInternal verifies a registrar host that
+// passes the check is connected to at the vetted address, and that its
+// redirect to a loopback address is refused without being followed.
+func TestRDAPQueryRegistrarRedirectToInternal(t *testing.T) {
+	var internalHits atomic.Int32
+	internal := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		internalHits.Add(1)
+	}))
+	defer internal.Close()
+	registrar := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/domain/ok.example" {
+			_, _ = w.Write([]byte(`{"objectClassName":"domain"}`))
+			return
+		}
+		http.Redirect(w, r, internal.URL+"/latest/meta-data/", http.StatusFound)
+	}))
+	defer registrar.Close()
+
+	origClient, origResolve := config.HttpClient, ResolveRegistrarHost
+	config.HttpClient = registrar.Client()
+	// example.com (a name the test certificate covers) stands in for a public
+	// registrar host; it is reached at the address the check returned.
+	ResolveRegistrarHost = func(ctx context.Context, host string) (net.IP, error) {
+		if host == "example.com" {
+			return net.IPv4(127, 0, 0, 1), nil
+		}
+		return origResolve(ctx, host)
+	}
+	t.Cleanup(func() { config.HttpClient, ResolveRegistrarHost = origClient, origResolve })
+
+	base := strings.Replace(registrar.URL, "127.0.0.1", "example.com", 1)
+	if got, err := RDAPQueryRegistrar(context.Background(), base+"/domain/ok.example"); err != nil || got != `{"objectClassName":"domain"}` {
+		t.Fatalf("vetted host: got %q, %v", got, err)
+	}
+	if _, err := RDAPQueryRegistrar(context.Background(), base+"/domain/redirect.example"); err == nil || !strings.Contains(err.Error(), "non-public") {
+		t.Errorf("err = %v, want the redirect refused", err)
+	}
+	if n := internalHits.Load(); n != 0 {
+		t.Errorf("internal server reached %d times, want 0", n)
+	}
+}
+
 func TestRDAPQueryIP(t *testing.T) {
 	const body = `{"objectClassName": "ip network", "handle": "NET-192-0-2-0-1"}`
 	// Written by the httptest handler goroutine, read by the test goroutine.
@@ -120,7 +228,7 @@ func TestRDAPQueryIP(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	got, err := RDAPQueryIP(context.Background(), "192.0.2.1", srv.URL+"/")
+	got, err := RDAPQueryIP(context.Background(), "192.0.2.1", []string{srv.URL + "/"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -142,7 +250,7 @@ func TestRDAPQueryIPCIDR(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	if _, err := RDAPQueryIP(context.Background(), "192.0.2.0/24", srv.URL+"/"); err != nil {
+	if _, err := RDAPQueryIP(context.Background(), "192.0.2.0/24", []string{srv.URL + "/"}); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if got, _ := gotPath.Load().(string); got != "/ip/192.0.2.0/24" {
@@ -159,7 +267,7 @@ func TestRDAPQueryASN(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	got, err := RDAPQueryASN(context.Background(), "64500", srv.URL+"/")
+	got, err := RDAPQueryASN(context.Background(), "64500", []string{srv.URL + "/"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -170,3 +278,166 @@ func TestRDAPQueryASN(t *testing.T) {
 		t.Errorf("request path: %q", got)
 	}
 }
+
+func TestRDAPQueryNameserverNoServer(t *testing.T) {
+	if _, err := RDAPQueryNameserver(context.Background(), "ns1.example.zzqqxxnotld", "zzqqxxnotld"); err == nil {
+		t.Fatal("expected error when no RDAP server is known for the TLD")
+	}
+}
+
+func TestRDAPQueryNameserver(t *testing.T) {
+	var gotPath atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath.Store(r.URL.Path)
+		_, _ = w.Write([]byte(`{"objectClassName": "nameserver"}`))
+	}))
+	defer srv.Close()
+	serverlist.UpdateFromIANA(map[string]string{"zznsquery": srv.URL + "/"})
+	defer serverlist.UpdateFromIANA(nil)
+
+	if _, err := RDAPQueryNameserver(context.Background(), "ns1.example.zznsquery", "zznsquery"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := gotPath.Load().(string); got != "/nameserver/ns1.example.zznsquery" {
+		t.Errorf("request path: %q", got)
+	}
+}
+
+func TestRDAPQueryEntityNoServer(t *testing.T) {
+	if _, err := RDAPQueryEntity(context.Background(), "ABC123-ZZNOTAG", nil); err == nil {
+		t.Fatal("expected error when no RDAP server is known for the entity")
+	}
+}
+
+func TestRDAPQueryEntity(t *testing.T) {
+	var gotPath atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath.Store(r.URL.Path)
+		_, _ = w.Write([]byte(`{"objectClassName": "entity"}`))
+	}))
+	defer srv.Close()
+
+	if _, err := RDAPQueryEntity(context.Background(), "ABC123-ARIN", []string{srv.URL + "/"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := gotPath.Load().(string); got != "/entity/ABC123-ARIN" {
+		t.Errorf("request path: %q", got)
+	}
+}
+
+// withBaseURLs lists primary and backup as one RDAP service for tld, and
+// sets the hedge delay, for the duration of a test.
+func withBaseURLs(t *testing.T, tld string, primary, backup http.HandlerFunc, hedgeDelay time.Duration) (primaryHits, backupHits *atomic.Int32) {
+	t.Helper()
+	primaryHits, backupHits = new(atomic.Int32), new(atomic.Int32)
+	p := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryHits.Add(1)
+		primary(w, r)
+	}))
+	b := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		backupHits.Add(1)
+		backup(w, r)
+	}))
+	serverlist.UpdateFromIANAServices(map[string][]string{tld: {p.URL + "/", b.URL + "/"}})
+	oldDelay := config.RDAPHedgeDelay
+	config.RDAPHedgeDelay = hedgeDelay
+	t.Cleanup(func() {
+		p.Close()
+		b.Close()
+		serverlist.UpdateFromIANA(nil)
+		config.RDAPHedgeDelay = oldDelay
+	})
+	return primaryHits, backupHits
+}
+
+func answerDomain(w http.ResponseWriter, r *http.Request) {
+	_, _ = w.Write([]byte(`{"objectClassName": "domain"}`))
+}
+
+// TestRDAPFailover verifies a 5xx from a service's first base URL moves on
+// to the next, while an answer such as not found does not.
+func TestRDAPFailover(t *testing.T) {
+	status := http.StatusBadGateway
+	primaryHits, backupHits := withBaseURLs(t, "zzfailover", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}, answerDomain, 0)
+
+	got, err := RDAPQuery(context.Background(), "example.zzfailover", "zzfailover")
+	if err != nil || !strings.Contains(got, "domain") {
+		t.Fatalf("failover: got %q, %v", got, err)
+	}
+	if primaryHits.Load() != 1 || backupHits.Load() != 1 {
+		t.Errorf("hits: primary %d, backup %d; want 1 each", primaryHits.Load(), backupHits.Load())
+	}
+
+	status = http.StatusNotFound
+	if _, err := RDAPQuery(context.Background(), "gone.zzfailover", "zzfailover"); !errors.Is(err, utils.ErrResourceNotFound) {
+		t.Errorf("not found: got %v", err)
+	}
+	if backupHits.Load() != 1 {
+		t.Errorf("backup queried after a not-found answer")
+	}
+}
+
+// TestRDAPFailoverAllFail verifies the last failure is returned when every
+// base URL fails.
+func TestRDAPFailoverAllFail(t *testing.T) {
+	unavailable := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusServiceUnavailable) }
+	withBaseURLs(t, "zzfailall", unavailable, unavailable, 0)
+
+	_, err := RDAPQuery(context.Background(), "example.zzfailall", "zzfailall")
+	var status *StatusError
+	if !errors.As(err, &status) || status.Code != http.StatusServiceUnavailable {
+		t.Errorf("got %v, want the 503", err)
+	}
+}
+
+// TestRDAPHedge verifies a slow first base URL is hedged to the next once
+// the delay has passed, and the first answer wins.
+func TestRDAPHedge(t *testing.T) {
+	_, backupHits := withBaseURLs(t, "zzhedge", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}, answerDomain, 20*time.Millisecond)
+
+	start := time.Now()
+	got, err := RDAPQuery(context.Background(), "example.zzhedge", "zzhedge")
+	if err != nil || !strings.Contains(got, "domain") {
+		t.Fatalf("hedge: got %q, %v", got, err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("hedged query took %v", elapsed)
+	}
+	if backupHits.Load() != 1 {
+		t.Errorf("backup hits = %d, want 1", backupHits.Load())
+	}
+}
+
+// TestRDAPSingleURLAnswerCounted verifies an answer from a service with one
+// base URL is counted as its primary, and a transient failure is not.
+func TestRDAPSingleURLAnswerCounted(t *testing.T) {
+	status := http.StatusNotFound
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+	answers := func() float64 {
+		return testutil.ToFloat64(metrics.RDAPBaseURLAnswersTotal.WithLabelValues(srv.URL+"/", "primary"))
+	}
+
+	if _, err := RDAPQueryASN(context.Background(), "64500", []string{srv.URL + "/"}); !errors.Is(err, utils.ErrResourceNotFound) {
+		t.Fatalf("got %v, want not found", err)
+	}
+	if got := answers(); got != 1 {
+		t.Errorf("answers after not found = %v, want 1", got)
+	}
+	status = http.StatusBadGateway
+	if _, err := RDAPQueryASN(context.Background(), "64500", []string{srv.URL + "/"}); err == nil {
+		t.Fatal("want the 502")
+	}
+	if got := answers(); got != 1 {
+		t.Errorf("answers after a transient failure = %v, want 1", got)
+	}
+}
@@ -0,0 +1,153 @@
+package rdap
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/KincaidYang/whois/internal/model"
+)
+
+// rdapRedacted is one entry of the RFC 9537 "redacted" array. Name and
+// reason are each either a registered type or a free-form description.
+type rdapRedacted struct {
+	Name     rdapRedactedText `json:"name"`
+	Reason   rdapRedactedText `json:"reason"`
+	PrePath  string           `json:"prePath"`
+	PostPath string           `json:"postPath"`
+	Method   string           `json:"method"`
+}
+
+type rdapRedactedText struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+func (t rdapRedactedText) text() string {
+	if t.Type != "" {
+		return t.Type
+	}
+	return t.Description
+}
+
+// parseRedactions converts the redacted array into the API form. An entry
+// without a method was removed (RFC 9537 section 4.2); one without a name is
+// skipped, since nothing could say what it hid. Each entry is decoded on its
+// own so a malformed one does not lose the rest.
+func parseRedactions(raw []json.RawMessage) []model.Redaction {
+	var out []model.Redaction
+	for _, item := range raw {
+		var r rdapRedacted
+		if err := json.Unmarshal(item, &r); err != nil {
+			continue
+		}
+		name := strings.TrimSpace(r.Name.text())
+		if name == "" {
+			continue
+		}
+		redaction := model.Redaction{
+			Name:   name,
+			Method: r.Method,
+			Reason: strings.TrimSpace(r.Reason.text()),
+			Path:   r.PrePath,
+		}
+		if redaction.Method == "" {
+			redaction.Method = model.RedactionRemoval
+		}
+		if redaction.Path == "" {
+			redaction.Path = r.PostPath
+		}
+		out = append(out, redaction)
+	}
+	return out
+}
+
+// Contact roles and fields as the ICANN redaction types name them
+// ("Registrant Name", "Tech Email", "Registry Registrant ID").
+var (
+	redactionRoles = map[string]string{
+		"registrant":     "registrant",
+		"admin":          "administrative",
+		"administrative": "administrative",
+		"tech":           "technical",
+		"technical":      "technical",
+		"billing":        "billing",
+	}
+	redactionFields = map[string]string{
+		"name":           "name",
+		"organization":   "organization",
+		"email":          "email",
+		"phone":          "phone",
+		"phone ext":      "phone",
+		"country":        "country",
+		"street":         "address",
+		"city":           "address",
+		"state/province": "address",
+		"postal code":    "address",
+		"id":             "handle",
+	}
+	vcardFields = map[string]string{
+		"fn":    "name",
+		"org":   "organization",
+		"email": "email",
+		"tel":   "phone",
+		"adr":   "address",
+	}
+
+	// JSONPath fragments of the shape the RFC 9537 examples use:
+	// $.entities[?(@.roles[0]=='registrant')].vcardArray[1][?(@[0]=='fn')]
+	reRedactedRole   = regexp.MustCompile(`roles[^'"]*['"]([A-Za-z]+)['"]`)
+	reRedactedVCard  = regexp.MustCompile(`@\[0\]\s*==\s*['"]([A-Za-z]+)['"]`)
+	reRedactedHandle = regexp.MustCompile(`\.handle\b`)
+)
+
+// redactedContactField maps a redaction to the contact role and field it
+// hides, from the redaction's name or, failing that, its JSONPath. ok is
+// false when the redaction is not of a contact field (the registry domain
+// ID, for instance) or cannot be placed.
+func redactedContactField(r model.Redaction) (role, field string, ok bool) {
+	name := strings.ToLower(strings.TrimPrefix(r.Name, "Registry "))
+	if first, rest, found := strings.Cut(name, " "); found {
+		role, field = redactionRoles[first], redactionFields[rest]
+	}
+	if role == "" {
+		if m := reRedactedRole.FindStringSubmatch(r.Path); m != nil {
+			role = strings.ToLower(m[1])
+		}
+	}
+	if field == "" {
+		if m := reRedactedVCard.FindStringSubmatch(r.Path); m != nil {
+			field = vcardFields[strings.ToLower(m[1])]
+		} else if reRedactedHandle.MatchString(r.Path) {
+			field = "handle"
+		}
+	}
+	return role, field, role != "" && field != ""
+}
+
+// flagRedactedContacts marks each contact field a redaction hid. A role the
+// registry removed entirely gets a contact of its own carrying only the
+// flags, so a redacted registrant is told apart from a missing one.
+func flagRedactedContacts(contacts []model.Contact, redactions []model.Redaction) []model.Contact {
+	for _, r := range redactions {
+		role, field, ok := redactedContactField(r)
+		if !ok {
+			continue
+		}
+		found := false
+		for i := range contacts {
+			if contacts[i].Role != role {
+				continue
+			}
+			found = true
+			if !slices.Contains(contacts[i].Redacted, field) {
+				contacts[i].Redacted = append(contacts[i].Redacted, field)
+			}
+		}
+		if !found {
+			contacts = append(contacts, model.Contact{Role: role, Redacted: []string{field}})
+		}
+	}
+	return contacts
+}
@@ -0,0 +1,80 @@
+package rdap
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/KincaidYang/whois/internal/model"
+)
+
+// TestParseRDAPDomainRedactions covers the RFC 9537 redacted array, shaped
+// after the RFC's examples: a registrant whose name was emptied and email
+// removed, a technical contact removed entirely, and a non-contact field.
+func TestParseRDAPDomainRedactions(t *testing.T) {
+	response := `{
+		"objectClassName": "domain",
+		"ldhName": "example.com",
+		"entities": [{
+			"objectClassName": "entity",
+			"roles": ["registrant"],
+			"vcardArray": ["vcard", [
+				["fn", {}, "text", ""],
+				["adr", {"cc": "CA"}, "text", ["", "", "", "", "QC", "", ""]]
+			]]
+		}],
+		"redacted": [
+			{"name": {"type": "Registry Domain ID"}, "prePath": "$.handle", "pathLang": "jsonpath", "method": "removal", "reason": {"type": "Server policy"}},
+			{"name": {"type": "Registrant Name"}, "postPath": "$.entities[?(@.roles[0]=='registrant')].vcardArray[1][?(@[0]=='fn')][3]", "method": "emptyValue", "reason": {"type": "Server policy"}},
+			{"name": {"description": "Registrant Email"}, "prePath": "$.entities[?(@.roles[0]=='registrant')].vcardArray[1][?(@[0]=='email')]", "reason": {"description": "Privacy law"}},
+			{"name": {"description": "Technical contact"}, "prePath": "$.entities[?(@.roles[0]=='technical')].vcardArray[1][?(@[0]=='fn')]"},
+			{"name": {}, "prePath": "$.port43"},
+			"not an object"
+		]
+	}`
+
+	info, err := ParseRDAPResponseforDomain(response)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantRedactions := []model.Redaction{
+		{Name: "Registry Domain ID", Method: "removal", Reason: "Server policy", Path: "$.handle"},
+		{Name: "Registrant Name", Method: "emptyValue", Reason: "Server policy", Path: "$.entities[?(@.roles[0]=='registrant')].vcardArray[1][?(@[0]=='fn')][3]"},
+		{Name: "Registrant Email", Method: "removal", Reason: "Privacy law", Path: "$.entities[?(@.roles[0]=='registrant')].vcardArray[1][?(@[0]=='email')]"},
+		{Name: "Technical contact", Method: "removal", Path: "$.entities[?(@.roles[0]=='technical')].vcardArray[1][?(@[0]=='fn')]"},
+	}
+	if !reflect.DeepEqual(info.Redactions, wantRedactions) {
+		t.Errorf("Redactions = %+v, want %+v", info.Redactions, wantRedactions)
+	}
+
+	wantContacts := []model.Contact{
+		{Role: "registrant", Country: "CA", Address: &model.Address{Region: "QC", Country: "CA"}, Redacted: []string{"name", "email"}},
+		{Role: "technical", Redacted: []string{"name"}},
+	}
+	if !reflect.DeepEqual(info.Contacts, wantContacts) {
+		t.Errorf("Contacts = %+v, want %+v", info.Contacts, wantContacts)
+	}
+}
+
+func TestRedactedContactField(t *testing.T) {
+	tests := []struct {
+		redaction   model.Redaction
+		role, field string
+		ok          bool
+	}{
+		{model.Redaction{Name: "Registrant Phone Ext"}, "registrant", "phone", true},
+		{model.Redaction{Name: "Tech Email"}, "technical", "email", true},
+		{model.Redaction{Name: "Admin Postal Code"}, "administrative", "address", true},
+		{model.Redaction{Name: "Registry Registrant ID"}, "registrant", "handle", true},
+		{model.Redaction{Name: "Billing contact", Path: "$.entities[?(@.roles[0]=='billing')].handle"}, "billing", "handle", true},
+		{model.Redaction{Name: "Registrant Fax"}, "registrant", "", false},
+		{model.Redaction{Name: "Registry Domain ID", Path: "$.handle"}, "", "handle", false},
+	}
+	for _, tt := range tests {
+		role, field, ok := redactedContactField(tt.redaction)
+		if role != tt.role || field != tt.field || ok != tt.ok {
+			t.Errorf("redactedContactField(%q) = %q, %q, %v; want %q, %q, %v",
+				tt.redaction.Name, role, field, ok, tt.role, tt.field, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package rdap
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/KincaidYang/whois/internal/model"
+)
+
+// vcard is the subset of a jCard (RFC 7095) this service reports for an
+// entity. Properties other than these are ignored.
+type vcard struct {
+	fn    string
+	org   string
+	email []string
+	tel   []string
+	adr   *model.Address
+}
+
+// parseVCard flattens an entity's vcardArray. The jCard format is
+// ["vcard", [[name, params, type, value...], ...]]; each property is decoded
+// on its own, so a malformed one is skipped without losing the rest, and a
+// malformed array yields an empty vcard rather than an error.
+func parseVCard(vcardArray []json.RawMessage) vcard {
+	var card vcard
+	if len(vcardArray) < 2 {
+		return card
+	}
+	var properties []json.RawMessage
+	if err := json.Unmarshal(vcardArray[1], &properties); err != nil {
+		return card
+	}
+	for _, prop := range properties {
+		var fields []json.RawMessage
+		if err := json.Unmarshal(prop, &fields); err != nil {
+			continue
+		}
+		if len(fields) < 4 {
+			continue
+		}
+		var propName string
+		if err := json.Unmarshal(fields[0], &propName); err != nil {
+			continue
+		}
+		switch strings.ToLower(propName) {
+		case "fn":
+			if card.fn == "" {
+				card.fn = firstText(fields[3])
+			}
+		case "org":
+			// A structured org is the name followed by its units.
+			if card.org == "" {
+				card.org = strings.Join(textValues(fields[3]), ", ")
+			}
+		case "email":
+			if v := firstText(fields[3]); v != "" {
+				card.email = append(card.email, v)
+			}
+		case "tel":
+			// Most registries type tel as a "tel:" URI (RFC 3966); the scheme
+			// is noise in an API response.
+			v := firstText(fields[3])
+			if len(v) > 4 && strings.EqualFold(v[:4], "tel:") {
+				v = v[4:]
+			}
+			if v != "" {
+				card.tel = append(card.tel, v)
+			}
+		case "adr":
+			if card.adr == nil {
+				card.adr = parseAddress(fields[1], fields[3])
+			}
+		}
+	}
+	return card
+}
+
+// parseAddress decodes an adr property: a seven-component structured value
+// (post office box, extended address, street, locality, region, postal code,
+// country), optionally with the whole address preformatted in a "label"
+// parameter and the ISO 3166 country code in "cc" (RFC 8605). Returns nil when
+// every part is empty.
+func parseAddress(params, value json.RawMessage) *model.Address {
+	var addr model.Address
+
+	var p struct {
+		Label json.RawMessage `json:"label"`
+		CC    json.RawMessage `json:"cc"`
+	}
+	if err := json.Unmarshal(params, &p); err == nil {
+		addr.Label = strings.TrimSpace(firstText(p.Label))
+		addr.Country = strings.TrimSpace(firstText(p.CC))
+	}
+
+	var components []json.RawMessage
+	if err := json.Unmarshal(value, &components); err == nil {
+		component := func(i int) []string {
+			if i >= len(components) {
+				return nil
+			}
+			return textValues(components[i])
+		}
+		for i := 0; i <= 2; i++ {
+			addr.Street = append(addr.Street, component(i)...)
+		}
+		addr.Locality = strings.Join(component(3), " ")
+		addr.Region = strings.Join(component(4), " ")
+		addr.PostalCode = strings.Join(component(5), " ")
+		if country := strings.Join(component(6), " "); country != "" {
+			addr.Country = country
+		}
+	}
+
+	if len(addr.Street) == 0 && addr.Locality == "" && addr.Region == "" &&
+		addr.PostalCode == "" && addr.Country == "" && addr.Label == "" {
+		return nil
+	}
+	return &addr
+}
+
+// textValues decodes a jCard value that is either a single string or a list
+// of strings, dropping empty and non-string entries.
+func textValues(raw json.RawMessage) []string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if s = strings.TrimSpace(s); s != "" {
+			return []string{s}
+		}
+		return nil
+	}
+	var list []json.RawMessage
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil
+	}
+	var out []string
+	for _, item := range list {
+		if err := json.Unmarshal(item, &s); err == nil {
+			if s = strings.TrimSpace(s); s != "" {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+// firstText returns the first non-empty text of a jCard value, or "".
+func firstText(raw json.RawMessage) string {
+	if values := textValues(raw); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
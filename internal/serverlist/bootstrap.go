@@ -14,14 +14,16 @@ import (
 )
 
 // bootstrapResponse is the common structure for IANA RDAP bootstrap JSON files.
-// See RFC 9224.
+// See RFC 9224; the object tags file (RFC 8521) adds a leading contacts
+// element to each service.
 type bootstrapResponse struct {
-	Services [][]json.RawMessage `json:"services"`
+	Publication string              `json:"publication"`
+	Services    [][]json.RawMessage `json:"services"`
 }
 
 // maxBootstrapResponseSize caps how much we read from an IANA bootstrap file,
 // matching the limit on WHOIS/RDAP upstream reads (the dns.json file, the
-// largest of the four, is around 200 KB).
+// largest of the five, is around 200 KB).
 const maxBootstrapResponseSize = 2 << 20 // 2 MiB
 
 var ianaBootstrapURLs = map[string]string{
@@ -29,43 +31,78 @@ var ianaBootstrapURLs = map[string]string{
 	"ipv4": "https://data.iana.org/rdap/ipv4.json",
 	"ipv6": "https://data.iana.org/rdap/ipv6.json",
 	"asn":  "https://data.iana.org/rdap/asn.json",
+	// Object tags map the suffix of an entity handle ("ABC123-ARIN") to the
+	// registry that issued it (RFC 8521).
+	"objecttags": "https://data.iana.org/rdap/object-tags.json",
 }
 
-// fetchBootstrap fetches and parses one IANA bootstrap JSON file.
-// Returns a map of identifier → first RDAP server URL.
-func fetchBootstrap(ctx context.Context, client *http.Client, url string) (map[string]string, error) {
+// fetchBootstrap fetches and parses one IANA bootstrap JSON file. The
+// services map identifier → the service's RDAP base URLs, in the order
+// preferredURLs gives them.
+//
+// When prev holds an earlier fetch of the file, the request is conditional
+// on its validators, and a 304 answer returns prev unchanged with modified
+// false. A file whose publication timestamp is older than prev's is
+// rejected: it comes from a stale mirror or cache and would roll the data
+// back.
+func fetchBootstrap(ctx context.Context, client *http.Client, url string, prev fetchedCategory) (result fetchedCategory, modified bool, err error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, err
+		return result, false, err
+	}
+	if prev.Services != nil {
+		if prev.ETag != "" {
+			req.Header.Set("If-None-Match", prev.ETag)
+		}
+		if prev.LastModified != "" {
+			req.Header.Set("If-Modified-Since", prev.LastModified)
+		}
 	}
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return result, false, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if resp.StatusCode == http.StatusNotModified && prev.Services != nil {
+		return prev, false, nil
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+		return result, false, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
 	}
 
 	// Read one byte past the limit so an oversized response is detected and
 	// rejected rather than silently truncated.
 	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBootstrapResponseSize+1))
 	if err != nil {
-		return nil, err
+		return result, false, err
 	}
 	if len(body) > maxBootstrapResponseSize {
-		return nil, fmt.Errorf("bootstrap response from %s exceeds %d bytes", url, maxBootstrapResponseSize)
+		return result, false, fmt.Errorf("bootstrap response from %s exceeds %d bytes", url, maxBootstrapResponseSize)
 	}
 
 	var bootstrap bootstrapResponse
 	if err := json.Unmarshal(body, &bootstrap); err != nil {
-		return nil, err
+		return result, false, err
+	}
+	if bootstrap.Publication != "" {
+		// An unparsable timestamp is kept as zero rather than failing a file
+		// whose services are fine.
+		result.Publication, _ = time.Parse(time.RFC3339, bootstrap.Publication)
+	}
+	if !result.Publication.IsZero() && result.Publication.Before(prev.Publication) {
+		return fetchedCategory{}, false, fmt.Errorf("bootstrap file from %s published %s, before the %s already held",
+			url, bootstrap.Publication, prev.Publication.Format(time.RFC3339))
 	}
 
-	result := make(map[string]string)
+	result.Services = make(map[string][]string)
 	for _, service := range bootstrap.Services {
+		// An object tags service is [contacts, tags, urls]; the contacts
+		// are informational only.
+		if len(service) == 3 {
+			service = service[1:]
+		}
 		if len(service) < 2 {
 			continue
 		}
@@ -80,42 +117,82 @@ func fetchBootstrap(ctx context.Context, client *http.Client, url string) (map[s
 			continue
 		}
 
-		// Prefer HTTPS; fall back to first URL.
-		serverURL := urls[0]
-		for _, u := range urls {
-			if strings.HasPrefix(u, "https") {
-				serverURL = u
-				break
-			}
-		}
-
+		serverURLs := preferredURLs(urls)
 		for _, id := range identifiers {
-			result[id] = serverURL
+			result.Services[id] = serverURLs
 		}
 	}
+	result.ETag = resp.Header.Get("ETag")
+	result.LastModified = resp.Header.Get("Last-Modified")
+
+	return result, true, nil
+}
 
-	return result, nil
+// preferredURLs returns a service's HTTPS base URLs in their published
+// order, or all of them when it has none. RFC 9224 lets a service list
+// several; the first is the primary and the rest are failover alternates,
+// never a downgrade from HTTPS to plain HTTP.
+func preferredURLs(urls []string) []string {
+	var secure []string
+	for _, u := range urls {
+		if strings.HasPrefix(u, "https") {
+			secure = append(secure, u)
+		}
+	}
+	if len(secure) > 0 {
+		return secure
+	}
+	return urls
 }
 
-// FetchIANA fetches all four IANA bootstrap files. Results are keyed by
+// FetchIANA fetches all five IANA bootstrap files. Results are keyed by
 // category so the caller can commit each independently; categories that fail
 // to fetch are absent from the map and listed in failed, so the caller can
 // report a partial update rather than a clean success.
-func FetchIANA(ctx context.Context, client *http.Client) (perCategory map[string]map[string]string, failed []string) {
-	perCategory = make(map[string]map[string]string)
+func FetchIANA(ctx context.Context, client *http.Client) (perCategory map[string]map[string][]string, failed []string) {
+	fetched, failed := fetchCategories(ctx, client, nil)
+	perCategory = make(map[string]map[string][]string, len(fetched))
+	for category, c := range fetched {
+		perCategory[category] = c.Services
+	}
+	return perCategory, failed
+}
+
+// fetchCategories is FetchIANA, fetching each category conditionally on
+// its data in known.
+func fetchCategories(ctx context.Context, client *http.Client, known lastKnownGood) (perCategory map[string]fetchedCategory, failed []string) {
+	perCategory = make(map[string]fetchedCategory)
 	for category, url := range ianaBootstrapURLs {
-		data, err := fetchBootstrap(ctx, client, url)
+		data, modified, err := fetchBootstrap(ctx, client, url, known[category])
 		if err != nil {
 			slog.Warn("RDAP bootstrap fetch failed", "category", category, "err", err)
 			failed = append(failed, category)
 			continue
 		}
+		if !modified {
+			slog.Debug("RDAP bootstrap not modified", "category", category)
+			perCategory[category] = data
+			continue
+		}
+		if category == "objecttags" {
+			data.Services = objectTagEntries(data.Services)
+		}
 		perCategory[category] = data
-		slog.Debug("RDAP bootstrap fetched", "category", category, "entries", len(data))
+		slog.Debug("RDAP bootstrap fetched", "category", category, "entries", len(data.Services))
 	}
 	return perCategory, failed
 }
 
+// objectTagEntries re-keys fetched object tags with ObjectTagKey, so they can
+// share the server map with TLDs without ever matching one.
+func objectTagEntries(tags map[string][]string) map[string][]string {
+	out := make(map[string][]string, len(tags))
+	for tag, urls := range tags {
+		out[ObjectTagKey(tag)] = urls
+	}
+	return out
+}
+
 // commitBootstrap folds one round of per-category fetch results into
 // lastGood and rebuilds the active index from every category's last-known-good
 // data, so a category whose refresh failed keeps serving its most recent
@@ -125,46 +202,58 @@ func FetchIANA(ctx context.Context, client *http.Client) (perCategory map[string
 // logs and the refresh metric. It returns the outcome label for the metric —
 // "failure" (nothing fetched, index untouched), "partial" or "success" —
 // and the number of entries committed.
-func commitBootstrap(lastGood, perCategory map[string]map[string]string, failed []string) (outcome string, entries int) {
+func commitBootstrap(lastGood lastKnownGood, perCategory map[string]fetchedCategory, failed []string) (outcome string, entries int) {
 	if len(perCategory) == 0 {
 		return "failure", 0
 	}
+	fetchedAt := time.Now()
 	for category, data := range perCategory {
+		data.FetchedAt = fetchedAt
 		lastGood[category] = data
 	}
-	merged := make(map[string]string)
-	for _, data := range lastGood {
-		for k, v := range data {
+	entries = lastGood.install()
+	if len(failed) > 0 {
+		return "partial", entries
+	}
+	return "success", entries
+}
+
+// install makes l the IANA data the index is built from, returning the
+// number of entries.
+func (l lastKnownGood) install() int {
+	merged := make(map[string][]string)
+	for _, c := range l {
+		for k, v := range c.Services {
 			merged[k] = v
 		}
 	}
-	UpdateFromIANA(merged)
-	if len(failed) > 0 {
-		return "partial", len(merged)
-	}
-	return "success", len(merged)
+	UpdateFromIANAServices(merged)
+	return len(merged)
 }
 
 // StartBootstrapRefresh fetches IANA data immediately on startup, then
-// refreshes on the given interval. Stops when ctx is cancelled.
+// refreshes on the given interval. Stops when ctx is cancelled. After each
+// round that fetched anything, the last-known-good data is written to caches
+// for LoadBootstrapCache to restore on the next start.
 // interval must be positive; callers should guard with interval > 0.
-func StartBootstrapRefresh(ctx context.Context, client *http.Client, interval time.Duration) {
+func StartBootstrapRefresh(ctx context.Context, client *http.Client, interval time.Duration, caches ...BootstrapCache) {
 	if interval <= 0 {
 		return
 	}
-	// lastGood holds each category's most recent successful fetch. refresh
-	// only ever runs on the single goroutine below, so no locking is needed.
-	lastGood := make(map[string]map[string]string)
 	refresh := func() {
 		fetchCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 		defer cancel()
 
-		perCategory, failed := FetchIANA(fetchCtx, client)
-		outcome, entries := commitBootstrap(lastGood, perCategory, failed)
+		perCategory, failed := fetchCategories(fetchCtx, client, bootstrapSnapshot())
+		bootstrapState.Lock()
+		outcome, entries := commitBootstrap(bootstrapState.data, perCategory, failed)
+		bootstrapState.Unlock()
+
 		metrics.BootstrapRefreshTotal.WithLabelValues(outcome).Inc()
 		switch outcome {
 		case "failure":
 			slog.Warn("RDAP bootstrap: no data fetched, retaining current index")
+			return
 		case "partial":
 			slog.Warn("RDAP bootstrap partially updated; failed categories retain last-known-good data",
 				"failed", failed, "entries", entries)
@@ -172,6 +261,10 @@ func StartBootstrapRefresh(ctx context.Context, client *http.Client, interval ti
 			metrics.BootstrapLastFetchTimestamp.Set(float64(time.Now().Unix()))
 			slog.Info("RDAP bootstrap index updated", "entries", entries)
 		}
+
+		saveCtx, cancelSave := context.WithTimeout(ctx, 5*time.Second)
+		defer cancelSave()
+		saveBootstrapCache(saveCtx, bootstrapSnapshot(), caches)
 	}
 
 	// Initial fetch at startup (non-blocking).
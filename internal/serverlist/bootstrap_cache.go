@@ -0,0 +1,249 @@
+package serverlist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/KincaidYang/whois/internal/metrics"
+	"github.com/redis/go-redis/v9"
+)
+
+// BootstrapCache persists the last-known-good IANA bootstrap data, so that a
+// restart serves it — rather than the compiled baseline — until the first
+// fetch succeeds, or indefinitely where IANA cannot be reached.
+type BootstrapCache interface {
+	// Load returns the stored data, or nil when nothing has been stored.
+	Load(ctx context.Context) ([]byte, error)
+	Save(ctx context.Context, data []byte) error
+}
+
+// FileCache stores the bootstrap data in a file at the given path.
+type FileCache string
+
+func (f FileCache) Load(ctx context.Context) ([]byte, error) {
+	data, err := os.ReadFile(string(f))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Save replaces the file through a rename, so a crash mid-write never leaves
+// a truncated file behind.
+func (f FileCache) Save(ctx context.Context, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(string(f)), filepath.Base(string(f))+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), string(f))
+}
+
+// RedisCache stores the bootstrap data under a Redis key, shared by every
+// replica using the same Redis. The key does not expire.
+type RedisCache struct {
+	Client *redis.Client
+	Key    string
+}
+
+func (c RedisCache) Load(ctx context.Context) ([]byte, error) {
+	data, err := c.Client.Get(ctx, c.Key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	return data, err
+}
+
+func (c RedisCache) Save(ctx context.Context, data []byte) error {
+	return c.Client.Set(ctx, c.Key, data, 0).Err()
+}
+
+// bootstrapCacheVersion is bumped whenever the stored layout changes; data
+// of another version is ignored.
+const bootstrapCacheVersion = 1
+
+// cachedBootstrap is the stored form of lastKnownGood.
+type cachedBootstrap struct {
+	Version    int           `json:"version"`
+	Categories lastKnownGood `json:"categories"`
+}
+
+// fetchedCategory is one category's most recent successful fetch.
+type fetchedCategory struct {
+	// FetchedAt is when IANA last served the data or confirmed it unchanged.
+	FetchedAt time.Time `json:"fetchedAt"`
+	// Publication is the file's own publication timestamp, if it had one.
+	Publication time.Time           `json:"publication,omitzero"`
+	Services    map[string][]string `json:"services"`
+	// ETag and LastModified are the validators the next fetch is made
+	// conditional on.
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// lastKnownGood holds each bootstrap category's most recent successful
+// fetch, keyed by category ("dns", "ipv4", ...).
+type lastKnownGood map[string]fetchedCategory
+
+// bootstrapState is the process's last-known-good bootstrap data: seeded by
+// LoadBootstrapCache, then updated by each bootstrap refresh.
+var bootstrapState = struct {
+	sync.Mutex
+	data lastKnownGood
+}{data: make(lastKnownGood)}
+
+// bootstrapSnapshot copies bootstrapState's data. Categories are replaced,
+// never modified, so the copy can be used after the lock is released.
+func bootstrapSnapshot() lastKnownGood {
+	bootstrapState.Lock()
+	defer bootstrapState.Unlock()
+	snapshot := make(lastKnownGood, len(bootstrapState.data))
+	for category, c := range bootstrapState.data {
+		snapshot[category] = c
+	}
+	return snapshot
+}
+
+// BootstrapFetchedAt returns when the oldest category of the RDAP bootstrap
+// data in use was fetched from IANA, possibly by an earlier run restored from
+// a BootstrapCache. It is the zero time while any category is still served
+// from the compiled baseline.
+func BootstrapFetchedAt() time.Time {
+	bootstrapState.Lock()
+	defer bootstrapState.Unlock()
+	return bootstrapState.data.oldestFetch()
+}
+
+// BootstrapCategory is one category of RDAP bootstrap data as reported for
+// staleness checks.
+type BootstrapCategory struct {
+	Name string
+	// FetchedAt is when IANA last served the data or confirmed it unchanged;
+	// zero while the category is served from the compiled baseline.
+	FetchedAt time.Time
+	// Publication is the IANA file's own publication timestamp, if known.
+	Publication time.Time
+}
+
+// BootstrapCategories returns every RDAP bootstrap category, by name.
+func BootstrapCategories() []BootstrapCategory {
+	bootstrapState.Lock()
+	defer bootstrapState.Unlock()
+	out := make([]BootstrapCategory, 0, len(ianaBootstrapURLs))
+	for category := range ianaBootstrapURLs {
+		c := bootstrapState.data[category]
+		out = append(out, BootstrapCategory{Name: category, FetchedAt: c.FetchedAt, Publication: c.Publication})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// oldestFetch is BootstrapFetchedAt for one set of data.
+func (l lastKnownGood) oldestFetch() time.Time {
+	var oldest time.Time
+	for category := range ianaBootstrapURLs {
+		c, ok := l[category]
+		if !ok {
+			return time.Time{}
+		}
+		if oldest.IsZero() || c.FetchedAt.Before(oldest) {
+			oldest = c.FetchedAt
+		}
+	}
+	return oldest
+}
+
+// LoadBootstrapCache installs the bootstrap data stored in caches, taking
+// each category from whichever cache holds its most recent fetch. Caches that
+// fail or hold unusable data are logged and skipped. It is meant to run once
+// at startup, before StartBootstrapRefresh.
+func LoadBootstrapCache(ctx context.Context, caches ...BootstrapCache) {
+	loaded := make(lastKnownGood)
+	for _, cache := range caches {
+		data, err := cache.Load(ctx)
+		if err != nil {
+			slog.Warn("RDAP bootstrap cache unreadable", "cache", cacheName(cache), "err", err)
+			continue
+		}
+		if data == nil {
+			continue
+		}
+		var stored cachedBootstrap
+		if err := json.Unmarshal(data, &stored); err != nil {
+			slog.Warn("RDAP bootstrap cache corrupt, ignoring it", "cache", cacheName(cache), "err", err)
+			continue
+		}
+		if stored.Version != bootstrapCacheVersion {
+			slog.Warn("RDAP bootstrap cache has an unknown version, ignoring it",
+				"cache", cacheName(cache), "version", stored.Version)
+			continue
+		}
+		for category, c := range stored.Categories {
+			if _, known := ianaBootstrapURLs[category]; !known || len(c.Services) == 0 {
+				continue
+			}
+			if current, ok := loaded[category]; !ok || c.FetchedAt.After(current.FetchedAt) {
+				loaded[category] = c
+			}
+		}
+	}
+	if len(loaded) == 0 {
+		return
+	}
+
+	bootstrapState.Lock()
+	defer bootstrapState.Unlock()
+	for category, c := range loaded {
+		bootstrapState.data[category] = c
+	}
+	entries := bootstrapState.data.install()
+	fetchedAt := bootstrapState.data.oldestFetch()
+	if !fetchedAt.IsZero() {
+		metrics.BootstrapLastFetchTimestamp.Set(float64(fetchedAt.Unix()))
+	}
+	slog.Info("RDAP bootstrap restored from cache", "categories", len(loaded), "entries", entries,
+		"fetchedAt", fetchedAt)
+}
+
+// saveBootstrapCache writes l to every cache, logging the ones that fail.
+func saveBootstrapCache(ctx context.Context, l lastKnownGood, caches []BootstrapCache) {
+	if len(caches) == 0 {
+		return
+	}
+	data, err := json.Marshal(cachedBootstrap{Version: bootstrapCacheVersion, Categories: l})
+	if err != nil {
+		slog.Warn("RDAP bootstrap cache not saved", "err", err)
+		return
+	}
+	for _, cache := range caches {
+		if err := cache.Save(ctx, data); err != nil {
+			slog.Warn("RDAP bootstrap cache not saved", "cache", cacheName(cache), "err", err)
+		}
+	}
+}
+
+// cacheName describes cache in log messages.
+func cacheName(cache BootstrapCache) string {
+	switch c := cache.(type) {
+	case FileCache:
+		return string(c)
+	case RedisCache:
+		return "redis:" + c.Key
+	}
+	return fmt.Sprintf("%T", cache)
+}
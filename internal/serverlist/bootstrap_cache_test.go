@@ -0,0 +1,132 @@
+package serverlist
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// resetBootstrapState starts a test from no last-known-good data and restores
+// the compiled baseline afterwards.
+func resetBootstrapState(t *testing.T) {
+	t.Helper()
+	bootstrapState.Lock()
+	bootstrapState.data = make(lastKnownGood)
+	bootstrapState.Unlock()
+	t.Cleanup(func() {
+		bootstrapState.Lock()
+		bootstrapState.data = make(lastKnownGood)
+		bootstrapState.Unlock()
+		UpdateFromIANA(nil)
+	})
+}
+
+// TestBootstrapCacheRoundTrip verifies data a refresh saved is served again
+// after a restart, before any fetch, together with its fetch time.
+func TestBootstrapCacheRoundTrip(t *testing.T) {
+	resetBootstrapState(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"services":[[["zzcached"],["https://cached.example/rdap/"]]]}`))
+	}))
+	t.Cleanup(srv.Close)
+	swapBootstrapURLs(t, map[string]string{
+		"dns": srv.URL, "ipv4": srv.URL, "ipv6": srv.URL, "asn": srv.URL, "objecttags": srv.URL,
+	})
+	cache := FileCache(filepath.Join(t.TempDir(), "bootstrap.json"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	StartBootstrapRefresh(ctx, srv.Client(), time.Hour, cache)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if data, _ := cache.Load(context.Background()); data != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("bootstrap refresh never saved the cache")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	fetchedAt := BootstrapFetchedAt()
+	if fetchedAt.IsZero() {
+		t.Fatal("no fetch time recorded after a full refresh")
+	}
+
+	// "Restart": forget everything, then restore from the file alone.
+	bootstrapState.Lock()
+	bootstrapState.data = make(lastKnownGood)
+	bootstrapState.Unlock()
+	UpdateFromIANA(nil)
+	if _, ok := LookupRdapServer("zzcached"); ok {
+		t.Fatal("index not reset")
+	}
+
+	LoadBootstrapCache(context.Background(), cache)
+	if url, ok := LookupRdapServer("zzcached"); !ok || url != "https://cached.example/rdap/" {
+		t.Errorf("zzcached = %q, %v; want the cached URL", url, ok)
+	}
+	if got := BootstrapFetchedAt(); !got.Equal(fetchedAt) {
+		t.Errorf("restored fetch time = %v, want %v", got, fetchedAt)
+	}
+}
+
+// TestLoadBootstrapCacheNewestWins verifies each category comes from the
+// cache holding its most recent fetch, and that unusable caches are skipped.
+func TestLoadBootstrapCacheNewestWins(t *testing.T) {
+	resetBootstrapState(t)
+	dir := t.TempDir()
+	older := FileCache(filepath.Join(dir, "older.json"))
+	newer := FileCache(filepath.Join(dir, "newer.json"))
+	corrupt := FileCache(filepath.Join(dir, "corrupt.json"))
+	missing := FileCache(filepath.Join(dir, "missing.json"))
+
+	write := func(f FileCache, body string) {
+		if err := os.WriteFile(string(f), []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(older, `{"version":1,"categories":{
+		"dns":{"fetchedAt":"2026-01-01T00:00:00Z","services":{"zznewest":["https://old.example/"]}},
+		"ipv4":{"fetchedAt":"2026-01-01T00:00:00Z","services":{"198.51.100.0/24":["https://old.example/"]}}}}`)
+	write(newer, `{"version":1,"categories":{
+		"dns":{"fetchedAt":"2026-02-01T00:00:00Z","services":{"zznewest":["https://new.example/"]}},
+		"bogus":{"fetchedAt":"2026-02-01T00:00:00Z","services":{"x":["https://bogus.example/"]}}}}`)
+	write(corrupt, `{"version":1,"categories":`)
+
+	LoadBootstrapCache(context.Background(), corrupt, older, missing, newer)
+
+	if url, _ := LookupRdapServer("zznewest"); url != "https://new.example/" {
+		t.Errorf("dns from the newer cache: got %q", url)
+	}
+	if url, _ := LookupRdapServer("198.51.100.0/24"); url != "https://old.example/" {
+		t.Errorf("ipv4 from the only cache holding it: got %q", url)
+	}
+	// Three categories were never fetched, so the data is still partly the
+	// compiled baseline.
+	if got := BootstrapFetchedAt(); !got.IsZero() {
+		t.Errorf("BootstrapFetchedAt = %v, want zero", got)
+	}
+}
+
+func TestFileCacheSaveReplaces(t *testing.T) {
+	cache := FileCache(filepath.Join(t.TempDir(), "bootstrap.json"))
+	if data, err := cache.Load(context.Background()); data != nil || err != nil {
+		t.Fatalf("missing file: %q, %v; want nothing stored", data, err)
+	}
+	for _, body := range []string{"first, longer body", "second"} {
+		if err := cache.Save(context.Background(), []byte(body)); err != nil {
+			t.Fatalf("save: %v", err)
+		}
+	}
+	if data, _ := cache.Load(context.Background()); string(data) != "second" {
+		t.Errorf("loaded %q, want the last save", data)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(string(cache)))
+	if len(entries) != 1 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+}
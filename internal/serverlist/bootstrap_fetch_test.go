@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"sort"
 	"strings"
 	"testing"
@@ -11,10 +12,12 @@ import (
 )
 
 func TestFetchBootstrapParsesServices(t *testing.T) {
-	// One well-formed service with an HTTPS alternative, plus every malformed
-	// service shape the parser must skip without failing the whole file.
+	// Well-formed services with an HTTPS alternative and with two HTTPS
+	// URLs, plus every malformed service shape the parser must skip without
+	// failing the whole file.
 	body := `{"services":[
 		[["com","net"],["http://insecure.example/","https://secure.example/rdap/"]],
+		[["org"],["https://primary.example/","http://insecure.example/","https://backup.example/"]],
 		[["tooshort"]],
 		[[123],["https://badids.example/"]],
 		[["badurls"],"not-an-array"],
@@ -26,16 +29,51 @@ func TestFetchBootstrapParsesServices(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	got, err := fetchBootstrap(context.Background(), srv.Client(), srv.URL)
+	fetched, _, err := fetchBootstrap(context.Background(), srv.Client(), srv.URL, fetchedCategory{})
 	if err != nil {
 		t.Fatalf("fetchBootstrap: %v", err)
 	}
+	got := fetched.Services
+
+	want := map[string][]string{
+		"com":      {"https://secure.example/rdap/"}, // HTTPS preferred over first URL
+		"net":      {"https://secure.example/rdap/"},
+		"org":      {"https://primary.example/", "https://backup.example/"}, // order kept, HTTP dropped
+		"httponly": {"http://plain.example/"},                               // no HTTPS available
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d entries (%v), want %d", len(got), got, len(want))
+	}
+	for k, v := range want {
+		if !slices.Equal(got[k], v) {
+			t.Errorf("got[%q] = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestFetchBootstrapParsesObjectTags(t *testing.T) {
+	// RFC 8521 services lead with a contacts array.
+	body := `{"services":[
+		[["rdap@example.net"],["ARIN"],["http://rdap.example/","https://rdap.example/"]],
+		[["ops@example.org"],["AP","apnic"],["https://ap.example/"]]
+	]}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	swapBootstrapURLs(t, map[string]string{"objecttags": srv.URL})
+	perCategory, failed := FetchIANA(context.Background(), srv.Client())
+	if len(failed) != 0 {
+		t.Fatalf("failed = %v", failed)
+	}
 
 	want := map[string]string{
-		"com":      "https://secure.example/rdap/", // HTTPS preferred over first URL
-		"net":      "https://secure.example/rdap/",
-		"httponly": "http://plain.example/", // no HTTPS available: first URL
+		"tag:ARIN":  "https://rdap.example/",
+		"tag:AP":    "https://ap.example/",
+		"tag:APNIC": "https://ap.example/",
 	}
+	got := PrimaryURLs(perCategory["objecttags"])
 	if len(got) != len(want) {
 		t.Errorf("got %d entries (%v), want %d", len(got), got, len(want))
 	}
@@ -57,7 +95,7 @@ func TestFetchBootstrapErrors(t *testing.T) {
 		srv := newServer(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusInternalServerError)
 		})
-		if _, err := fetchBootstrap(context.Background(), srv.Client(), srv.URL); err == nil || !strings.Contains(err.Error(), "unexpected status") {
+		if _, _, err := fetchBootstrap(context.Background(), srv.Client(), srv.URL, fetchedCategory{}); err == nil || !strings.Contains(err.Error(), "unexpected status") {
 			t.Errorf("err = %v, want unexpected status", err)
 		}
 	})
@@ -66,7 +104,7 @@ func TestFetchBootstrapErrors(t *testing.T) {
 		srv := newServer(func(w http.ResponseWriter, r *http.Request) {
 			_, _ = w.Write([]byte("{not json"))
 		})
-		if _, err := fetchBootstrap(context.Background(), srv.Client(), srv.URL); err == nil {
+		if _, _, err := fetchBootstrap(context.Background(), srv.Client(), srv.URL, fetchedCategory{}); err == nil {
 			t.Error("want JSON decode error")
 		}
 	})
@@ -75,13 +113,13 @@ func TestFetchBootstrapErrors(t *testing.T) {
 		srv := newServer(func(w http.ResponseWriter, r *http.Request) {
 			_, _ = w.Write(make([]byte, maxBootstrapResponseSize+1))
 		})
-		if _, err := fetchBootstrap(context.Background(), srv.Client(), srv.URL); err == nil || !strings.Contains(err.Error(), "exceeds") {
+		if _, _, err := fetchBootstrap(context.Background(), srv.Client(), srv.URL, fetchedCategory{}); err == nil || !strings.Contains(err.Error(), "exceeds") {
 			t.Errorf("err = %v, want size limit error", err)
 		}
 	})
 
 	t.Run("invalid URL", func(t *testing.T) {
-		if _, err := fetchBootstrap(context.Background(), http.DefaultClient, "://bad"); err == nil {
+		if _, _, err := fetchBootstrap(context.Background(), http.DefaultClient, "://bad", fetchedCategory{}); err == nil {
 			t.Error("want request creation error")
 		}
 	})
@@ -90,12 +128,80 @@ func TestFetchBootstrapErrors(t *testing.T) {
 		srv := newServer(func(w http.ResponseWriter, r *http.Request) {})
 		url := srv.URL
 		srv.Close()
-		if _, err := fetchBootstrap(context.Background(), http.DefaultClient, url); err == nil {
+		if _, _, err := fetchBootstrap(context.Background(), http.DefaultClient, url, fetchedCategory{}); err == nil {
 			t.Error("want connection error")
 		}
 	})
 }
 
+// TestFetchBootstrapConditional verifies a refetch carries the previous
+// fetch's validators and that a 304 answer keeps the data it already has.
+func TestFetchBootstrapConditional(t *testing.T) {
+	var gotETag, gotSince string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotETag, gotSince = r.Header.Get("If-None-Match"), r.Header.Get("If-Modified-Since")
+		if gotETag == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 05 Oct 2026 12:00:00 GMT")
+		_, _ = w.Write([]byte(`{"publication":"2026-10-05T12:00:00Z","services":[[["zzcond"],["https://cond.example/"]]]}`))
+	}))
+	defer srv.Close()
+
+	first, modified, err := fetchBootstrap(context.Background(), srv.Client(), srv.URL, fetchedCategory{})
+	if err != nil || !modified {
+		t.Fatalf("first fetch: modified=%v err=%v", modified, err)
+	}
+	if gotETag != "" || gotSince != "" {
+		t.Errorf("first fetch was conditional: %q, %q", gotETag, gotSince)
+	}
+	if first.ETag != `"v1"` || first.LastModified == "" || !first.Publication.Equal(time.Date(2026, 10, 5, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("first fetch: %+v", first)
+	}
+
+	second, modified, err := fetchBootstrap(context.Background(), srv.Client(), srv.URL, first)
+	if err != nil || modified {
+		t.Fatalf("second fetch: modified=%v err=%v, want not modified", modified, err)
+	}
+	if gotSince != first.LastModified {
+		t.Errorf("If-Modified-Since = %q, want %q", gotSince, first.LastModified)
+	}
+	if !slices.Equal(second.Services["zzcond"], []string{"https://cond.example/"}) {
+		t.Errorf("304 lost the data: %+v", second)
+	}
+
+	// A 304 with nothing to keep is an error, not an empty success.
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	})
+	if _, _, err := fetchBootstrap(context.Background(), srv.Client(), srv.URL, fetchedCategory{}); err == nil {
+		t.Error("unsolicited 304 accepted")
+	}
+}
+
+// TestFetchBootstrapRejectsOlderPublication verifies a file published before
+// the data already held is refused rather than rolling it back.
+func TestFetchBootstrapRejectsOlderPublication(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"publication":"2026-01-01T00:00:00Z","services":[[["zzold"],["https://old.example/"]]]}`))
+	}))
+	defer srv.Close()
+
+	held := fetchedCategory{
+		Publication: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+		Services:    map[string][]string{"zzold": {"https://new.example/"}},
+	}
+	if _, _, err := fetchBootstrap(context.Background(), srv.Client(), srv.URL, held); err == nil || !strings.Contains(err.Error(), "before") {
+		t.Errorf("err = %v, want an older-publication error", err)
+	}
+	held.Publication = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	if _, modified, err := fetchBootstrap(context.Background(), srv.Client(), srv.URL, held); err != nil || !modified {
+		t.Errorf("newer publication: modified=%v err=%v", modified, err)
+	}
+}
+
 // swapBootstrapURLs points every IANA category at test URLs and restores the
 // real ones on cleanup.
 func swapBootstrapURLs(t *testing.T, urls map[string]string) {
@@ -126,7 +232,7 @@ func TestFetchIANAPartialFailure(t *testing.T) {
 	perCategory, failed := FetchIANA(context.Background(), srv.Client())
 
 	for _, want := range []string{"dns", "ipv4"} {
-		if data, ok := perCategory[want]; !ok || data["example"] != "https://ok.example/rdap/" {
+		if data, ok := perCategory[want]; !ok || !slices.Equal(data["example"], []string{"https://ok.example/rdap/"}) {
 			t.Errorf("category %s = %v, want fetched data", want, data)
 		}
 	}
@@ -143,6 +249,7 @@ func TestStartBootstrapRefreshDisabled(t *testing.T) {
 }
 
 func TestStartBootstrapRefreshUpdatesIndex(t *testing.T) {
+	resetBootstrapState(t)
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/broken" {
 			// One permanently failing category exercises the partial-update path.
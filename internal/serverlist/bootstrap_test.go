@@ -8,22 +8,22 @@ import "testing"
 func TestCommitBootstrapLastKnownGood(t *testing.T) {
 	t.Cleanup(func() { UpdateFromIANA(nil) })
 
-	lastGood := make(map[string]map[string]string)
+	lastGood := make(lastKnownGood)
 
 	// Round 1: both categories fetch successfully. The zzlkg TLD exists only
 	// in the fetched data, never in the compiled baseline, so its survival
 	// proves last-known-good retention.
-	outcome, entries := commitBootstrap(lastGood, map[string]map[string]string{
-		"dns":  {"zzlkg": "https://round1.example/rdap/"},
-		"ipv4": {"192.0.2.0/24": "https://round1.example/rdap/"},
+	outcome, entries := commitBootstrap(lastGood, map[string]fetchedCategory{
+		"dns":  {Services: map[string][]string{"zzlkg": {"https://round1.example/rdap/"}}},
+		"ipv4": {Services: map[string][]string{"192.0.2.0/24": {"https://round1.example/rdap/"}}},
 	}, nil)
 	if outcome != "success" || entries != 2 {
 		t.Fatalf("round 1: outcome=%q entries=%d, want success/2", outcome, entries)
 	}
 
 	// Round 2: dns fails, ipv4 fetches fresh data.
-	outcome, entries = commitBootstrap(lastGood, map[string]map[string]string{
-		"ipv4": {"192.0.2.0/24": "https://round2.example/rdap/"},
+	outcome, entries = commitBootstrap(lastGood, map[string]fetchedCategory{
+		"ipv4": {Services: map[string][]string{"192.0.2.0/24": {"https://round2.example/rdap/"}}},
 	}, []string{"dns"})
 	if outcome != "partial" || entries != 2 {
 		t.Fatalf("round 2: outcome=%q entries=%d, want partial/2", outcome, entries)
@@ -36,7 +36,7 @@ func TestCommitBootstrapLastKnownGood(t *testing.T) {
 	}
 
 	// Round 3: everything fails; the index must be left untouched.
-	outcome, entries = commitBootstrap(lastGood, map[string]map[string]string{}, []string{"dns", "ipv4", "ipv6", "asn"})
+	outcome, entries = commitBootstrap(lastGood, map[string]fetchedCategory{}, []string{"dns", "ipv4", "ipv6", "asn"})
 	if outcome != "failure" || entries != 0 {
 		t.Fatalf("round 3: outcome=%q entries=%d, want failure/0", outcome, entries)
 	}
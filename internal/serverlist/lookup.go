@@ -8,41 +8,59 @@ import (
 	"sync"
 )
 
-// ipNetEntry maps a parsed CIDR network to its RDAP server URL.
+// ipNetEntry maps a parsed CIDR network to its RDAP base URLs.
 type ipNetEntry struct {
 	net   *net.IPNet
 	start []byte // normalized network address bytes (4 bytes for IPv4, 16 for IPv6)
-	url   string
+	urls  []string
 }
 
-// asnRangeEntry maps an ASN range to its RDAP server URL.
+// asnRangeEntry maps an ASN range to its RDAP base URLs.
 type asnRangeEntry struct {
 	lower int
 	upper int
-	url   string
+	urls  []string
 }
 
-// serverIndex holds the runtime lookup structures derived from the active server map.
+// serverIndex holds the runtime lookup structures derived from the active
+// server map. Every key maps to its service's base URLs, primary first.
 type serverIndex struct {
-	servers      map[string]string // full key→URL map (for TLD/domain lookups)
-	ipv4NetList  []ipNetEntry      // sorted by start address
-	ipv6NetList  []ipNetEntry      // sorted by start address
-	asnRangeList []asnRangeEntry   // sorted by lower bound
+	servers      map[string][]string // full key→URLs map (for TLD/domain lookups)
+	ipv4NetList  []ipNetEntry        // sorted by start address
+	ipv6NetList  []ipNetEntry        // sorted by start address
+	asnRangeList []asnRangeEntry     // sorted by lower bound
+	// overrideNets and overrideASNs hold the CIDR and ASN-range keys of
+	// custom and configured entries. Unlike IANA's ranges they may nest
+	// inside or overlap one another, so they are kept out of the sorted
+	// lists and scanned first, the most specific match winning; there are
+	// only ever a handful.
+	overrideNets []ipNetEntry
+	overrideASNs []asnRangeEntry
 }
 
 var (
 	mu    sync.RWMutex
 	index serverIndex
+
+	// updateMu serializes index rebuilds, which run outside mu so lookups
+	// are not held up while one is built. It guards ianaServers and
+	// configuredRdapServers.
+	updateMu sync.Mutex
+	// ianaServers is the data last passed to UpdateFromIANAServices, kept
+	// so a change of configured servers can rebuild the index without a
+	// refetch.
+	ianaServers map[string][]string
+	// configuredRdapServers holds servers.rdap from the configuration.
+	configuredRdapServers map[string]string
 )
 
 func init() {
-	merged := mergeServers(compiledRdapServers, customRdapServers)
-	index = buildIndex(merged)
+	rebuildIndex()
 }
 
 // mergeServers returns a new map with base entries overlaid by overrides.
-func mergeServers(base, overrides map[string]string) map[string]string {
-	merged := make(map[string]string, len(base)+len(overrides))
+func mergeServers[V any](base, overrides map[string]V) map[string]V {
+	merged := make(map[string]V, len(base)+len(overrides))
 	for k, v := range base {
 		merged[k] = v
 	}
@@ -52,14 +70,67 @@ func mergeServers(base, overrides map[string]string) map[string]string {
 	return merged
 }
 
-// UpdateFromIANA rebuilds the active index by merging fetched IANA data with
-// the compiled-in baseline (as fallback for missing categories) and custom entries.
-// custom always wins; fetched IANA beats compiled baseline.
-func UpdateFromIANA(ianaServers map[string]string) {
-	// Start from compiled baseline, overlay fetched IANA, then custom on top.
-	merged := mergeServers(compiledRdapServers, ianaServers)
-	merged = mergeServers(merged, customRdapServers)
-	newIndex := buildIndex(merged)
+// singleURLs returns servers with each URL as a one-element list. Custom and
+// configured entries name one base URL, and carry only that one.
+func singleURLs(servers map[string]string) map[string][]string {
+	out := make(map[string][]string, len(servers))
+	for k, v := range servers {
+		out[k] = []string{v}
+	}
+	return out
+}
+
+// UpdateFromIANA is UpdateFromIANAServices for data with a single base URL
+// per key.
+func UpdateFromIANA(servers map[string]string) {
+	var services map[string][]string
+	if servers != nil {
+		services = singleURLs(servers)
+	}
+	UpdateFromIANAServices(services)
+}
+
+// UpdateFromIANAServices rebuilds the active index by merging fetched IANA
+// data — each key's base URLs, primary first — with the compiled-in baseline
+// (as fallback for missing categories) and custom entries. custom always
+// wins; fetched IANA beats compiled baseline. A service's further URLs are
+// its failover alternates (see LookupRdapServers).
+func UpdateFromIANAServices(services map[string][]string) {
+	updateMu.Lock()
+	defer updateMu.Unlock()
+	ianaServers = services
+	rebuildIndex()
+}
+
+// PrimaryURLs returns each key's first base URL.
+func PrimaryURLs(services map[string][]string) map[string]string {
+	out := make(map[string]string, len(services))
+	for k, urls := range services {
+		if len(urls) > 0 {
+			out[k] = urls[0]
+		}
+	}
+	return out
+}
+
+// SetRdapOverrides installs the operator's servers.rdap entries, keyed like
+// the compiled data (TLD, CIDR, ASN range or object tag). They layer on top
+// of the compiled and IANA data with the precedence of custom entries, and
+// win over a custom entry for the same key. nil removes them.
+func SetRdapOverrides(servers map[string]string) {
+	updateMu.Lock()
+	defer updateMu.Unlock()
+	configuredRdapServers = servers
+	rebuildIndex()
+}
+
+// rebuildIndex swaps in an index built from the compiled baseline, overlaid
+// with IANA data, then custom and configured entries. Callers hold updateMu
+// (init excepted, which runs before anything else can).
+func rebuildIndex() {
+	overrides := singleURLs(mergeServers(customRdapServers, configuredRdapServers))
+	merged := mergeServers(mergeServers(compiledRdapServers, ianaServers), overrides)
+	newIndex := buildIndex(merged, overrides)
 
 	mu.Lock()
 	index = newIndex
@@ -68,10 +139,58 @@ func UpdateFromIANA(ianaServers map[string]string) {
 
 // LookupRdapServer returns the RDAP server URL for a given key (TLD, CIDR, ASN range).
 func LookupRdapServer(key string) (string, bool) {
+	return primary(LookupRdapServers(key))
+}
+
+// LookupRdapServers is LookupRdapServer returning every base URL of the
+// service, primary first. The list must not be modified.
+func LookupRdapServers(key string) ([]string, bool) {
 	mu.RLock()
 	v, ok := index.servers[key]
 	mu.RUnlock()
-	return v, ok
+	return v, ok && len(v) > 0
+}
+
+// primary returns the first of a lookup's base URLs.
+func primary(urls []string, ok bool) (string, bool) {
+	if !ok {
+		return "", false
+	}
+	return urls[0], true
+}
+
+// objectTagPrefix marks object tag keys in the server map. Tags such as
+// "ARIN" would otherwise share a namespace with TLDs; no TLD contains a
+// colon, and buildIndex only parses keys containing a slash or a hyphen,
+// which a tag never does.
+const objectTagPrefix = "tag:"
+
+// ObjectTagKey returns the server map key for an RFC 8521 object tag. Tags
+// are case-insensitive, so the key uses the upper-case form IANA publishes.
+func ObjectTagKey(tag string) string {
+	return objectTagPrefix + strings.ToUpper(tag)
+}
+
+// LookupEntityServer returns the RDAP server URL for an entity handle, routed
+// by its object tag: the text after the last hyphen ("ABC123-ARIN" → "ARIN",
+// RFC 8521 section 2). It also returns the tag, which is empty when the
+// handle carries none.
+func LookupEntityServer(handle string) (url, tag string, ok bool) {
+	urls, tag, ok := LookupEntityServers(handle)
+	url, ok = primary(urls, ok)
+	return url, tag, ok
+}
+
+// LookupEntityServers is LookupEntityServer returning every base URL of the
+// service, primary first.
+func LookupEntityServers(handle string) (urls []string, tag string, ok bool) {
+	i := strings.LastIndex(handle, "-")
+	if i < 0 || i == len(handle)-1 {
+		return nil, "", false
+	}
+	tag = strings.ToUpper(handle[i+1:])
+	urls, ok = LookupRdapServers(ObjectTagKey(tag))
+	return urls, tag, ok
 }
 
 // compareIPs compares two equal-length IP byte slices lexicographically.
@@ -87,38 +206,46 @@ func compareIPs(a, b []byte) int {
 	return 0
 }
 
-// buildIndex parses a server map into a serverIndex with sorted lookup structures.
-func buildIndex(servers map[string]string) serverIndex {
+// buildIndex parses a server map into a serverIndex with sorted lookup
+// structures. CIDR and ASN-range keys also present in overrides are indexed
+// as overrides instead.
+func buildIndex(servers, overrides map[string][]string) serverIndex {
 	idx := serverIndex{
 		servers: servers,
 	}
 
-	for key, url := range servers {
+	for key, urls := range servers {
+		_, override := overrides[key]
 		if strings.Contains(key, "/") {
 			_, ipNet, err := net.ParseCIDR(key)
 			if err != nil {
 				continue
 			}
+			var entry ipNetEntry
 			if v4 := ipNet.IP.To4(); v4 != nil {
 				start := make([]byte, 4)
 				copy(start, v4)
-				idx.ipv4NetList = append(idx.ipv4NetList, ipNetEntry{net: ipNet, start: start, url: url})
+				entry = ipNetEntry{net: ipNet, start: start, urls: urls}
 			} else {
 				start := make([]byte, 16)
 				copy(start, ipNet.IP.To16())
-				idx.ipv6NetList = append(idx.ipv6NetList, ipNetEntry{net: ipNet, start: start, url: url})
+				entry = ipNetEntry{net: ipNet, start: start, urls: urls}
 			}
-		} else if strings.Contains(key, "-") {
-			parts := strings.SplitN(key, "-", 2)
-			lower, err := strconv.Atoi(parts[0])
-			if err != nil {
-				continue
+			switch {
+			case override:
+				idx.overrideNets = append(idx.overrideNets, entry)
+			case len(entry.start) == 4:
+				idx.ipv4NetList = append(idx.ipv4NetList, entry)
+			default:
+				idx.ipv6NetList = append(idx.ipv6NetList, entry)
 			}
-			upper, err := strconv.Atoi(parts[1])
-			if err != nil {
-				continue
+		} else if lower, upper, ok := parseASNRange(key); ok {
+			entry := asnRangeEntry{lower: lower, upper: upper, urls: urls}
+			if override {
+				idx.overrideASNs = append(idx.overrideASNs, entry)
+			} else {
+				idx.asnRangeList = append(idx.asnRangeList, entry)
 			}
-			idx.asnRangeList = append(idx.asnRangeList, asnRangeEntry{lower: lower, upper: upper, url: url})
 		}
 	}
 
@@ -150,37 +277,95 @@ func binarySearchIP(list []ipNetEntry, ip []byte) int {
 	return idx
 }
 
+// parseASNRange parses an ASN-range server key ("64496-64511").
+func parseASNRange(key string) (lower, upper int, ok bool) {
+	lo, hi, found := strings.Cut(key, "-")
+	if !found {
+		return 0, 0, false
+	}
+	lower, err := strconv.Atoi(lo)
+	if err != nil {
+		return 0, 0, false
+	}
+	upper, err = strconv.Atoi(hi)
+	if err != nil {
+		return 0, 0, false
+	}
+	return lower, upper, true
+}
+
 // LookupIPKey returns the RDAP server URL for the given IP address.
 // Uses binary search on pre-sorted, non-overlapping CIDR lists: O(log n).
 func LookupIPKey(ip net.IP) (string, bool) {
+	return primary(LookupIPServers(ip))
+}
+
+// LookupIPServers is LookupIPKey returning every base URL of the service,
+// primary first. The list must not be modified.
+func LookupIPServers(ip net.IP) ([]string, bool) {
 	mu.RLock()
 	defer mu.RUnlock()
 
+	if urls, ok := lookupOverrideNet(ip); ok {
+		return urls, true
+	}
+
 	if v4 := ip.To4(); v4 != nil {
 		i := binarySearchIP(index.ipv4NetList, v4)
 		if i >= 0 && index.ipv4NetList[i].net.Contains(ip) {
-			return index.ipv4NetList[i].url, true
+			return index.ipv4NetList[i].urls, true
 		}
-		return "", false
+		return nil, false
 	}
 
 	v6 := ip.To16()
 	if v6 == nil {
-		return "", false
+		return nil, false
 	}
 	i := binarySearchIP(index.ipv6NetList, v6)
 	if i >= 0 && index.ipv6NetList[i].net.Contains(ip) {
-		return index.ipv6NetList[i].url, true
+		return index.ipv6NetList[i].urls, true
+	}
+	return nil, false
+}
+
+// lookupOverrideNet returns the URLs of the most specific override CIDR
+// containing ip. Callers hold mu.
+func lookupOverrideNet(ip net.IP) ([]string, bool) {
+	var urls []string
+	bestBits := -1
+	for _, e := range index.overrideNets {
+		if bits, _ := e.net.Mask.Size(); bits > bestBits && e.net.Contains(ip) {
+			urls, bestBits = e.urls, bits
+		}
 	}
-	return "", false
+	return urls, bestBits >= 0
 }
 
 // LookupASNKey returns the RDAP server URL for the given ASN number.
-// Uses binary search on the pre-sorted range list.
+// Uses binary search on the pre-sorted range list, after the override ranges
+// (the narrowest containing range wins).
 func LookupASNKey(asn int) (string, bool) {
+	return primary(LookupASNServers(asn))
+}
+
+// LookupASNServers is LookupASNKey returning every base URL of the service,
+// primary first. The list must not be modified.
+func LookupASNServers(asn int) ([]string, bool) {
 	mu.RLock()
 	defer mu.RUnlock()
 
+	var urls []string
+	width := -1
+	for _, e := range index.overrideASNs {
+		if asn >= e.lower && asn <= e.upper && (width < 0 || e.upper-e.lower < width) {
+			urls, width = e.urls, e.upper-e.lower
+		}
+	}
+	if width >= 0 {
+		return urls, true
+	}
+
 	lo, hi, idx := 0, len(index.asnRangeList)-1, -1
 	for lo <= hi {
 		mid := (lo + hi) / 2
@@ -192,7 +377,7 @@ func LookupASNKey(asn int) (string, bool) {
 		}
 	}
 	if idx >= 0 && asn <= index.asnRangeList[idx].upper {
-		return index.asnRangeList[idx].url, true
+		return index.asnRangeList[idx].urls, true
 	}
-	return "", false
+	return nil, false
 }
@@ -2,6 +2,7 @@ package serverlist
 
 import (
 	"net"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -122,9 +123,176 @@ func TestUpdateFromIANA(t *testing.T) {
 	}
 
 	// Restore with original compiled data so other tests aren't affected.
-	UpdateFromIANA(compiledRdapServers)
+	UpdateFromIANAServices(compiledRdapServers)
 	restored, _ := LookupRdapServer("com")
 	if restored != original {
 		t.Errorf("after restore: got %q, want %q", restored, original)
 	}
 }
+
+// TestLookupRdapServers verifies a service keeps every IANA base URL, primary
+// first, and that an override carries only its own URL.
+func TestLookupRdapServers(t *testing.T) {
+	UpdateFromIANAServices(map[string][]string{
+		"zzalt":           {"https://primary.example/", "https://backup.example/"},
+		"zzsingle":        {"https://single.example/"},
+		"zzoverride":      {"https://iana.example/", "https://iana-backup.example/"},
+		"198.51.100.0/24": {"https://primary.example/", "https://backup.example/"},
+		"64496-64511":     {"https://primary.example/", "https://backup.example/"},
+	})
+	t.Cleanup(func() { UpdateFromIANA(nil) })
+	SetRdapOverrides(map[string]string{"zzoverride": "https://override.example/"})
+	t.Cleanup(func() { SetRdapOverrides(nil) })
+
+	both := []string{"https://primary.example/", "https://backup.example/"}
+	if got, _ := LookupRdapServer("zzalt"); got != "https://primary.example/" {
+		t.Errorf("zzalt: got %q, want the primary", got)
+	}
+	if got, _ := LookupRdapServers("zzalt"); !slices.Equal(got, both) {
+		t.Errorf("zzalt base URLs = %q", got)
+	}
+	if got, _ := LookupRdapServers("zzsingle"); !slices.Equal(got, []string{"https://single.example/"}) {
+		t.Errorf("zzsingle base URLs = %q", got)
+	}
+	if got, _ := LookupRdapServers("zzoverride"); !slices.Equal(got, []string{"https://override.example/"}) {
+		t.Errorf("zzoverride base URLs = %q, want the override alone", got)
+	}
+	if got, _ := LookupIPServers(net.ParseIP("198.51.100.7")); !slices.Equal(got, both) {
+		t.Errorf("IP base URLs = %q", got)
+	}
+	if got, _ := LookupASNServers(64500); !slices.Equal(got, both) {
+		t.Errorf("ASN base URLs = %q", got)
+	}
+	if _, ok := LookupRdapServers("zznonexistent"); ok {
+		t.Error("zznonexistent: want not found")
+	}
+}
+
+// TestCompiledAlternates verifies compiled entries listing several base URLs
+// keep their alternates when no IANA data replaces them.
+func TestCompiledAlternates(t *testing.T) {
+	old := compiledRdapServers
+	compiledRdapServers = mergeServers(old, map[string][]string{
+		"zzcompiled": {"https://primary.example/", "https://backup.example/"},
+	})
+	UpdateFromIANA(nil)
+	t.Cleanup(func() {
+		compiledRdapServers = old
+		UpdateFromIANA(nil)
+	})
+
+	if got, _ := LookupRdapServers("zzcompiled"); !slices.Equal(got, []string{"https://primary.example/", "https://backup.example/"}) {
+		t.Errorf("zzcompiled base URLs = %q", got)
+	}
+}
+
+// TestSetRdapOverrides verifies configured entries beat compiled and IANA
+// data, and that a CIDR or ASN range nested inside an IANA block overrides
+// only its own addresses.
+func TestSetRdapOverrides(t *testing.T) {
+	SetRdapOverrides(map[string]string{
+		"com":         "https://override.example/com/",
+		"1.1.1.0/24":  "https://override.example/net/",
+		"1.1.1.0/28":  "https://override.example/small/",
+		"36900-36910": "https://override.example/asn/",
+	})
+	t.Cleanup(func() { SetRdapOverrides(nil) })
+	// Overrides survive an IANA refresh.
+	UpdateFromIANA(map[string]string{"com": "https://fake-iana.example/rdap/"})
+	t.Cleanup(func() { UpdateFromIANA(nil) })
+
+	if got, _ := LookupRdapServer("com"); got != "https://override.example/com/" {
+		t.Errorf("com: got %q", got)
+	}
+	ipTests := []struct{ ip, want string }{
+		{"1.1.1.1", "https://override.example/small/"}, // most specific wins
+		{"1.1.1.200", "https://override.example/net/"},
+		{"1.1.2.1", "rdap.apnic.net"},   // rest of APNIC's 1.0.0.0/8
+		{"1.255.0.1", "rdap.apnic.net"}, // past the nested override
+	}
+	for _, tt := range ipTests {
+		if got, ok := LookupIPKey(net.ParseIP(tt.ip)); !ok || !strings.Contains(got, tt.want) {
+			t.Errorf("LookupIPKey(%s): got %q, want %q", tt.ip, got, tt.want)
+		}
+	}
+	asnTests := []struct {
+		asn  int
+		want string
+	}{
+		{36905, "https://override.example/asn/"},
+		{36899, "rdap.afrinic.net"},
+		{36911, "rdap.afrinic.net"},
+	}
+	for _, tt := range asnTests {
+		if got, ok := LookupASNKey(tt.asn); !ok || !strings.Contains(got, tt.want) {
+			t.Errorf("LookupASNKey(%d): got %q, want %q", tt.asn, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeRdapKey(t *testing.T) {
+	valid := map[string]string{
+		"IO":            "io",
+		"co.jp.":        "co.jp",
+		"xn--p1ai":      "xn--p1ai",
+		"192.0.2.7/24":  "192.0.2.0/24",
+		"2001:DB8::/32": "2001:db8::/32",
+		"64496-64511":   "64496-64511",
+		"64496":         "64496-64496",
+		"AS64496":       "64496-64496",
+		"as4294967295":  "4294967295-4294967295",
+		"asia":          "asia",
+		"AS":            "as", // American Samoa
+		"tag:arin":      "tag:ARIN",
+	}
+	for key, want := range valid {
+		if got, err := NormalizeRdapKey(key); err != nil || got != want {
+			t.Errorf("NormalizeRdapKey(%q) = %q, %v; want %q", key, got, err, want)
+		}
+	}
+	for _, key := range []string{"", "bad key", "192.0.2.0/33", "64511-64496", "1-4294967296", "tag:", "a..b", "4294967296", "AS4294967296", "as99999999999999999999"} {
+		if got, err := NormalizeRdapKey(key); err == nil {
+			t.Errorf("NormalizeRdapKey(%q) = %q, want error", key, got)
+		}
+	}
+}
+
+func TestLookupEntityServer(t *testing.T) {
+	tests := []struct {
+		handle    string
+		wantURL   string // substring expected in the returned URL
+		wantTag   string
+		wantFound bool
+	}{
+		{"ABC123-ARIN", "rdap.arin.net", "ARIN", true},
+		{"ripe-ncc-hm-mnt", "", "MNT", false}, // last hyphen decides
+		{"XYZ1-ripe", "rdap.db.ripe.net", "RIPE", true},
+		{"292-IANA", "rdap.iana.org", "IANA", true}, // custom entry
+		{"NOTAG", "", "", false},
+		{"TRAILING-", "", "", false},
+		{"ABC-ZZNOTAG", "", "ZZNOTAG", false},
+	}
+
+	for _, tt := range tests {
+		gotURL, gotTag, gotFound := LookupEntityServer(tt.handle)
+		if gotFound != tt.wantFound || gotTag != tt.wantTag {
+			t.Errorf("LookupEntityServer(%q): tag=%q found=%v, want %q %v", tt.handle, gotTag, gotFound, tt.wantTag, tt.wantFound)
+			continue
+		}
+		if tt.wantFound && !strings.Contains(gotURL, tt.wantURL) {
+			t.Errorf("LookupEntityServer(%q): url=%q, want to contain %q", tt.handle, gotURL, tt.wantURL)
+		}
+	}
+}
+
+// TestObjectTagKeysStayOutOfIPAndASNIndexes verifies tag keys are only
+// reachable through LookupEntityServer and never parsed as a range.
+func TestObjectTagKeysStayOutOfIPAndASNIndexes(t *testing.T) {
+	idx := buildIndex(map[string][]string{ObjectTagKey("arin"): {"https://rdap.arin.net/registry/"}}, nil)
+	if len(idx.ipv4NetList)+len(idx.ipv6NetList)+len(idx.asnRangeList) != 0 {
+		t.Errorf("tag key leaked into a range index: %+v", idx)
+	}
+	if _, ok := idx.servers["tag:ARIN"]; !ok {
+		t.Errorf("ObjectTagKey(%q) not upper-cased: %v", "arin", idx.servers)
+	}
+}
@@ -0,0 +1,76 @@
+package serverlist
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// maxASN is the largest 32-bit AS number (RFC 6793).
+const maxASN = 1<<32 - 1
+
+// NormalizeRdapKey validates a servers.rdap key and returns it in the form
+// the server map uses: a domain suffix lowercased ("io", "co.jp"), a CIDR
+// block in canonical form ("192.0.2.0/24"), an ASN range ("64496-64511"), a
+// single AS number as a one-number range ("64496" and "AS64496" both become
+// "64496-64496") or an object tag upper-cased ("tag:ARIN"). A key of digits,
+// with or without "AS", is always an AS number, never a domain suffix.
+func NormalizeRdapKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if tag, ok := strings.CutPrefix(strings.ToLower(key), objectTagPrefix); ok {
+		if tag == "" || !isTLDLabel(tag) {
+			return "", fmt.Errorf("invalid object tag %q", key)
+		}
+		return ObjectTagKey(tag), nil
+	}
+	if strings.Contains(key, "/") {
+		_, ipNet, err := net.ParseCIDR(key)
+		if err != nil {
+			return "", fmt.Errorf("invalid CIDR block %q", key)
+		}
+		return ipNet.String(), nil
+	}
+	if lower, upper, ok := parseASNRange(key); ok {
+		if lower < 0 || lower > upper || upper > maxASN {
+			return "", fmt.Errorf("invalid ASN range %q", key)
+		}
+		return fmt.Sprintf("%d-%d", lower, upper), nil
+	}
+	if digits := strings.TrimPrefix(strings.ToLower(key), "as"); isDigits(digits) {
+		asn, err := strconv.ParseUint(digits, 10, 64)
+		if err != nil || asn > maxASN {
+			return "", fmt.Errorf("invalid AS number %q", key)
+		}
+		return fmt.Sprintf("%d-%d", asn, asn), nil
+	}
+	return NormalizeWhoisKey(key)
+}
+
+// isDigits reports whether s is a non-empty run of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeWhoisKey validates a servers.whois key — a domain suffix such as
+// "io" or "co.jp" — and returns it lowercased.
+func NormalizeWhoisKey(key string) (string, error) {
+	suffix := strings.ToLower(strings.Trim(strings.TrimSpace(key), "."))
+	if suffix == "" {
+		return "", fmt.Errorf("invalid domain suffix %q", key)
+	}
+	for _, label := range strings.Split(suffix, ".") {
+		if !isTLDLabel(label) {
+			return "", fmt.Errorf("invalid domain suffix %q", key)
+		}
+	}
+	return suffix, nil
+}
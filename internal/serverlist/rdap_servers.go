@@ -5,1626 +5,1633 @@ package serverlist
 // compiledRdapServers is the compiled-in IANA baseline data.
 // It is used as a fallback when the live IANA bootstrap fetch fails.
 // Source: https://data.iana.org/rdap/
-var compiledRdapServers = map[string]string{
+var compiledRdapServers = map[string][]string{
 	// IPv4 RDAP servers
-	"102.0.0.0/8": "https://rdap.afrinic.net/rdap/",
-	"105.0.0.0/8": "https://rdap.afrinic.net/rdap/",
-	"154.0.0.0/8": "https://rdap.afrinic.net/rdap/",
-	"196.0.0.0/8": "https://rdap.afrinic.net/rdap/",
-	"197.0.0.0/8": "https://rdap.afrinic.net/rdap/",
-	"41.0.0.0/8":  "https://rdap.afrinic.net/rdap/",
-	"1.0.0.0/8":   "https://rdap.apnic.net/",
-	"101.0.0.0/8": "https://rdap.apnic.net/",
-	"103.0.0.0/8": "https://rdap.apnic.net/",
-	"106.0.0.0/8": "https://rdap.apnic.net/",
-	"110.0.0.0/8": "https://rdap.apnic.net/",
-	"111.0.0.0/8": "https://rdap.apnic.net/",
-	"112.0.0.0/8": "https://rdap.apnic.net/",
-	"113.0.0.0/8": "https://rdap.apnic.net/",
-	"114.0.0.0/8": "https://rdap.apnic.net/",
-	"115.0.0.0/8": "https://rdap.apnic.net/",
-	"116.0.0.0/8": "https://rdap.apnic.net/",
-	"117.0.0.0/8": "https://rdap.apnic.net/",
-	"118.0.0.0/8": "https://rdap.apnic.net/",
-	"119.0.0.0/8": "https://rdap.apnic.net/",
-	"120.0.0.0/8": "https://rdap.apnic.net/",
-	"121.0.0.0/8": "https://rdap.apnic.net/",
-	"122.0.0.0/8": "https://rdap.apnic.net/",
-	"123.0.0.0/8": "https://rdap.apnic.net/",
-	"124.0.0.0/8": "https://rdap.apnic.net/",
-	"125.0.0.0/8": "https://rdap.apnic.net/",
-	"126.0.0.0/8": "https://rdap.apnic.net/",
-	"133.0.0.0/8": "https://rdap.apnic.net/",
-	"14.0.0.0/8":  "https://rdap.apnic.net/",
-	"150.0.0.0/8": "https://rdap.apnic.net/",
-	"153.0.0.0/8": "https://rdap.apnic.net/",
-	"163.0.0.0/8": "https://rdap.apnic.net/",
-	"171.0.0.0/8": "https://rdap.apnic.net/",
-	"175.0.0.0/8": "https://rdap.apnic.net/",
-	"180.0.0.0/8": "https://rdap.apnic.net/",
-	"182.0.0.0/8": "https://rdap.apnic.net/",
-	"183.0.0.0/8": "https://rdap.apnic.net/",
-	"202.0.0.0/8": "https://rdap.apnic.net/",
-	"203.0.0.0/8": "https://rdap.apnic.net/",
-	"210.0.0.0/8": "https://rdap.apnic.net/",
-	"211.0.0.0/8": "https://rdap.apnic.net/",
-	"218.0.0.0/8": "https://rdap.apnic.net/",
-	"219.0.0.0/8": "https://rdap.apnic.net/",
-	"220.0.0.0/8": "https://rdap.apnic.net/",
-	"221.0.0.0/8": "https://rdap.apnic.net/",
-	"222.0.0.0/8": "https://rdap.apnic.net/",
-	"223.0.0.0/8": "https://rdap.apnic.net/",
-	"27.0.0.0/8":  "https://rdap.apnic.net/",
-	"36.0.0.0/8":  "https://rdap.apnic.net/",
-	"39.0.0.0/8":  "https://rdap.apnic.net/",
-	"42.0.0.0/8":  "https://rdap.apnic.net/",
-	"43.0.0.0/8":  "https://rdap.apnic.net/",
-	"49.0.0.0/8":  "https://rdap.apnic.net/",
-	"58.0.0.0/8":  "https://rdap.apnic.net/",
-	"59.0.0.0/8":  "https://rdap.apnic.net/",
-	"60.0.0.0/8":  "https://rdap.apnic.net/",
-	"61.0.0.0/8":  "https://rdap.apnic.net/",
-	"100.0.0.0/8": "https://rdap.arin.net/registry/",
-	"104.0.0.0/8": "https://rdap.arin.net/registry/",
-	"107.0.0.0/8": "https://rdap.arin.net/registry/",
-	"108.0.0.0/8": "https://rdap.arin.net/registry/",
-	"11.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"12.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"128.0.0.0/8": "https://rdap.arin.net/registry/",
-	"129.0.0.0/8": "https://rdap.arin.net/registry/",
-	"13.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"130.0.0.0/8": "https://rdap.arin.net/registry/",
-	"131.0.0.0/8": "https://rdap.arin.net/registry/",
-	"132.0.0.0/8": "https://rdap.arin.net/registry/",
-	"134.0.0.0/8": "https://rdap.arin.net/registry/",
-	"135.0.0.0/8": "https://rdap.arin.net/registry/",
-	"136.0.0.0/8": "https://rdap.arin.net/registry/",
-	"137.0.0.0/8": "https://rdap.arin.net/registry/",
-	"138.0.0.0/8": "https://rdap.arin.net/registry/",
-	"139.0.0.0/8": "https://rdap.arin.net/registry/",
-	"140.0.0.0/8": "https://rdap.arin.net/registry/",
-	"142.0.0.0/8": "https://rdap.arin.net/registry/",
-	"143.0.0.0/8": "https://rdap.arin.net/registry/",
-	"144.0.0.0/8": "https://rdap.arin.net/registry/",
-	"146.0.0.0/8": "https://rdap.arin.net/registry/",
-	"147.0.0.0/8": "https://rdap.arin.net/registry/",
-	"148.0.0.0/8": "https://rdap.arin.net/registry/",
-	"149.0.0.0/8": "https://rdap.arin.net/registry/",
-	"15.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"152.0.0.0/8": "https://rdap.arin.net/registry/",
-	"155.0.0.0/8": "https://rdap.arin.net/registry/",
-	"156.0.0.0/8": "https://rdap.arin.net/registry/",
-	"157.0.0.0/8": "https://rdap.arin.net/registry/",
-	"158.0.0.0/8": "https://rdap.arin.net/registry/",
-	"159.0.0.0/8": "https://rdap.arin.net/registry/",
-	"16.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"160.0.0.0/8": "https://rdap.arin.net/registry/",
-	"161.0.0.0/8": "https://rdap.arin.net/registry/",
-	"162.0.0.0/8": "https://rdap.arin.net/registry/",
-	"164.0.0.0/8": "https://rdap.arin.net/registry/",
-	"165.0.0.0/8": "https://rdap.arin.net/registry/",
-	"166.0.0.0/8": "https://rdap.arin.net/registry/",
-	"167.0.0.0/8": "https://rdap.arin.net/registry/",
-	"168.0.0.0/8": "https://rdap.arin.net/registry/",
-	"169.0.0.0/8": "https://rdap.arin.net/registry/",
-	"17.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"170.0.0.0/8": "https://rdap.arin.net/registry/",
-	"172.0.0.0/8": "https://rdap.arin.net/registry/",
-	"173.0.0.0/8": "https://rdap.arin.net/registry/",
-	"174.0.0.0/8": "https://rdap.arin.net/registry/",
-	"18.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"184.0.0.0/8": "https://rdap.arin.net/registry/",
-	"19.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"192.0.0.0/8": "https://rdap.arin.net/registry/",
-	"198.0.0.0/8": "https://rdap.arin.net/registry/",
-	"199.0.0.0/8": "https://rdap.arin.net/registry/",
-	"20.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"204.0.0.0/8": "https://rdap.arin.net/registry/",
-	"205.0.0.0/8": "https://rdap.arin.net/registry/",
-	"206.0.0.0/8": "https://rdap.arin.net/registry/",
-	"207.0.0.0/8": "https://rdap.arin.net/registry/",
-	"208.0.0.0/8": "https://rdap.arin.net/registry/",
-	"209.0.0.0/8": "https://rdap.arin.net/registry/",
-	"21.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"214.0.0.0/8": "https://rdap.arin.net/registry/",
-	"215.0.0.0/8": "https://rdap.arin.net/registry/",
-	"216.0.0.0/8": "https://rdap.arin.net/registry/",
-	"22.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"23.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"24.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"26.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"28.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"29.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"3.0.0.0/8":   "https://rdap.arin.net/registry/",
-	"30.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"32.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"33.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"34.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"35.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"38.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"4.0.0.0/8":   "https://rdap.arin.net/registry/",
-	"40.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"44.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"45.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"47.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"48.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"50.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"52.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"54.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"55.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"56.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"6.0.0.0/8":   "https://rdap.arin.net/registry/",
-	"63.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"64.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"65.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"66.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"67.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"68.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"69.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"7.0.0.0/8":   "https://rdap.arin.net/registry/",
-	"70.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"71.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"72.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"73.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"74.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"75.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"76.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"8.0.0.0/8":   "https://rdap.arin.net/registry/",
-	"9.0.0.0/8":   "https://rdap.arin.net/registry/",
-	"96.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"97.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"98.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"99.0.0.0/8":  "https://rdap.arin.net/registry/",
-	"109.0.0.0/8": "https://rdap.db.ripe.net/",
-	"141.0.0.0/8": "https://rdap.db.ripe.net/",
-	"145.0.0.0/8": "https://rdap.db.ripe.net/",
-	"151.0.0.0/8": "https://rdap.db.ripe.net/",
-	"176.0.0.0/8": "https://rdap.db.ripe.net/",
-	"178.0.0.0/8": "https://rdap.db.ripe.net/",
-	"185.0.0.0/8": "https://rdap.db.ripe.net/",
-	"188.0.0.0/8": "https://rdap.db.ripe.net/",
-	"193.0.0.0/8": "https://rdap.db.ripe.net/",
-	"194.0.0.0/8": "https://rdap.db.ripe.net/",
-	"195.0.0.0/8": "https://rdap.db.ripe.net/",
-	"2.0.0.0/8":   "https://rdap.db.ripe.net/",
-	"212.0.0.0/8": "https://rdap.db.ripe.net/",
-	"213.0.0.0/8": "https://rdap.db.ripe.net/",
-	"217.0.0.0/8": "https://rdap.db.ripe.net/",
-	"25.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"31.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"37.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"46.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"5.0.0.0/8":   "https://rdap.db.ripe.net/",
-	"51.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"53.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"57.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"62.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"77.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"78.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"79.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"80.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"81.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"82.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"83.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"84.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"85.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"86.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"87.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"88.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"89.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"90.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"91.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"92.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"93.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"94.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"95.0.0.0/8":  "https://rdap.db.ripe.net/",
-	"177.0.0.0/8": "https://rdap.lacnic.net/rdap/",
-	"179.0.0.0/8": "https://rdap.lacnic.net/rdap/",
-	"181.0.0.0/8": "https://rdap.lacnic.net/rdap/",
-	"186.0.0.0/8": "https://rdap.lacnic.net/rdap/",
-	"187.0.0.0/8": "https://rdap.lacnic.net/rdap/",
-	"189.0.0.0/8": "https://rdap.lacnic.net/rdap/",
-	"190.0.0.0/8": "https://rdap.lacnic.net/rdap/",
-	"191.0.0.0/8": "https://rdap.lacnic.net/rdap/",
-	"200.0.0.0/8": "https://rdap.lacnic.net/rdap/",
-	"201.0.0.0/8": "https://rdap.lacnic.net/rdap/",
+	"102.0.0.0/8": {"https://rdap.afrinic.net/rdap/"},
+	"105.0.0.0/8": {"https://rdap.afrinic.net/rdap/"},
+	"154.0.0.0/8": {"https://rdap.afrinic.net/rdap/"},
+	"196.0.0.0/8": {"https://rdap.afrinic.net/rdap/"},
+	"197.0.0.0/8": {"https://rdap.afrinic.net/rdap/"},
+	"41.0.0.0/8":  {"https://rdap.afrinic.net/rdap/"},
+	"1.0.0.0/8":   {"https://rdap.apnic.net/"},
+	"101.0.0.0/8": {"https://rdap.apnic.net/"},
+	"103.0.0.0/8": {"https://rdap.apnic.net/"},
+	"106.0.0.0/8": {"https://rdap.apnic.net/"},
+	"110.0.0.0/8": {"https://rdap.apnic.net/"},
+	"111.0.0.0/8": {"https://rdap.apnic.net/"},
+	"112.0.0.0/8": {"https://rdap.apnic.net/"},
+	"113.0.0.0/8": {"https://rdap.apnic.net/"},
+	"114.0.0.0/8": {"https://rdap.apnic.net/"},
+	"115.0.0.0/8": {"https://rdap.apnic.net/"},
+	"116.0.0.0/8": {"https://rdap.apnic.net/"},
+	"117.0.0.0/8": {"https://rdap.apnic.net/"},
+	"118.0.0.0/8": {"https://rdap.apnic.net/"},
+	"119.0.0.0/8": {"https://rdap.apnic.net/"},
+	"120.0.0.0/8": {"https://rdap.apnic.net/"},
+	"121.0.0.0/8": {"https://rdap.apnic.net/"},
+	"122.0.0.0/8": {"https://rdap.apnic.net/"},
+	"123.0.0.0/8": {"https://rdap.apnic.net/"},
+	"124.0.0.0/8": {"https://rdap.apnic.net/"},
+	"125.0.0.0/8": {"https://rdap.apnic.net/"},
+	"126.0.0.0/8": {"https://rdap.apnic.net/"},
+	"133.0.0.0/8": {"https://rdap.apnic.net/"},
+	"14.0.0.0/8":  {"https://rdap.apnic.net/"},
+	"150.0.0.0/8": {"https://rdap.apnic.net/"},
+	"153.0.0.0/8": {"https://rdap.apnic.net/"},
+	"163.0.0.0/8": {"https://rdap.apnic.net/"},
+	"171.0.0.0/8": {"https://rdap.apnic.net/"},
+	"175.0.0.0/8": {"https://rdap.apnic.net/"},
+	"180.0.0.0/8": {"https://rdap.apnic.net/"},
+	"182.0.0.0/8": {"https://rdap.apnic.net/"},
+	"183.0.0.0/8": {"https://rdap.apnic.net/"},
+	"202.0.0.0/8": {"https://rdap.apnic.net/"},
+	"203.0.0.0/8": {"https://rdap.apnic.net/"},
+	"210.0.0.0/8": {"https://rdap.apnic.net/"},
+	"211.0.0.0/8": {"https://rdap.apnic.net/"},
+	"218.0.0.0/8": {"https://rdap.apnic.net/"},
+	"219.0.0.0/8": {"https://rdap.apnic.net/"},
+	"220.0.0.0/8": {"https://rdap.apnic.net/"},
+	"221.0.0.0/8": {"https://rdap.apnic.net/"},
+	"222.0.0.0/8": {"https://rdap.apnic.net/"},
+	"223.0.0.0/8": {"https://rdap.apnic.net/"},
+	"27.0.0.0/8":  {"https://rdap.apnic.net/"},
+	"36.0.0.0/8":  {"https://rdap.apnic.net/"},
+	"39.0.0.0/8":  {"https://rdap.apnic.net/"},
+	"42.0.0.0/8":  {"https://rdap.apnic.net/"},
+	"43.0.0.0/8":  {"https://rdap.apnic.net/"},
+	"49.0.0.0/8":  {"https://rdap.apnic.net/"},
+	"58.0.0.0/8":  {"https://rdap.apnic.net/"},
+	"59.0.0.0/8":  {"https://rdap.apnic.net/"},
+	"60.0.0.0/8":  {"https://rdap.apnic.net/"},
+	"61.0.0.0/8":  {"https://rdap.apnic.net/"},
+	"100.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"104.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"107.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"108.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"11.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"12.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"128.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"129.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"13.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"130.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"131.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"132.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"134.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"135.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"136.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"137.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"138.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"139.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"140.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"142.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"143.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"144.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"146.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"147.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"148.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"149.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"15.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"152.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"155.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"156.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"157.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"158.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"159.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"16.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"160.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"161.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"162.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"164.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"165.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"166.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"167.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"168.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"169.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"17.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"170.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"172.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"173.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"174.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"18.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"184.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"19.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"192.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"198.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"199.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"20.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"204.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"205.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"206.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"207.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"208.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"209.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"21.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"214.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"215.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"216.0.0.0/8": {"https://rdap.arin.net/registry/"},
+	"22.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"23.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"24.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"26.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"28.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"29.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"3.0.0.0/8":   {"https://rdap.arin.net/registry/"},
+	"30.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"32.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"33.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"34.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"35.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"38.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"4.0.0.0/8":   {"https://rdap.arin.net/registry/"},
+	"40.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"44.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"45.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"47.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"48.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"50.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"52.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"54.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"55.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"56.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"6.0.0.0/8":   {"https://rdap.arin.net/registry/"},
+	"63.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"64.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"65.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"66.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"67.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"68.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"69.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"7.0.0.0/8":   {"https://rdap.arin.net/registry/"},
+	"70.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"71.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"72.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"73.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"74.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"75.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"76.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"8.0.0.0/8":   {"https://rdap.arin.net/registry/"},
+	"9.0.0.0/8":   {"https://rdap.arin.net/registry/"},
+	"96.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"97.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"98.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"99.0.0.0/8":  {"https://rdap.arin.net/registry/"},
+	"109.0.0.0/8": {"https://rdap.db.ripe.net/"},
+	"141.0.0.0/8": {"https://rdap.db.ripe.net/"},
+	"145.0.0.0/8": {"https://rdap.db.ripe.net/"},
+	"151.0.0.0/8": {"https://rdap.db.ripe.net/"},
+	"176.0.0.0/8": {"https://rdap.db.ripe.net/"},
+	"178.0.0.0/8": {"https://rdap.db.ripe.net/"},
+	"185.0.0.0/8": {"https://rdap.db.ripe.net/"},
+	"188.0.0.0/8": {"https://rdap.db.ripe.net/"},
+	"193.0.0.0/8": {"https://rdap.db.ripe.net/"},
+	"194.0.0.0/8": {"https://rdap.db.ripe.net/"},
+	"195.0.0.0/8": {"https://rdap.db.ripe.net/"},
+	"2.0.0.0/8":   {"https://rdap.db.ripe.net/"},
+	"212.0.0.0/8": {"https://rdap.db.ripe.net/"},
+	"213.0.0.0/8": {"https://rdap.db.ripe.net/"},
+	"217.0.0.0/8": {"https://rdap.db.ripe.net/"},
+	"25.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"31.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"37.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"46.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"5.0.0.0/8":   {"https://rdap.db.ripe.net/"},
+	"51.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"53.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"57.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"62.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"77.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"78.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"79.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"80.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"81.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"82.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"83.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"84.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"85.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"86.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"87.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"88.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"89.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"90.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"91.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"92.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"93.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"94.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"95.0.0.0/8":  {"https://rdap.db.ripe.net/"},
+	"177.0.0.0/8": {"https://rdap.lacnic.net/rdap/"},
+	"179.0.0.0/8": {"https://rdap.lacnic.net/rdap/"},
+	"181.0.0.0/8": {"https://rdap.lacnic.net/rdap/"},
+	"186.0.0.0/8": {"https://rdap.lacnic.net/rdap/"},
+	"187.0.0.0/8": {"https://rdap.lacnic.net/rdap/"},
+	"189.0.0.0/8": {"https://rdap.lacnic.net/rdap/"},
+	"190.0.0.0/8": {"https://rdap.lacnic.net/rdap/"},
+	"191.0.0.0/8": {"https://rdap.lacnic.net/rdap/"},
+	"200.0.0.0/8": {"https://rdap.lacnic.net/rdap/"},
+	"201.0.0.0/8": {"https://rdap.lacnic.net/rdap/"},
 
 	// IPv6 RDAP servers
-	"2001:4200::/23": "https://rdap.afrinic.net/rdap/",
-	"2c00::/12":      "https://rdap.afrinic.net/rdap/",
-	"2001:200::/23":  "https://rdap.apnic.net/",
-	"2001:4400::/23": "https://rdap.apnic.net/",
-	"2001:8000::/19": "https://rdap.apnic.net/",
-	"2001:a000::/20": "https://rdap.apnic.net/",
-	"2001:b000::/20": "https://rdap.apnic.net/",
-	"2001:c00::/23":  "https://rdap.apnic.net/",
-	"2001:e00::/23":  "https://rdap.apnic.net/",
-	"2400::/12":      "https://rdap.apnic.net/",
-	"2410::/12":      "https://rdap.apnic.net/",
-	"2001:1800::/23": "https://rdap.arin.net/registry/",
-	"2001:400::/23":  "https://rdap.arin.net/registry/",
-	"2001:4800::/23": "https://rdap.arin.net/registry/",
-	"2600::/12":      "https://rdap.arin.net/registry/",
-	"2610::/23":      "https://rdap.arin.net/registry/",
-	"2620::/23":      "https://rdap.arin.net/registry/",
-	"2630::/12":      "https://rdap.arin.net/registry/",
-	"2001:1400::/22": "https://rdap.db.ripe.net/",
-	"2001:1a00::/23": "https://rdap.db.ripe.net/",
-	"2001:1c00::/22": "https://rdap.db.ripe.net/",
-	"2001:2000::/19": "https://rdap.db.ripe.net/",
-	"2001:4000::/23": "https://rdap.db.ripe.net/",
-	"2001:4600::/23": "https://rdap.db.ripe.net/",
-	"2001:4a00::/23": "https://rdap.db.ripe.net/",
-	"2001:4c00::/23": "https://rdap.db.ripe.net/",
-	"2001:5000::/20": "https://rdap.db.ripe.net/",
-	"2001:600::/23":  "https://rdap.db.ripe.net/",
-	"2001:800::/22":  "https://rdap.db.ripe.net/",
-	"2003::/18":      "https://rdap.db.ripe.net/",
-	"2a00::/12":      "https://rdap.db.ripe.net/",
-	"2a10::/12":      "https://rdap.db.ripe.net/",
-	"2001:1200::/23": "https://rdap.lacnic.net/rdap/",
-	"2800::/12":      "https://rdap.lacnic.net/rdap/",
+	"2001:4200::/23": {"https://rdap.afrinic.net/rdap/"},
+	"2c00::/12":      {"https://rdap.afrinic.net/rdap/"},
+	"2001:200::/23":  {"https://rdap.apnic.net/"},
+	"2001:4400::/23": {"https://rdap.apnic.net/"},
+	"2001:8000::/19": {"https://rdap.apnic.net/"},
+	"2001:a000::/20": {"https://rdap.apnic.net/"},
+	"2001:b000::/20": {"https://rdap.apnic.net/"},
+	"2001:c00::/23":  {"https://rdap.apnic.net/"},
+	"2001:e00::/23":  {"https://rdap.apnic.net/"},
+	"2400::/12":      {"https://rdap.apnic.net/"},
+	"2410::/12":      {"https://rdap.apnic.net/"},
+	"2001:1800::/23": {"https://rdap.arin.net/registry/"},
+	"2001:400::/23":  {"https://rdap.arin.net/registry/"},
+	"2001:4800::/23": {"https://rdap.arin.net/registry/"},
+	"2600::/12":      {"https://rdap.arin.net/registry/"},
+	"2610::/23":      {"https://rdap.arin.net/registry/"},
+	"2620::/23":      {"https://rdap.arin.net/registry/"},
+	"2630::/12":      {"https://rdap.arin.net/registry/"},
+	"2001:1400::/22": {"https://rdap.db.ripe.net/"},
+	"2001:1a00::/23": {"https://rdap.db.ripe.net/"},
+	"2001:1c00::/22": {"https://rdap.db.ripe.net/"},
+	"2001:2000::/19": {"https://rdap.db.ripe.net/"},
+	"2001:4000::/23": {"https://rdap.db.ripe.net/"},
+	"2001:4600::/23": {"https://rdap.db.ripe.net/"},
+	"2001:4a00::/23": {"https://rdap.db.ripe.net/"},
+	"2001:4c00::/23": {"https://rdap.db.ripe.net/"},
+	"2001:5000::/20": {"https://rdap.db.ripe.net/"},
+	"2001:600::/23":  {"https://rdap.db.ripe.net/"},
+	"2001:800::/22":  {"https://rdap.db.ripe.net/"},
+	"2003::/18":      {"https://rdap.db.ripe.net/"},
+	"2a00::/12":      {"https://rdap.db.ripe.net/"},
+	"2a10::/12":      {"https://rdap.db.ripe.net/"},
+	"2001:1200::/23": {"https://rdap.lacnic.net/rdap/"},
+	"2800::/12":      {"https://rdap.lacnic.net/rdap/"},
 
 	// ASN RDAP servers
-	"327680-328703": "https://rdap.afrinic.net/rdap/",
-	"328704-329727": "https://rdap.afrinic.net/rdap/",
-	"329728-330751": "https://rdap.afrinic.net/rdap/",
-	"36864-37887":   "https://rdap.afrinic.net/rdap/",
-	"131072-132095": "https://rdap.apnic.net/",
-	"132096-133119": "https://rdap.apnic.net/",
-	"133120-133631": "https://rdap.apnic.net/",
-	"133632-134556": "https://rdap.apnic.net/",
-	"134557-135580": "https://rdap.apnic.net/",
-	"135581-136505": "https://rdap.apnic.net/",
-	"136506-137529": "https://rdap.apnic.net/",
-	"137530-138553": "https://rdap.apnic.net/",
-	"138554-139577": "https://rdap.apnic.net/",
-	"139578-140601": "https://rdap.apnic.net/",
-	"140602-141625": "https://rdap.apnic.net/",
-	"141626-142649": "https://rdap.apnic.net/",
-	"142650-143673": "https://rdap.apnic.net/",
-	"143674-144697": "https://rdap.apnic.net/",
-	"144698-145721": "https://rdap.apnic.net/",
-	"145722-146745": "https://rdap.apnic.net/",
-	"146746-147769": "https://rdap.apnic.net/",
-	"147770-148793": "https://rdap.apnic.net/",
-	"148794-149817": "https://rdap.apnic.net/",
-	"149818-150841": "https://rdap.apnic.net/",
-	"150842-151865": "https://rdap.apnic.net/",
-	"151866-152889": "https://rdap.apnic.net/",
-	"152890-153913": "https://rdap.apnic.net/",
-	"153914-154937": "https://rdap.apnic.net/",
-	"154938-155961": "https://rdap.apnic.net/",
-	"17408-18431":   "https://rdap.apnic.net/",
-	"23552-24575":   "https://rdap.apnic.net/",
-	"37888-38911":   "https://rdap.apnic.net/",
-	"45056-46079":   "https://rdap.apnic.net/",
-	"4608-4865":     "https://rdap.apnic.net/",
-	"55296-56319":   "https://rdap.apnic.net/",
-	"58368-59391":   "https://rdap.apnic.net/",
-	"63488-63999":   "https://rdap.apnic.net/",
-	"64000-64098":   "https://rdap.apnic.net/",
-	"64297-64395":   "https://rdap.apnic.net/",
-	"7467-7722":     "https://rdap.apnic.net/",
-	"9216-10239":    "https://rdap.apnic.net/",
-	"1-1876":        "https://rdap.arin.net/registry/",
-	"10240-12287":   "https://rdap.arin.net/registry/",
-	"13312-15359":   "https://rdap.arin.net/registry/",
-	"16384-17407":   "https://rdap.arin.net/registry/",
-	"18432-20479":   "https://rdap.arin.net/registry/",
-	"1902-2042":     "https://rdap.arin.net/registry/",
-	"2044-2046":     "https://rdap.arin.net/registry/",
-	"2048-2106":     "https://rdap.arin.net/registry/",
-	"2137-2584":     "https://rdap.arin.net/registry/",
-	"21504-23455":   "https://rdap.arin.net/registry/",
-	"23457-23551":   "https://rdap.arin.net/registry/",
-	"25600-26623":   "https://rdap.arin.net/registry/",
-	"2615-2772":     "https://rdap.arin.net/registry/",
-	"26624-27647":   "https://rdap.arin.net/registry/",
-	"2823-2829":     "https://rdap.arin.net/registry/",
-	"2880-3153":     "https://rdap.arin.net/registry/",
-	"29696-30719":   "https://rdap.arin.net/registry/",
-	"31744-32767":   "https://rdap.arin.net/registry/",
-	"32768-33791":   "https://rdap.arin.net/registry/",
-	"3354-4607":     "https://rdap.arin.net/registry/",
-	"35840-36863":   "https://rdap.arin.net/registry/",
-	"393216-394239": "https://rdap.arin.net/registry/",
-	"394240-395164": "https://rdap.arin.net/registry/",
-	"395165-396188": "https://rdap.arin.net/registry/",
-	"396189-397212": "https://rdap.arin.net/registry/",
-	"397213-398236": "https://rdap.arin.net/registry/",
-	"398237-399260": "https://rdap.arin.net/registry/",
-	"399261-400284": "https://rdap.arin.net/registry/",
-	"39936-40959":   "https://rdap.arin.net/registry/",
-	"400285-401308": "https://rdap.arin.net/registry/",
-	"401309-402332": "https://rdap.arin.net/registry/",
-	"402333-403356": "https://rdap.arin.net/registry/",
-	"403357-404380": "https://rdap.arin.net/registry/",
-	"46080-47103":   "https://rdap.arin.net/registry/",
-	"4866-5376":     "https://rdap.arin.net/registry/",
-	"53248-54271":   "https://rdap.arin.net/registry/",
-	"54272-55295":   "https://rdap.arin.net/registry/",
-	"5632-6655":     "https://rdap.arin.net/registry/",
-	"62464-63487":   "https://rdap.arin.net/registry/",
-	"64198-64296":   "https://rdap.arin.net/registry/",
-	"6912-7466":     "https://rdap.arin.net/registry/",
-	"7723-8191":     "https://rdap.arin.net/registry/",
-	"12288-13311":   "https://rdap.db.ripe.net/",
-	"15360-16383":   "https://rdap.db.ripe.net/",
-	"1877-1901":     "https://rdap.db.ripe.net/",
-	"196608-197631": "https://rdap.db.ripe.net/",
-	"197632-198655": "https://rdap.db.ripe.net/",
-	"198656-199679": "https://rdap.db.ripe.net/",
-	"199680-200191": "https://rdap.db.ripe.net/",
-	"200192-201215": "https://rdap.db.ripe.net/",
-	"201216-202239": "https://rdap.db.ripe.net/",
-	"202240-203263": "https://rdap.db.ripe.net/",
-	"203264-204287": "https://rdap.db.ripe.net/",
-	"204288-205211": "https://rdap.db.ripe.net/",
-	"2043":          "https://rdap.db.ripe.net/",
-	"2047":          "https://rdap.db.ripe.net/",
-	"20480-21503":   "https://rdap.db.ripe.net/",
-	"205212-206235": "https://rdap.db.ripe.net/",
-	"206236-207259": "https://rdap.db.ripe.net/",
-	"207260-208283": "https://rdap.db.ripe.net/",
-	"208284-209307": "https://rdap.db.ripe.net/",
-	"209308-210331": "https://rdap.db.ripe.net/",
-	"210332-211355": "https://rdap.db.ripe.net/",
-	"2107-2136":     "https://rdap.db.ripe.net/",
-	"211356-212379": "https://rdap.db.ripe.net/",
-	"212380-213403": "https://rdap.db.ripe.net/",
-	"213404-214427": "https://rdap.db.ripe.net/",
-	"214428-215451": "https://rdap.db.ripe.net/",
-	"215452-216475": "https://rdap.db.ripe.net/",
-	"216476-217499": "https://rdap.db.ripe.net/",
-	"217500-218523": "https://rdap.db.ripe.net/",
-	"218524-219547": "https://rdap.db.ripe.net/",
-	"24576-25599":   "https://rdap.db.ripe.net/",
-	"2585-2614":     "https://rdap.db.ripe.net/",
-	"2773-2822":     "https://rdap.db.ripe.net/",
-	"2830-2879":     "https://rdap.db.ripe.net/",
-	"28672-29695":   "https://rdap.db.ripe.net/",
-	"30720-31743":   "https://rdap.db.ripe.net/",
-	"3154-3353":     "https://rdap.db.ripe.net/",
-	"33792-34815":   "https://rdap.db.ripe.net/",
-	"34816-35839":   "https://rdap.db.ripe.net/",
-	"38912-39935":   "https://rdap.db.ripe.net/",
-	"40960-41983":   "https://rdap.db.ripe.net/",
-	"41984-43007":   "https://rdap.db.ripe.net/",
-	"43008-44031":   "https://rdap.db.ripe.net/",
-	"44032-45055":   "https://rdap.db.ripe.net/",
-	"47104-48127":   "https://rdap.db.ripe.net/",
-	"48128-49151":   "https://rdap.db.ripe.net/",
-	"49152-50175":   "https://rdap.db.ripe.net/",
-	"50176-51199":   "https://rdap.db.ripe.net/",
-	"51200-52223":   "https://rdap.db.ripe.net/",
-	"5377-5631":     "https://rdap.db.ripe.net/",
-	"56320-57343":   "https://rdap.db.ripe.net/",
-	"57344-58367":   "https://rdap.db.ripe.net/",
-	"59392-60415":   "https://rdap.db.ripe.net/",
-	"60416-61439":   "https://rdap.db.ripe.net/",
-	"61952-62463":   "https://rdap.db.ripe.net/",
-	"64396-64495":   "https://rdap.db.ripe.net/",
-	"6656-6911":     "https://rdap.db.ripe.net/",
-	"8192-9215":     "https://rdap.db.ripe.net/",
-	"262144-263167": "https://rdap.lacnic.net/rdap/",
-	"263168-263679": "https://rdap.lacnic.net/rdap/",
-	"263680-264604": "https://rdap.lacnic.net/rdap/",
-	"264605-265628": "https://rdap.lacnic.net/rdap/",
-	"265629-266652": "https://rdap.lacnic.net/rdap/",
-	"266653-267676": "https://rdap.lacnic.net/rdap/",
-	"267677-268700": "https://rdap.lacnic.net/rdap/",
-	"268701-269724": "https://rdap.lacnic.net/rdap/",
-	"269725-270748": "https://rdap.lacnic.net/rdap/",
-	"270749-271772": "https://rdap.lacnic.net/rdap/",
-	"271773-272796": "https://rdap.lacnic.net/rdap/",
-	"272797-273820": "https://rdap.lacnic.net/rdap/",
-	"273821-274844": "https://rdap.lacnic.net/rdap/",
-	"274845-275868": "https://rdap.lacnic.net/rdap/",
-	"27648-28671":   "https://rdap.lacnic.net/rdap/",
-	"52224-53247":   "https://rdap.lacnic.net/rdap/",
-	"61440-61951":   "https://rdap.lacnic.net/rdap/",
-	"64099-64197":   "https://rdap.lacnic.net/rdap/",
+	"327680-328703": {"https://rdap.afrinic.net/rdap/"},
+	"328704-329727": {"https://rdap.afrinic.net/rdap/"},
+	"329728-330751": {"https://rdap.afrinic.net/rdap/"},
+	"36864-37887":   {"https://rdap.afrinic.net/rdap/"},
+	"131072-132095": {"https://rdap.apnic.net/"},
+	"132096-133119": {"https://rdap.apnic.net/"},
+	"133120-133631": {"https://rdap.apnic.net/"},
+	"133632-134556": {"https://rdap.apnic.net/"},
+	"134557-135580": {"https://rdap.apnic.net/"},
+	"135581-136505": {"https://rdap.apnic.net/"},
+	"136506-137529": {"https://rdap.apnic.net/"},
+	"137530-138553": {"https://rdap.apnic.net/"},
+	"138554-139577": {"https://rdap.apnic.net/"},
+	"139578-140601": {"https://rdap.apnic.net/"},
+	"140602-141625": {"https://rdap.apnic.net/"},
+	"141626-142649": {"https://rdap.apnic.net/"},
+	"142650-143673": {"https://rdap.apnic.net/"},
+	"143674-144697": {"https://rdap.apnic.net/"},
+	"144698-145721": {"https://rdap.apnic.net/"},
+	"145722-146745": {"https://rdap.apnic.net/"},
+	"146746-147769": {"https://rdap.apnic.net/"},
+	"147770-148793": {"https://rdap.apnic.net/"},
+	"148794-149817": {"https://rdap.apnic.net/"},
+	"149818-150841": {"https://rdap.apnic.net/"},
+	"150842-151865": {"https://rdap.apnic.net/"},
+	"151866-152889": {"https://rdap.apnic.net/"},
+	"152890-153913": {"https://rdap.apnic.net/"},
+	"153914-154937": {"https://rdap.apnic.net/"},
+	"154938-155961": {"https://rdap.apnic.net/"},
+	"17408-18431":   {"https://rdap.apnic.net/"},
+	"23552-24575":   {"https://rdap.apnic.net/"},
+	"37888-38911":   {"https://rdap.apnic.net/"},
+	"45056-46079":   {"https://rdap.apnic.net/"},
+	"4608-4865":     {"https://rdap.apnic.net/"},
+	"55296-56319":   {"https://rdap.apnic.net/"},
+	"58368-59391":   {"https://rdap.apnic.net/"},
+	"63488-63999":   {"https://rdap.apnic.net/"},
+	"64000-64098":   {"https://rdap.apnic.net/"},
+	"64297-64395":   {"https://rdap.apnic.net/"},
+	"7467-7722":     {"https://rdap.apnic.net/"},
+	"9216-10239":    {"https://rdap.apnic.net/"},
+	"1-1876":        {"https://rdap.arin.net/registry/"},
+	"10240-12287":   {"https://rdap.arin.net/registry/"},
+	"13312-15359":   {"https://rdap.arin.net/registry/"},
+	"16384-17407":   {"https://rdap.arin.net/registry/"},
+	"18432-20479":   {"https://rdap.arin.net/registry/"},
+	"1902-2042":     {"https://rdap.arin.net/registry/"},
+	"2044-2046":     {"https://rdap.arin.net/registry/"},
+	"2048-2106":     {"https://rdap.arin.net/registry/"},
+	"2137-2584":     {"https://rdap.arin.net/registry/"},
+	"21504-23455":   {"https://rdap.arin.net/registry/"},
+	"23457-23551":   {"https://rdap.arin.net/registry/"},
+	"25600-26623":   {"https://rdap.arin.net/registry/"},
+	"2615-2772":     {"https://rdap.arin.net/registry/"},
+	"26624-27647":   {"https://rdap.arin.net/registry/"},
+	"2823-2829":     {"https://rdap.arin.net/registry/"},
+	"2880-3153":     {"https://rdap.arin.net/registry/"},
+	"29696-30719":   {"https://rdap.arin.net/registry/"},
+	"31744-32767":   {"https://rdap.arin.net/registry/"},
+	"32768-33791":   {"https://rdap.arin.net/registry/"},
+	"3354-4607":     {"https://rdap.arin.net/registry/"},
+	"35840-36863":   {"https://rdap.arin.net/registry/"},
+	"393216-394239": {"https://rdap.arin.net/registry/"},
+	"394240-395164": {"https://rdap.arin.net/registry/"},
+	"395165-396188": {"https://rdap.arin.net/registry/"},
+	"396189-397212": {"https://rdap.arin.net/registry/"},
+	"397213-398236": {"https://rdap.arin.net/registry/"},
+	"398237-399260": {"https://rdap.arin.net/registry/"},
+	"399261-400284": {"https://rdap.arin.net/registry/"},
+	"39936-40959":   {"https://rdap.arin.net/registry/"},
+	"400285-401308": {"https://rdap.arin.net/registry/"},
+	"401309-402332": {"https://rdap.arin.net/registry/"},
+	"402333-403356": {"https://rdap.arin.net/registry/"},
+	"403357-404380": {"https://rdap.arin.net/registry/"},
+	"46080-47103":   {"https://rdap.arin.net/registry/"},
+	"4866-5376":     {"https://rdap.arin.net/registry/"},
+	"53248-54271":   {"https://rdap.arin.net/registry/"},
+	"54272-55295":   {"https://rdap.arin.net/registry/"},
+	"5632-6655":     {"https://rdap.arin.net/registry/"},
+	"62464-63487":   {"https://rdap.arin.net/registry/"},
+	"64198-64296":   {"https://rdap.arin.net/registry/"},
+	"6912-7466":     {"https://rdap.arin.net/registry/"},
+	"7723-8191":     {"https://rdap.arin.net/registry/"},
+	"12288-13311":   {"https://rdap.db.ripe.net/"},
+	"15360-16383":   {"https://rdap.db.ripe.net/"},
+	"1877-1901":     {"https://rdap.db.ripe.net/"},
+	"196608-197631": {"https://rdap.db.ripe.net/"},
+	"197632-198655": {"https://rdap.db.ripe.net/"},
+	"198656-199679": {"https://rdap.db.ripe.net/"},
+	"199680-200191": {"https://rdap.db.ripe.net/"},
+	"200192-201215": {"https://rdap.db.ripe.net/"},
+	"201216-202239": {"https://rdap.db.ripe.net/"},
+	"202240-203263": {"https://rdap.db.ripe.net/"},
+	"203264-204287": {"https://rdap.db.ripe.net/"},
+	"204288-205211": {"https://rdap.db.ripe.net/"},
+	"2043":          {"https://rdap.db.ripe.net/"},
+	"2047":          {"https://rdap.db.ripe.net/"},
+	"20480-21503":   {"https://rdap.db.ripe.net/"},
+	"205212-206235": {"https://rdap.db.ripe.net/"},
+	"206236-207259": {"https://rdap.db.ripe.net/"},
+	"207260-208283": {"https://rdap.db.ripe.net/"},
+	"208284-209307": {"https://rdap.db.ripe.net/"},
+	"209308-210331": {"https://rdap.db.ripe.net/"},
+	"210332-211355": {"https://rdap.db.ripe.net/"},
+	"2107-2136":     {"https://rdap.db.ripe.net/"},
+	"211356-212379": {"https://rdap.db.ripe.net/"},
+	"212380-213403": {"https://rdap.db.ripe.net/"},
+	"213404-214427": {"https://rdap.db.ripe.net/"},
+	"214428-215451": {"https://rdap.db.ripe.net/"},
+	"215452-216475": {"https://rdap.db.ripe.net/"},
+	"216476-217499": {"https://rdap.db.ripe.net/"},
+	"217500-218523": {"https://rdap.db.ripe.net/"},
+	"218524-219547": {"https://rdap.db.ripe.net/"},
+	"24576-25599":   {"https://rdap.db.ripe.net/"},
+	"2585-2614":     {"https://rdap.db.ripe.net/"},
+	"2773-2822":     {"https://rdap.db.ripe.net/"},
+	"2830-2879":     {"https://rdap.db.ripe.net/"},
+	"28672-29695":   {"https://rdap.db.ripe.net/"},
+	"30720-31743":   {"https://rdap.db.ripe.net/"},
+	"3154-3353":     {"https://rdap.db.ripe.net/"},
+	"33792-34815":   {"https://rdap.db.ripe.net/"},
+	"34816-35839":   {"https://rdap.db.ripe.net/"},
+	"38912-39935":   {"https://rdap.db.ripe.net/"},
+	"40960-41983":   {"https://rdap.db.ripe.net/"},
+	"41984-43007":   {"https://rdap.db.ripe.net/"},
+	"43008-44031":   {"https://rdap.db.ripe.net/"},
+	"44032-45055":   {"https://rdap.db.ripe.net/"},
+	"47104-48127":   {"https://rdap.db.ripe.net/"},
+	"48128-49151":   {"https://rdap.db.ripe.net/"},
+	"49152-50175":   {"https://rdap.db.ripe.net/"},
+	"50176-51199":   {"https://rdap.db.ripe.net/"},
+	"51200-52223":   {"https://rdap.db.ripe.net/"},
+	"5377-5631":     {"https://rdap.db.ripe.net/"},
+	"56320-57343":   {"https://rdap.db.ripe.net/"},
+	"57344-58367":   {"https://rdap.db.ripe.net/"},
+	"59392-60415":   {"https://rdap.db.ripe.net/"},
+	"60416-61439":   {"https://rdap.db.ripe.net/"},
+	"61952-62463":   {"https://rdap.db.ripe.net/"},
+	"64396-64495":   {"https://rdap.db.ripe.net/"},
+	"6656-6911":     {"https://rdap.db.ripe.net/"},
+	"8192-9215":     {"https://rdap.db.ripe.net/"},
+	"262144-263167": {"https://rdap.lacnic.net/rdap/"},
+	"263168-263679": {"https://rdap.lacnic.net/rdap/"},
+	"263680-264604": {"https://rdap.lacnic.net/rdap/"},
+	"264605-265628": {"https://rdap.lacnic.net/rdap/"},
+	"265629-266652": {"https://rdap.lacnic.net/rdap/"},
+	"266653-267676": {"https://rdap.lacnic.net/rdap/"},
+	"267677-268700": {"https://rdap.lacnic.net/rdap/"},
+	"268701-269724": {"https://rdap.lacnic.net/rdap/"},
+	"269725-270748": {"https://rdap.lacnic.net/rdap/"},
+	"270749-271772": {"https://rdap.lacnic.net/rdap/"},
+	"271773-272796": {"https://rdap.lacnic.net/rdap/"},
+	"272797-273820": {"https://rdap.lacnic.net/rdap/"},
+	"273821-274844": {"https://rdap.lacnic.net/rdap/"},
+	"274845-275868": {"https://rdap.lacnic.net/rdap/"},
+	"27648-28671":   {"https://rdap.lacnic.net/rdap/"},
+	"52224-53247":   {"https://rdap.lacnic.net/rdap/"},
+	"61440-61951":   {"https://rdap.lacnic.net/rdap/"},
+	"64099-64197":   {"https://rdap.lacnic.net/rdap/"},
 
 	// TLD RDAP servers
-	"kg":                       "http://rdap.cctld.kg/",
-	"mg":                       "http://rdap.nic.mg/",
-	"xn--kpry57d":              "https://ccrdap.twnic.tw/taiwan/",
-	"tw":                       "https://ccrdap.twnic.tw/tw/",
-	"na":                       "https://keetmans.omadhina.co.na/",
-	"samsung":                  "https://nic.samsung/rdap/",
-	"xn--cg4bki":               "https://nic.samsung/rdap/",
-	"ads":                      "https://pubapi.registry.google/rdap/",
-	"android":                  "https://pubapi.registry.google/rdap/",
-	"app":                      "https://pubapi.registry.google/rdap/",
-	"boo":                      "https://pubapi.registry.google/rdap/",
-	"cal":                      "https://pubapi.registry.google/rdap/",
-	"channel":                  "https://pubapi.registry.google/rdap/",
-	"chrome":                   "https://pubapi.registry.google/rdap/",
-	"dad":                      "https://pubapi.registry.google/rdap/",
-	"day":                      "https://pubapi.registry.google/rdap/",
-	"dclk":                     "https://pubapi.registry.google/rdap/",
-	"dev":                      "https://pubapi.registry.google/rdap/",
-	"docs":                     "https://pubapi.registry.google/rdap/",
-	"drive":                    "https://pubapi.registry.google/rdap/",
-	"eat":                      "https://pubapi.registry.google/rdap/",
-	"esq":                      "https://pubapi.registry.google/rdap/",
-	"fly":                      "https://pubapi.registry.google/rdap/",
-	"foo":                      "https://pubapi.registry.google/rdap/",
-	"gbiz":                     "https://pubapi.registry.google/rdap/",
-	"gle":                      "https://pubapi.registry.google/rdap/",
-	"gmail":                    "https://pubapi.registry.google/rdap/",
-	"goog":                     "https://pubapi.registry.google/rdap/",
-	"google":                   "https://pubapi.registry.google/rdap/",
-	"guge":                     "https://pubapi.registry.google/rdap/",
-	"hangout":                  "https://pubapi.registry.google/rdap/",
-	"here":                     "https://pubapi.registry.google/rdap/",
-	"how":                      "https://pubapi.registry.google/rdap/",
-	"ing":                      "https://pubapi.registry.google/rdap/",
-	"map":                      "https://pubapi.registry.google/rdap/",
-	"meet":                     "https://pubapi.registry.google/rdap/",
-	"meme":                     "https://pubapi.registry.google/rdap/",
-	"mov":                      "https://pubapi.registry.google/rdap/",
-	"new":                      "https://pubapi.registry.google/rdap/",
-	"nexus":                    "https://pubapi.registry.google/rdap/",
-	"page":                     "https://pubapi.registry.google/rdap/",
-	"phd":                      "https://pubapi.registry.google/rdap/",
-	"play":                     "https://pubapi.registry.google/rdap/",
-	"prod":                     "https://pubapi.registry.google/rdap/",
-	"prof":                     "https://pubapi.registry.google/rdap/",
-	"rsvp":                     "https://pubapi.registry.google/rdap/",
-	"search":                   "https://pubapi.registry.google/rdap/",
-	"soy":                      "https://pubapi.registry.google/rdap/",
-	"xn--flw351e":              "https://pubapi.registry.google/rdap/",
-	"xn--q9jyb4c":              "https://pubapi.registry.google/rdap/",
-	"xn--qcka1pmc":             "https://pubapi.registry.google/rdap/",
-	"youtube":                  "https://pubapi.registry.google/rdap/",
-	"zip":                      "https://pubapi.registry.google/rdap/",
-	"merck":                    "https://rdap-merck.dns.business/rdap/",
-	"alibaba":                  "https://rdap.aliregistry.cn/rdap/",
-	"blog":                     "https://rdap.blog.fury.ca/rdap/",
-	"ca":                       "https://rdap.ca.fury.ca/rdap/",
-	"au":                       "https://rdap.cctld.au/rdap/",
-	"uz":                       "https://rdap.cctld.uz/",
-	"allfinanz":                "https://rdap.centralnic.com/allfinanz/",
-	"art":                      "https://rdap.centralnic.com/art/",
-	"audio":                    "https://rdap.centralnic.com/audio/",
-	"auto":                     "https://rdap.centralnic.com/auto/",
-	"autos":                    "https://rdap.centralnic.com/autos/",
-	"baby":                     "https://rdap.centralnic.com/baby/",
-	"beauty":                   "https://rdap.centralnic.com/beauty/",
-	"best":                     "https://rdap.centralnic.com/best/",
-	"bmw":                      "https://rdap.centralnic.com/bmw/",
-	"boats":                    "https://rdap.centralnic.com/boats/",
-	"bond":                     "https://rdap.centralnic.com/bond/",
-	"box":                      "https://rdap.centralnic.com/box/",
-	"build":                    "https://rdap.centralnic.com/build/",
-	"cam":                      "https://rdap.centralnic.com/cam/",
-	"car":                      "https://rdap.centralnic.com/car/",
-	"cars":                     "https://rdap.centralnic.com/cars/",
-	"case":                     "https://rdap.centralnic.com/case/",
-	"ceo":                      "https://rdap.centralnic.com/ceo/",
-	"cfd":                      "https://rdap.centralnic.com/cfd/",
-	"christmas":                "https://rdap.centralnic.com/christmas/",
-	"college":                  "https://rdap.centralnic.com/college/",
-	"cyou":                     "https://rdap.centralnic.com/cyou/",
-	"dealer":                   "https://rdap.centralnic.com/dealer/",
-	"deloitte":                 "https://rdap.centralnic.com/deloitte/",
-	"dhl":                      "https://rdap.centralnic.com/dhl/",
-	"diet":                     "https://rdap.centralnic.com/diet/",
-	"dvag":                     "https://rdap.centralnic.com/dvag/",
-	"fans":                     "https://rdap.centralnic.com/fans/",
-	"flowers":                  "https://rdap.centralnic.com/flowers/",
-	"fm":                       "https://rdap.centralnic.com/fm/",
-	"fo":                       "https://rdap.centralnic.com/fo/",
-	"fresenius":                "https://rdap.centralnic.com/fresenius/",
-	"frl":                      "https://rdap.centralnic.com/frl/",
-	"game":                     "https://rdap.centralnic.com/game/",
-	"gd":                       "https://rdap.centralnic.com/gd/",
-	"gent":                     "https://rdap.centralnic.com/gent/",
-	"guitars":                  "https://rdap.centralnic.com/guitars/",
-	"hair":                     "https://rdap.centralnic.com/hair/",
-	"help":                     "https://rdap.centralnic.com/help/",
-	"homes":                    "https://rdap.centralnic.com/homes/",
-	"hosting":                  "https://rdap.centralnic.com/hosting/",
-	"icu":                      "https://rdap.centralnic.com/icu/",
-	"inc":                      "https://rdap.centralnic.com/inc/",
-	"kpn":                      "https://rdap.centralnic.com/kpn/",
-	"kred":                     "https://rdap.centralnic.com/kred/",
-	"lat":                      "https://rdap.centralnic.com/lat/",
-	"lidl":                     "https://rdap.centralnic.com/lidl/",
-	"llp":                      "https://rdap.centralnic.com/llp/",
-	"lol":                      "https://rdap.centralnic.com/lol/",
-	"london":                   "https://rdap.centralnic.com/london/",
-	"lpl":                      "https://rdap.centralnic.com/lpl/",
-	"lplfinancial":             "https://rdap.centralnic.com/lplfinancial/",
-	"luxury":                   "https://rdap.centralnic.com/luxury/",
-	"makeup":                   "https://rdap.centralnic.com/makeup/",
-	"mini":                     "https://rdap.centralnic.com/mini/",
-	"mom":                      "https://rdap.centralnic.com/mom/",
-	"monster":                  "https://rdap.centralnic.com/monster/",
-	"motorcycles":              "https://rdap.centralnic.com/motorcycles/",
-	"nokia":                    "https://rdap.centralnic.com/nokia/",
-	"ooo":                      "https://rdap.centralnic.com/ooo/",
-	"pics":                     "https://rdap.centralnic.com/pics/",
-	"pohl":                     "https://rdap.centralnic.com/pohl/",
-	"protection":               "https://rdap.centralnic.com/protection/",
-	"qpon":                     "https://rdap.centralnic.com/qpon/",
-	"quest":                    "https://rdap.centralnic.com/quest/",
-	"reit":                     "https://rdap.centralnic.com/reit/",
-	"rent":                     "https://rdap.centralnic.com/rent/",
-	"ruhr":                     "https://rdap.centralnic.com/ruhr/",
-	"saarland":                 "https://rdap.centralnic.com/saarland/",
-	"sbs":                      "https://rdap.centralnic.com/sbs/",
-	"schwarz":                  "https://rdap.centralnic.com/schwarz/",
-	"security":                 "https://rdap.centralnic.com/security/",
-	"sfr":                      "https://rdap.centralnic.com/sfr/",
-	"skin":                     "https://rdap.centralnic.com/skin/",
-	"smart":                    "https://rdap.centralnic.com/smart/",
-	"stc":                      "https://rdap.centralnic.com/stc/",
-	"stcgroup":                 "https://rdap.centralnic.com/stcgroup/",
-	"storage":                  "https://rdap.centralnic.com/storage/",
-	"theatre":                  "https://rdap.centralnic.com/theatre/",
-	"tickets":                  "https://rdap.centralnic.com/tickets/",
-	"tui":                      "https://rdap.centralnic.com/tui/",
-	"vg":                       "https://rdap.centralnic.com/vg/",
-	"viva":                     "https://rdap.centralnic.com/viva/",
-	"wme":                      "https://rdap.centralnic.com/wme/",
-	"xn--4gbrim":               "https://rdap.centralnic.com/xn--4gbrim/",
-	"xn--vermgensberater-ctb":  "https://rdap.centralnic.com/xn--vermgensberater-ctb/",
-	"xn--vermgensberatung-pwb": "https://rdap.centralnic.com/xn--vermgensberatung-pwb/",
-	"xyz":                      "https://rdap.centralnic.com/xyz/",
-	"yachts":                   "https://rdap.centralnic.com/yachts/",
-	"zuerich":                  "https://rdap.centralnic.com/zuerich/",
-	"jnj":                      "https://rdap.centralnicregistry.com/jnj/",
-	"crown":                    "https://rdap.crown.fury.ca/rdap/",
-	"pl":                       "https://rdap.dns.pl/",
-	"eco":                      "https://rdap.eco.fury.ca/rdap/",
-	"fi":                       "https://rdap.fi/rdap/rdap/",
-	"moscow":                   "https://rdap.flexireg.net/",
-	"xn--80adxhks":             "https://rdap.flexireg.net/",
-	"bridgestone":              "https://rdap.gmoregistry.net/rdap/",
-	"brother":                  "https://rdap.gmoregistry.net/rdap/",
-	"canon":                    "https://rdap.gmoregistry.net/rdap/",
-	"datsun":                   "https://rdap.gmoregistry.net/rdap/",
-	"dnp":                      "https://rdap.gmoregistry.net/rdap/",
-	"epson":                    "https://rdap.gmoregistry.net/rdap/",
-	"firestone":                "https://rdap.gmoregistry.net/rdap/",
-	"fujitsu":                  "https://rdap.gmoregistry.net/rdap/",
-	"ggee":                     "https://rdap.gmoregistry.net/rdap/",
-	"gmo":                      "https://rdap.gmoregistry.net/rdap/",
-	"goldpoint":                "https://rdap.gmoregistry.net/rdap/",
-	"hisamitsu":                "https://rdap.gmoregistry.net/rdap/",
-	"hitachi":                  "https://rdap.gmoregistry.net/rdap/",
-	"honda":                    "https://rdap.gmoregistry.net/rdap/",
-	"hyundai":                  "https://rdap.gmoregistry.net/rdap/",
-	"infiniti":                 "https://rdap.gmoregistry.net/rdap/",
-	"jcb":                      "https://rdap.gmoregistry.net/rdap/",
-	"kddi":                     "https://rdap.gmoregistry.net/rdap/",
-	"kia":                      "https://rdap.gmoregistry.net/rdap/",
-	"komatsu":                  "https://rdap.gmoregistry.net/rdap/",
-	"kyoto":                    "https://rdap.gmoregistry.net/rdap/",
-	"lexus":                    "https://rdap.gmoregistry.net/rdap/",
-	"lotte":                    "https://rdap.gmoregistry.net/rdap/",
-	"mitsubishi":               "https://rdap.gmoregistry.net/rdap/",
-	"nagoya":                   "https://rdap.gmoregistry.net/rdap/",
-	"nec":                      "https://rdap.gmoregistry.net/rdap/",
-	"nhk":                      "https://rdap.gmoregistry.net/rdap/",
-	"nico":                     "https://rdap.gmoregistry.net/rdap/",
-	"nissan":                   "https://rdap.gmoregistry.net/rdap/",
-	"okinawa":                  "https://rdap.gmoregistry.net/rdap/",
-	"otsuka":                   "https://rdap.gmoregistry.net/rdap/",
-	"panasonic":                "https://rdap.gmoregistry.net/rdap/",
-	"playstation":              "https://rdap.gmoregistry.net/rdap/",
-	"ricoh":                    "https://rdap.gmoregistry.net/rdap/",
-	"ryukyu":                   "https://rdap.gmoregistry.net/rdap/",
-	"sharp":                    "https://rdap.gmoregistry.net/rdap/",
-	"shop":                     "https://rdap.gmoregistry.net/rdap/",
-	"softbank":                 "https://rdap.gmoregistry.net/rdap/",
-	"sony":                     "https://rdap.gmoregistry.net/rdap/",
-	"suzuki":                   "https://rdap.gmoregistry.net/rdap/",
-	"tokyo":                    "https://rdap.gmoregistry.net/rdap/",
-	"toray":                    "https://rdap.gmoregistry.net/rdap/",
-	"toshiba":                  "https://rdap.gmoregistry.net/rdap/",
-	"toyota":                   "https://rdap.gmoregistry.net/rdap/",
-	"yodobashi":                "https://rdap.gmoregistry.net/rdap/",
-	"yokohama":                 "https://rdap.gmoregistry.net/rdap/",
-	"bom":                      "https://rdap.gtlds.nic.br/",
-	"final":                    "https://rdap.gtlds.nic.br/",
-	"globo":                    "https://rdap.gtlds.nic.br/",
-	"rio":                      "https://rdap.gtlds.nic.br/",
-	"uol":                      "https://rdap.gtlds.nic.br/",
-	"ua":                       "https://rdap.hostmaster.ua/",
-	"int":                      "https://rdap.iana.org/",
-	"abb":                      "https://rdap.identitydigital.services/rdap/",
-	"abbott":                   "https://rdap.identitydigital.services/rdap/",
-	"abc":                      "https://rdap.identitydigital.services/rdap/",
-	"academy":                  "https://rdap.identitydigital.services/rdap/",
-	"accenture":                "https://rdap.identitydigital.services/rdap/",
-	"accountants":              "https://rdap.identitydigital.services/rdap/",
-	"actor":                    "https://rdap.identitydigital.services/rdap/",
-	"aeg":                      "https://rdap.identitydigital.services/rdap/",
-	"aero":                     "https://rdap.identitydigital.services/rdap/",
-	"agakhan":                  "https://rdap.identitydigital.services/rdap/",
-	"agency":                   "https://rdap.identitydigital.services/rdap/",
-	"ai":                       "https://rdap.identitydigital.services/rdap/",
-	"airbus":                   "https://rdap.identitydigital.services/rdap/",
-	"airforce":                 "https://rdap.identitydigital.services/rdap/",
-	"akdn":                     "https://rdap.identitydigital.services/rdap/",
-	"alipay":                   "https://rdap.identitydigital.services/rdap/",
-	"allstate":                 "https://rdap.identitydigital.services/rdap/",
-	"aol":                      "https://rdap.identitydigital.services/rdap/",
-	"apartments":               "https://rdap.identitydigital.services/rdap/",
-	"archi":                    "https://rdap.identitydigital.services/rdap/",
-	"army":                     "https://rdap.identitydigital.services/rdap/",
-	"arte":                     "https://rdap.identitydigital.services/rdap/",
-	"asda":                     "https://rdap.identitydigital.services/rdap/",
-	"asia":                     "https://rdap.identitydigital.services/rdap/",
-	"associates":               "https://rdap.identitydigital.services/rdap/",
-	"attorney":                 "https://rdap.identitydigital.services/rdap/",
-	"auction":                  "https://rdap.identitydigital.services/rdap/",
-	"audi":                     "https://rdap.identitydigital.services/rdap/",
-	"band":                     "https://rdap.identitydigital.services/rdap/",
-	"barclaycard":              "https://rdap.identitydigital.services/rdap/",
-	"barclays":                 "https://rdap.identitydigital.services/rdap/",
-	"barefoot":                 "https://rdap.identitydigital.services/rdap/",
-	"bargains":                 "https://rdap.identitydigital.services/rdap/",
-	"bbt":                      "https://rdap.identitydigital.services/rdap/",
-	"bcg":                      "https://rdap.identitydigital.services/rdap/",
-	"beats":                    "https://rdap.identitydigital.services/rdap/",
-	"bestbuy":                  "https://rdap.identitydigital.services/rdap/",
-	"bet":                      "https://rdap.identitydigital.services/rdap/",
-	"bike":                     "https://rdap.identitydigital.services/rdap/",
-	"bingo":                    "https://rdap.identitydigital.services/rdap/",
-	"bio":                      "https://rdap.identitydigital.services/rdap/",
-	"black":                    "https://rdap.identitydigital.services/rdap/",
-	"bloomberg":                "https://rdap.identitydigital.services/rdap/",
-	"blue":                     "https://rdap.identitydigital.services/rdap/",
-	"bm":                       "https://rdap.identitydigital.services/rdap/",
-	"bms":                      "https://rdap.identitydigital.services/rdap/",
-	"bnpparibas":               "https://rdap.identitydigital.services/rdap/",
-	"boehringer":               "https://rdap.identitydigital.services/rdap/",
-	"bofa":                     "https://rdap.identitydigital.services/rdap/",
-	"bosch":                    "https://rdap.identitydigital.services/rdap/",
-	"boutique":                 "https://rdap.identitydigital.services/rdap/",
-	"bradesco":                 "https://rdap.identitydigital.services/rdap/",
-	"broker":                   "https://rdap.identitydigital.services/rdap/",
-	"builders":                 "https://rdap.identitydigital.services/rdap/",
-	"business":                 "https://rdap.identitydigital.services/rdap/",
-	"cab":                      "https://rdap.identitydigital.services/rdap/",
-	"cafe":                     "https://rdap.identitydigital.services/rdap/",
-	"camera":                   "https://rdap.identitydigital.services/rdap/",
-	"camp":                     "https://rdap.identitydigital.services/rdap/",
-	"capital":                  "https://rdap.identitydigital.services/rdap/",
-	"cards":                    "https://rdap.identitydigital.services/rdap/",
-	"care":                     "https://rdap.identitydigital.services/rdap/",
-	"careers":                  "https://rdap.identitydigital.services/rdap/",
-	"cash":                     "https://rdap.identitydigital.services/rdap/",
-	"casino":                   "https://rdap.identitydigital.services/rdap/",
-	"catering":                 "https://rdap.identitydigital.services/rdap/",
-	"center":                   "https://rdap.identitydigital.services/rdap/",
-	"cern":                     "https://rdap.identitydigital.services/rdap/",
-	"cfa":                      "https://rdap.identitydigital.services/rdap/",
-	"chanel":                   "https://rdap.identitydigital.services/rdap/",
-	"chat":                     "https://rdap.identitydigital.services/rdap/",
-	"cheap":                    "https://rdap.identitydigital.services/rdap/",
-	"church":                   "https://rdap.identitydigital.services/rdap/",
-	"cipriani":                 "https://rdap.identitydigital.services/rdap/",
-	"circle":                   "https://rdap.identitydigital.services/rdap/",
-	"citadel":                  "https://rdap.identitydigital.services/rdap/",
-	"city":                     "https://rdap.identitydigital.services/rdap/",
-	"claims":                   "https://rdap.identitydigital.services/rdap/",
-	"cleaning":                 "https://rdap.identitydigital.services/rdap/",
-	"clinic":                   "https://rdap.identitydigital.services/rdap/",
-	"clinique":                 "https://rdap.identitydigital.services/rdap/",
-	"clothing":                 "https://rdap.identitydigital.services/rdap/",
-	"clubmed":                  "https://rdap.identitydigital.services/rdap/",
-	"coach":                    "https://rdap.identitydigital.services/rdap/",
-	"codes":                    "https://rdap.identitydigital.services/rdap/",
-	"coffee":                   "https://rdap.identitydigital.services/rdap/",
-	"community":                "https://rdap.identitydigital.services/rdap/",
-	"company":                  "https://rdap.identitydigital.services/rdap/",
-	"computer":                 "https://rdap.identitydigital.services/rdap/",
-	"condos":                   "https://rdap.identitydigital.services/rdap/",
-	"construction":             "https://rdap.identitydigital.services/rdap/",
-	"consulting":               "https://rdap.identitydigital.services/rdap/",
-	"contact":                  "https://rdap.identitydigital.services/rdap/",
-	"contractors":              "https://rdap.identitydigital.services/rdap/",
-	"cool":                     "https://rdap.identitydigital.services/rdap/",
-	"coupon":                   "https://rdap.identitydigital.services/rdap/",
-	"coupons":                  "https://rdap.identitydigital.services/rdap/",
-	"credit":                   "https://rdap.identitydigital.services/rdap/",
-	"creditcard":               "https://rdap.identitydigital.services/rdap/",
-	"crs":                      "https://rdap.identitydigital.services/rdap/",
-	"cruise":                   "https://rdap.identitydigital.services/rdap/",
-	"cruises":                  "https://rdap.identitydigital.services/rdap/",
-	"dance":                    "https://rdap.identitydigital.services/rdap/",
-	"dating":                   "https://rdap.identitydigital.services/rdap/",
-	"deals":                    "https://rdap.identitydigital.services/rdap/",
-	"degree":                   "https://rdap.identitydigital.services/rdap/",
-	"delivery":                 "https://rdap.identitydigital.services/rdap/",
-	"delta":                    "https://rdap.identitydigital.services/rdap/",
-	"democrat":                 "https://rdap.identitydigital.services/rdap/",
-	"dental":                   "https://rdap.identitydigital.services/rdap/",
-	"dentist":                  "https://rdap.identitydigital.services/rdap/",
-	"diamonds":                 "https://rdap.identitydigital.services/rdap/",
-	"digital":                  "https://rdap.identitydigital.services/rdap/",
-	"direct":                   "https://rdap.identitydigital.services/rdap/",
-	"directory":                "https://rdap.identitydigital.services/rdap/",
-	"discount":                 "https://rdap.identitydigital.services/rdap/",
-	"discover":                 "https://rdap.identitydigital.services/rdap/",
-	"doctor":                   "https://rdap.identitydigital.services/rdap/",
-	"dog":                      "https://rdap.identitydigital.services/rdap/",
-	"domains":                  "https://rdap.identitydigital.services/rdap/",
-	"edeka":                    "https://rdap.identitydigital.services/rdap/",
-	"education":                "https://rdap.identitydigital.services/rdap/",
-	"email":                    "https://rdap.identitydigital.services/rdap/",
-	"emerck":                   "https://rdap.identitydigital.services/rdap/",
-	"energy":                   "https://rdap.identitydigital.services/rdap/",
-	"engineer":                 "https://rdap.identitydigital.services/rdap/",
-	"engineering":              "https://rdap.identitydigital.services/rdap/",
-	"enterprises":              "https://rdap.identitydigital.services/rdap/",
-	"equipment":                "https://rdap.identitydigital.services/rdap/",
-	"ericsson":                 "https://rdap.identitydigital.services/rdap/",
-	"estate":                   "https://rdap.identitydigital.services/rdap/",
-	"events":                   "https://rdap.identitydigital.services/rdap/",
-	"exchange":                 "https://rdap.identitydigital.services/rdap/",
-	"expert":                   "https://rdap.identitydigital.services/rdap/",
-	"exposed":                  "https://rdap.identitydigital.services/rdap/",
-	"express":                  "https://rdap.identitydigital.services/rdap/",
-	"extraspace":               "https://rdap.identitydigital.services/rdap/",
-	"fage":                     "https://rdap.identitydigital.services/rdap/",
-	"fail":                     "https://rdap.identitydigital.services/rdap/",
-	"family":                   "https://rdap.identitydigital.services/rdap/",
-	"fan":                      "https://rdap.identitydigital.services/rdap/",
-	"farm":                     "https://rdap.identitydigital.services/rdap/",
-	"fedex":                    "https://rdap.identitydigital.services/rdap/",
-	"ferrari":                  "https://rdap.identitydigital.services/rdap/",
-	"fidelity":                 "https://rdap.identitydigital.services/rdap/",
-	"fido":                     "https://rdap.identitydigital.services/rdap/",
-	"finance":                  "https://rdap.identitydigital.services/rdap/",
-	"financial":                "https://rdap.identitydigital.services/rdap/",
-	"fish":                     "https://rdap.identitydigital.services/rdap/",
-	"fitness":                  "https://rdap.identitydigital.services/rdap/",
-	"flights":                  "https://rdap.identitydigital.services/rdap/",
-	"florist":                  "https://rdap.identitydigital.services/rdap/",
-	"football":                 "https://rdap.identitydigital.services/rdap/",
-	"forex":                    "https://rdap.identitydigital.services/rdap/",
-	"forsale":                  "https://rdap.identitydigital.services/rdap/",
-	"frogans":                  "https://rdap.identitydigital.services/rdap/",
-	"fund":                     "https://rdap.identitydigital.services/rdap/",
-	"furniture":                "https://rdap.identitydigital.services/rdap/",
-	"futbol":                   "https://rdap.identitydigital.services/rdap/",
-	"fyi":                      "https://rdap.identitydigital.services/rdap/",
-	"gallery":                  "https://rdap.identitydigital.services/rdap/",
-	"gallo":                    "https://rdap.identitydigital.services/rdap/",
-	"gallup":                   "https://rdap.identitydigital.services/rdap/",
-	"games":                    "https://rdap.identitydigital.services/rdap/",
-	"genting":                  "https://rdap.identitydigital.services/rdap/",
-	"gifts":                    "https://rdap.identitydigital.services/rdap/",
-	"glass":                    "https://rdap.identitydigital.services/rdap/",
-	"global":                   "https://rdap.identitydigital.services/rdap/",
-	"gmbh":                     "https://rdap.identitydigital.services/rdap/",
-	"gold":                     "https://rdap.identitydigital.services/rdap/",
-	"golf":                     "https://rdap.identitydigital.services/rdap/",
-	"goodyear":                 "https://rdap.identitydigital.services/rdap/",
-	"got":                      "https://rdap.identitydigital.services/rdap/",
-	"graphics":                 "https://rdap.identitydigital.services/rdap/",
-	"gratis":                   "https://rdap.identitydigital.services/rdap/",
-	"green":                    "https://rdap.identitydigital.services/rdap/",
-	"gripe":                    "https://rdap.identitydigital.services/rdap/",
-	"group":                    "https://rdap.identitydigital.services/rdap/",
-	"guide":                    "https://rdap.identitydigital.services/rdap/",
-	"guru":                     "https://rdap.identitydigital.services/rdap/",
-	"haus":                     "https://rdap.identitydigital.services/rdap/",
-	"hdfc":                     "https://rdap.identitydigital.services/rdap/",
-	"hdfcbank":                 "https://rdap.identitydigital.services/rdap/",
-	"healthcare":               "https://rdap.identitydigital.services/rdap/",
-	"helsinki":                 "https://rdap.identitydigital.services/rdap/",
-	"hermes":                   "https://rdap.identitydigital.services/rdap/",
-	"hkt":                      "https://rdap.identitydigital.services/rdap/",
-	"hockey":                   "https://rdap.identitydigital.services/rdap/",
-	"holdings":                 "https://rdap.identitydigital.services/rdap/",
-	"holiday":                  "https://rdap.identitydigital.services/rdap/",
-	"homedepot":                "https://rdap.identitydigital.services/rdap/",
-	"hospital":                 "https://rdap.identitydigital.services/rdap/",
-	"house":                    "https://rdap.identitydigital.services/rdap/",
-	"hughes":                   "https://rdap.identitydigital.services/rdap/",
-	"ice":                      "https://rdap.identitydigital.services/rdap/",
-	"imamat":                   "https://rdap.identitydigital.services/rdap/",
-	"immo":                     "https://rdap.identitydigital.services/rdap/",
-	"immobilien":               "https://rdap.identitydigital.services/rdap/",
-	"industries":               "https://rdap.identitydigital.services/rdap/",
-	"info":                     "https://rdap.identitydigital.services/rdap/",
-	"institute":                "https://rdap.identitydigital.services/rdap/",
-	"insure":                   "https://rdap.identitydigital.services/rdap/",
-	"international":            "https://rdap.identitydigital.services/rdap/",
-	"investments":              "https://rdap.identitydigital.services/rdap/",
-	"irish":                    "https://rdap.identitydigital.services/rdap/",
-	"ismaili":                  "https://rdap.identitydigital.services/rdap/",
-	"ist":                      "https://rdap.identitydigital.services/rdap/",
-	"istanbul":                 "https://rdap.identitydigital.services/rdap/",
-	"itv":                      "https://rdap.identitydigital.services/rdap/",
-	"jaguar":                   "https://rdap.identitydigital.services/rdap/",
-	"java":                     "https://rdap.identitydigital.services/rdap/",
-	"jeep":                     "https://rdap.identitydigital.services/rdap/",
-	"jetzt":                    "https://rdap.identitydigital.services/rdap/",
-	"jewelry":                  "https://rdap.identitydigital.services/rdap/",
-	"jio":                      "https://rdap.identitydigital.services/rdap/",
-	"jll":                      "https://rdap.identitydigital.services/rdap/",
-	"jot":                      "https://rdap.identitydigital.services/rdap/",
-	"juegos":                   "https://rdap.identitydigital.services/rdap/",
-	"juniper":                  "https://rdap.identitydigital.services/rdap/",
-	"kaufen":                   "https://rdap.identitydigital.services/rdap/",
-	"kerryhotels":              "https://rdap.identitydigital.services/rdap/",
-	"kerryproperties":          "https://rdap.identitydigital.services/rdap/",
-	"kids":                     "https://rdap.identitydigital.services/rdap/",
-	"kim":                      "https://rdap.identitydigital.services/rdap/",
-	"kitchen":                  "https://rdap.identitydigital.services/rdap/",
-	"kosher":                   "https://rdap.identitydigital.services/rdap/",
-	"kuokgroup":                "https://rdap.identitydigital.services/rdap/",
-	"lamborghini":              "https://rdap.identitydigital.services/rdap/",
-	"lamer":                    "https://rdap.identitydigital.services/rdap/",
-	"land":                     "https://rdap.identitydigital.services/rdap/",
-	"landrover":                "https://rdap.identitydigital.services/rdap/",
-	"lasalle":                  "https://rdap.identitydigital.services/rdap/",
-	"lawyer":                   "https://rdap.identitydigital.services/rdap/",
-	"lds":                      "https://rdap.identitydigital.services/rdap/",
-	"lease":                    "https://rdap.identitydigital.services/rdap/",
-	"lefrak":                   "https://rdap.identitydigital.services/rdap/",
-	"legal":                    "https://rdap.identitydigital.services/rdap/",
-	"lego":                     "https://rdap.identitydigital.services/rdap/",
-	"lgbt":                     "https://rdap.identitydigital.services/rdap/",
-	"life":                     "https://rdap.identitydigital.services/rdap/",
-	"lighting":                 "https://rdap.identitydigital.services/rdap/",
-	"limited":                  "https://rdap.identitydigital.services/rdap/",
-	"limo":                     "https://rdap.identitydigital.services/rdap/",
-	"live":                     "https://rdap.identitydigital.services/rdap/",
-	"llc":                      "https://rdap.identitydigital.services/rdap/",
-	"loans":                    "https://rdap.identitydigital.services/rdap/",
-	"lotto":                    "https://rdap.identitydigital.services/rdap/",
-	"ltd":                      "https://rdap.identitydigital.services/rdap/",
-	"ltda":                     "https://rdap.identitydigital.services/rdap/",
-	"lundbeck":                 "https://rdap.identitydigital.services/rdap/",
-	"maif":                     "https://rdap.identitydigital.services/rdap/",
-	"maison":                   "https://rdap.identitydigital.services/rdap/",
-	"management":               "https://rdap.identitydigital.services/rdap/",
-	"market":                   "https://rdap.identitydigital.services/rdap/",
-	"marketing":                "https://rdap.identitydigital.services/rdap/",
-	"markets":                  "https://rdap.identitydigital.services/rdap/",
-	"marriott":                 "https://rdap.identitydigital.services/rdap/",
-	"mba":                      "https://rdap.identitydigital.services/rdap/",
-	"mckinsey":                 "https://rdap.identitydigital.services/rdap/",
-	"media":                    "https://rdap.identitydigital.services/rdap/",
-	"memorial":                 "https://rdap.identitydigital.services/rdap/",
-	"mit":                      "https://rdap.identitydigital.services/rdap/",
-	"mobi":                     "https://rdap.identitydigital.services/rdap/",
-	"moda":                     "https://rdap.identitydigital.services/rdap/",
-	"money":                    "https://rdap.identitydigital.services/rdap/",
-	"mormon":                   "https://rdap.identitydigital.services/rdap/",
-	"mortgage":                 "https://rdap.identitydigital.services/rdap/",
-	"movie":                    "https://rdap.identitydigital.services/rdap/",
-	"mu":                       "https://rdap.identitydigital.services/rdap/",
-	"nab":                      "https://rdap.identitydigital.services/rdap/",
-	"navy":                     "https://rdap.identitydigital.services/rdap/",
-	"network":                  "https://rdap.identitydigital.services/rdap/",
-	"news":                     "https://rdap.identitydigital.services/rdap/",
-	"next":                     "https://rdap.identitydigital.services/rdap/",
-	"nextdirect":               "https://rdap.identitydigital.services/rdap/",
-	"nikon":                    "https://rdap.identitydigital.services/rdap/",
-	"ninja":                    "https://rdap.identitydigital.services/rdap/",
-	"nissay":                   "https://rdap.identitydigital.services/rdap/",
-	"nowtv":                    "https://rdap.identitydigital.services/rdap/",
-	"nra":                      "https://rdap.identitydigital.services/rdap/",
-	"obi":                      "https://rdap.identitydigital.services/rdap/",
-	"onl":                      "https://rdap.identitydigital.services/rdap/",
-	"oracle":                   "https://rdap.identitydigital.services/rdap/",
-	"orange":                   "https://rdap.identitydigital.services/rdap/",
-	"organic":                  "https://rdap.identitydigital.services/rdap/",
-	"origins":                  "https://rdap.identitydigital.services/rdap/",
-	"partners":                 "https://rdap.identitydigital.services/rdap/",
-	"parts":                    "https://rdap.identitydigital.services/rdap/",
-	"pccw":                     "https://rdap.identitydigital.services/rdap/",
-	"pet":                      "https://rdap.identitydigital.services/rdap/",
-	"photography":              "https://rdap.identitydigital.services/rdap/",
-	"photos":                   "https://rdap.identitydigital.services/rdap/",
-	"pictet":                   "https://rdap.identitydigital.services/rdap/",
-	"pictures":                 "https://rdap.identitydigital.services/rdap/",
-	"pink":                     "https://rdap.identitydigital.services/rdap/",
-	"pizza":                    "https://rdap.identitydigital.services/rdap/",
-	"place":                    "https://rdap.identitydigital.services/rdap/",
-	"plumbing":                 "https://rdap.identitydigital.services/rdap/",
-	"plus":                     "https://rdap.identitydigital.services/rdap/",
-	"pnc":                      "https://rdap.identitydigital.services/rdap/",
-	"poker":                    "https://rdap.identitydigital.services/rdap/",
-	"post":                     "https://rdap.identitydigital.services/rdap/",
-	"pro":                      "https://rdap.identitydigital.services/rdap/",
-	"productions":              "https://rdap.identitydigital.services/rdap/",
-	"progressive":              "https://rdap.identitydigital.services/rdap/",
-	"promo":                    "https://rdap.identitydigital.services/rdap/",
-	"properties":               "https://rdap.identitydigital.services/rdap/",
-	"pub":                      "https://rdap.identitydigital.services/rdap/",
-	"pwc":                      "https://rdap.identitydigital.services/rdap/",
-	"recipes":                  "https://rdap.identitydigital.services/rdap/",
-	"red":                      "https://rdap.identitydigital.services/rdap/",
-	"redumbrella":              "https://rdap.identitydigital.services/rdap/",
-	"rehab":                    "https://rdap.identitydigital.services/rdap/",
-	"reise":                    "https://rdap.identitydigital.services/rdap/",
-	"reisen":                   "https://rdap.identitydigital.services/rdap/",
-	"reliance":                 "https://rdap.identitydigital.services/rdap/",
-	"rentals":                  "https://rdap.identitydigital.services/rdap/",
-	"repair":                   "https://rdap.identitydigital.services/rdap/",
-	"report":                   "https://rdap.identitydigital.services/rdap/",
-	"republican":               "https://rdap.identitydigital.services/rdap/",
-	"restaurant":               "https://rdap.identitydigital.services/rdap/",
-	"reviews":                  "https://rdap.identitydigital.services/rdap/",
-	"rexroth":                  "https://rdap.identitydigital.services/rdap/",
-	"rich":                     "https://rdap.identitydigital.services/rdap/",
-	"richardli":                "https://rdap.identitydigital.services/rdap/",
-	"ril":                      "https://rdap.identitydigital.services/rdap/",
-	"rip":                      "https://rdap.identitydigital.services/rdap/",
-	"rocks":                    "https://rdap.identitydigital.services/rdap/",
-	"rogers":                   "https://rdap.identitydigital.services/rdap/",
-	"run":                      "https://rdap.identitydigital.services/rdap/",
-	"rwe":                      "https://rdap.identitydigital.services/rdap/",
-	"safety":                   "https://rdap.identitydigital.services/rdap/",
-	"sale":                     "https://rdap.identitydigital.services/rdap/",
-	"salon":                    "https://rdap.identitydigital.services/rdap/",
-	"sanofi":                   "https://rdap.identitydigital.services/rdap/",
-	"sarl":                     "https://rdap.identitydigital.services/rdap/",
-	"saxo":                     "https://rdap.identitydigital.services/rdap/",
-	"sbi":                      "https://rdap.identitydigital.services/rdap/",
-	"scholarships":             "https://rdap.identitydigital.services/rdap/",
-	"school":                   "https://rdap.identitydigital.services/rdap/",
-	"schule":                   "https://rdap.identitydigital.services/rdap/",
-	"sener":                    "https://rdap.identitydigital.services/rdap/",
-	"services":                 "https://rdap.identitydigital.services/rdap/",
-	"sew":                      "https://rdap.identitydigital.services/rdap/",
-	"shangrila":                "https://rdap.identitydigital.services/rdap/",
-	"shiksha":                  "https://rdap.identitydigital.services/rdap/",
-	"shoes":                    "https://rdap.identitydigital.services/rdap/",
-	"shopping":                 "https://rdap.identitydigital.services/rdap/",
-	"show":                     "https://rdap.identitydigital.services/rdap/",
-	"singles":                  "https://rdap.identitydigital.services/rdap/",
-	"ski":                      "https://rdap.identitydigital.services/rdap/",
-	"soccer":                   "https://rdap.identitydigital.services/rdap/",
-	"social":                   "https://rdap.identitydigital.services/rdap/",
-	"software":                 "https://rdap.identitydigital.services/rdap/",
-	"solar":                    "https://rdap.identitydigital.services/rdap/",
-	"solutions":                "https://rdap.identitydigital.services/rdap/",
-	"song":                     "https://rdap.identitydigital.services/rdap/",
-	"spa":                      "https://rdap.identitydigital.services/rdap/",
-	"srl":                      "https://rdap.identitydigital.services/rdap/",
-	"stada":                    "https://rdap.identitydigital.services/rdap/",
-	"star":                     "https://rdap.identitydigital.services/rdap/",
-	"statebank":                "https://rdap.identitydigital.services/rdap/",
-	"stockholm":                "https://rdap.identitydigital.services/rdap/",
-	"studio":                   "https://rdap.identitydigital.services/rdap/",
-	"style":                    "https://rdap.identitydigital.services/rdap/",
-	"supplies":                 "https://rdap.identitydigital.services/rdap/",
-	"supply":                   "https://rdap.identitydigital.services/rdap/",
-	"support":                  "https://rdap.identitydigital.services/rdap/",
-	"surgery":                  "https://rdap.identitydigital.services/rdap/",
-	"systems":                  "https://rdap.identitydigital.services/rdap/",
-	"taobao":                   "https://rdap.identitydigital.services/rdap/",
-	"tatamotors":               "https://rdap.identitydigital.services/rdap/",
-	"tax":                      "https://rdap.identitydigital.services/rdap/",
-	"taxi":                     "https://rdap.identitydigital.services/rdap/",
-	"team":                     "https://rdap.identitydigital.services/rdap/",
-	"technology":               "https://rdap.identitydigital.services/rdap/",
-	"temasek":                  "https://rdap.identitydigital.services/rdap/",
-	"tennis":                   "https://rdap.identitydigital.services/rdap/",
-	"thd":                      "https://rdap.identitydigital.services/rdap/",
-	"theater":                  "https://rdap.identitydigital.services/rdap/",
-	"tiaa":                     "https://rdap.identitydigital.services/rdap/",
-	"tienda":                   "https://rdap.identitydigital.services/rdap/",
-	"tips":                     "https://rdap.identitydigital.services/rdap/",
-	"tires":                    "https://rdap.identitydigital.services/rdap/",
-	"tmall":                    "https://rdap.identitydigital.services/rdap/",
-	"today":                    "https://rdap.identitydigital.services/rdap/",
-	"tools":                    "https://rdap.identitydigital.services/rdap/",
-	"tours":                    "https://rdap.identitydigital.services/rdap/",
-	"town":                     "https://rdap.identitydigital.services/rdap/",
-	"toys":                     "https://rdap.identitydigital.services/rdap/",
-	"trading":                  "https://rdap.identitydigital.services/rdap/",
-	"training":                 "https://rdap.identitydigital.services/rdap/",
-	"travel":                   "https://rdap.identitydigital.services/rdap/",
-	"travelers":                "https://rdap.identitydigital.services/rdap/",
-	"travelersinsurance":       "https://rdap.identitydigital.services/rdap/",
-	"trv":                      "https://rdap.identitydigital.services/rdap/",
-	"tvs":                      "https://rdap.identitydigital.services/rdap/",
-	"ubank":                    "https://rdap.identitydigital.services/rdap/",
-	"ubs":                      "https://rdap.identitydigital.services/rdap/",
-	"university":               "https://rdap.identitydigital.services/rdap/",
-	"ups":                      "https://rdap.identitydigital.services/rdap/",
-	"vacations":                "https://rdap.identitydigital.services/rdap/",
-	"vanguard":                 "https://rdap.identitydigital.services/rdap/",
-	"vegas":                    "https://rdap.identitydigital.services/rdap/",
-	"ventures":                 "https://rdap.identitydigital.services/rdap/",
-	"vet":                      "https://rdap.identitydigital.services/rdap/",
-	"viajes":                   "https://rdap.identitydigital.services/rdap/",
-	"video":                    "https://rdap.identitydigital.services/rdap/",
-	"vig":                      "https://rdap.identitydigital.services/rdap/",
-	"viking":                   "https://rdap.identitydigital.services/rdap/",
-	"villas":                   "https://rdap.identitydigital.services/rdap/",
-	"vin":                      "https://rdap.identitydigital.services/rdap/",
-	"visa":                     "https://rdap.identitydigital.services/rdap/",
-	"vision":                   "https://rdap.identitydigital.services/rdap/",
-	"volvo":                    "https://rdap.identitydigital.services/rdap/",
-	"vote":                     "https://rdap.identitydigital.services/rdap/",
-	"voto":                     "https://rdap.identitydigital.services/rdap/",
-	"voyage":                   "https://rdap.identitydigital.services/rdap/",
-	"watch":                    "https://rdap.identitydigital.services/rdap/",
-	"watches":                  "https://rdap.identitydigital.services/rdap/",
-	"weber":                    "https://rdap.identitydigital.services/rdap/",
-	"weir":                     "https://rdap.identitydigital.services/rdap/",
-	"wine":                     "https://rdap.identitydigital.services/rdap/",
-	"works":                    "https://rdap.identitydigital.services/rdap/",
-	"world":                    "https://rdap.identitydigital.services/rdap/",
-	"wtf":                      "https://rdap.identitydigital.services/rdap/",
-	"xin":                      "https://rdap.identitydigital.services/rdap/",
-	"xn--1ck2e1b":              "https://rdap.identitydigital.services/rdap/",
-	"xn--5su34j936bgsg":        "https://rdap.identitydigital.services/rdap/",
-	"xn--5tzm5g":               "https://rdap.identitydigital.services/rdap/",
-	"xn--6frz82g":              "https://rdap.identitydigital.services/rdap/",
-	"xn--b4w605ferd":           "https://rdap.identitydigital.services/rdap/",
-	"xn--bck1b9a5dre4c":        "https://rdap.identitydigital.services/rdap/",
-	"xn--cck2b3b":              "https://rdap.identitydigital.services/rdap/",
-	"xn--czrs0t":               "https://rdap.identitydigital.services/rdap/",
-	"xn--eckvdtc9d":            "https://rdap.identitydigital.services/rdap/",
-	"xn--fct429k":              "https://rdap.identitydigital.services/rdap/",
-	"xn--fjq720a":              "https://rdap.identitydigital.services/rdap/",
-	"xn--fzys8d69uvgm":         "https://rdap.identitydigital.services/rdap/",
-	"xn--gckr3f0f":             "https://rdap.identitydigital.services/rdap/",
-	"xn--gk3at1e":              "https://rdap.identitydigital.services/rdap/",
-	"xn--jvr189m":              "https://rdap.identitydigital.services/rdap/",
-	"xn--rovu88b":              "https://rdap.identitydigital.services/rdap/",
-	"xn--unup4y":               "https://rdap.identitydigital.services/rdap/",
-	"xn--vhquv":                "https://rdap.identitydigital.services/rdap/",
-	"xn--w4r85el8fhu5dnra":     "https://rdap.identitydigital.services/rdap/",
-	"xn--w4rs40l":              "https://rdap.identitydigital.services/rdap/",
-	"yahoo":                    "https://rdap.identitydigital.services/rdap/",
-	"zara":                     "https://rdap.identitydigital.services/rdap/",
-	"zero":                     "https://rdap.identitydigital.services/rdap/",
-	"zone":                     "https://rdap.identitydigital.services/rdap/",
-	"is":                       "https://rdap.isnic.is/rdap/",
-	"ke":                       "https://rdap.kenic.or.ke/",
-	"kiwi":                     "https://rdap.kiwi.fury.ca/rdap/",
-	"lb":                       "https://rdap.lbdr.org.lb/",
-	"mls":                      "https://rdap.mls.fury.ca/rdap/",
-	"blockbuster":              "https://rdap.mobile-registry.com/rdap/",
-	"data":                     "https://rdap.mobile-registry.com/rdap/",
-	"dish":                     "https://rdap.mobile-registry.com/rdap/",
-	"dot":                      "https://rdap.mobile-registry.com/rdap/",
-	"dtv":                      "https://rdap.mobile-registry.com/rdap/",
-	"dvr":                      "https://rdap.mobile-registry.com/rdap/",
-	"latino":                   "https://rdap.mobile-registry.com/rdap/",
-	"mobile":                   "https://rdap.mobile-registry.com/rdap/",
-	"ollo":                     "https://rdap.mobile-registry.com/rdap/",
-	"ott":                      "https://rdap.mobile-registry.com/rdap/",
-	"phone":                    "https://rdap.mobile-registry.com/rdap/",
-	"sling":                    "https://rdap.mobile-registry.com/rdap/",
-	"sina":                     "https://rdap.ngtld.cn/",
-	"weibo":                    "https://rdap.ngtld.cn/",
-	"xn--55qw42g":              "https://rdap.ngtld.cn/",
-	"xn--55qx5d":               "https://rdap.ngtld.cn/",
-	"xn--9krt00a":              "https://rdap.ngtld.cn/",
-	"xn--io0a7i":               "https://rdap.ngtld.cn/",
-	"xn--zfr164b":              "https://rdap.ngtld.cn/",
-	"aaa":                      "https://rdap.nic.aaa/",
-	"aarp":                     "https://rdap.nic.aarp/",
-	"able":                     "https://rdap.nic.able/",
-	"abogado":                  "https://rdap.nic.abogado/",
-	"abudhabi":                 "https://rdap.nic.abudhabi/",
-	"accountant":               "https://rdap.nic.accountant/",
-	"aco":                      "https://rdap.nic.aco/",
-	"ad":                       "https://rdap.nic.ad/",
-	"adult":                    "https://rdap.nic.adult/",
-	"aetna":                    "https://rdap.nic.aetna/",
-	"afl":                      "https://rdap.nic.afl/",
-	"africa":                   "https://rdap.nic.africa/rdap/",
-	"aig":                      "https://rdap.nic.aig/",
-	"airtel":                   "https://rdap.nic.airtel/",
-	"ally":                     "https://rdap.nic.ally/",
-	"alsace":                   "https://rdap.nic.alsace/",
-	"alstom":                   "https://rdap.nic.alstom/",
-	"americanexpress":          "https://rdap.nic.americanexpress/",
-	"americanfamily":           "https://rdap.nic.americanfamily/",
-	"amex":                     "https://rdap.nic.amex/",
-	"amfam":                    "https://rdap.nic.amfam/",
-	"amica":                    "https://rdap.nic.amica/",
-	"amsterdam":                "https://rdap.nic.amsterdam/",
-	"analytics":                "https://rdap.nic.analytics/",
-	"anz":                      "https://rdap.nic.anz/",
-	"apple":                    "https://rdap.nic.apple/",
-	"aquarelle":                "https://rdap.nic.aquarelle/",
-	"ar":                       "https://rdap.nic.ar/",
-	"arab":                     "https://rdap.nic.arab/",
-	"aramco":                   "https://rdap.nic.aramco/",
-	"as":                       "https://rdap.nic.as/",
-	"athleta":                  "https://rdap.nic.athleta/",
-	"auspost":                  "https://rdap.nic.auspost/",
-	"axa":                      "https://rdap.nic.axa/",
-	"banamex":                  "https://rdap.nic.banamex/",
-	"bank":                     "https://rdap.nic.bank/",
-	"barcelona":                "https://rdap.nic.barcelona/",
-	"baseball":                 "https://rdap.nic.baseball/",
-	"basketball":               "https://rdap.nic.basketball/",
-	"bauhaus":                  "https://rdap.nic.bauhaus/",
-	"bayern":                   "https://rdap.nic.bayern/",
-	"bcn":                      "https://rdap.nic.bcn/",
-	"beer":                     "https://rdap.nic.beer/",
-	"berlin":                   "https://rdap.nic.berlin/v1/",
-	"bharti":                   "https://rdap.nic.bharti/",
-	"bible":                    "https://rdap.nic.bible/",
-	"bid":                      "https://rdap.nic.bid/",
-	"biz":                      "https://rdap.nic.biz/",
-	"blackfriday":              "https://rdap.nic.blackfriday/",
-	"booking":                  "https://rdap.nic.booking/",
-	"bostik":                   "https://rdap.nic.bostik/",
-	"boston":                   "https://rdap.nic.boston/",
-	"brussels":                 "https://rdap.nic.brussels/",
-	"buzz":                     "https://rdap.nic.buzz/",
-	"bzh":                      "https://rdap.nic.bzh/",
-	"calvinklein":              "https://rdap.nic.calvinklein/",
-	"capetown":                 "https://rdap.nic.capetown/rdap/",
-	"capitalone":               "https://rdap.nic.capitalone/",
-	"caravan":                  "https://rdap.nic.caravan/",
-	"casa":                     "https://rdap.nic.casa/",
-	"cat":                      "https://rdap.nic.cat/",
-	"catholic":                 "https://rdap.nic.catholic/",
-	"cba":                      "https://rdap.nic.cba/",
-	"cbn":                      "https://rdap.nic.cbn/",
-	"cbre":                     "https://rdap.nic.cbre/",
-	"chase":                    "https://rdap.nic.chase/",
-	"chintai":                  "https://rdap.nic.chintai/",
-	"cisco":                    "https://rdap.nic.cisco/",
-	"citi":                     "https://rdap.nic.citi/",
-	"club":                     "https://rdap.nic.club/",
-	"cm":                       "https://rdap.nic.cm/",
-	"commbank":                 "https://rdap.nic.commbank/",
-	"compare":                  "https://rdap.nic.compare/",
-	"cooking":                  "https://rdap.nic.cooking/",
-	"corsica":                  "https://rdap.nic.corsica/",
-	"courses":                  "https://rdap.nic.courses/",
-	"cpa":                      "https://rdap.nic.cpa/",
-	"cr":                       "https://rdap.nic.cr/",
-	"cricket":                  "https://rdap.nic.cricket/",
-	"cuisinella":               "https://rdap.nic.cuisinella/",
-	"cv":                       "https://rdap.nic.cv/",
-	"cx":                       "https://rdap.nic.cx/",
-	"cz":                       "https://rdap.nic.cz/",
-	"date":                     "https://rdap.nic.date/",
-	"dds":                      "https://rdap.nic.dds/",
-	"dell":                     "https://rdap.nic.dell/",
-	"design":                   "https://rdap.nic.design/",
-	"download":                 "https://rdap.nic.download/",
-	"dubai":                    "https://rdap.nic.dubai/",
-	"dupont":                   "https://rdap.nic.dupont/",
-	"durban":                   "https://rdap.nic.durban/rdap/",
-	"earth":                    "https://rdap.nic.earth/",
-	"erni":                     "https://rdap.nic.erni/",
-	"eurovision":               "https://rdap.nic.eurovision/",
-	"eus":                      "https://rdap.nic.eus/",
-	"faith":                    "https://rdap.nic.faith/",
-	"farmers":                  "https://rdap.nic.farmers/",
-	"fashion":                  "https://rdap.nic.fashion/",
-	"ferrero":                  "https://rdap.nic.ferrero/",
-	"film":                     "https://rdap.nic.film/",
-	"firmdale":                 "https://rdap.nic.firmdale/",
-	"fishing":                  "https://rdap.nic.fishing/",
-	"fit":                      "https://rdap.nic.fit/",
-	"flickr":                   "https://rdap.nic.flickr/",
-	"flir":                     "https://rdap.nic.flir/",
-	"ford":                     "https://rdap.nic.ford/",
-	"fox":                      "https://rdap.nic.fox/",
-	"fr":                       "https://rdap.nic.fr/",
-	"frontier":                 "https://rdap.nic.frontier/",
-	"ftr":                      "https://rdap.nic.ftr/",
-	"gal":                      "https://rdap.nic.gal/",
-	"gap":                      "https://rdap.nic.gap/",
-	"garden":                   "https://rdap.nic.garden/",
-	"gay":                      "https://rdap.nic.gay/",
-	"gdn":                      "https://rdap.nic.gdn/",
-	"gea":                      "https://rdap.nic.gea/",
-	"george":                   "https://rdap.nic.george/",
-	"gmx":                      "https://rdap.nic.gmx/",
-	"godaddy":                  "https://rdap.nic.godaddy/",
-	"gov":                      "https://rdap.nic.gov/rdap/",
-	"grainger":                 "https://rdap.nic.grainger/",
-	"grocery":                  "https://rdap.nic.grocery/",
-	"gs":                       "https://rdap.nic.gs/",
-	"hamburg":                  "https://rdap.nic.hamburg/v1/",
-	"hbo":                      "https://rdap.nic.hbo/",
-	"health":                   "https://rdap.nic.health/",
-	"hn":                       "https://rdap.nic.hn/",
-	"homegoods":                "https://rdap.nic.homegoods/",
-	"homesense":                "https://rdap.nic.homesense/",
-	"horse":                    "https://rdap.nic.horse/",
-	"hotels":                   "https://rdap.nic.hotels/",
-	"hsbc":                     "https://rdap.nic.hsbc/",
-	"ht":                       "https://rdap.nic.ht/",
-	"hyatt":                    "https://rdap.nic.hyatt/",
-	"ibm":                      "https://rdap.nic.ibm/",
-	"ifm":                      "https://rdap.nic.ifm/",
-	"ikano":                    "https://rdap.nic.ikano/v1/",
-	"ink":                      "https://rdap.nic.ink/",
-	"insurance":                "https://rdap.nic.insurance/",
-	"intuit":                   "https://rdap.nic.intuit/",
-	"ipiranga":                 "https://rdap.nic.ipiranga/",
-	"itau":                     "https://rdap.nic.itau/",
-	"jmp":                      "https://rdap.nic.jmp/",
-	"joburg":                   "https://rdap.nic.joburg/rdap/",
-	"jpmorgan":                 "https://rdap.nic.jpmorgan/",
-	"jprs":                     "https://rdap.nic.jprs/rdap/",
-	"kpmg":                     "https://rdap.nic.kpmg/",
-	"krd":                      "https://rdap.nic.krd/",
-	"lacaixa":                  "https://rdap.nic.lacaixa/",
-	"lanxess":                  "https://rdap.nic.lanxess/",
-	"latrobe":                  "https://rdap.nic.latrobe/",
-	"law":                      "https://rdap.nic.law/",
-	"leclerc":                  "https://rdap.nic.leclerc/",
-	"lifeinsurance":            "https://rdap.nic.lifeinsurance/",
-	"lilly":                    "https://rdap.nic.lilly/",
-	"lincoln":                  "https://rdap.nic.lincoln/",
-	"loan":                     "https://rdap.nic.loan/",
-	"locker":                   "https://rdap.nic.locker/rdap/",
-	"luxe":                     "https://rdap.nic.luxe/",
-	"ly":                       "https://rdap.nic.ly/",
-	"madrid":                   "https://rdap.nic.madrid/",
-	"man":                      "https://rdap.nic.man/",
-	"mango":                    "https://rdap.nic.mango/",
-	"marshalls":                "https://rdap.nic.marshalls/",
-	"mattel":                   "https://rdap.nic.mattel/",
-	"melbourne":                "https://rdap.nic.melbourne/",
-	"men":                      "https://rdap.nic.men/",
-	"menu":                     "https://rdap.nic.menu/",
-	"merckmsd":                 "https://rdap.nic.merckmsd/",
-	"miami":                    "https://rdap.nic.miami/",
-	"mint":                     "https://rdap.nic.mint/",
-	"ml":                       "https://rdap.nic.ml/",
-	"mlb":                      "https://rdap.nic.mlb/",
-	"mma":                      "https://rdap.nic.mma/",
-	"moe":                      "https://rdap.nic.moe/",
-	"monash":                   "https://rdap.nic.monash/",
-	"moto":                     "https://rdap.nic.moto/",
-	"ms":                       "https://rdap.nic.ms/",
-	"msd":                      "https://rdap.nic.msd/",
-	"museum":                   "https://rdap.nic.museum/",
-	"nba":                      "https://rdap.nic.nba/",
-	"ng":                       "https://rdap.nic.net.ng/",
-	"netbank":                  "https://rdap.nic.netbank/",
-	"netflix":                  "https://rdap.nic.netflix/",
-	"neustar":                  "https://rdap.nic.neustar/",
-	"nf":                       "https://rdap.nic.nf/",
-	"nfl":                      "https://rdap.nic.nfl/",
-	"nike":                     "https://rdap.nic.nike/",
-	"norton":                   "https://rdap.nic.norton/",
-	"nrw":                      "https://rdap.nic.nrw/",
-	"ntt":                      "https://rdap.nic.ntt/rdap/",
-	"nyc":                      "https://rdap.nic.nyc/",
-	"olayan":                   "https://rdap.nic.olayan/",
-	"olayangroup":              "https://rdap.nic.olayangroup/",
-	"one":                      "https://rdap.nic.one/",
-	"open":                     "https://rdap.nic.open/",
-	"osaka":                    "https://rdap.nic.osaka/",
-	"ovh":                      "https://rdap.nic.ovh/",
-	"paris":                    "https://rdap.nic.paris/",
-	"party":                    "https://rdap.nic.party/",
-	"pfizer":                   "https://rdap.nic.pfizer/",
-	"pg":                       "https://rdap.nic.pg/",
-	"philips":                  "https://rdap.nic.philips/",
-	"photo":                    "https://rdap.nic.photo/",
-	"physio":                   "https://rdap.nic.physio/",
-	"ping":                     "https://rdap.nic.ping/",
-	"pm":                       "https://rdap.nic.pm/",
-	"politie":                  "https://rdap.nic.politie/",
-	"porn":                     "https://rdap.nic.porn/",
-	"praxi":                    "https://rdap.nic.praxi/",
-	"pru":                      "https://rdap.nic.pru/",
-	"prudential":               "https://rdap.nic.prudential/",
-	"quebec":                   "https://rdap.nic.quebec/",
-	"racing":                   "https://rdap.nic.racing/",
-	"radio":                    "https://rdap.nic.radio/",
-	"re":                       "https://rdap.nic.re/",
-	"review":                   "https://rdap.nic.review/",
-	"rodeo":                    "https://rdap.nic.rodeo/",
-	"rugby":                    "https://rdap.nic.rugby/",
-	"sakura":                   "https://rdap.nic.sakura/rdap/",
-	"samsclub":                 "https://rdap.nic.samsclub/",
-	"sandvik":                  "https://rdap.nic.sandvik/",
-	"sandvikcoromant":          "https://rdap.nic.sandvikcoromant/",
-	"sap":                      "https://rdap.nic.sap/",
-	"sas":                      "https://rdap.nic.sas/",
-	"scb":                      "https://rdap.nic.scb/",
-	"schaeffler":               "https://rdap.nic.schaeffler/",
-	"schmidt":                  "https://rdap.nic.schmidt/",
-	"science":                  "https://rdap.nic.science/",
-	"scot":                     "https://rdap.nic.scot/",
-	"sd":                       "https://rdap.nic.sd/",
-	"seat":                     "https://rdap.nic.seat/",
-	"seek":                     "https://rdap.nic.seek/",
-	"select":                   "https://rdap.nic.select/",
-	"seven":                    "https://rdap.nic.seven/",
-	"sex":                      "https://rdap.nic.sex/",
-	"sn":                       "https://rdap.nic.sn/whois43/",
-	"sncf":                     "https://rdap.nic.sncf/",
-	"sport":                    "https://rdap.nic.sport/",
-	"ss":                       "https://rdap.nic.ss/",
-	"staples":                  "https://rdap.nic.staples/",
-	"statefarm":                "https://rdap.nic.statefarm/",
-	"stream":                   "https://rdap.nic.stream/",
-	"study":                    "https://rdap.nic.study/",
-	"sucks":                    "https://rdap.nic.sucks/",
-	"surf":                     "https://rdap.nic.surf/",
-	"swiss":                    "https://rdap.nic.swiss/",
-	"sydney":                   "https://rdap.nic.sydney/",
-	"tab":                      "https://rdap.nic.tab/",
-	"taipei":                   "https://rdap.nic.taipei/",
-	"target":                   "https://rdap.nic.target/",
-	"tattoo":                   "https://rdap.nic.tattoo/",
-	"tdk":                      "https://rdap.nic.tdk/",
-	"tel":                      "https://rdap.nic.tel/",
-	"teva":                     "https://rdap.nic.teva/",
-	"tf":                       "https://rdap.nic.tf/",
-	"tjmaxx":                   "https://rdap.nic.tjmaxx/",
-	"tjx":                      "https://rdap.nic.tjx/",
-	"tkmaxx":                   "https://rdap.nic.tkmaxx/",
-	"total":                    "https://rdap.nic.total/",
-	"trade":                    "https://rdap.nic.trade/",
-	"tube":                     "https://rdap.nic.tube/",
-	"tv":                       "https://rdap.nic.tv/",
-	"versicherung":             "https://rdap.nic.versicherung/v1/",
-	"vi":                       "https://rdap.nic.vi/",
-	"vip":                      "https://rdap.nic.vip/",
-	"vivo":                     "https://rdap.nic.vivo/",
-	"vlaanderen":               "https://rdap.nic.vlaanderen/",
-	"vodka":                    "https://rdap.nic.vodka/",
-	"voting":                   "https://rdap.nic.voting/",
-	"walmart":                  "https://rdap.nic.walmart/",
-	"walter":                   "https://rdap.nic.walter/",
-	"weather":                  "https://rdap.nic.weather/",
-	"weatherchannel":           "https://rdap.nic.weatherchannel/",
-	"webcam":                   "https://rdap.nic.webcam/",
-	"wedding":                  "https://rdap.nic.wedding/",
-	"wf":                       "https://rdap.nic.wf/",
-	"whoswho":                  "https://rdap.nic.whoswho/",
-	"wiki":                     "https://rdap.nic.wiki/",
-	"williamhill":              "https://rdap.nic.williamhill/",
-	"win":                      "https://rdap.nic.win/",
-	"winners":                  "https://rdap.nic.winners/",
-	"woodside":                 "https://rdap.nic.woodside/",
-	"work":                     "https://rdap.nic.work/",
-	"wtc":                      "https://rdap.nic.wtc/",
-	"xerox":                    "https://rdap.nic.xerox/",
-	"xn--80aqecdr1a":           "https://rdap.nic.xn--80aqecdr1a/",
-	"xn--80asehdb":             "https://rdap.nic.xn--80asehdb/",
-	"xn--80aswg":               "https://rdap.nic.xn--80aswg/",
-	"xn--g2xx48c":              "https://rdap.nic.xn--g2xx48c/",
-	"xn--kcrx77d1x4a":          "https://rdap.nic.xn--kcrx77d1x4a/",
-	"xn--mgba3a3ejt":           "https://rdap.nic.xn--mgba3a3ejt/",
-	"xn--mgba7c0bbn0a":         "https://rdap.nic.xn--mgba7c0bbn0a/",
-	"xn--mgbab2bd":             "https://rdap.nic.xn--mgbab2bd/",
-	"xn--mgbca7dzdo":           "https://rdap.nic.xn--mgbca7dzdo/",
-	"xn--mgbi4ecexp":           "https://rdap.nic.xn--mgbi4ecexp/",
-	"xn--ngbc5azd":             "https://rdap.nic.xn--ngbc5azd/",
-	"xn--ngbrx":                "https://rdap.nic.xn--ngbrx/",
-	"xn--p1acf":                "https://rdap.nic.xn--p1acf/",
-	"xn--tiq49xqyj":            "https://rdap.nic.xn--tiq49xqyj/",
-	"xxx":                      "https://rdap.nic.xxx/",
-	"yandex":                   "https://rdap.nic.yandex/rdap/",
-	"yoga":                     "https://rdap.nic.yoga/",
-	"yt":                       "https://rdap.nic.yt/",
-	"zm":                       "https://rdap.nic.zm/",
-	"in":                       "https://rdap.nixiregistry.in/rdap/",
-	"abbvie":                   "https://rdap.nominet.uk/abbvie/",
-	"amazon":                   "https://rdap.nominet.uk/amazon/",
-	"audible":                  "https://rdap.nominet.uk/audible/",
-	"author":                   "https://rdap.nominet.uk/author/",
-	"aws":                      "https://rdap.nominet.uk/aws/",
-	"azure":                    "https://rdap.nominet.uk/azure/",
-	"bbc":                      "https://rdap.nominet.uk/bbc/",
-	"bbva":                     "https://rdap.nominet.uk/bbva/",
-	"bing":                     "https://rdap.nominet.uk/bing/",
-	"book":                     "https://rdap.nominet.uk/book/",
-	"bot":                      "https://rdap.nominet.uk/bot/",
-	"broadway":                 "https://rdap.nominet.uk/broadway/",
-	"buy":                      "https://rdap.nominet.uk/buy/",
-	"call":                     "https://rdap.nominet.uk/call/",
-	"career":                   "https://rdap.nominet.uk/career/",
-	"cymru":                    "https://rdap.nominet.uk/cymru/",
-	"deal":                     "https://rdap.nominet.uk/deal/",
-	"desi":                     "https://rdap.nominet.uk/desi/",
-	"fairwinds":                "https://rdap.nominet.uk/fairwinds/",
-	"fast":                     "https://rdap.nominet.uk/fast/",
-	"fire":                     "https://rdap.nominet.uk/fire/",
-	"free":                     "https://rdap.nominet.uk/free/",
-	"gop":                      "https://rdap.nominet.uk/gop/",
-	"gucci":                    "https://rdap.nominet.uk/gucci/",
-	"hot":                      "https://rdap.nominet.uk/hot/",
-	"hotmail":                  "https://rdap.nominet.uk/hotmail/",
-	"ieee":                     "https://rdap.nominet.uk/ieee/",
-	"imdb":                     "https://rdap.nominet.uk/imdb/",
-	"jobs":                     "https://rdap.nominet.uk/jobs/",
-	"joy":                      "https://rdap.nominet.uk/joy/",
-	"kindle":                   "https://rdap.nominet.uk/kindle/",
-	"like":                     "https://rdap.nominet.uk/like/",
-	"locus":                    "https://rdap.nominet.uk/locus/",
-	"med":                      "https://rdap.nominet.uk/med/",
-	"microsoft":                "https://rdap.nominet.uk/microsoft/",
-	"moi":                      "https://rdap.nominet.uk/moi/",
-	"mtn":                      "https://rdap.nominet.uk/mtn/",
-	"now":                      "https://rdap.nominet.uk/now/",
-	"nowruz":                   "https://rdap.nominet.uk/nowruz/",
-	"office":                   "https://rdap.nominet.uk/office/",
-	"omega":                    "https://rdap.nominet.uk/omega/",
-	"pars":                     "https://rdap.nominet.uk/pars/",
-	"pay":                      "https://rdap.nominet.uk/pay/",
-	"pharmacy":                 "https://rdap.nominet.uk/pharmacy/",
-	"pin":                      "https://rdap.nominet.uk/pin/",
-	"pioneer":                  "https://rdap.nominet.uk/pioneer/",
-	"pn":                       "https://rdap.nominet.uk/pn/",
-	"prime":                    "https://rdap.nominet.uk/prime/",
-	"read":                     "https://rdap.nominet.uk/read/",
-	"realestate":               "https://rdap.nominet.uk/realestate/",
-	"realtor":                  "https://rdap.nominet.uk/realtor/",
-	"room":                     "https://rdap.nominet.uk/room/",
-	"safe":                     "https://rdap.nominet.uk/safe/",
-	"save":                     "https://rdap.nominet.uk/save/",
-	"secure":                   "https://rdap.nominet.uk/secure/",
-	"shell":                    "https://rdap.nominet.uk/shell/",
-	"shia":                     "https://rdap.nominet.uk/shia/",
-	"silk":                     "https://rdap.nominet.uk/silk/",
-	"sky":                      "https://rdap.nominet.uk/sky/",
-	"skype":                    "https://rdap.nominet.uk/skype/",
-	"smile":                    "https://rdap.nominet.uk/smile/",
-	"spot":                     "https://rdap.nominet.uk/spot/",
-	"swatch":                   "https://rdap.nominet.uk/swatch/",
-	"talk":                     "https://rdap.nominet.uk/talk/",
-	"tci":                      "https://rdap.nominet.uk/tci/",
-	"tunes":                    "https://rdap.nominet.uk/tunes/",
-	"tushu":                    "https://rdap.nominet.uk/tushu/",
-	"uk":                       "https://rdap.nominet.uk/uk/",
-	"virgin":                   "https://rdap.nominet.uk/virgin/",
-	"wales":                    "https://rdap.nominet.uk/wales/",
-	"wanggou":                  "https://rdap.nominet.uk/wanggou/",
-	"wed":                      "https://rdap.nominet.uk/wed/",
-	"windows":                  "https://rdap.nominet.uk/windows/",
-	"wow":                      "https://rdap.nominet.uk/wow/",
-	"xbox":                     "https://rdap.nominet.uk/xbox/",
-	"xn--cckwcxetd":            "https://rdap.nominet.uk/xn--cckwcxetd/",
-	"xn--jlq480n2rg":           "https://rdap.nominet.uk/xn--jlq480n2rg/",
-	"xn--mgbt3dhd":             "https://rdap.nominet.uk/xn--mgbt3dhd/",
-	"yamaxun":                  "https://rdap.nominet.uk/yamaxun/",
-	"you":                      "https://rdap.nominet.uk/you/",
-	"zappos":                   "https://rdap.nominet.uk/zappos/",
-	"no":                       "https://rdap.norid.no/",
-	"id":                       "https://rdap.pandi.id/rdap/",
-	"charity":                  "https://rdap.publicinterestregistry.org/rdap/",
-	"foundation":               "https://rdap.publicinterestregistry.org/rdap/",
-	"gives":                    "https://rdap.publicinterestregistry.org/rdap/",
-	"giving":                   "https://rdap.publicinterestregistry.org/rdap/",
-	"ngo":                      "https://rdap.publicinterestregistry.org/rdap/",
-	"ong":                      "https://rdap.publicinterestregistry.org/rdap/",
-	"org":                      "https://rdap.publicinterestregistry.org/rdap/",
-	"xn--c1avg":                "https://rdap.publicinterestregistry.org/rdap/",
-	"xn--i1b6b1a6a2e":          "https://rdap.publicinterestregistry.org/rdap/",
-	"xn--nqv7f":                "https://rdap.publicinterestregistry.org/rdap/",
-	"xn--nqv7fs00ema":          "https://rdap.publicinterestregistry.org/rdap/",
-	"fun":                      "https://rdap.radix.host/rdap/",
-	"host":                     "https://rdap.radix.host/rdap/",
-	"online":                   "https://rdap.radix.host/rdap/",
-	"press":                    "https://rdap.radix.host/rdap/",
-	"pw":                       "https://rdap.radix.host/rdap/",
-	"site":                     "https://rdap.radix.host/rdap/",
-	"space":                    "https://rdap.radix.host/rdap/",
-	"store":                    "https://rdap.radix.host/rdap/",
-	"tech":                     "https://rdap.radix.host/rdap/",
-	"uno":                      "https://rdap.radix.host/rdap/",
-	"website":                  "https://rdap.radix.host/rdap/",
-	"si":                       "https://rdap.register.si/",
-	"br":                       "https://rdap.registro.br/",
-	"bar":                      "https://rdap.registry.bar/rdap/",
-	"rest":                     "https://rdap.registry.bar/rdap/",
-	"click":                    "https://rdap.registry.click/rdap/",
-	"country":                  "https://rdap.registry.click/rdap/",
-	"diy":                      "https://rdap.registry.click/rdap/",
-	"feedback":                 "https://rdap.registry.click/rdap/",
-	"food":                     "https://rdap.registry.click/rdap/",
-	"forum":                    "https://rdap.registry.click/rdap/",
-	"hiv":                      "https://rdap.registry.click/rdap/",
-	"lifestyle":                "https://rdap.registry.click/rdap/",
-	"living":                   "https://rdap.registry.click/rdap/",
-	"observer":                 "https://rdap.registry.click/rdap/",
-	"pid":                      "https://rdap.registry.click/rdap/",
-	"property":                 "https://rdap.registry.click/rdap/",
-	"realty":                   "https://rdap.registry.click/rdap/",
-	"sexy":                     "https://rdap.registry.click/rdap/",
-	"trust":                    "https://rdap.registry.click/rdap/",
-	"vana":                     "https://rdap.registry.click/rdap/",
-	"cloud":                    "https://rdap.registry.cloud/rdap/",
-	"coop":                     "https://rdap.registry.coop/rdap/",
-	"creditunion":              "https://rdap.registry.coop/rdap/",
-	"ec":                       "https://rdap.registry.ec/",
-	"gift":                     "https://rdap.registry.gift/rdap/",
-	"gy":                       "https://rdap.registry.gy/",
-	"hiphop":                   "https://rdap.registry.hiphop/rdap/",
-	"kfh":                      "https://rdap.registry.kfh/rdap/",
-	"xn--ngbe9e0a":             "https://rdap.registry.kfh/rdap/",
-	"love":                     "https://rdap.registry.love/rdap/",
-	"music":                    "https://rdap.registryservices.music/rdap/",
-	"rw":                       "https://rdap.ricta.org.rw/",
-	"cologne":                  "https://rdap.ryce-rsp.com/rdap/",
-	"koeln":                    "https://rdap.ryce-rsp.com/rdap/",
-	"tirol":                    "https://rdap.ryce-rsp.com/rdap/",
-	"wien":                     "https://rdap.ryce-rsp.com/rdap/",
-	"sg":                       "https://rdap.sgnic.sg/rdap/",
-	"nl":                       "https://rdap.sidn.nl/",
-	"xn--clchc0ea0b2g2a9gcd":   "https://rdap.ta.sgnic.sg/rdap/",
-	"anquan":                   "https://rdap.teleinfo.cn/",
-	"shouji":                   "https://rdap.teleinfo.cn/",
-	"xihuan":                   "https://rdap.teleinfo.cn/",
-	"xn--vuq861b":              "https://rdap.teleinfo.cn/",
-	"yun":                      "https://rdap.teleinfo.cn/",
-	"xn--3ds443g":              "https://rdap.teleinfo.cn/xn--3ds443g/",
-	"xn--fiq228c5hs":           "https://rdap.teleinfo.cn/xn--fiq228c5hs/",
-	"xn--kput3i":               "https://rdap.teleinfo.cn/xn--kput3i/",
-	"xn--nyqy26a":              "https://rdap.teleinfo.cn/xn--nyqy26a/",
-	"xn--rhqv96g":              "https://rdap.teleinfo.cn/xn--rhqv96g/",
-	"th":                       "https://rdap.thains.co.th/",
-	"xn--o3cw4h":               "https://rdap.thains.co.th/",
-	"to":                       "https://rdap.tonicregistry.to/rdap/",
-	"xn--mxtq1m":               "https://rdap.twnic.tw/rdap/",
-	"tz":                       "https://rdap.tznic.or.tz/",
-	"link":                     "https://rdap.uniregistry.net/rdap/",
-	"com":                      "https://rdap.verisign.com/com/v1/",
-	"net":                      "https://rdap.verisign.com/net/v1/",
-	"ye":                       "https://rdap.y.net.ye/",
-	"xn--45q11c":               "https://rdap.zdnsgtld.com/XN--45Q11C/",
-	"xn--efvy88h":              "https://rdap.zdnsgtld.com/XN--EFVY88H/",
-	"baidu":                    "https://rdap.zdnsgtld.com/baidu/",
-	"citic":                    "https://rdap.zdnsgtld.com/citic/",
-	"icbc":                     "https://rdap.zdnsgtld.com/icbc/",
-	"ren":                      "https://rdap.zdnsgtld.com/ren/",
-	"sohu":                     "https://rdap.zdnsgtld.com/sohu/",
-	"top":                      "https://rdap.zdnsgtld.com/top/",
-	"unicom":                   "https://rdap.zdnsgtld.com/unicom/",
-	"wang":                     "https://rdap.zdnsgtld.com/wang/",
-	"xn--30rr7y":               "https://rdap.zdnsgtld.com/xn--30rr7y/",
-	"xn--3bst00m":              "https://rdap.zdnsgtld.com/xn--3bst00m/",
-	"xn--6qq986b3xl":           "https://rdap.zdnsgtld.com/xn--6qq986b3xl/",
-	"xn--8y0a063a":             "https://rdap.zdnsgtld.com/xn--8y0a063a/",
-	"xn--9et52u":               "https://rdap.zdnsgtld.com/xn--9et52u/",
-	"xn--czr694b":              "https://rdap.zdnsgtld.com/xn--czr694b/",
-	"xn--czru2d":               "https://rdap.zdnsgtld.com/xn--czru2d/",
-	"xn--fiq64b":               "https://rdap.zdnsgtld.com/xn--fiq64b/",
-	"xn--hxt814e":              "https://rdap.zdnsgtld.com/xn--hxt814e/",
-	"xn--imr513n":              "https://rdap.zdnsgtld.com/xn--imr513n/",
-	"xn--otu796d":              "https://rdap.zdnsgtld.com/xn--otu796d/",
-	"xn--ses554g":              "https://rdap.zdnsgtld.com/xn--ses554g/",
-	"xn--yfro4i67o":            "https://rdap.zh.sgnic.sg/rdap/",
-	"xn--1qqw23a":              "https://restwhois.ngtld.cn/",
-	"xn--xhq521b":              "https://restwhois.ngtld.cn/",
-	"cc":                       "https://tld-rdap.verisign.com/cc/v1/",
-	"comsec":                   "https://tld-rdap.verisign.com/comsec/v1/",
-	"name":                     "https://tld-rdap.verisign.com/name/v1/",
-	"verisign":                 "https://tld-rdap.verisign.com/verisign/v1/",
-	"web":                      "https://tld-rdap.verisign.com/web/v1/",
-	"xn--11b4c3d":              "https://tld-rdap.verisign.com/xn--11b4c3d/v1/",
-	"xn--3pxu8k":               "https://tld-rdap.verisign.com/xn--3pxu8k/v1/",
-	"xn--42c2d9a":              "https://tld-rdap.verisign.com/xn--42c2d9a/v1/",
-	"xn--9dbq2a":               "https://tld-rdap.verisign.com/xn--9dbq2a/v1/",
-	"xn--c2br7g":               "https://tld-rdap.verisign.com/xn--c2br7g/v1/",
-	"xn--fhbei":                "https://tld-rdap.verisign.com/xn--fhbei/v1/",
-	"xn--j1aef":                "https://tld-rdap.verisign.com/xn--j1aef/v1/",
-	"xn--mk1bu44c":             "https://tld-rdap.verisign.com/xn--mk1bu44c/v1/",
-	"xn--pssy2u":               "https://tld-rdap.verisign.com/xn--pssy2u/v1/",
-	"xn--t60b56a":              "https://tld-rdap.verisign.com/xn--t60b56a/v1/",
-	"xn--tckwe":                "https://tld-rdap.verisign.com/xn--tckwe/v1/",
-	"ky":                       "https://whois.kyregistry.ky/rdap/",
-	"mtr":                      "https://whois.nic.mtr/rdap/",
-	"tatar":                    "https://whois.nic.tatar/rdap/",
-	"xn--d1acj3b":              "https://whois.nic.xn--d1acj3b/rdap/",
-	"sr":                       "https://whois.sr/rdap/",
-	"fj":                       "https://www.rdap.fj/",
+	"kg":                       {"http://rdap.cctld.kg/"},
+	"mg":                       {"http://rdap.nic.mg/"},
+	"xn--kpry57d":              {"https://ccrdap.twnic.tw/taiwan/"},
+	"tw":                       {"https://ccrdap.twnic.tw/tw/"},
+	"na":                       {"https://keetmans.omadhina.co.na/"},
+	"samsung":                  {"https://nic.samsung/rdap/"},
+	"xn--cg4bki":               {"https://nic.samsung/rdap/"},
+	"ads":                      {"https://pubapi.registry.google/rdap/"},
+	"android":                  {"https://pubapi.registry.google/rdap/"},
+	"app":                      {"https://pubapi.registry.google/rdap/"},
+	"boo":                      {"https://pubapi.registry.google/rdap/"},
+	"cal":                      {"https://pubapi.registry.google/rdap/"},
+	"channel":                  {"https://pubapi.registry.google/rdap/"},
+	"chrome":                   {"https://pubapi.registry.google/rdap/"},
+	"dad":                      {"https://pubapi.registry.google/rdap/"},
+	"day":                      {"https://pubapi.registry.google/rdap/"},
+	"dclk":                     {"https://pubapi.registry.google/rdap/"},
+	"dev":                      {"https://pubapi.registry.google/rdap/"},
+	"docs":                     {"https://pubapi.registry.google/rdap/"},
+	"drive":                    {"https://pubapi.registry.google/rdap/"},
+	"eat":                      {"https://pubapi.registry.google/rdap/"},
+	"esq":                      {"https://pubapi.registry.google/rdap/"},
+	"fly":                      {"https://pubapi.registry.google/rdap/"},
+	"foo":                      {"https://pubapi.registry.google/rdap/"},
+	"gbiz":                     {"https://pubapi.registry.google/rdap/"},
+	"gle":                      {"https://pubapi.registry.google/rdap/"},
+	"gmail":                    {"https://pubapi.registry.google/rdap/"},
+	"goog":                     {"https://pubapi.registry.google/rdap/"},
+	"google":                   {"https://pubapi.registry.google/rdap/"},
+	"guge":                     {"https://pubapi.registry.google/rdap/"},
+	"hangout":                  {"https://pubapi.registry.google/rdap/"},
+	"here":                     {"https://pubapi.registry.google/rdap/"},
+	"how":                      {"https://pubapi.registry.google/rdap/"},
+	"ing":                      {"https://pubapi.registry.google/rdap/"},
+	"map":                      {"https://pubapi.registry.google/rdap/"},
+	"meet":                     {"https://pubapi.registry.google/rdap/"},
+	"meme":                     {"https://pubapi.registry.google/rdap/"},
+	"mov":                      {"https://pubapi.registry.google/rdap/"},
+	"new":                      {"https://pubapi.registry.google/rdap/"},
+	"nexus":                    {"https://pubapi.registry.google/rdap/"},
+	"page":                     {"https://pubapi.registry.google/rdap/"},
+	"phd":                      {"https://pubapi.registry.google/rdap/"},
+	"play":                     {"https://pubapi.registry.google/rdap/"},
+	"prod":                     {"https://pubapi.registry.google/rdap/"},
+	"prof":                     {"https://pubapi.registry.google/rdap/"},
+	"rsvp":                     {"https://pubapi.registry.google/rdap/"},
+	"search":                   {"https://pubapi.registry.google/rdap/"},
+	"soy":                      {"https://pubapi.registry.google/rdap/"},
+	"xn--flw351e":              {"https://pubapi.registry.google/rdap/"},
+	"xn--q9jyb4c":              {"https://pubapi.registry.google/rdap/"},
+	"xn--qcka1pmc":             {"https://pubapi.registry.google/rdap/"},
+	"youtube":                  {"https://pubapi.registry.google/rdap/"},
+	"zip":                      {"https://pubapi.registry.google/rdap/"},
+	"merck":                    {"https://rdap-merck.dns.business/rdap/"},
+	"alibaba":                  {"https://rdap.aliregistry.cn/rdap/"},
+	"blog":                     {"https://rdap.blog.fury.ca/rdap/"},
+	"ca":                       {"https://rdap.ca.fury.ca/rdap/"},
+	"au":                       {"https://rdap.cctld.au/rdap/"},
+	"uz":                       {"https://rdap.cctld.uz/"},
+	"allfinanz":                {"https://rdap.centralnic.com/allfinanz/"},
+	"art":                      {"https://rdap.centralnic.com/art/"},
+	"audio":                    {"https://rdap.centralnic.com/audio/"},
+	"auto":                     {"https://rdap.centralnic.com/auto/"},
+	"autos":                    {"https://rdap.centralnic.com/autos/"},
+	"baby":                     {"https://rdap.centralnic.com/baby/"},
+	"beauty":                   {"https://rdap.centralnic.com/beauty/"},
+	"best":                     {"https://rdap.centralnic.com/best/"},
+	"bmw":                      {"https://rdap.centralnic.com/bmw/"},
+	"boats":                    {"https://rdap.centralnic.com/boats/"},
+	"bond":                     {"https://rdap.centralnic.com/bond/"},
+	"box":                      {"https://rdap.centralnic.com/box/"},
+	"build":                    {"https://rdap.centralnic.com/build/"},
+	"cam":                      {"https://rdap.centralnic.com/cam/"},
+	"car":                      {"https://rdap.centralnic.com/car/"},
+	"cars":                     {"https://rdap.centralnic.com/cars/"},
+	"case":                     {"https://rdap.centralnic.com/case/"},
+	"ceo":                      {"https://rdap.centralnic.com/ceo/"},
+	"cfd":                      {"https://rdap.centralnic.com/cfd/"},
+	"christmas":                {"https://rdap.centralnic.com/christmas/"},
+	"college":                  {"https://rdap.centralnic.com/college/"},
+	"cyou":                     {"https://rdap.centralnic.com/cyou/"},
+	"dealer":                   {"https://rdap.centralnic.com/dealer/"},
+	"deloitte":                 {"https://rdap.centralnic.com/deloitte/"},
+	"dhl":                      {"https://rdap.centralnic.com/dhl/"},
+	"diet":                     {"https://rdap.centralnic.com/diet/"},
+	"dvag":                     {"https://rdap.centralnic.com/dvag/"},
+	"fans":                     {"https://rdap.centralnic.com/fans/"},
+	"flowers":                  {"https://rdap.centralnic.com/flowers/"},
+	"fm":                       {"https://rdap.centralnic.com/fm/"},
+	"fo":                       {"https://rdap.centralnic.com/fo/"},
+	"fresenius":                {"https://rdap.centralnic.com/fresenius/"},
+	"frl":                      {"https://rdap.centralnic.com/frl/"},
+	"game":                     {"https://rdap.centralnic.com/game/"},
+	"gd":                       {"https://rdap.centralnic.com/gd/"},
+	"gent":                     {"https://rdap.centralnic.com/gent/"},
+	"guitars":                  {"https://rdap.centralnic.com/guitars/"},
+	"hair":                     {"https://rdap.centralnic.com/hair/"},
+	"help":                     {"https://rdap.centralnic.com/help/"},
+	"homes":                    {"https://rdap.centralnic.com/homes/"},
+	"hosting":                  {"https://rdap.centralnic.com/hosting/"},
+	"icu":                      {"https://rdap.centralnic.com/icu/"},
+	"inc":                      {"https://rdap.centralnic.com/inc/"},
+	"kpn":                      {"https://rdap.centralnic.com/kpn/"},
+	"kred":                     {"https://rdap.centralnic.com/kred/"},
+	"lat":                      {"https://rdap.centralnic.com/lat/"},
+	"lidl":                     {"https://rdap.centralnic.com/lidl/"},
+	"llp":                      {"https://rdap.centralnic.com/llp/"},
+	"lol":                      {"https://rdap.centralnic.com/lol/"},
+	"london":                   {"https://rdap.centralnic.com/london/"},
+	"lpl":                      {"https://rdap.centralnic.com/lpl/"},
+	"lplfinancial":             {"https://rdap.centralnic.com/lplfinancial/"},
+	"luxury":                   {"https://rdap.centralnic.com/luxury/"},
+	"makeup":                   {"https://rdap.centralnic.com/makeup/"},
+	"mini":                     {"https://rdap.centralnic.com/mini/"},
+	"mom":                      {"https://rdap.centralnic.com/mom/"},
+	"monster":                  {"https://rdap.centralnic.com/monster/"},
+	"motorcycles":              {"https://rdap.centralnic.com/motorcycles/"},
+	"nokia":                    {"https://rdap.centralnic.com/nokia/"},
+	"ooo":                      {"https://rdap.centralnic.com/ooo/"},
+	"pics":                     {"https://rdap.centralnic.com/pics/"},
+	"pohl":                     {"https://rdap.centralnic.com/pohl/"},
+	"protection":               {"https://rdap.centralnic.com/protection/"},
+	"qpon":                     {"https://rdap.centralnic.com/qpon/"},
+	"quest":                    {"https://rdap.centralnic.com/quest/"},
+	"reit":                     {"https://rdap.centralnic.com/reit/"},
+	"rent":                     {"https://rdap.centralnic.com/rent/"},
+	"ruhr":                     {"https://rdap.centralnic.com/ruhr/"},
+	"saarland":                 {"https://rdap.centralnic.com/saarland/"},
+	"sbs":                      {"https://rdap.centralnic.com/sbs/"},
+	"schwarz":                  {"https://rdap.centralnic.com/schwarz/"},
+	"security":                 {"https://rdap.centralnic.com/security/"},
+	"sfr":                      {"https://rdap.centralnic.com/sfr/"},
+	"skin":                     {"https://rdap.centralnic.com/skin/"},
+	"smart":                    {"https://rdap.centralnic.com/smart/"},
+	"stc":                      {"https://rdap.centralnic.com/stc/"},
+	"stcgroup":                 {"https://rdap.centralnic.com/stcgroup/"},
+	"storage":                  {"https://rdap.centralnic.com/storage/"},
+	"theatre":                  {"https://rdap.centralnic.com/theatre/"},
+	"tickets":                  {"https://rdap.centralnic.com/tickets/"},
+	"tui":                      {"https://rdap.centralnic.com/tui/"},
+	"vg":                       {"https://rdap.centralnic.com/vg/"},
+	"viva":                     {"https://rdap.centralnic.com/viva/"},
+	"wme":                      {"https://rdap.centralnic.com/wme/"},
+	"xn--4gbrim":               {"https://rdap.centralnic.com/xn--4gbrim/"},
+	"xn--vermgensberater-ctb":  {"https://rdap.centralnic.com/xn--vermgensberater-ctb/"},
+	"xn--vermgensberatung-pwb": {"https://rdap.centralnic.com/xn--vermgensberatung-pwb/"},
+	"xyz":                      {"https://rdap.centralnic.com/xyz/"},
+	"yachts":                   {"https://rdap.centralnic.com/yachts/"},
+	"zuerich":                  {"https://rdap.centralnic.com/zuerich/"},
+	"jnj":                      {"https://rdap.centralnicregistry.com/jnj/"},
+	"crown":                    {"https://rdap.crown.fury.ca/rdap/"},
+	"pl":                       {"https://rdap.dns.pl/"},
+	"eco":                      {"https://rdap.eco.fury.ca/rdap/"},
+	"fi":                       {"https://rdap.fi/rdap/rdap/"},
+	"moscow":                   {"https://rdap.flexireg.net/"},
+	"xn--80adxhks":             {"https://rdap.flexireg.net/"},
+	"bridgestone":              {"https://rdap.gmoregistry.net/rdap/"},
+	"brother":                  {"https://rdap.gmoregistry.net/rdap/"},
+	"canon":                    {"https://rdap.gmoregistry.net/rdap/"},
+	"datsun":                   {"https://rdap.gmoregistry.net/rdap/"},
+	"dnp":                      {"https://rdap.gmoregistry.net/rdap/"},
+	"epson":                    {"https://rdap.gmoregistry.net/rdap/"},
+	"firestone":                {"https://rdap.gmoregistry.net/rdap/"},
+	"fujitsu":                  {"https://rdap.gmoregistry.net/rdap/"},
+	"ggee":                     {"https://rdap.gmoregistry.net/rdap/"},
+	"gmo":                      {"https://rdap.gmoregistry.net/rdap/"},
+	"goldpoint":                {"https://rdap.gmoregistry.net/rdap/"},
+	"hisamitsu":                {"https://rdap.gmoregistry.net/rdap/"},
+	"hitachi":                  {"https://rdap.gmoregistry.net/rdap/"},
+	"honda":                    {"https://rdap.gmoregistry.net/rdap/"},
+	"hyundai":                  {"https://rdap.gmoregistry.net/rdap/"},
+	"infiniti":                 {"https://rdap.gmoregistry.net/rdap/"},
+	"jcb":                      {"https://rdap.gmoregistry.net/rdap/"},
+	"kddi":                     {"https://rdap.gmoregistry.net/rdap/"},
+	"kia":                      {"https://rdap.gmoregistry.net/rdap/"},
+	"komatsu":                  {"https://rdap.gmoregistry.net/rdap/"},
+	"kyoto":                    {"https://rdap.gmoregistry.net/rdap/"},
+	"lexus":                    {"https://rdap.gmoregistry.net/rdap/"},
+	"lotte":                    {"https://rdap.gmoregistry.net/rdap/"},
+	"mitsubishi":               {"https://rdap.gmoregistry.net/rdap/"},
+	"nagoya":                   {"https://rdap.gmoregistry.net/rdap/"},
+	"nec":                      {"https://rdap.gmoregistry.net/rdap/"},
+	"nhk":                      {"https://rdap.gmoregistry.net/rdap/"},
+	"nico":                     {"https://rdap.gmoregistry.net/rdap/"},
+	"nissan":                   {"https://rdap.gmoregistry.net/rdap/"},
+	"okinawa":                  {"https://rdap.gmoregistry.net/rdap/"},
+	"otsuka":                   {"https://rdap.gmoregistry.net/rdap/"},
+	"panasonic":                {"https://rdap.gmoregistry.net/rdap/"},
+	"playstation":              {"https://rdap.gmoregistry.net/rdap/"},
+	"ricoh":                    {"https://rdap.gmoregistry.net/rdap/"},
+	"ryukyu":                   {"https://rdap.gmoregistry.net/rdap/"},
+	"sharp":                    {"https://rdap.gmoregistry.net/rdap/"},
+	"shop":                     {"https://rdap.gmoregistry.net/rdap/"},
+	"softbank":                 {"https://rdap.gmoregistry.net/rdap/"},
+	"sony":                     {"https://rdap.gmoregistry.net/rdap/"},
+	"suzuki":                   {"https://rdap.gmoregistry.net/rdap/"},
+	"tokyo":                    {"https://rdap.gmoregistry.net/rdap/"},
+	"toray":                    {"https://rdap.gmoregistry.net/rdap/"},
+	"toshiba":                  {"https://rdap.gmoregistry.net/rdap/"},
+	"toyota":                   {"https://rdap.gmoregistry.net/rdap/"},
+	"yodobashi":                {"https://rdap.gmoregistry.net/rdap/"},
+	"yokohama":                 {"https://rdap.gmoregistry.net/rdap/"},
+	"bom":                      {"https://rdap.gtlds.nic.br/"},
+	"final":                    {"https://rdap.gtlds.nic.br/"},
+	"globo":                    {"https://rdap.gtlds.nic.br/"},
+	"rio":                      {"https://rdap.gtlds.nic.br/"},
+	"uol":                      {"https://rdap.gtlds.nic.br/"},
+	"ua":                       {"https://rdap.hostmaster.ua/"},
+	"int":                      {"https://rdap.iana.org/"},
+	"abb":                      {"https://rdap.identitydigital.services/rdap/"},
+	"abbott":                   {"https://rdap.identitydigital.services/rdap/"},
+	"abc":                      {"https://rdap.identitydigital.services/rdap/"},
+	"academy":                  {"https://rdap.identitydigital.services/rdap/"},
+	"accenture":                {"https://rdap.identitydigital.services/rdap/"},
+	"accountants":              {"https://rdap.identitydigital.services/rdap/"},
+	"actor":                    {"https://rdap.identitydigital.services/rdap/"},
+	"aeg":                      {"https://rdap.identitydigital.services/rdap/"},
+	"aero":                     {"https://rdap.identitydigital.services/rdap/"},
+	"agakhan":                  {"https://rdap.identitydigital.services/rdap/"},
+	"agency":                   {"https://rdap.identitydigital.services/rdap/"},
+	"ai":                       {"https://rdap.identitydigital.services/rdap/"},
+	"airbus":                   {"https://rdap.identitydigital.services/rdap/"},
+	"airforce":                 {"https://rdap.identitydigital.services/rdap/"},
+	"akdn":                     {"https://rdap.identitydigital.services/rdap/"},
+	"alipay":                   {"https://rdap.identitydigital.services/rdap/"},
+	"allstate":                 {"https://rdap.identitydigital.services/rdap/"},
+	"aol":                      {"https://rdap.identitydigital.services/rdap/"},
+	"apartments":               {"https://rdap.identitydigital.services/rdap/"},
+	"archi":                    {"https://rdap.identitydigital.services/rdap/"},
+	"army":                     {"https://rdap.identitydigital.services/rdap/"},
+	"arte":                     {"https://rdap.identitydigital.services/rdap/"},
+	"asda":                     {"https://rdap.identitydigital.services/rdap/"},
+	"asia":                     {"https://rdap.identitydigital.services/rdap/"},
+	"associates":               {"https://rdap.identitydigital.services/rdap/"},
+	"attorney":                 {"https://rdap.identitydigital.services/rdap/"},
+	"auction":                  {"https://rdap.identitydigital.services/rdap/"},
+	"audi":                     {"https://rdap.identitydigital.services/rdap/"},
+	"band":                     {"https://rdap.identitydigital.services/rdap/"},
+	"barclaycard":              {"https://rdap.identitydigital.services/rdap/"},
+	"barclays":                 {"https://rdap.identitydigital.services/rdap/"},
+	"barefoot":                 {"https://rdap.identitydigital.services/rdap/"},
+	"bargains":                 {"https://rdap.identitydigital.services/rdap/"},
+	"bbt":                      {"https://rdap.identitydigital.services/rdap/"},
+	"bcg":                      {"https://rdap.identitydigital.services/rdap/"},
+	"beats":                    {"https://rdap.identitydigital.services/rdap/"},
+	"bestbuy":                  {"https://rdap.identitydigital.services/rdap/"},
+	"bet":                      {"https://rdap.identitydigital.services/rdap/"},
+	"bike":                     {"https://rdap.identitydigital.services/rdap/"},
+	"bingo":                    {"https://rdap.identitydigital.services/rdap/"},
+	"bio":                      {"https://rdap.identitydigital.services/rdap/"},
+	"black":                    {"https://rdap.identitydigital.services/rdap/"},
+	"bloomberg":                {"https://rdap.identitydigital.services/rdap/"},
+	"blue":                     {"https://rdap.identitydigital.services/rdap/"},
+	"bm":                       {"https://rdap.identitydigital.services/rdap/"},
+	"bms":                      {"https://rdap.identitydigital.services/rdap/"},
+	"bnpparibas":               {"https://rdap.identitydigital.services/rdap/"},
+	"boehringer":               {"https://rdap.identitydigital.services/rdap/"},
+	"bofa":                     {"https://rdap.identitydigital.services/rdap/"},
+	"bosch":                    {"https://rdap.identitydigital.services/rdap/"},
+	"boutique":                 {"https://rdap.identitydigital.services/rdap/"},
+	"bradesco":                 {"https://rdap.identitydigital.services/rdap/"},
+	"broker":                   {"https://rdap.identitydigital.services/rdap/"},
+	"builders":                 {"https://rdap.identitydigital.services/rdap/"},
+	"business":                 {"https://rdap.identitydigital.services/rdap/"},
+	"cab":                      {"https://rdap.identitydigital.services/rdap/"},
+	"cafe":                     {"https://rdap.identitydigital.services/rdap/"},
+	"camera":                   {"https://rdap.identitydigital.services/rdap/"},
+	"camp":                     {"https://rdap.identitydigital.services/rdap/"},
+	"capital":                  {"https://rdap.identitydigital.services/rdap/"},
+	"cards":                    {"https://rdap.identitydigital.services/rdap/"},
+	"care":                     {"https://rdap.identitydigital.services/rdap/"},
+	"careers":                  {"https://rdap.identitydigital.services/rdap/"},
+	"cash":                     {"https://rdap.identitydigital.services/rdap/"},
+	"casino":                   {"https://rdap.identitydigital.services/rdap/"},
+	"catering":                 {"https://rdap.identitydigital.services/rdap/"},
+	"center":                   {"https://rdap.identitydigital.services/rdap/"},
+	"cern":                     {"https://rdap.identitydigital.services/rdap/"},
+	"cfa":                      {"https://rdap.identitydigital.services/rdap/"},
+	"chanel":                   {"https://rdap.identitydigital.services/rdap/"},
+	"chat":                     {"https://rdap.identitydigital.services/rdap/"},
+	"cheap":                    {"https://rdap.identitydigital.services/rdap/"},
+	"church":                   {"https://rdap.identitydigital.services/rdap/"},
+	"cipriani":                 {"https://rdap.identitydigital.services/rdap/"},
+	"circle":                   {"https://rdap.identitydigital.services/rdap/"},
+	"citadel":                  {"https://rdap.identitydigital.services/rdap/"},
+	"city":                     {"https://rdap.identitydigital.services/rdap/"},
+	"claims":                   {"https://rdap.identitydigital.services/rdap/"},
+	"cleaning":                 {"https://rdap.identitydigital.services/rdap/"},
+	"clinic":                   {"https://rdap.identitydigital.services/rdap/"},
+	"clinique":                 {"https://rdap.identitydigital.services/rdap/"},
+	"clothing":                 {"https://rdap.identitydigital.services/rdap/"},
+	"clubmed":                  {"https://rdap.identitydigital.services/rdap/"},
+	"coach":                    {"https://rdap.identitydigital.services/rdap/"},
+	"codes":                    {"https://rdap.identitydigital.services/rdap/"},
+	"coffee":                   {"https://rdap.identitydigital.services/rdap/"},
+	"community":                {"https://rdap.identitydigital.services/rdap/"},
+	"company":                  {"https://rdap.identitydigital.services/rdap/"},
+	"computer":                 {"https://rdap.identitydigital.services/rdap/"},
+	"condos":                   {"https://rdap.identitydigital.services/rdap/"},
+	"construction":             {"https://rdap.identitydigital.services/rdap/"},
+	"consulting":               {"https://rdap.identitydigital.services/rdap/"},
+	"contact":                  {"https://rdap.identitydigital.services/rdap/"},
+	"contractors":              {"https://rdap.identitydigital.services/rdap/"},
+	"cool":                     {"https://rdap.identitydigital.services/rdap/"},
+	"coupon":                   {"https://rdap.identitydigital.services/rdap/"},
+	"coupons":                  {"https://rdap.identitydigital.services/rdap/"},
+	"credit":                   {"https://rdap.identitydigital.services/rdap/"},
+	"creditcard":               {"https://rdap.identitydigital.services/rdap/"},
+	"crs":                      {"https://rdap.identitydigital.services/rdap/"},
+	"cruise":                   {"https://rdap.identitydigital.services/rdap/"},
+	"cruises":                  {"https://rdap.identitydigital.services/rdap/"},
+	"dance":                    {"https://rdap.identitydigital.services/rdap/"},
+	"dating":                   {"https://rdap.identitydigital.services/rdap/"},
+	"deals":                    {"https://rdap.identitydigital.services/rdap/"},
+	"degree":                   {"https://rdap.identitydigital.services/rdap/"},
+	"delivery":                 {"https://rdap.identitydigital.services/rdap/"},
+	"delta":                    {"https://rdap.identitydigital.services/rdap/"},
+	"democrat":                 {"https://rdap.identitydigital.services/rdap/"},
+	"dental":                   {"https://rdap.identitydigital.services/rdap/"},
+	"dentist":                  {"https://rdap.identitydigital.services/rdap/"},
+	"diamonds":                 {"https://rdap.identitydigital.services/rdap/"},
+	"digital":                  {"https://rdap.identitydigital.services/rdap/"},
+	"direct":                   {"https://rdap.identitydigital.services/rdap/"},
+	"directory":                {"https://rdap.identitydigital.services/rdap/"},
+	"discount":                 {"https://rdap.identitydigital.services/rdap/"},
+	"discover":                 {"https://rdap.identitydigital.services/rdap/"},
+	"doctor":                   {"https://rdap.identitydigital.services/rdap/"},
+	"dog":                      {"https://rdap.identitydigital.services/rdap/"},
+	"domains":                  {"https://rdap.identitydigital.services/rdap/"},
+	"edeka":                    {"https://rdap.identitydigital.services/rdap/"},
+	"education":                {"https://rdap.identitydigital.services/rdap/"},
+	"email":                    {"https://rdap.identitydigital.services/rdap/"},
+	"emerck":                   {"https://rdap.identitydigital.services/rdap/"},
+	"energy":                   {"https://rdap.identitydigital.services/rdap/"},
+	"engineer":                 {"https://rdap.identitydigital.services/rdap/"},
+	"engineering":              {"https://rdap.identitydigital.services/rdap/"},
+	"enterprises":              {"https://rdap.identitydigital.services/rdap/"},
+	"equipment":                {"https://rdap.identitydigital.services/rdap/"},
+	"ericsson":                 {"https://rdap.identitydigital.services/rdap/"},
+	"estate":                   {"https://rdap.identitydigital.services/rdap/"},
+	"events":                   {"https://rdap.identitydigital.services/rdap/"},
+	"exchange":                 {"https://rdap.identitydigital.services/rdap/"},
+	"expert":                   {"https://rdap.identitydigital.services/rdap/"},
+	"exposed":                  {"https://rdap.identitydigital.services/rdap/"},
+	"express":                  {"https://rdap.identitydigital.services/rdap/"},
+	"extraspace":               {"https://rdap.identitydigital.services/rdap/"},
+	"fage":                     {"https://rdap.identitydigital.services/rdap/"},
+	"fail":                     {"https://rdap.identitydigital.services/rdap/"},
+	"family":                   {"https://rdap.identitydigital.services/rdap/"},
+	"fan":                      {"https://rdap.identitydigital.services/rdap/"},
+	"farm":                     {"https://rdap.identitydigital.services/rdap/"},
+	"fedex":                    {"https://rdap.identitydigital.services/rdap/"},
+	"ferrari":                  {"https://rdap.identitydigital.services/rdap/"},
+	"fidelity":                 {"https://rdap.identitydigital.services/rdap/"},
+	"fido":                     {"https://rdap.identitydigital.services/rdap/"},
+	"finance":                  {"https://rdap.identitydigital.services/rdap/"},
+	"financial":                {"https://rdap.identitydigital.services/rdap/"},
+	"fish":                     {"https://rdap.identitydigital.services/rdap/"},
+	"fitness":                  {"https://rdap.identitydigital.services/rdap/"},
+	"flights":                  {"https://rdap.identitydigital.services/rdap/"},
+	"florist":                  {"https://rdap.identitydigital.services/rdap/"},
+	"football":                 {"https://rdap.identitydigital.services/rdap/"},
+	"forex":                    {"https://rdap.identitydigital.services/rdap/"},
+	"forsale":                  {"https://rdap.identitydigital.services/rdap/"},
+	"frogans":                  {"https://rdap.identitydigital.services/rdap/"},
+	"fund":                     {"https://rdap.identitydigital.services/rdap/"},
+	"furniture":                {"https://rdap.identitydigital.services/rdap/"},
+	"futbol":                   {"https://rdap.identitydigital.services/rdap/"},
+	"fyi":                      {"https://rdap.identitydigital.services/rdap/"},
+	"gallery":                  {"https://rdap.identitydigital.services/rdap/"},
+	"gallo":                    {"https://rdap.identitydigital.services/rdap/"},
+	"gallup":                   {"https://rdap.identitydigital.services/rdap/"},
+	"games":                    {"https://rdap.identitydigital.services/rdap/"},
+	"genting":                  {"https://rdap.identitydigital.services/rdap/"},
+	"gifts":                    {"https://rdap.identitydigital.services/rdap/"},
+	"glass":                    {"https://rdap.identitydigital.services/rdap/"},
+	"global":                   {"https://rdap.identitydigital.services/rdap/"},
+	"gmbh":                     {"https://rdap.identitydigital.services/rdap/"},
+	"gold":                     {"https://rdap.identitydigital.services/rdap/"},
+	"golf":                     {"https://rdap.identitydigital.services/rdap/"},
+	"goodyear":                 {"https://rdap.identitydigital.services/rdap/"},
+	"got":                      {"https://rdap.identitydigital.services/rdap/"},
+	"graphics":                 {"https://rdap.identitydigital.services/rdap/"},
+	"gratis":                   {"https://rdap.identitydigital.services/rdap/"},
+	"green":                    {"https://rdap.identitydigital.services/rdap/"},
+	"gripe":                    {"https://rdap.identitydigital.services/rdap/"},
+	"group":                    {"https://rdap.identitydigital.services/rdap/"},
+	"guide":                    {"https://rdap.identitydigital.services/rdap/"},
+	"guru":                     {"https://rdap.identitydigital.services/rdap/"},
+	"haus":                     {"https://rdap.identitydigital.services/rdap/"},
+	"hdfc":                     {"https://rdap.identitydigital.services/rdap/"},
+	"hdfcbank":                 {"https://rdap.identitydigital.services/rdap/"},
+	"healthcare":               {"https://rdap.identitydigital.services/rdap/"},
+	"helsinki":                 {"https://rdap.identitydigital.services/rdap/"},
+	"hermes":                   {"https://rdap.identitydigital.services/rdap/"},
+	"hkt":                      {"https://rdap.identitydigital.services/rdap/"},
+	"hockey":                   {"https://rdap.identitydigital.services/rdap/"},
+	"holdings":                 {"https://rdap.identitydigital.services/rdap/"},
+	"holiday":                  {"https://rdap.identitydigital.services/rdap/"},
+	"homedepot":                {"https://rdap.identitydigital.services/rdap/"},
+	"hospital":                 {"https://rdap.identitydigital.services/rdap/"},
+	"house":                    {"https://rdap.identitydigital.services/rdap/"},
+	"hughes":                   {"https://rdap.identitydigital.services/rdap/"},
+	"ice":                      {"https://rdap.identitydigital.services/rdap/"},
+	"imamat":                   {"https://rdap.identitydigital.services/rdap/"},
+	"immo":                     {"https://rdap.identitydigital.services/rdap/"},
+	"immobilien":               {"https://rdap.identitydigital.services/rdap/"},
+	"industries":               {"https://rdap.identitydigital.services/rdap/"},
+	"info":                     {"https://rdap.identitydigital.services/rdap/"},
+	"institute":                {"https://rdap.identitydigital.services/rdap/"},
+	"insure":                   {"https://rdap.identitydigital.services/rdap/"},
+	"international":            {"https://rdap.identitydigital.services/rdap/"},
+	"investments":              {"https://rdap.identitydigital.services/rdap/"},
+	"irish":                    {"https://rdap.identitydigital.services/rdap/"},
+	"ismaili":                  {"https://rdap.identitydigital.services/rdap/"},
+	"ist":                      {"https://rdap.identitydigital.services/rdap/"},
+	"istanbul":                 {"https://rdap.identitydigital.services/rdap/"},
+	"itv":                      {"https://rdap.identitydigital.services/rdap/"},
+	"jaguar":                   {"https://rdap.identitydigital.services/rdap/"},
+	"java":                     {"https://rdap.identitydigital.services/rdap/"},
+	"jeep":                     {"https://rdap.identitydigital.services/rdap/"},
+	"jetzt":                    {"https://rdap.identitydigital.services/rdap/"},
+	"jewelry":                  {"https://rdap.identitydigital.services/rdap/"},
+	"jio":                      {"https://rdap.identitydigital.services/rdap/"},
+	"jll":                      {"https://rdap.identitydigital.services/rdap/"},
+	"jot":                      {"https://rdap.identitydigital.services/rdap/"},
+	"juegos":                   {"https://rdap.identitydigital.services/rdap/"},
+	"juniper":                  {"https://rdap.identitydigital.services/rdap/"},
+	"kaufen":                   {"https://rdap.identitydigital.services/rdap/"},
+	"kerryhotels":              {"https://rdap.identitydigital.services/rdap/"},
+	"kerryproperties":          {"https://rdap.identitydigital.services/rdap/"},
+	"kids":                     {"https://rdap.identitydigital.services/rdap/"},
+	"kim":                      {"https://rdap.identitydigital.services/rdap/"},
+	"kitchen":                  {"https://rdap.identitydigital.services/rdap/"},
+	"kosher":                   {"https://rdap.identitydigital.services/rdap/"},
+	"kuokgroup":                {"https://rdap.identitydigital.services/rdap/"},
+	"lamborghini":              {"https://rdap.identitydigital.services/rdap/"},
+	"lamer":                    {"https://rdap.identitydigital.services/rdap/"},
+	"land":                     {"https://rdap.identitydigital.services/rdap/"},
+	"landrover":                {"https://rdap.identitydigital.services/rdap/"},
+	"lasalle":                  {"https://rdap.identitydigital.services/rdap/"},
+	"lawyer":                   {"https://rdap.identitydigital.services/rdap/"},
+	"lds":                      {"https://rdap.identitydigital.services/rdap/"},
+	"lease":                    {"https://rdap.identitydigital.services/rdap/"},
+	"lefrak":                   {"https://rdap.identitydigital.services/rdap/"},
+	"legal":                    {"https://rdap.identitydigital.services/rdap/"},
+	"lego":                     {"https://rdap.identitydigital.services/rdap/"},
+	"lgbt":                     {"https://rdap.identitydigital.services/rdap/"},
+	"life":                     {"https://rdap.identitydigital.services/rdap/"},
+	"lighting":                 {"https://rdap.identitydigital.services/rdap/"},
+	"limited":                  {"https://rdap.identitydigital.services/rdap/"},
+	"limo":                     {"https://rdap.identitydigital.services/rdap/"},
+	"live":                     {"https://rdap.identitydigital.services/rdap/"},
+	"llc":                      {"https://rdap.identitydigital.services/rdap/"},
+	"loans":                    {"https://rdap.identitydigital.services/rdap/"},
+	"lotto":                    {"https://rdap.identitydigital.services/rdap/"},
+	"ltd":                      {"https://rdap.identitydigital.services/rdap/"},
+	"ltda":                     {"https://rdap.identitydigital.services/rdap/"},
+	"lundbeck":                 {"https://rdap.identitydigital.services/rdap/"},
+	"maif":                     {"https://rdap.identitydigital.services/rdap/"},
+	"maison":                   {"https://rdap.identitydigital.services/rdap/"},
+	"management":               {"https://rdap.identitydigital.services/rdap/"},
+	"market":                   {"https://rdap.identitydigital.services/rdap/"},
+	"marketing":                {"https://rdap.identitydigital.services/rdap/"},
+	"markets":                  {"https://rdap.identitydigital.services/rdap/"},
+	"marriott":                 {"https://rdap.identitydigital.services/rdap/"},
+	"mba":                      {"https://rdap.identitydigital.services/rdap/"},
+	"mckinsey":                 {"https://rdap.identitydigital.services/rdap/"},
+	"media":                    {"https://rdap.identitydigital.services/rdap/"},
+	"memorial":                 {"https://rdap.identitydigital.services/rdap/"},
+	"mit":                      {"https://rdap.identitydigital.services/rdap/"},
+	"mobi":                     {"https://rdap.identitydigital.services/rdap/"},
+	"moda":                     {"https://rdap.identitydigital.services/rdap/"},
+	"money":                    {"https://rdap.identitydigital.services/rdap/"},
+	"mormon":                   {"https://rdap.identitydigital.services/rdap/"},
+	"mortgage":                 {"https://rdap.identitydigital.services/rdap/"},
+	"movie":                    {"https://rdap.identitydigital.services/rdap/"},
+	"mu":                       {"https://rdap.identitydigital.services/rdap/"},
+	"nab":                      {"https://rdap.identitydigital.services/rdap/"},
+	"navy":                     {"https://rdap.identitydigital.services/rdap/"},
+	"network":                  {"https://rdap.identitydigital.services/rdap/"},
+	"news":                     {"https://rdap.identitydigital.services/rdap/"},
+	"next":                     {"https://rdap.identitydigital.services/rdap/"},
+	"nextdirect":               {"https://rdap.identitydigital.services/rdap/"},
+	"nikon":                    {"https://rdap.identitydigital.services/rdap/"},
+	"ninja":                    {"https://rdap.identitydigital.services/rdap/"},
+	"nissay":                   {"https://rdap.identitydigital.services/rdap/"},
+	"nowtv":                    {"https://rdap.identitydigital.services/rdap/"},
+	"nra":                      {"https://rdap.identitydigital.services/rdap/"},
+	"obi":                      {"https://rdap.identitydigital.services/rdap/"},
+	"onl":                      {"https://rdap.identitydigital.services/rdap/"},
+	"oracle":                   {"https://rdap.identitydigital.services/rdap/"},
+	"orange":                   {"https://rdap.identitydigital.services/rdap/"},
+	"organic":                  {"https://rdap.identitydigital.services/rdap/"},
+	"origins":                  {"https://rdap.identitydigital.services/rdap/"},
+	"partners":                 {"https://rdap.identitydigital.services/rdap/"},
+	"parts":                    {"https://rdap.identitydigital.services/rdap/"},
+	"pccw":                     {"https://rdap.identitydigital.services/rdap/"},
+	"pet":                      {"https://rdap.identitydigital.services/rdap/"},
+	"photography":              {"https://rdap.identitydigital.services/rdap/"},
+	"photos":                   {"https://rdap.identitydigital.services/rdap/"},
+	"pictet":                   {"https://rdap.identitydigital.services/rdap/"},
+	"pictures":                 {"https://rdap.identitydigital.services/rdap/"},
+	"pink":                     {"https://rdap.identitydigital.services/rdap/"},
+	"pizza":                    {"https://rdap.identitydigital.services/rdap/"},
+	"place":                    {"https://rdap.identitydigital.services/rdap/"},
+	"plumbing":                 {"https://rdap.identitydigital.services/rdap/"},
+	"plus":                     {"https://rdap.identitydigital.services/rdap/"},
+	"pnc":                      {"https://rdap.identitydigital.services/rdap/"},
+	"poker":                    {"https://rdap.identitydigital.services/rdap/"},
+	"post":                     {"https://rdap.identitydigital.services/rdap/"},
+	"pro":                      {"https://rdap.identitydigital.services/rdap/"},
+	"productions":              {"https://rdap.identitydigital.services/rdap/"},
+	"progressive":              {"https://rdap.identitydigital.services/rdap/"},
+	"promo":                    {"https://rdap.identitydigital.services/rdap/"},
+	"properties":               {"https://rdap.identitydigital.services/rdap/"},
+	"pub":                      {"https://rdap.identitydigital.services/rdap/"},
+	"pwc":                      {"https://rdap.identitydigital.services/rdap/"},
+	"recipes":                  {"https://rdap.identitydigital.services/rdap/"},
+	"red":                      {"https://rdap.identitydigital.services/rdap/"},
+	"redumbrella":              {"https://rdap.identitydigital.services/rdap/"},
+	"rehab":                    {"https://rdap.identitydigital.services/rdap/"},
+	"reise":                    {"https://rdap.identitydigital.services/rdap/"},
+	"reisen":                   {"https://rdap.identitydigital.services/rdap/"},
+	"reliance":                 {"https://rdap.identitydigital.services/rdap/"},
+	"rentals":                  {"https://rdap.identitydigital.services/rdap/"},
+	"repair":                   {"https://rdap.identitydigital.services/rdap/"},
+	"report":                   {"https://rdap.identitydigital.services/rdap/"},
+	"republican":               {"https://rdap.identitydigital.services/rdap/"},
+	"restaurant":               {"https://rdap.identitydigital.services/rdap/"},
+	"reviews":                  {"https://rdap.identitydigital.services/rdap/"},
+	"rexroth":                  {"https://rdap.identitydigital.services/rdap/"},
+	"rich":                     {"https://rdap.identitydigital.services/rdap/"},
+	"richardli":                {"https://rdap.identitydigital.services/rdap/"},
+	"ril":                      {"https://rdap.identitydigital.services/rdap/"},
+	"rip":                      {"https://rdap.identitydigital.services/rdap/"},
+	"rocks":                    {"https://rdap.identitydigital.services/rdap/"},
+	"rogers":                   {"https://rdap.identitydigital.services/rdap/"},
+	"run":                      {"https://rdap.identitydigital.services/rdap/"},
+	"rwe":                      {"https://rdap.identitydigital.services/rdap/"},
+	"safety":                   {"https://rdap.identitydigital.services/rdap/"},
+	"sale":                     {"https://rdap.identitydigital.services/rdap/"},
+	"salon":                    {"https://rdap.identitydigital.services/rdap/"},
+	"sanofi":                   {"https://rdap.identitydigital.services/rdap/"},
+	"sarl":                     {"https://rdap.identitydigital.services/rdap/"},
+	"saxo":                     {"https://rdap.identitydigital.services/rdap/"},
+	"sbi":                      {"https://rdap.identitydigital.services/rdap/"},
+	"scholarships":             {"https://rdap.identitydigital.services/rdap/"},
+	"school":                   {"https://rdap.identitydigital.services/rdap/"},
+	"schule":                   {"https://rdap.identitydigital.services/rdap/"},
+	"sener":                    {"https://rdap.identitydigital.services/rdap/"},
+	"services":                 {"https://rdap.identitydigital.services/rdap/"},
+	"sew":                      {"https://rdap.identitydigital.services/rdap/"},
+	"shangrila":                {"https://rdap.identitydigital.services/rdap/"},
+	"shiksha":                  {"https://rdap.identitydigital.services/rdap/"},
+	"shoes":                    {"https://rdap.identitydigital.services/rdap/"},
+	"shopping":                 {"https://rdap.identitydigital.services/rdap/"},
+	"show":                     {"https://rdap.identitydigital.services/rdap/"},
+	"singles":                  {"https://rdap.identitydigital.services/rdap/"},
+	"ski":                      {"https://rdap.identitydigital.services/rdap/"},
+	"soccer":                   {"https://rdap.identitydigital.services/rdap/"},
+	"social":                   {"https://rdap.identitydigital.services/rdap/"},
+	"software":                 {"https://rdap.identitydigital.services/rdap/"},
+	"solar":                    {"https://rdap.identitydigital.services/rdap/"},
+	"solutions":                {"https://rdap.identitydigital.services/rdap/"},
+	"song":                     {"https://rdap.identitydigital.services/rdap/"},
+	"spa":                      {"https://rdap.identitydigital.services/rdap/"},
+	"srl":                      {"https://rdap.identitydigital.services/rdap/"},
+	"stada":                    {"https://rdap.identitydigital.services/rdap/"},
+	"star":                     {"https://rdap.identitydigital.services/rdap/"},
+	"statebank":                {"https://rdap.identitydigital.services/rdap/"},
+	"stockholm":                {"https://rdap.identitydigital.services/rdap/"},
+	"studio":                   {"https://rdap.identitydigital.services/rdap/"},
+	"style":                    {"https://rdap.identitydigital.services/rdap/"},
+	"supplies":                 {"https://rdap.identitydigital.services/rdap/"},
+	"supply":                   {"https://rdap.identitydigital.services/rdap/"},
+	"support":                  {"https://rdap.identitydigital.services/rdap/"},
+	"surgery":                  {"https://rdap.identitydigital.services/rdap/"},
+	"systems":                  {"https://rdap.identitydigital.services/rdap/"},
+	"taobao":                   {"https://rdap.identitydigital.services/rdap/"},
+	"tatamotors":               {"https://rdap.identitydigital.services/rdap/"},
+	"tax":                      {"https://rdap.identitydigital.services/rdap/"},
+	"taxi":                     {"https://rdap.identitydigital.services/rdap/"},
+	"team":                     {"https://rdap.identitydigital.services/rdap/"},
+	"technology":               {"https://rdap.identitydigital.services/rdap/"},
+	"temasek":                  {"https://rdap.identitydigital.services/rdap/"},
+	"tennis":                   {"https://rdap.identitydigital.services/rdap/"},
+	"thd":                      {"https://rdap.identitydigital.services/rdap/"},
+	"theater":                  {"https://rdap.identitydigital.services/rdap/"},
+	"tiaa":                     {"https://rdap.identitydigital.services/rdap/"},
+	"tienda":                   {"https://rdap.identitydigital.services/rdap/"},
+	"tips":                     {"https://rdap.identitydigital.services/rdap/"},
+	"tires":                    {"https://rdap.identitydigital.services/rdap/"},
+	"tmall":                    {"https://rdap.identitydigital.services/rdap/"},
+	"today":                    {"https://rdap.identitydigital.services/rdap/"},
+	"tools":                    {"https://rdap.identitydigital.services/rdap/"},
+	"tours":                    {"https://rdap.identitydigital.services/rdap/"},
+	"town":                     {"https://rdap.identitydigital.services/rdap/"},
+	"toys":                     {"https://rdap.identitydigital.services/rdap/"},
+	"trading":                  {"https://rdap.identitydigital.services/rdap/"},
+	"training":                 {"https://rdap.identitydigital.services/rdap/"},
+	"travel":                   {"https://rdap.identitydigital.services/rdap/"},
+	"travelers":                {"https://rdap.identitydigital.services/rdap/"},
+	"travelersinsurance":       {"https://rdap.identitydigital.services/rdap/"},
+	"trv":                      {"https://rdap.identitydigital.services/rdap/"},
+	"tvs":                      {"https://rdap.identitydigital.services/rdap/"},
+	"ubank":                    {"https://rdap.identitydigital.services/rdap/"},
+	"ubs":                      {"https://rdap.identitydigital.services/rdap/"},
+	"university":               {"https://rdap.identitydigital.services/rdap/"},
+	"ups":                      {"https://rdap.identitydigital.services/rdap/"},
+	"vacations":                {"https://rdap.identitydigital.services/rdap/"},
+	"vanguard":                 {"https://rdap.identitydigital.services/rdap/"},
+	"vegas":                    {"https://rdap.identitydigital.services/rdap/"},
+	"ventures":                 {"https://rdap.identitydigital.services/rdap/"},
+	"vet":                      {"https://rdap.identitydigital.services/rdap/"},
+	"viajes":                   {"https://rdap.identitydigital.services/rdap/"},
+	"video":                    {"https://rdap.identitydigital.services/rdap/"},
+	"vig":                      {"https://rdap.identitydigital.services/rdap/"},
+	"viking":                   {"https://rdap.identitydigital.services/rdap/"},
+	"villas":                   {"https://rdap.identitydigital.services/rdap/"},
+	"vin":                      {"https://rdap.identitydigital.services/rdap/"},
+	"visa":                     {"https://rdap.identitydigital.services/rdap/"},
+	"vision":                   {"https://rdap.identitydigital.services/rdap/"},
+	"volvo":                    {"https://rdap.identitydigital.services/rdap/"},
+	"vote":                     {"https://rdap.identitydigital.services/rdap/"},
+	"voto":                     {"https://rdap.identitydigital.services/rdap/"},
+	"voyage":                   {"https://rdap.identitydigital.services/rdap/"},
+	"watch":                    {"https://rdap.identitydigital.services/rdap/"},
+	"watches":                  {"https://rdap.identitydigital.services/rdap/"},
+	"weber":                    {"https://rdap.identitydigital.services/rdap/"},
+	"weir":                     {"https://rdap.identitydigital.services/rdap/"},
+	"wine":                     {"https://rdap.identitydigital.services/rdap/"},
+	"works":                    {"https://rdap.identitydigital.services/rdap/"},
+	"world":                    {"https://rdap.identitydigital.services/rdap/"},
+	"wtf":                      {"https://rdap.identitydigital.services/rdap/"},
+	"xin":                      {"https://rdap.identitydigital.services/rdap/"},
+	"xn--1ck2e1b":              {"https://rdap.identitydigital.services/rdap/"},
+	"xn--5su34j936bgsg":        {"https://rdap.identitydigital.services/rdap/"},
+	"xn--5tzm5g":               {"https://rdap.identitydigital.services/rdap/"},
+	"xn--6frz82g":              {"https://rdap.identitydigital.services/rdap/"},
+	"xn--b4w605ferd":           {"https://rdap.identitydigital.services/rdap/"},
+	"xn--bck1b9a5dre4c":        {"https://rdap.identitydigital.services/rdap/"},
+	"xn--cck2b3b":              {"https://rdap.identitydigital.services/rdap/"},
+	"xn--czrs0t":               {"https://rdap.identitydigital.services/rdap/"},
+	"xn--eckvdtc9d":            {"https://rdap.identitydigital.services/rdap/"},
+	"xn--fct429k":              {"https://rdap.identitydigital.services/rdap/"},
+	"xn--fjq720a":              {"https://rdap.identitydigital.services/rdap/"},
+	"xn--fzys8d69uvgm":         {"https://rdap.identitydigital.services/rdap/"},
+	"xn--gckr3f0f":             {"https://rdap.identitydigital.services/rdap/"},
+	"xn--gk3at1e":              {"https://rdap.identitydigital.services/rdap/"},
+	"xn--jvr189m":              {"https://rdap.identitydigital.services/rdap/"},
+	"xn--rovu88b":              {"https://rdap.identitydigital.services/rdap/"},
+	"xn--unup4y":               {"https://rdap.identitydigital.services/rdap/"},
+	"xn--vhquv":                {"https://rdap.identitydigital.services/rdap/"},
+	"xn--w4r85el8fhu5dnra":     {"https://rdap.identitydigital.services/rdap/"},
+	"xn--w4rs40l":              {"https://rdap.identitydigital.services/rdap/"},
+	"yahoo":                    {"https://rdap.identitydigital.services/rdap/"},
+	"zara":                     {"https://rdap.identitydigital.services/rdap/"},
+	"zero":                     {"https://rdap.identitydigital.services/rdap/"},
+	"zone":                     {"https://rdap.identitydigital.services/rdap/"},
+	"is":                       {"https://rdap.isnic.is/rdap/"},
+	"ke":                       {"https://rdap.kenic.or.ke/"},
+	"kiwi":                     {"https://rdap.kiwi.fury.ca/rdap/"},
+	"lb":                       {"https://rdap.lbdr.org.lb/"},
+	"mls":                      {"https://rdap.mls.fury.ca/rdap/"},
+	"blockbuster":              {"https://rdap.mobile-registry.com/rdap/"},
+	"data":                     {"https://rdap.mobile-registry.com/rdap/"},
+	"dish":                     {"https://rdap.mobile-registry.com/rdap/"},
+	"dot":                      {"https://rdap.mobile-registry.com/rdap/"},
+	"dtv":                      {"https://rdap.mobile-registry.com/rdap/"},
+	"dvr":                      {"https://rdap.mobile-registry.com/rdap/"},
+	"latino":                   {"https://rdap.mobile-registry.com/rdap/"},
+	"mobile":                   {"https://rdap.mobile-registry.com/rdap/"},
+	"ollo":                     {"https://rdap.mobile-registry.com/rdap/"},
+	"ott":                      {"https://rdap.mobile-registry.com/rdap/"},
+	"phone":                    {"https://rdap.mobile-registry.com/rdap/"},
+	"sling":                    {"https://rdap.mobile-registry.com/rdap/"},
+	"sina":                     {"https://rdap.ngtld.cn/"},
+	"weibo":                    {"https://rdap.ngtld.cn/"},
+	"xn--55qw42g":              {"https://rdap.ngtld.cn/"},
+	"xn--55qx5d":               {"https://rdap.ngtld.cn/"},
+	"xn--9krt00a":              {"https://rdap.ngtld.cn/"},
+	"xn--io0a7i":               {"https://rdap.ngtld.cn/"},
+	"xn--zfr164b":              {"https://rdap.ngtld.cn/"},
+	"aaa":                      {"https://rdap.nic.aaa/"},
+	"aarp":                     {"https://rdap.nic.aarp/"},
+	"able":                     {"https://rdap.nic.able/"},
+	"abogado":                  {"https://rdap.nic.abogado/"},
+	"abudhabi":                 {"https://rdap.nic.abudhabi/"},
+	"accountant":               {"https://rdap.nic.accountant/"},
+	"aco":                      {"https://rdap.nic.aco/"},
+	"ad":                       {"https://rdap.nic.ad/"},
+	"adult":                    {"https://rdap.nic.adult/"},
+	"aetna":                    {"https://rdap.nic.aetna/"},
+	"afl":                      {"https://rdap.nic.afl/"},
+	"africa":                   {"https://rdap.nic.africa/rdap/"},
+	"aig":                      {"https://rdap.nic.aig/"},
+	"airtel":                   {"https://rdap.nic.airtel/"},
+	"ally":                     {"https://rdap.nic.ally/"},
+	"alsace":                   {"https://rdap.nic.alsace/"},
+	"alstom":                   {"https://rdap.nic.alstom/"},
+	"americanexpress":          {"https://rdap.nic.americanexpress/"},
+	"americanfamily":           {"https://rdap.nic.americanfamily/"},
+	"amex":                     {"https://rdap.nic.amex/"},
+	"amfam":                    {"https://rdap.nic.amfam/"},
+	"amica":                    {"https://rdap.nic.amica/"},
+	"amsterdam":                {"https://rdap.nic.amsterdam/"},
+	"analytics":                {"https://rdap.nic.analytics/"},
+	"anz":                      {"https://rdap.nic.anz/"},
+	"apple":                    {"https://rdap.nic.apple/"},
+	"aquarelle":                {"https://rdap.nic.aquarelle/"},
+	"ar":                       {"https://rdap.nic.ar/"},
+	"arab":                     {"https://rdap.nic.arab/"},
+	"aramco":                   {"https://rdap.nic.aramco/"},
+	"as":                       {"https://rdap.nic.as/"},
+	"athleta":                  {"https://rdap.nic.athleta/"},
+	"auspost":                  {"https://rdap.nic.auspost/"},
+	"axa":                      {"https://rdap.nic.axa/"},
+	"banamex":                  {"https://rdap.nic.banamex/"},
+	"bank":                     {"https://rdap.nic.bank/"},
+	"barcelona":                {"https://rdap.nic.barcelona/"},
+	"baseball":                 {"https://rdap.nic.baseball/"},
+	"basketball":               {"https://rdap.nic.basketball/"},
+	"bauhaus":                  {"https://rdap.nic.bauhaus/"},
+	"bayern":                   {"https://rdap.nic.bayern/"},
+	"bcn":                      {"https://rdap.nic.bcn/"},
+	"beer":                     {"https://rdap.nic.beer/"},
+	"berlin":                   {"https://rdap.nic.berlin/v1/"},
+	"bharti":                   {"https://rdap.nic.bharti/"},
+	"bible":                    {"https://rdap.nic.bible/"},
+	"bid":                      {"https://rdap.nic.bid/"},
+	"biz":                      {"https://rdap.nic.biz/"},
+	"blackfriday":              {"https://rdap.nic.blackfriday/"},
+	"booking":                  {"https://rdap.nic.booking/"},
+	"bostik":                   {"https://rdap.nic.bostik/"},
+	"boston":                   {"https://rdap.nic.boston/"},
+	"brussels":                 {"https://rdap.nic.brussels/"},
+	"buzz":                     {"https://rdap.nic.buzz/"},
+	"bzh":                      {"https://rdap.nic.bzh/"},
+	"calvinklein":              {"https://rdap.nic.calvinklein/"},
+	"capetown":                 {"https://rdap.nic.capetown/rdap/"},
+	"capitalone":               {"https://rdap.nic.capitalone/"},
+	"caravan":                  {"https://rdap.nic.caravan/"},
+	"casa":                     {"https://rdap.nic.casa/"},
+	"cat":                      {"https://rdap.nic.cat/"},
+	"catholic":                 {"https://rdap.nic.catholic/"},
+	"cba":                      {"https://rdap.nic.cba/"},
+	"cbn":                      {"https://rdap.nic.cbn/"},
+	"cbre":                     {"https://rdap.nic.cbre/"},
+	"chase":                    {"https://rdap.nic.chase/"},
+	"chintai":                  {"https://rdap.nic.chintai/"},
+	"cisco":                    {"https://rdap.nic.cisco/"},
+	"citi":                     {"https://rdap.nic.citi/"},
+	"club":                     {"https://rdap.nic.club/"},
+	"cm":                       {"https://rdap.nic.cm/"},
+	"commbank":                 {"https://rdap.nic.commbank/"},
+	"compare":                  {"https://rdap.nic.compare/"},
+	"cooking":                  {"https://rdap.nic.cooking/"},
+	"corsica":                  {"https://rdap.nic.corsica/"},
+	"courses":                  {"https://rdap.nic.courses/"},
+	"cpa":                      {"https://rdap.nic.cpa/"},
+	"cr":                       {"https://rdap.nic.cr/"},
+	"cricket":                  {"https://rdap.nic.cricket/"},
+	"cuisinella":               {"https://rdap.nic.cuisinella/"},
+	"cv":                       {"https://rdap.nic.cv/"},
+	"cx":                       {"https://rdap.nic.cx/"},
+	"cz":                       {"https://rdap.nic.cz/"},
+	"date":                     {"https://rdap.nic.date/"},
+	"dds":                      {"https://rdap.nic.dds/"},
+	"dell":                     {"https://rdap.nic.dell/"},
+	"design":                   {"https://rdap.nic.design/"},
+	"download":                 {"https://rdap.nic.download/"},
+	"dubai":                    {"https://rdap.nic.dubai/"},
+	"dupont":                   {"https://rdap.nic.dupont/"},
+	"durban":                   {"https://rdap.nic.durban/rdap/"},
+	"earth":                    {"https://rdap.nic.earth/"},
+	"erni":                     {"https://rdap.nic.erni/"},
+	"eurovision":               {"https://rdap.nic.eurovision/"},
+	"eus":                      {"https://rdap.nic.eus/"},
+	"faith":                    {"https://rdap.nic.faith/"},
+	"farmers":                  {"https://rdap.nic.farmers/"},
+	"fashion":                  {"https://rdap.nic.fashion/"},
+	"ferrero":                  {"https://rdap.nic.ferrero/"},
+	"film":                     {"https://rdap.nic.film/"},
+	"firmdale":                 {"https://rdap.nic.firmdale/"},
+	"fishing":                  {"https://rdap.nic.fishing/"},
+	"fit":                      {"https://rdap.nic.fit/"},
+	"flickr":                   {"https://rdap.nic.flickr/"},
+	"flir":                     {"https://rdap.nic.flir/"},
+	"ford":                     {"https://rdap.nic.ford/"},
+	"fox":                      {"https://rdap.nic.fox/"},
+	"fr":                       {"https://rdap.nic.fr/"},
+	"frontier":                 {"https://rdap.nic.frontier/"},
+	"ftr":                      {"https://rdap.nic.ftr/"},
+	"gal":                      {"https://rdap.nic.gal/"},
+	"gap":                      {"https://rdap.nic.gap/"},
+	"garden":                   {"https://rdap.nic.garden/"},
+	"gay":                      {"https://rdap.nic.gay/"},
+	"gdn":                      {"https://rdap.nic.gdn/"},
+	"gea":                      {"https://rdap.nic.gea/"},
+	"george":                   {"https://rdap.nic.george/"},
+	"gmx":                      {"https://rdap.nic.gmx/"},
+	"godaddy":                  {"https://rdap.nic.godaddy/"},
+	"gov":                      {"https://rdap.nic.gov/rdap/"},
+	"grainger":                 {"https://rdap.nic.grainger/"},
+	"grocery":                  {"https://rdap.nic.grocery/"},
+	"gs":                       {"https://rdap.nic.gs/"},
+	"hamburg":                  {"https://rdap.nic.hamburg/v1/"},
+	"hbo":                      {"https://rdap.nic.hbo/"},
+	"health":                   {"https://rdap.nic.health/"},
+	"hn":                       {"https://rdap.nic.hn/"},
+	"homegoods":                {"https://rdap.nic.homegoods/"},
+	"homesense":                {"https://rdap.nic.homesense/"},
+	"horse":                    {"https://rdap.nic.horse/"},
+	"hotels":                   {"https://rdap.nic.hotels/"},
+	"hsbc":                     {"https://rdap.nic.hsbc/"},
+	"ht":                       {"https://rdap.nic.ht/"},
+	"hyatt":                    {"https://rdap.nic.hyatt/"},
+	"ibm":                      {"https://rdap.nic.ibm/"},
+	"ifm":                      {"https://rdap.nic.ifm/"},
+	"ikano":                    {"https://rdap.nic.ikano/v1/"},
+	"ink":                      {"https://rdap.nic.ink/"},
+	"insurance":                {"https://rdap.nic.insurance/"},
+	"intuit":                   {"https://rdap.nic.intuit/"},
+	"ipiranga":                 {"https://rdap.nic.ipiranga/"},
+	"itau":                     {"https://rdap.nic.itau/"},
+	"jmp":                      {"https://rdap.nic.jmp/"},
+	"joburg":                   {"https://rdap.nic.joburg/rdap/"},
+	"jpmorgan":                 {"https://rdap.nic.jpmorgan/"},
+	"jprs":                     {"https://rdap.nic.jprs/rdap/"},
+	"kpmg":                     {"https://rdap.nic.kpmg/"},
+	"krd":                      {"https://rdap.nic.krd/"},
+	"lacaixa":                  {"https://rdap.nic.lacaixa/"},
+	"lanxess":                  {"https://rdap.nic.lanxess/"},
+	"latrobe":                  {"https://rdap.nic.latrobe/"},
+	"law":                      {"https://rdap.nic.law/"},
+	"leclerc":                  {"https://rdap.nic.leclerc/"},
+	"lifeinsurance":            {"https://rdap.nic.lifeinsurance/"},
+	"lilly":                    {"https://rdap.nic.lilly/"},
+	"lincoln":                  {"https://rdap.nic.lincoln/"},
+	"loan":                     {"https://rdap.nic.loan/"},
+	"locker":                   {"https://rdap.nic.locker/rdap/"},
+	"luxe":                     {"https://rdap.nic.luxe/"},
+	"ly":                       {"https://rdap.nic.ly/"},
+	"madrid":                   {"https://rdap.nic.madrid/"},
+	"man":                      {"https://rdap.nic.man/"},
+	"mango":                    {"https://rdap.nic.mango/"},
+	"marshalls":                {"https://rdap.nic.marshalls/"},
+	"mattel":                   {"https://rdap.nic.mattel/"},
+	"melbourne":                {"https://rdap.nic.melbourne/"},
+	"men":                      {"https://rdap.nic.men/"},
+	"menu":                     {"https://rdap.nic.menu/"},
+	"merckmsd":                 {"https://rdap.nic.merckmsd/"},
+	"miami":                    {"https://rdap.nic.miami/"},
+	"mint":                     {"https://rdap.nic.mint/"},
+	"ml":                       {"https://rdap.nic.ml/"},
+	"mlb":                      {"https://rdap.nic.mlb/"},
+	"mma":                      {"https://rdap.nic.mma/"},
+	"moe":                      {"https://rdap.nic.moe/"},
+	"monash":                   {"https://rdap.nic.monash/"},
+	"moto":                     {"https://rdap.nic.moto/"},
+	"ms":                       {"https://rdap.nic.ms/"},
+	"msd":                      {"https://rdap.nic.msd/"},
+	"museum":                   {"https://rdap.nic.museum/"},
+	"nba":                      {"https://rdap.nic.nba/"},
+	"ng":                       {"https://rdap.nic.net.ng/"},
+	"netbank":                  {"https://rdap.nic.netbank/"},
+	"netflix":                  {"https://rdap.nic.netflix/"},
+	"neustar":                  {"https://rdap.nic.neustar/"},
+	"nf":                       {"https://rdap.nic.nf/"},
+	"nfl":                      {"https://rdap.nic.nfl/"},
+	"nike":                     {"https://rdap.nic.nike/"},
+	"norton":                   {"https://rdap.nic.norton/"},
+	"nrw":                      {"https://rdap.nic.nrw/"},
+	"ntt":                      {"https://rdap.nic.ntt/rdap/"},
+	"nyc":                      {"https://rdap.nic.nyc/"},
+	"olayan":                   {"https://rdap.nic.olayan/"},
+	"olayangroup":              {"https://rdap.nic.olayangroup/"},
+	"one":                      {"https://rdap.nic.one/"},
+	"open":                     {"https://rdap.nic.open/"},
+	"osaka":                    {"https://rdap.nic.osaka/"},
+	"ovh":                      {"https://rdap.nic.ovh/"},
+	"paris":                    {"https://rdap.nic.paris/"},
+	"party":                    {"https://rdap.nic.party/"},
+	"pfizer":                   {"https://rdap.nic.pfizer/"},
+	"pg":                       {"https://rdap.nic.pg/"},
+	"philips":                  {"https://rdap.nic.philips/"},
+	"photo":                    {"https://rdap.nic.photo/"},
+	"physio":                   {"https://rdap.nic.physio/"},
+	"ping":                     {"https://rdap.nic.ping/"},
+	"pm":                       {"https://rdap.nic.pm/"},
+	"politie":                  {"https://rdap.nic.politie/"},
+	"porn":                     {"https://rdap.nic.porn/"},
+	"praxi":                    {"https://rdap.nic.praxi/"},
+	"pru":                      {"https://rdap.nic.pru/"},
+	"prudential":               {"https://rdap.nic.prudential/"},
+	"quebec":                   {"https://rdap.nic.quebec/"},
+	"racing":                   {"https://rdap.nic.racing/"},
+	"radio":                    {"https://rdap.nic.radio/"},
+	"re":                       {"https://rdap.nic.re/"},
+	"review":                   {"https://rdap.nic.review/"},
+	"rodeo":                    {"https://rdap.nic.rodeo/"},
+	"rugby":                    {"https://rdap.nic.rugby/"},
+	"sakura":                   {"https://rdap.nic.sakura/rdap/"},
+	"samsclub":                 {"https://rdap.nic.samsclub/"},
+	"sandvik":                  {"https://rdap.nic.sandvik/"},
+	"sandvikcoromant":          {"https://rdap.nic.sandvikcoromant/"},
+	"sap":                      {"https://rdap.nic.sap/"},
+	"sas":                      {"https://rdap.nic.sas/"},
+	"scb":                      {"https://rdap.nic.scb/"},
+	"schaeffler":               {"https://rdap.nic.schaeffler/"},
+	"schmidt":                  {"https://rdap.nic.schmidt/"},
+	"science":                  {"https://rdap.nic.science/"},
+	"scot":                     {"https://rdap.nic.scot/"},
+	"sd":                       {"https://rdap.nic.sd/"},
+	"seat":                     {"https://rdap.nic.seat/"},
+	"seek":                     {"https://rdap.nic.seek/"},
+	"select":                   {"https://rdap.nic.select/"},
+	"seven":                    {"https://rdap.nic.seven/"},
+	"sex":                      {"https://rdap.nic.sex/"},
+	"sn":                       {"https://rdap.nic.sn/whois43/"},
+	"sncf":                     {"https://rdap.nic.sncf/"},
+	"sport":                    {"https://rdap.nic.sport/"},
+	"ss":                       {"https://rdap.nic.ss/"},
+	"staples":                  {"https://rdap.nic.staples/"},
+	"statefarm":                {"https://rdap.nic.statefarm/"},
+	"stream":                   {"https://rdap.nic.stream/"},
+	"study":                    {"https://rdap.nic.study/"},
+	"sucks":                    {"https://rdap.nic.sucks/"},
+	"surf":                     {"https://rdap.nic.surf/"},
+	"swiss":                    {"https://rdap.nic.swiss/"},
+	"sydney":                   {"https://rdap.nic.sydney/"},
+	"tab":                      {"https://rdap.nic.tab/"},
+	"taipei":                   {"https://rdap.nic.taipei/"},
+	"target":                   {"https://rdap.nic.target/"},
+	"tattoo":                   {"https://rdap.nic.tattoo/"},
+	"tdk":                      {"https://rdap.nic.tdk/"},
+	"tel":                      {"https://rdap.nic.tel/"},
+	"teva":                     {"https://rdap.nic.teva/"},
+	"tf":                       {"https://rdap.nic.tf/"},
+	"tjmaxx":                   {"https://rdap.nic.tjmaxx/"},
+	"tjx":                      {"https://rdap.nic.tjx/"},
+	"tkmaxx":                   {"https://rdap.nic.tkmaxx/"},
+	"total":                    {"https://rdap.nic.total/"},
+	"trade":                    {"https://rdap.nic.trade/"},
+	"tube":                     {"https://rdap.nic.tube/"},
+	"tv":                       {"https://rdap.nic.tv/"},
+	"versicherung":             {"https://rdap.nic.versicherung/v1/"},
+	"vi":                       {"https://rdap.nic.vi/"},
+	"vip":                      {"https://rdap.nic.vip/"},
+	"vivo":                     {"https://rdap.nic.vivo/"},
+	"vlaanderen":               {"https://rdap.nic.vlaanderen/"},
+	"vodka":                    {"https://rdap.nic.vodka/"},
+	"voting":                   {"https://rdap.nic.voting/"},
+	"walmart":                  {"https://rdap.nic.walmart/"},
+	"walter":                   {"https://rdap.nic.walter/"},
+	"weather":                  {"https://rdap.nic.weather/"},
+	"weatherchannel":           {"https://rdap.nic.weatherchannel/"},
+	"webcam":                   {"https://rdap.nic.webcam/"},
+	"wedding":                  {"https://rdap.nic.wedding/"},
+	"wf":                       {"https://rdap.nic.wf/"},
+	"whoswho":                  {"https://rdap.nic.whoswho/"},
+	"wiki":                     {"https://rdap.nic.wiki/"},
+	"williamhill":              {"https://rdap.nic.williamhill/"},
+	"win":                      {"https://rdap.nic.win/"},
+	"winners":                  {"https://rdap.nic.winners/"},
+	"woodside":                 {"https://rdap.nic.woodside/"},
+	"work":                     {"https://rdap.nic.work/"},
+	"wtc":                      {"https://rdap.nic.wtc/"},
+	"xerox":                    {"https://rdap.nic.xerox/"},
+	"xn--80aqecdr1a":           {"https://rdap.nic.xn--80aqecdr1a/"},
+	"xn--80asehdb":             {"https://rdap.nic.xn--80asehdb/"},
+	"xn--80aswg":               {"https://rdap.nic.xn--80aswg/"},
+	"xn--g2xx48c":              {"https://rdap.nic.xn--g2xx48c/"},
+	"xn--kcrx77d1x4a":          {"https://rdap.nic.xn--kcrx77d1x4a/"},
+	"xn--mgba3a3ejt":           {"https://rdap.nic.xn--mgba3a3ejt/"},
+	"xn--mgba7c0bbn0a":         {"https://rdap.nic.xn--mgba7c0bbn0a/"},
+	"xn--mgbab2bd":             {"https://rdap.nic.xn--mgbab2bd/"},
+	"xn--mgbca7dzdo":           {"https://rdap.nic.xn--mgbca7dzdo/"},
+	"xn--mgbi4ecexp":           {"https://rdap.nic.xn--mgbi4ecexp/"},
+	"xn--ngbc5azd":             {"https://rdap.nic.xn--ngbc5azd/"},
+	"xn--ngbrx":                {"https://rdap.nic.xn--ngbrx/"},
+	"xn--p1acf":                {"https://rdap.nic.xn--p1acf/"},
+	"xn--tiq49xqyj":            {"https://rdap.nic.xn--tiq49xqyj/"},
+	"xxx":                      {"https://rdap.nic.xxx/"},
+	"yandex":                   {"https://rdap.nic.yandex/rdap/"},
+	"yoga":                     {"https://rdap.nic.yoga/"},
+	"yt":                       {"https://rdap.nic.yt/"},
+	"zm":                       {"https://rdap.nic.zm/"},
+	"in":                       {"https://rdap.nixiregistry.in/rdap/"},
+	"abbvie":                   {"https://rdap.nominet.uk/abbvie/"},
+	"amazon":                   {"https://rdap.nominet.uk/amazon/"},
+	"audible":                  {"https://rdap.nominet.uk/audible/"},
+	"author":                   {"https://rdap.nominet.uk/author/"},
+	"aws":                      {"https://rdap.nominet.uk/aws/"},
+	"azure":                    {"https://rdap.nominet.uk/azure/"},
+	"bbc":                      {"https://rdap.nominet.uk/bbc/"},
+	"bbva":                     {"https://rdap.nominet.uk/bbva/"},
+	"bing":                     {"https://rdap.nominet.uk/bing/"},
+	"book":                     {"https://rdap.nominet.uk/book/"},
+	"bot":                      {"https://rdap.nominet.uk/bot/"},
+	"broadway":                 {"https://rdap.nominet.uk/broadway/"},
+	"buy":                      {"https://rdap.nominet.uk/buy/"},
+	"call":                     {"https://rdap.nominet.uk/call/"},
+	"career":                   {"https://rdap.nominet.uk/career/"},
+	"cymru":                    {"https://rdap.nominet.uk/cymru/"},
+	"deal":                     {"https://rdap.nominet.uk/deal/"},
+	"desi":                     {"https://rdap.nominet.uk/desi/"},
+	"fairwinds":                {"https://rdap.nominet.uk/fairwinds/"},
+	"fast":                     {"https://rdap.nominet.uk/fast/"},
+	"fire":                     {"https://rdap.nominet.uk/fire/"},
+	"free":                     {"https://rdap.nominet.uk/free/"},
+	"gop":                      {"https://rdap.nominet.uk/gop/"},
+	"gucci":                    {"https://rdap.nominet.uk/gucci/"},
+	"hot":                      {"https://rdap.nominet.uk/hot/"},
+	"hotmail":                  {"https://rdap.nominet.uk/hotmail/"},
+	"ieee":                     {"https://rdap.nominet.uk/ieee/"},
+	"imdb":                     {"https://rdap.nominet.uk/imdb/"},
+	"jobs":                     {"https://rdap.nominet.uk/jobs/"},
+	"joy":                      {"https://rdap.nominet.uk/joy/"},
+	"kindle":                   {"https://rdap.nominet.uk/kindle/"},
+	"like":                     {"https://rdap.nominet.uk/like/"},
+	"locus":                    {"https://rdap.nominet.uk/locus/"},
+	"med":                      {"https://rdap.nominet.uk/med/"},
+	"microsoft":                {"https://rdap.nominet.uk/microsoft/"},
+	"moi":                      {"https://rdap.nominet.uk/moi/"},
+	"mtn":                      {"https://rdap.nominet.uk/mtn/"},
+	"now":                      {"https://rdap.nominet.uk/now/"},
+	"nowruz":                   {"https://rdap.nominet.uk/nowruz/"},
+	"office":                   {"https://rdap.nominet.uk/office/"},
+	"omega":                    {"https://rdap.nominet.uk/omega/"},
+	"pars":                     {"https://rdap.nominet.uk/pars/"},
+	"pay":                      {"https://rdap.nominet.uk/pay/"},
+	"pharmacy":                 {"https://rdap.nominet.uk/pharmacy/"},
+	"pin":                      {"https://rdap.nominet.uk/pin/"},
+	"pioneer":                  {"https://rdap.nominet.uk/pioneer/"},
+	"pn":                       {"https://rdap.nominet.uk/pn/"},
+	"prime":                    {"https://rdap.nominet.uk/prime/"},
+	"read":                     {"https://rdap.nominet.uk/read/"},
+	"realestate":               {"https://rdap.nominet.uk/realestate/"},
+	"realtor":                  {"https://rdap.nominet.uk/realtor/"},
+	"room":                     {"https://rdap.nominet.uk/room/"},
+	"safe":                     {"https://rdap.nominet.uk/safe/"},
+	"save":                     {"https://rdap.nominet.uk/save/"},
+	"secure":                   {"https://rdap.nominet.uk/secure/"},
+	"shell":                    {"https://rdap.nominet.uk/shell/"},
+	"shia":                     {"https://rdap.nominet.uk/shia/"},
+	"silk":                     {"https://rdap.nominet.uk/silk/"},
+	"sky":                      {"https://rdap.nominet.uk/sky/"},
+	"skype":                    {"https://rdap.nominet.uk/skype/"},
+	"smile":                    {"https://rdap.nominet.uk/smile/"},
+	"spot":                     {"https://rdap.nominet.uk/spot/"},
+	"swatch":                   {"https://rdap.nominet.uk/swatch/"},
+	"talk":                     {"https://rdap.nominet.uk/talk/"},
+	"tci":                      {"https://rdap.nominet.uk/tci/"},
+	"tunes":                    {"https://rdap.nominet.uk/tunes/"},
+	"tushu":                    {"https://rdap.nominet.uk/tushu/"},
+	"uk":                       {"https://rdap.nominet.uk/uk/"},
+	"virgin":                   {"https://rdap.nominet.uk/virgin/"},
+	"wales":                    {"https://rdap.nominet.uk/wales/"},
+	"wanggou":                  {"https://rdap.nominet.uk/wanggou/"},
+	"wed":                      {"https://rdap.nominet.uk/wed/"},
+	"windows":                  {"https://rdap.nominet.uk/windows/"},
+	"wow":                      {"https://rdap.nominet.uk/wow/"},
+	"xbox":                     {"https://rdap.nominet.uk/xbox/"},
+	"xn--cckwcxetd":            {"https://rdap.nominet.uk/xn--cckwcxetd/"},
+	"xn--jlq480n2rg":           {"https://rdap.nominet.uk/xn--jlq480n2rg/"},
+	"xn--mgbt3dhd":             {"https://rdap.nominet.uk/xn--mgbt3dhd/"},
+	"yamaxun":                  {"https://rdap.nominet.uk/yamaxun/"},
+	"you":                      {"https://rdap.nominet.uk/you/"},
+	"zappos":                   {"https://rdap.nominet.uk/zappos/"},
+	"no":                       {"https://rdap.norid.no/"},
+	"id":                       {"https://rdap.pandi.id/rdap/"},
+	"charity":                  {"https://rdap.publicinterestregistry.org/rdap/"},
+	"foundation":               {"https://rdap.publicinterestregistry.org/rdap/"},
+	"gives":                    {"https://rdap.publicinterestregistry.org/rdap/"},
+	"giving":                   {"https://rdap.publicinterestregistry.org/rdap/"},
+	"ngo":                      {"https://rdap.publicinterestregistry.org/rdap/"},
+	"ong":                      {"https://rdap.publicinterestregistry.org/rdap/"},
+	"org":                      {"https://rdap.publicinterestregistry.org/rdap/"},
+	"xn--c1avg":                {"https://rdap.publicinterestregistry.org/rdap/"},
+	"xn--i1b6b1a6a2e":          {"https://rdap.publicinterestregistry.org/rdap/"},
+	"xn--nqv7f":                {"https://rdap.publicinterestregistry.org/rdap/"},
+	"xn--nqv7fs00ema":          {"https://rdap.publicinterestregistry.org/rdap/"},
+	"fun":                      {"https://rdap.radix.host/rdap/"},
+	"host":                     {"https://rdap.radix.host/rdap/"},
+	"online":                   {"https://rdap.radix.host/rdap/"},
+	"press":                    {"https://rdap.radix.host/rdap/"},
+	"pw":                       {"https://rdap.radix.host/rdap/"},
+	"site":                     {"https://rdap.radix.host/rdap/"},
+	"space":                    {"https://rdap.radix.host/rdap/"},
+	"store":                    {"https://rdap.radix.host/rdap/"},
+	"tech":                     {"https://rdap.radix.host/rdap/"},
+	"uno":                      {"https://rdap.radix.host/rdap/"},
+	"website":                  {"https://rdap.radix.host/rdap/"},
+	"si":                       {"https://rdap.register.si/"},
+	"br":                       {"https://rdap.registro.br/"},
+	"bar":                      {"https://rdap.registry.bar/rdap/"},
+	"rest":                     {"https://rdap.registry.bar/rdap/"},
+	"click":                    {"https://rdap.registry.click/rdap/"},
+	"country":                  {"https://rdap.registry.click/rdap/"},
+	"diy":                      {"https://rdap.registry.click/rdap/"},
+	"feedback":                 {"https://rdap.registry.click/rdap/"},
+	"food":                     {"https://rdap.registry.click/rdap/"},
+	"forum":                    {"https://rdap.registry.click/rdap/"},
+	"hiv":                      {"https://rdap.registry.click/rdap/"},
+	"lifestyle":                {"https://rdap.registry.click/rdap/"},
+	"living":                   {"https://rdap.registry.click/rdap/"},
+	"observer":                 {"https://rdap.registry.click/rdap/"},
+	"pid":                      {"https://rdap.registry.click/rdap/"},
+	"property":                 {"https://rdap.registry.click/rdap/"},
+	"realty":                   {"https://rdap.registry.click/rdap/"},
+	"sexy":                     {"https://rdap.registry.click/rdap/"},
+	"trust":                    {"https://rdap.registry.click/rdap/"},
+	"vana":                     {"https://rdap.registry.click/rdap/"},
+	"cloud":                    {"https://rdap.registry.cloud/rdap/"},
+	"coop":                     {"https://rdap.registry.coop/rdap/"},
+	"creditunion":              {"https://rdap.registry.coop/rdap/"},
+	"ec":                       {"https://rdap.registry.ec/"},
+	"gift":                     {"https://rdap.registry.gift/rdap/"},
+	"gy":                       {"https://rdap.registry.gy/"},
+	"hiphop":                   {"https://rdap.registry.hiphop/rdap/"},
+	"kfh":                      {"https://rdap.registry.kfh/rdap/"},
+	"xn--ngbe9e0a":             {"https://rdap.registry.kfh/rdap/"},
+	"love":                     {"https://rdap.registry.love/rdap/"},
+	"music":                    {"https://rdap.registryservices.music/rdap/"},
+	"rw":                       {"https://rdap.ricta.org.rw/"},
+	"cologne":                  {"https://rdap.ryce-rsp.com/rdap/"},
+	"koeln":                    {"https://rdap.ryce-rsp.com/rdap/"},
+	"tirol":                    {"https://rdap.ryce-rsp.com/rdap/"},
+	"wien":                     {"https://rdap.ryce-rsp.com/rdap/"},
+	"sg":                       {"https://rdap.sgnic.sg/rdap/"},
+	"nl":                       {"https://rdap.sidn.nl/"},
+	"xn--clchc0ea0b2g2a9gcd":   {"https://rdap.ta.sgnic.sg/rdap/"},
+	"anquan":                   {"https://rdap.teleinfo.cn/"},
+	"shouji":                   {"https://rdap.teleinfo.cn/"},
+	"xihuan":                   {"https://rdap.teleinfo.cn/"},
+	"xn--vuq861b":              {"https://rdap.teleinfo.cn/"},
+	"yun":                      {"https://rdap.teleinfo.cn/"},
+	"xn--3ds443g":              {"https://rdap.teleinfo.cn/xn--3ds443g/"},
+	"xn--fiq228c5hs":           {"https://rdap.teleinfo.cn/xn--fiq228c5hs/"},
+	"xn--kput3i":               {"https://rdap.teleinfo.cn/xn--kput3i/"},
+	"xn--nyqy26a":              {"https://rdap.teleinfo.cn/xn--nyqy26a/"},
+	"xn--rhqv96g":              {"https://rdap.teleinfo.cn/xn--rhqv96g/"},
+	"th":                       {"https://rdap.thains.co.th/"},
+	"xn--o3cw4h":               {"https://rdap.thains.co.th/"},
+	"to":                       {"https://rdap.tonicregistry.to/rdap/"},
+	"xn--mxtq1m":               {"https://rdap.twnic.tw/rdap/"},
+	"tz":                       {"https://rdap.tznic.or.tz/"},
+	"link":                     {"https://rdap.uniregistry.net/rdap/"},
+	"com":                      {"https://rdap.verisign.com/com/v1/"},
+	"net":                      {"https://rdap.verisign.com/net/v1/"},
+	"ye":                       {"https://rdap.y.net.ye/"},
+	"xn--45q11c":               {"https://rdap.zdnsgtld.com/XN--45Q11C/"},
+	"xn--efvy88h":              {"https://rdap.zdnsgtld.com/XN--EFVY88H/"},
+	"baidu":                    {"https://rdap.zdnsgtld.com/baidu/"},
+	"citic":                    {"https://rdap.zdnsgtld.com/citic/"},
+	"icbc":                     {"https://rdap.zdnsgtld.com/icbc/"},
+	"ren":                      {"https://rdap.zdnsgtld.com/ren/"},
+	"sohu":                     {"https://rdap.zdnsgtld.com/sohu/"},
+	"top":                      {"https://rdap.zdnsgtld.com/top/"},
+	"unicom":                   {"https://rdap.zdnsgtld.com/unicom/"},
+	"wang":                     {"https://rdap.zdnsgtld.com/wang/"},
+	"xn--30rr7y":               {"https://rdap.zdnsgtld.com/xn--30rr7y/"},
+	"xn--3bst00m":              {"https://rdap.zdnsgtld.com/xn--3bst00m/"},
+	"xn--6qq986b3xl":           {"https://rdap.zdnsgtld.com/xn--6qq986b3xl/"},
+	"xn--8y0a063a":             {"https://rdap.zdnsgtld.com/xn--8y0a063a/"},
+	"xn--9et52u":               {"https://rdap.zdnsgtld.com/xn--9et52u/"},
+	"xn--czr694b":              {"https://rdap.zdnsgtld.com/xn--czr694b/"},
+	"xn--czru2d":               {"https://rdap.zdnsgtld.com/xn--czru2d/"},
+	"xn--fiq64b":               {"https://rdap.zdnsgtld.com/xn--fiq64b/"},
+	"xn--hxt814e":              {"https://rdap.zdnsgtld.com/xn--hxt814e/"},
+	"xn--imr513n":              {"https://rdap.zdnsgtld.com/xn--imr513n/"},
+	"xn--otu796d":              {"https://rdap.zdnsgtld.com/xn--otu796d/"},
+	"xn--ses554g":              {"https://rdap.zdnsgtld.com/xn--ses554g/"},
+	"xn--yfro4i67o":            {"https://rdap.zh.sgnic.sg/rdap/"},
+	"xn--1qqw23a":              {"https://restwhois.ngtld.cn/"},
+	"xn--xhq521b":              {"https://restwhois.ngtld.cn/"},
+	"cc":                       {"https://tld-rdap.verisign.com/cc/v1/"},
+	"comsec":                   {"https://tld-rdap.verisign.com/comsec/v1/"},
+	"name":                     {"https://tld-rdap.verisign.com/name/v1/"},
+	"verisign":                 {"https://tld-rdap.verisign.com/verisign/v1/"},
+	"web":                      {"https://tld-rdap.verisign.com/web/v1/"},
+	"xn--11b4c3d":              {"https://tld-rdap.verisign.com/xn--11b4c3d/v1/"},
+	"xn--3pxu8k":               {"https://tld-rdap.verisign.com/xn--3pxu8k/v1/"},
+	"xn--42c2d9a":              {"https://tld-rdap.verisign.com/xn--42c2d9a/v1/"},
+	"xn--9dbq2a":               {"https://tld-rdap.verisign.com/xn--9dbq2a/v1/"},
+	"xn--c2br7g":               {"https://tld-rdap.verisign.com/xn--c2br7g/v1/"},
+	"xn--fhbei":                {"https://tld-rdap.verisign.com/xn--fhbei/v1/"},
+	"xn--j1aef":                {"https://tld-rdap.verisign.com/xn--j1aef/v1/"},
+	"xn--mk1bu44c":             {"https://tld-rdap.verisign.com/xn--mk1bu44c/v1/"},
+	"xn--pssy2u":               {"https://tld-rdap.verisign.com/xn--pssy2u/v1/"},
+	"xn--t60b56a":              {"https://tld-rdap.verisign.com/xn--t60b56a/v1/"},
+	"xn--tckwe":                {"https://tld-rdap.verisign.com/xn--tckwe/v1/"},
+	"ky":                       {"https://whois.kyregistry.ky/rdap/"},
+	"mtr":                      {"https://whois.nic.mtr/rdap/"},
+	"tatar":                    {"https://whois.nic.tatar/rdap/"},
+	"xn--d1acj3b":              {"https://whois.nic.xn--d1acj3b/rdap/"},
+	"sr":                       {"https://whois.sr/rdap/"},
+	"fj":                       {"https://www.rdap.fj/"},
+
+	// Object tag RDAP servers (RFC 8521)
+	"tag:AFRINIC": {"https://rdap.afrinic.net/rdap/"},
+	"tag:AP":      {"https://rdap.apnic.net/"},
+	"tag:ARIN":    {"https://rdap.arin.net/registry/"},
+	"tag:LACNIC":  {"https://rdap.lacnic.net/rdap/"},
+	"tag:RIPE":    {"https://rdap.db.ripe.net/"},
 }
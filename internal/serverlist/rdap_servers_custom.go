@@ -7,7 +7,12 @@ package serverlist
 //
 // TLDs listed here support RDAP but are not included in
 // https://data.iana.org/rdap/dns.json.
+//
+// IANA issues the handles of registrar entities ("292-IANA") but does not
+// list its own tag in https://data.iana.org/rdap/object-tags.json.
 var customRdapServers = map[string]string{
+	"tag:IANA": "https://rdap.iana.org/",
+
 	"us": "https://rdap.nic.us/",
 	"me": "https://rdap.identitydigital.services/rdap/",
 	"co": "https://rdap.registry.co/co/",
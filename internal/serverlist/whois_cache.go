@@ -0,0 +1,103 @@
+package serverlist
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// whoisCacheVersion is bumped whenever the stored layout changes; data of
+// another version is ignored.
+const whoisCacheVersion = 1
+
+// whoisSaveTimeout bounds saving the table after an on-demand discovery,
+// which runs detached from the request that made it.
+const whoisSaveTimeout = 10 * time.Second
+
+// cachedWhoisTable is the stored form of discoveredWhois.
+type cachedWhoisTable struct {
+	Version int `json:"version"`
+	// SavedAt orders the copies several caches hold: the newest wins.
+	SavedAt time.Time         `json:"savedAt"`
+	Servers map[string]string `json:"servers"`
+}
+
+var (
+	// whoisSaveMu serializes saves, so the copy written last holds the
+	// newest table; it also guards whoisCaches.
+	whoisSaveMu sync.Mutex
+	// whoisCaches are where discoveredWhois is kept across restarts, set by
+	// StartWhoisRefresh.
+	whoisCaches []BootstrapCache
+)
+
+// LoadWhoisCache installs the discovered WHOIS servers stored in caches,
+// from whichever holds the newest copy. Caches that fail or hold unusable
+// data are logged and skipped. It is meant to run once at startup, before
+// StartWhoisRefresh.
+func LoadWhoisCache(ctx context.Context, caches ...BootstrapCache) {
+	var newest cachedWhoisTable
+	from := ""
+	for _, cache := range caches {
+		data, err := cache.Load(ctx)
+		if err != nil {
+			slog.Warn("WHOIS table cache unreadable", "cache", cacheName(cache), "err", err)
+			continue
+		}
+		if data == nil {
+			continue
+		}
+		var stored cachedWhoisTable
+		if err := json.Unmarshal(data, &stored); err != nil {
+			slog.Warn("WHOIS table cache corrupt, ignoring it", "cache", cacheName(cache), "err", err)
+			continue
+		}
+		if stored.Version != whoisCacheVersion {
+			slog.Warn("WHOIS table cache has an unknown version, ignoring it",
+				"cache", cacheName(cache), "version", stored.Version)
+			continue
+		}
+		if len(stored.Servers) > 0 && stored.SavedAt.After(newest.SavedAt) {
+			newest, from = stored, cacheName(cache)
+		}
+	}
+	if len(newest.Servers) == 0 {
+		return
+	}
+
+	whoisMu.Lock()
+	for tld, server := range newest.Servers {
+		if isTLDLabel(tld) && server != "" {
+			discoveredWhois[tld] = server
+		}
+	}
+	entries := len(discoveredWhois)
+	whoisMu.Unlock()
+	slog.Info("WHOIS table restored from cache", "cache", from, "entries", entries, "savedAt", newest.SavedAt)
+}
+
+// saveWhoisCache writes discoveredWhois to every one of whoisCaches, logging
+// the ones that fail.
+func saveWhoisCache(ctx context.Context) {
+	whoisSaveMu.Lock()
+	defer whoisSaveMu.Unlock()
+	if len(whoisCaches) == 0 {
+		return
+	}
+
+	whoisMu.RLock()
+	stored := cachedWhoisTable{Version: whoisCacheVersion, SavedAt: time.Now().UTC(), Servers: discoveredWhois}
+	data, err := json.Marshal(stored)
+	whoisMu.RUnlock()
+	if err != nil {
+		slog.Warn("WHOIS table cache not saved", "err", err)
+		return
+	}
+	for _, cache := range whoisCaches {
+		if err := cache.Save(ctx, data); err != nil {
+			slog.Warn("WHOIS table cache not saved", "cache", cacheName(cache), "err", err)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package serverlist
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeRootZone serves a root zone list of tlds at rootZoneURL for the
+// duration of a test, counting fetches, and installs it right away.
+func fakeRootZone(t *testing.T, tlds ...string) *atomic.Int32 {
+	t.Helper()
+	var fetches atomic.Int32
+	root := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		_, _ = w.Write([]byte(strings.ToUpper(strings.Join(tlds, "\n")) + "\n"))
+	}))
+	oldURL := rootZoneURL
+	rootZoneURL = root.URL
+	t.Cleanup(func() {
+		root.Close()
+		rootZoneURL = oldURL
+	})
+	setRootZone(tlds)
+	return &fetches
+}
+
+// withWhoisCaches runs StartWhoisRefresh without a refresh interval, so
+// discovery saves to caches, and forgets the caches afterwards. The root
+// zone is fetched from rootZoneURL, which the test must have faked.
+func withWhoisCaches(t *testing.T, caches ...BootstrapCache) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	StartWhoisRefresh(ctx, http.DefaultClient, 0, caches...)
+	t.Cleanup(func() {
+		cancel()
+		whoisSaveMu.Lock()
+		whoisCaches = nil
+		whoisSaveMu.Unlock()
+	})
+}
+
+// TestWhoisCacheRoundTrip verifies a discovered server is saved and served
+// again after a restart without asking IANA.
+func TestWhoisCacheRoundTrip(t *testing.T) {
+	resetWhoisDiscovery(t, false)
+	queries := fakeIANA(t, map[string]string{"zzsaved": ianaRecord("zzsaved", "whois.nic.zzsaved")})
+	fakeRootZone(t, "zzsaved")
+	cache := FileCache(filepath.Join(t.TempDir(), "whois.json"))
+	withWhoisCaches(t, cache)
+
+	if _, ok := DiscoverWhoisServer(context.Background(), "zzsaved"); !ok {
+		t.Fatal("discovery failed")
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if data, _ := cache.Load(context.Background()); data != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("discovery never saved the table")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// "Restart": forget the table, then restore it from the file alone.
+	whoisMu.Lock()
+	discoveredWhois = make(map[string]string)
+	whoisMu.Unlock()
+	discoveryEnabled.Store(false)
+
+	LoadWhoisCache(context.Background(), cache)
+	if server, ok := LookupWhoisServer("zzsaved"); !ok || server != "whois.nic.zzsaved" {
+		t.Errorf("zzsaved = %q, %v; want the saved server", server, ok)
+	}
+	if got := queries.Load(); got != 1 {
+		t.Errorf("IANA queried %d times, want 1", got)
+	}
+}
+
+// TestLoadWhoisCacheNewestWins verifies the table comes from the cache that
+// saved it last, and that unusable caches and entries are skipped.
+func TestLoadWhoisCacheNewestWins(t *testing.T) {
+	resetWhoisDiscovery(t, false)
+	dir := t.TempDir()
+	older := FileCache(filepath.Join(dir, "older.json"))
+	newer := FileCache(filepath.Join(dir, "newer.json"))
+	corrupt := FileCache(filepath.Join(dir, "corrupt.json"))
+	otherVersion := FileCache(filepath.Join(dir, "v2.json"))
+
+	write := func(f FileCache, body string) {
+		if err := os.WriteFile(string(f), []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(older, `{"version":1,"savedAt":"2026-01-01T00:00:00Z","servers":{"zznewest":"whois.old.example","zzolder":"whois.old.example"}}`)
+	write(newer, `{"version":1,"savedAt":"2026-02-01T00:00:00Z","servers":{"zznewest":"whois.new.example","bad tld":"whois.bad.example"}}`)
+	write(corrupt, `{"version":1,"servers":`)
+	write(otherVersion, `{"version":2,"savedAt":"2026-03-01T00:00:00Z","servers":{"zznewest":"whois.v2.example"}}`)
+
+	LoadWhoisCache(context.Background(), corrupt, older, otherVersion, newer)
+
+	if server, _ := LookupWhoisServer("zznewest"); server != "whois.new.example" {
+		t.Errorf("zznewest: got %q, want the newer cache's server", server)
+	}
+	if _, ok := LookupWhoisServer("zzolder"); ok {
+		t.Error("zzolder: entries of an older copy were merged in")
+	}
+	if _, ok := LookupWhoisServer("bad tld"); ok {
+		t.Error("a key not shaped like a TLD was installed")
+	}
+}
+
+// TestStartWhoisRefreshWaitsOneInterval verifies starting the refresh
+// fetches the root zone list discovery is limited to, but does not sweep the
+// whole table at once.
+func TestStartWhoisRefreshWaitsOneInterval(t *testing.T) {
+	resetWhoisDiscovery(t, false)
+	queries := fakeIANA(t, nil)
+	fetches := fakeRootZone(t, "zzsweep")
+	setRootZone(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	StartWhoisRefresh(ctx, http.DefaultClient, time.Hour)
+	t.Cleanup(func() {
+		whoisSaveMu.Lock()
+		whoisCaches = nil
+		whoisSaveMu.Unlock()
+	})
+
+	time.Sleep(50 * time.Millisecond)
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("root zone fetched %d times on start, want 1", got)
+	}
+	if got := queries.Load(); got != 0 {
+		t.Errorf("IANA queried %d times on start, want 0", got)
+	}
+	whoisMu.RLock()
+	_, known := rootZone["zzsweep"]
+	whoisMu.RUnlock()
+	if !known {
+		t.Error("root zone list not installed")
+	}
+	if !discoveryEnabled.Load() {
+		t.Error("on-demand discovery not enabled")
+	}
+}
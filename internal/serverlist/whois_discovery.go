@@ -0,0 +1,441 @@
+package serverlist
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/KincaidYang/whois/internal/metrics"
+)
+
+// ianaWhoisAddr and rootZoneURL are where WHOIS servers are discovered from;
+// tests replace them to reach local servers.
+var (
+	ianaWhoisAddr = "whois.iana.org:43"
+	rootZoneURL   = "https://data.iana.org/TLD/tlds-alpha-by-domain.txt"
+)
+
+const (
+	// discoveryTimeout bounds one whois.iana.org query.
+	discoveryTimeout = 10 * time.Second
+	// undiscoverableTTL is how long a TLD IANA reports no WHOIS server for
+	// is not asked about again; discoveryRetryAfter is the same for a query
+	// that failed, so an unreachable IANA is not dialled on every request.
+	undiscoverableTTL   = time.Hour
+	discoveryRetryAfter = time.Minute
+	// maxUndiscoverable caps the negative cache; made-up TLDs cost one IANA
+	// query each, and must not grow it without bound.
+	maxUndiscoverable = 10000
+	// whoisRefreshWorkers is how many IANA queries a table refresh runs at
+	// once, and maxWhoisRefreshErrors how many may fail before the round is
+	// abandoned rather than dialling an unreachable IANA ~1500 times.
+	whoisRefreshWorkers   = 8
+	maxWhoisRefreshErrors = 20
+	// rootZoneInterval is how often the root zone list discovery is limited
+	// to is fetched again; a whole-table refresh fetches it as well.
+	rootZoneInterval = 24 * time.Hour
+)
+
+var (
+	whoisMu sync.RWMutex
+	// configuredWhois holds servers.whois from the configuration; it wins
+	// over both tables below.
+	configuredWhois map[string]string
+	// discoveredWhois holds servers learned from IANA. It overlays
+	// TLDToWhoisServer, so a stale compiled entry is replaced; a TLD IANA
+	// reports no server for falls back to the compiled entry, as fetched RDAP
+	// data does to compiledRdapServers.
+	discoveredWhois = make(map[string]string)
+	// undiscoverable maps a TLD to when it may be asked about again.
+	undiscoverable = make(map[string]time.Time)
+	// discovering holds a channel per in-flight discovery, closed when it
+	// finishes, so concurrent requests for one TLD share a single query.
+	discovering = make(map[string]chan struct{})
+	// rootZone is the set of TLDs in the root zone, the only ones discovery
+	// asks IANA about: a made-up TLD in a request must not cost a query.
+	// Nil until the list has been fetched, which leaves discovery off.
+	rootZone map[string]struct{}
+
+	// discoveryEnabled gates runtime queries to IANA. It is set by
+	// StartWhoisRefresh, so without it (and in tests) only the configured,
+	// restored and compiled tables are consulted.
+	discoveryEnabled atomic.Bool
+)
+
+// SetWhoisOverrides installs the operator's servers.whois entries (TLD →
+// host, optionally with a port). They win over discovered and compiled
+// servers; nil removes them.
+func SetWhoisOverrides(servers map[string]string) {
+	whoisMu.Lock()
+	configuredWhois = servers
+	whoisMu.Unlock()
+}
+
+// LookupWhoisServer returns the WHOIS server for a TLD from the configured,
+// discovered and compiled tables, without querying IANA.
+func LookupWhoisServer(tld string) (string, bool) {
+	whoisMu.RLock()
+	server, ok := configuredWhois[tld]
+	if !ok {
+		server, ok = discoveredWhois[tld]
+	}
+	whoisMu.RUnlock()
+	if ok {
+		return server, true
+	}
+	server, ok = TLDToWhoisServer[tld]
+	return server, ok
+}
+
+// DiscoverWhoisServer is LookupWhoisServer, falling back to asking
+// whois.iana.org for a root-zone TLD neither table knows. A server IANA
+// reports is remembered for every later lookup, and saved with the table; a
+// TLD it reports none for is not asked about again for an hour.
+func DiscoverWhoisServer(ctx context.Context, tld string) (string, bool) {
+	if server, ok := LookupWhoisServer(tld); ok {
+		return server, true
+	}
+	if !discoveryEnabled.Load() || !isTLDLabel(tld) {
+		return "", false
+	}
+
+	whoisMu.Lock()
+	if _, ok := rootZone[tld]; !ok {
+		whoisMu.Unlock()
+		return "", false
+	}
+	if until, ok := undiscoverable[tld]; ok && time.Now().Before(until) {
+		whoisMu.Unlock()
+		return "", false
+	}
+	if done, ok := discovering[tld]; ok {
+		whoisMu.Unlock()
+		select {
+		case <-done:
+			return LookupWhoisServer(tld)
+		case <-ctx.Done():
+			return "", false
+		}
+	}
+	done := make(chan struct{})
+	discovering[tld] = done
+	whoisMu.Unlock()
+
+	qctx, cancel := context.WithTimeout(ctx, discoveryTimeout)
+	server, err := queryIANA(qctx, ianaWhoisAddr, tld)
+	cancel()
+
+	whoisMu.Lock()
+	defer func() {
+		delete(discovering, tld)
+		close(done)
+		whoisMu.Unlock()
+	}()
+	switch {
+	case err != nil:
+		metrics.WhoisDiscoveryTotal.WithLabelValues("error").Inc()
+		slog.WarnContext(ctx, "WHOIS server discovery failed", "tld", tld, "err", err)
+		markUndiscoverable(tld, discoveryRetryAfter)
+		return "", false
+	case server == "":
+		metrics.WhoisDiscoveryTotal.WithLabelValues("none").Inc()
+		markUndiscoverable(tld, undiscoverableTTL)
+		return "", false
+	default:
+		metrics.WhoisDiscoveryTotal.WithLabelValues("found").Inc()
+		slog.InfoContext(ctx, "WHOIS server discovered", "tld", tld, "server", server)
+		discoveredWhois[tld] = server
+		go func() {
+			sctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), whoisSaveTimeout)
+			defer cancel()
+			saveWhoisCache(sctx)
+		}()
+		return server, true
+	}
+}
+
+// markUndiscoverable records that tld should not be asked about for ttl.
+// Callers hold whoisMu.
+func markUndiscoverable(tld string, ttl time.Duration) {
+	now := time.Now()
+	if len(undiscoverable) >= maxUndiscoverable {
+		for k, until := range undiscoverable {
+			if now.After(until) {
+				delete(undiscoverable, k)
+			}
+		}
+		if len(undiscoverable) >= maxUndiscoverable {
+			clear(undiscoverable)
+		}
+	}
+	undiscoverable[tld] = now.Add(ttl)
+}
+
+// isTLDLabel reports whether s is shaped like a TLD, which is all that may
+// be written to IANA: letters, digits and hyphens, as in "xn--p1ai".
+func isTLDLabel(s string) bool {
+	if s == "" || len(s) > 63 {
+		return false
+	}
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// queryIANA returns the WHOIS server IANA reports for one TLD, or the empty
+// string when it reports none.
+func queryIANA(ctx context.Context, addr, tld string) (string, error) {
+	dialer := net.Dialer{Timeout: discoveryTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = conn.Close() }()
+
+	deadline := time.Now().Add(discoveryTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return "", err
+	}
+
+	if _, err := conn.Write([]byte(tld + "\r\n")); err != nil {
+		return "", err
+	}
+	body, err := io.ReadAll(io.LimitReader(conn, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	return ParseIANAWhoisRecord(body)
+}
+
+// ParseIANAWhoisRecord returns the WHOIS server in whois.iana.org's answer
+// for one TLD, lowercased, or the empty string when the record has none or
+// IANA knows no such TLD. An answer that is not a complete record is an
+// error: reading it as "no server" would drop a live one.
+func ParseIANAWhoisRecord(body []byte) (string, error) {
+	record := strings.ToLower(string(body))
+	complete := false
+	for _, line := range strings.Split(record, "\n") {
+		line = strings.TrimSpace(line)
+		if rest, ok := strings.CutPrefix(line, "whois:"); ok {
+			// An empty whois: field is IANA saying the registry publishes no
+			// WHOIS server, which is an answer, not a failure.
+			return strings.TrimSpace(rest), nil
+		}
+		// Every IANA record ends with its source, so seeing that line is what
+		// distinguishes "no whois: field" from a record cut short.
+		if rest, ok := strings.CutPrefix(line, "source:"); ok && strings.TrimSpace(rest) == "iana" {
+			complete = true
+		}
+	}
+	if complete || strings.Contains(record, "this query returned 0 objects") {
+		return "", nil
+	}
+	return "", fmt.Errorf("response ended before the record did (%d bytes)", len(body))
+}
+
+// fetchRootZone returns every TLD in the root zone, lowercased.
+func fetchRootZone(ctx context.Context, client *http.Client) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rootZoneURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, rootZoneURL)
+	}
+
+	var tlds []string
+	scanner := bufio.NewScanner(io.LimitReader(resp.Body, maxBootstrapResponseSize))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		tlds = append(tlds, strings.ToLower(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(tlds) == 0 {
+		return nil, fmt.Errorf("%s listed no TLDs", rootZoneURL)
+	}
+	return tlds, nil
+}
+
+// setRootZone installs the root zone list discovery is limited to.
+func setRootZone(tlds []string) {
+	zone := make(map[string]struct{}, len(tlds))
+	for _, tld := range tlds {
+		zone[tld] = struct{}{}
+	}
+	whoisMu.Lock()
+	rootZone = zone
+	whoisMu.Unlock()
+}
+
+// refreshRootZone fetches the root zone list and installs it, reporting
+// whether that succeeded.
+func refreshRootZone(ctx context.Context, client *http.Client) bool {
+	tlds, err := fetchRootZone(ctx, client)
+	if err != nil {
+		slog.Warn("root zone fetch failed", "err", err)
+		return false
+	}
+	setRootZone(tlds)
+	return true
+}
+
+// refreshWhoisTable asks IANA for the WHOIS server of every root-zone TLD and
+// folds the answers into discoveredWhois. A TLD whose query failed keeps its
+// last-known-good entry, and once maxWhoisRefreshErrors queries have failed
+// the rest of the round is skipped, leaving every entry not yet refreshed as
+// it was. It returns the outcome label for the metric — "failure" (nothing
+// answered, table untouched), "partial" or "success" — and the number of
+// discovered entries.
+func refreshWhoisTable(ctx context.Context, client *http.Client) (outcome string, entries int) {
+	tlds, err := fetchRootZone(ctx, client)
+	if err != nil {
+		slog.Warn("WHOIS table refresh: root zone fetch failed", "err", err)
+		return "failure", 0
+	}
+	setRootZone(tlds)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		resultMu sync.Mutex
+		answered = make(map[string]string, len(tlds))
+		failed   int
+		wg       sync.WaitGroup
+	)
+	queue := make(chan string)
+	for range whoisRefreshWorkers {
+		wg.Go(func() {
+			for tld := range queue {
+				qctx, qcancel := context.WithTimeout(ctx, discoveryTimeout)
+				server, err := queryIANA(qctx, ianaWhoisAddr, tld)
+				qcancel()
+				resultMu.Lock()
+				if err != nil {
+					slog.Debug("WHOIS table refresh: query failed", "tld", tld, "err", err)
+					if failed++; failed == maxWhoisRefreshErrors {
+						cancel()
+					}
+				} else {
+					answered[tld] = server
+				}
+				resultMu.Unlock()
+			}
+		})
+	}
+	for _, tld := range tlds {
+		select {
+		case queue <- tld:
+		case <-ctx.Done():
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	if len(answered) == 0 {
+		return "failure", 0
+	}
+
+	whoisMu.Lock()
+	for tld, server := range answered {
+		if server == "" {
+			delete(discoveredWhois, tld)
+			continue
+		}
+		discoveredWhois[tld] = server
+		delete(undiscoverable, tld)
+	}
+	entries = len(discoveredWhois)
+	whoisMu.Unlock()
+
+	if len(answered) < len(tlds) {
+		return "partial", entries
+	}
+	return "success", entries
+}
+
+// StartWhoisRefresh enables on-demand WHOIS server discovery for root-zone
+// TLDs, keeping the table it builds in caches across restarts, and with a
+// positive interval refreshes the whole table from IANA on that interval.
+// The root zone list is fetched at once (retried every discoveryRetryAfter
+// until it succeeds) and then every rootZoneInterval; discovery waits for
+// it. A refresh is one query per root-zone TLD, so the first runs only after
+// one interval: until then the table restored by LoadWhoisCache and the
+// compiled one serve. Stops when ctx is cancelled.
+func StartWhoisRefresh(ctx context.Context, client *http.Client, interval time.Duration, caches ...BootstrapCache) {
+	whoisSaveMu.Lock()
+	whoisCaches = caches
+	whoisSaveMu.Unlock()
+	discoveryEnabled.Store(true)
+
+	go func() {
+		for {
+			wait := rootZoneInterval
+			if !refreshRootZone(ctx, client) {
+				wait = discoveryRetryAfter
+			}
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	if interval <= 0 {
+		return
+	}
+
+	refresh := func() {
+		outcome, entries := refreshWhoisTable(ctx, client)
+		metrics.WhoisRefreshTotal.WithLabelValues(outcome).Inc()
+		switch outcome {
+		case "failure":
+			slog.Warn("WHOIS table refresh: no data fetched, retaining current table")
+			return
+		case "partial":
+			slog.Warn("WHOIS table partially refreshed; unanswered TLDs retain last-known-good servers",
+				"entries", entries)
+		default:
+			metrics.WhoisLastRefreshTimestamp.Set(float64(time.Now().Unix()))
+			slog.Info("WHOIS table refreshed", "entries", entries)
+		}
+		saveWhoisCache(ctx)
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				refresh()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,223 @@
+package serverlist
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeIANA serves IANA-style records from answers (TLD → record body) on a
+// loopback listener; a TLD with no answer gets the "0 objects" reply. It
+// returns a counter of queries served.
+func fakeIANA(t *testing.T, answers map[string]string) *atomic.Int32 {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	var queries atomic.Int32
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				line, _ := bufio.NewReader(conn).ReadString('\n')
+				queries.Add(1)
+				record, ok := answers[strings.TrimSpace(line)]
+				if !ok {
+					record = "% This query returned 0 objects.\n"
+				}
+				_, _ = conn.Write([]byte(record))
+			}()
+		}
+	}()
+
+	oldAddr := ianaWhoisAddr
+	ianaWhoisAddr = ln.Addr().String()
+	t.Cleanup(func() {
+		_ = ln.Close()
+		ianaWhoisAddr = oldAddr
+	})
+	return &queries
+}
+
+// resetWhoisDiscovery enables discovery with empty tables, restoring the
+// previous state on cleanup.
+func resetWhoisDiscovery(t *testing.T, enabled bool) {
+	t.Helper()
+	oldDiscovered, oldUndiscoverable, oldRootZone := discoveredWhois, undiscoverable, rootZone
+	oldEnabled := discoveryEnabled.Load()
+	discoveredWhois, undiscoverable, rootZone = make(map[string]string), make(map[string]time.Time), nil
+	discoveryEnabled.Store(enabled)
+	t.Cleanup(func() {
+		whoisMu.Lock()
+		discoveredWhois, undiscoverable, rootZone = oldDiscovered, oldUndiscoverable, oldRootZone
+		whoisMu.Unlock()
+		discoveryEnabled.Store(oldEnabled)
+	})
+}
+
+func ianaRecord(tld, server string) string {
+	return "domain:       " + strings.ToUpper(tld) + "\n\nwhois:        " + server + "\n\nsource:       IANA\n"
+}
+
+func TestDiscoverWhoisServer(t *testing.T) {
+	resetWhoisDiscovery(t, true)
+	setRootZone([]string{"zzdiscover", "zznone", "zzunknown"})
+	queries := fakeIANA(t, map[string]string{
+		"zzdiscover": ianaRecord("zzdiscover", "whois.nic.zzdiscover"),
+		"zznone":     "domain:       ZZNONE\n\nwhois:\n\nsource:       IANA\n",
+	})
+
+	if server, ok := DiscoverWhoisServer(context.Background(), "zzdiscover"); !ok || server != "whois.nic.zzdiscover" {
+		t.Errorf("discover: got %q, %v", server, ok)
+	}
+	// Remembered: later lookups need no query.
+	if server, ok := LookupWhoisServer("zzdiscover"); !ok || server != "whois.nic.zzdiscover" {
+		t.Errorf("lookup after discovery: got %q, %v", server, ok)
+	}
+	if _, ok := DiscoverWhoisServer(context.Background(), "zzdiscover"); !ok || queries.Load() != 1 {
+		t.Errorf("second discovery queried IANA again (%d queries)", queries.Load())
+	}
+
+	// A TLD with no server, and one IANA has no record of, are each asked
+	// about once.
+	for _, tld := range []string{"zznone", "zzunknown"} {
+		for range 2 {
+			if server, ok := DiscoverWhoisServer(context.Background(), tld); ok {
+				t.Errorf("%s: got %q", tld, server)
+			}
+		}
+	}
+	if got := queries.Load(); got != 3 {
+		t.Errorf("expected 3 IANA queries, got %d", got)
+	}
+
+	// Nothing shaped unlike a TLD is written to IANA.
+	if _, ok := DiscoverWhoisServer(context.Background(), "bad\r\ntld"); ok || queries.Load() != 3 {
+		t.Errorf("malformed TLD reached IANA")
+	}
+}
+
+// TestDiscoverWhoisServerOutsideRootZone verifies a label that is not a
+// root-zone TLD, or any label before the root zone is known, never reaches
+// IANA.
+func TestDiscoverWhoisServerOutsideRootZone(t *testing.T) {
+	resetWhoisDiscovery(t, true)
+	queries := fakeIANA(t, map[string]string{"zzreal": ianaRecord("zzreal", "whois.nic.zzreal")})
+
+	if _, ok := DiscoverWhoisServer(context.Background(), "zzreal"); ok || queries.Load() != 0 {
+		t.Errorf("discovery ran before the root zone was known (%d queries)", queries.Load())
+	}
+	setRootZone([]string{"zzreal"})
+	for _, tld := range []string{"zzqxv", "zzqxw", "zzqxx"} {
+		if _, ok := DiscoverWhoisServer(context.Background(), tld); ok {
+			t.Errorf("%s: discovered", tld)
+		}
+	}
+	if got := queries.Load(); got != 0 {
+		t.Errorf("labels outside the root zone sent %d IANA queries", got)
+	}
+	if server, ok := DiscoverWhoisServer(context.Background(), "zzreal"); !ok || server != "whois.nic.zzreal" {
+		t.Errorf("root-zone TLD: got %q, %v", server, ok)
+	}
+}
+
+func TestDiscoverWhoisServerDisabled(t *testing.T) {
+	resetWhoisDiscovery(t, false)
+	setRootZone([]string{"zzoff"})
+	queries := fakeIANA(t, map[string]string{"zzoff": ianaRecord("zzoff", "whois.nic.zzoff")})
+
+	if _, ok := DiscoverWhoisServer(context.Background(), "zzoff"); ok || queries.Load() != 0 {
+		t.Errorf("discovery ran while disabled (%d queries)", queries.Load())
+	}
+}
+
+func TestDiscoverWhoisServerTruncatedRecord(t *testing.T) {
+	resetWhoisDiscovery(t, true)
+	setRootZone([]string{"zzcut"})
+	fakeIANA(t, map[string]string{"zzcut": "domain:       ZZCUT\n"})
+
+	if _, ok := DiscoverWhoisServer(context.Background(), "zzcut"); ok {
+		t.Error("truncated record produced a server")
+	}
+	if _, ok := undiscoverable["zzcut"]; !ok {
+		t.Error("failed discovery not held back from retrying")
+	}
+}
+
+func TestRefreshWhoisTableKeepsLastKnownGood(t *testing.T) {
+	resetWhoisDiscovery(t, false)
+	discoveredWhois["zzkeep"] = "whois.old.zzkeep"
+	discoveredWhois["zzgone"] = "whois.old.zzgone"
+
+	root := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("# Version 2026101700\nZZNEW\nZZKEEP\nZZGONE\n"))
+	}))
+	defer root.Close()
+	oldURL := rootZoneURL
+	rootZoneURL = root.URL
+	t.Cleanup(func() { rootZoneURL = oldURL })
+
+	fakeIANA(t, map[string]string{
+		"zznew":  ianaRecord("zznew", "whois.nic.zznew"),
+		"zzkeep": "domain:       ZZKEEP\n", // cut short: a failed query
+		"zzgone": "domain:       ZZGONE\n\nwhois:\n\nsource:       IANA\n",
+	})
+
+	outcome, entries := refreshWhoisTable(context.Background(), root.Client())
+	if outcome != "partial" || entries != 2 {
+		t.Errorf("got %s with %d entries, want partial with 2", outcome, entries)
+	}
+	want := map[string]string{"zznew": "whois.nic.zznew", "zzkeep": "whois.old.zzkeep"}
+	for tld, server := range want {
+		if got, _ := LookupWhoisServer(tld); got != server {
+			t.Errorf("%s: got %q, want %q", tld, got, server)
+		}
+	}
+	if _, ok := LookupWhoisServer("zzgone"); ok {
+		t.Error("zzgone: IANA reports no server, but the old entry was kept")
+	}
+}
+
+func TestRefreshWhoisTableRootZoneFailure(t *testing.T) {
+	resetWhoisDiscovery(t, false)
+	discoveredWhois["zzkeep"] = "whois.old.zzkeep"
+
+	root := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer root.Close()
+	oldURL := rootZoneURL
+	rootZoneURL = root.URL
+	t.Cleanup(func() { rootZoneURL = oldURL })
+
+	if outcome, _ := refreshWhoisTable(context.Background(), root.Client()); outcome != "failure" {
+		t.Errorf("got %s, want failure", outcome)
+	}
+	if got, _ := LookupWhoisServer("zzkeep"); got != "whois.old.zzkeep" {
+		t.Errorf("table changed after a failed refresh: %q", got)
+	}
+}
+
+func TestSetWhoisOverrides(t *testing.T) {
+	resetWhoisDiscovery(t, false)
+	discoveredWhois["zzover"] = "whois.discovered.zzover"
+	SetWhoisOverrides(map[string]string{"zzover": "whois.configured.zzover:4343", "cn": "whois.configured.cn"})
+	t.Cleanup(func() { SetWhoisOverrides(nil) })
+
+	for tld, want := range map[string]string{"zzover": "whois.configured.zzover:4343", "cn": "whois.configured.cn"} {
+		if got, _ := LookupWhoisServer(tld); got != want {
+			t.Errorf("%s: got %q, want %q", tld, got, want)
+		}
+	}
+}
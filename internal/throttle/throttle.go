@@ -0,0 +1,337 @@
+// Package throttle paces outbound queries to upstream servers under
+// servers.limits, so a burst of inbound traffic cannot get this service's
+// address banned by a registry that limits queries per client. With Redis
+// configured the limits are shared by every replica using the same Redis.
+package throttle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/metrics"
+	"github.com/KincaidYang/whois/internal/serverlist"
+	"github.com/KincaidYang/whois/internal/utils"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// redisKeyPrefix namespaces the shared limiter state in Redis.
+	redisKeyPrefix = "whois:throttle:"
+	// slotLease bounds how long a replica that died mid-query holds a shared
+	// concurrency slot; it is longer than any query may take.
+	slotLease = time.Minute
+	// slotPoll is how often a query waiting for a shared slot asks again.
+	slotPoll = 50 * time.Millisecond
+	// tldPrefix marks servers.limits keys naming a domain suffix.
+	tldPrefix = "tld:"
+)
+
+// LimitedError is returned when a query could not be admitted under its
+// upstream's limits before the request deadline. It wraps
+// utils.ErrUpstreamUnavailable.
+type LimitedError struct {
+	Upstream   string
+	retryAfter time.Duration
+}
+
+func (e *LimitedError) Error() string {
+	return fmt.Sprintf("outbound rate limit for %s exceeded", e.Upstream)
+}
+
+func (e *LimitedError) Unwrap() error {
+	return utils.ErrUpstreamUnavailable
+}
+
+// RetryAfter is how long until the upstream's limits would admit the query.
+func (e *LimitedError) RetryAfter() time.Duration {
+	return max(e.retryAfter, time.Second)
+}
+
+// Acquire waits until a query to upstream — an RDAP host or a WHOIS
+// host:port — may be sent under its servers.limits entry: first for a free
+// concurrency slot, then for a token from its bucket. The caller must call
+// release once the query has finished. Upstreams without an entry pass at
+// once. When the wait would outlast the request deadline the error is a
+// *LimitedError.
+func Acquire(ctx context.Context, upstream string) (release func(), err error) {
+	host := hostname(upstream)
+	limit, ok := limitFor(host)
+	if !ok {
+		return func() {}, nil
+	}
+
+	start := time.Now()
+	release = func() {}
+	if limit.Concurrency > 0 {
+		if release, err = acquireSlot(ctx, host, limit); err != nil {
+			metrics.UpstreamThrottleRejectionsTotal.WithLabelValues(host).Inc()
+			return nil, err
+		}
+	}
+	if limit.Rate > 0 {
+		if err := takeToken(ctx, host, limit); err != nil {
+			release()
+			metrics.UpstreamThrottleRejectionsTotal.WithLabelValues(host).Inc()
+			return nil, err
+		}
+	}
+	metrics.UpstreamThrottleWait.WithLabelValues(host).Observe(time.Since(start).Seconds())
+	return release, nil
+}
+
+// hostname returns upstream without its port, lowercased.
+func hostname(upstream string) string {
+	if host, _, err := net.SplitHostPort(upstream); err == nil {
+		upstream = host
+	}
+	return strings.ToLower(strings.TrimSuffix(upstream, "."))
+}
+
+// suffixState is the sorted suffixes of the "tld:" servers.limits entries of
+// one config.Settings.
+type suffixState struct {
+	settings *config.Settings
+	suffixes []string
+}
+
+// suffixCache caches the suffixState for the current settings. It is rebuilt
+// on first use after a configuration reload swaps the settings.
+var suffixCache atomic.Pointer[suffixState]
+
+// limitSuffixes returns the suffixes of the "tld:" entries of s's
+// servers.limits, in key order.
+func limitSuffixes(s *config.Settings) []string {
+	if c := suffixCache.Load(); c != nil && c.settings == s {
+		return c.suffixes
+	}
+	suffixes := make([]string, 0, len(s.ServerLimits))
+	for key := range s.ServerLimits {
+		if suffix, ok := strings.CutPrefix(key, tldPrefix); ok {
+			suffixes = append(suffixes, suffix)
+		}
+	}
+	sort.Strings(suffixes)
+	suffixCache.Store(&suffixState{settings: s, suffixes: suffixes})
+	return suffixes
+}
+
+// limitFor returns the servers.limits entry for host: its own entry, or the
+// first "tld:" entry (in key order) whose suffix host currently serves over
+// RDAP or WHOIS.
+func limitFor(host string) (config.ServerLimit, bool) {
+	s := config.Current()
+	limits := s.ServerLimits
+	if len(limits) == 0 {
+		return config.ServerLimit{}, false
+	}
+	if limit, ok := limits[host]; ok {
+		return limit, true
+	}
+	for _, suffix := range limitSuffixes(s) {
+		if servesSuffix(host, suffix) {
+			return limits[tldPrefix+suffix], true
+		}
+	}
+	return config.ServerLimit{}, false
+}
+
+// servesSuffix reports whether host is the RDAP or WHOIS server for suffix.
+func servesSuffix(host, suffix string) bool {
+	if server, ok := serverlist.LookupWhoisServer(suffix); ok && hostname(server) == host {
+		return true
+	}
+	if server, ok := serverlist.LookupRdapServer(suffix); ok {
+		if u, err := url.Parse(server); err == nil && strings.ToLower(u.Hostname()) == host {
+			return true
+		}
+	}
+	return false
+}
+
+// local is one host's in-process limiter, used without Redis or while
+// Redis is failing.
+type local struct {
+	limit  config.ServerLimit
+	bucket *rate.Limiter
+	slots  chan struct{}
+}
+
+var (
+	mu     sync.Mutex
+	locals = make(map[string]*local)
+)
+
+// localFor returns host's in-process limiter, replacing it when a reload
+// changed the limit. Queries holding a slot of the old one release it there.
+func localFor(host string, limit config.ServerLimit) *local {
+	mu.Lock()
+	defer mu.Unlock()
+	l := locals[host]
+	if l == nil || l.limit != limit {
+		l = &local{limit: limit, bucket: rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)}
+		if limit.Concurrency > 0 {
+			l.slots = make(chan struct{}, limit.Concurrency)
+		}
+		locals[host] = l
+	}
+	return l
+}
+
+// acquireSlot waits for one of host's concurrency slots.
+func acquireSlot(ctx context.Context, host string, limit config.ServerLimit) (func(), error) {
+	release, err := sharedSlot(ctx, host, limit.Concurrency)
+	var limited *LimitedError
+	if err == nil || errors.As(err, &limited) {
+		return release, err
+	}
+	slots := localFor(host, limit).slots
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, &LimitedError{Upstream: host}
+	}
+}
+
+// takeToken waits for a token from host's bucket, refusing at once when the
+// wait would outlast the request deadline.
+func takeToken(ctx context.Context, host string, limit config.ServerLimit) error {
+	maxWait := time.Duration(math.MaxInt64)
+	if deadline, ok := ctx.Deadline(); ok {
+		maxWait = time.Until(deadline)
+	}
+	wait, admitted, err := sharedToken(ctx, host, limit, maxWait)
+	cancel := func() {}
+	if err != nil {
+		r := localFor(host, limit).bucket.Reserve()
+		wait, admitted, cancel = r.Delay(), r.Delay() <= maxWait, r.Cancel
+		if !admitted {
+			r.Cancel()
+		}
+	}
+	if !admitted {
+		return &LimitedError{Upstream: host, retryAfter: wait}
+	}
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		cancel()
+		return &LimitedError{Upstream: host, retryAfter: wait}
+	}
+}
+
+// errNoRedis makes the limiters fall back to their local state.
+var errNoRedis = errors.New("redis not configured")
+
+// tokenScript is a GCRA token bucket: the key holds the bucket's theoretical
+// arrival time in milliseconds of the Redis clock. ARGV is the interval
+// between tokens in milliseconds, the burst and the longest acceptable wait.
+// It returns {admitted, wait in milliseconds}; a refused query takes no
+// token.
+var tokenScript = redis.NewScript(`
+if redis.replicate_commands then redis.replicate_commands() end
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + tonumber(t[2]) / 1000
+local interval = tonumber(ARGV[1])
+local tat = tonumber(redis.call('GET', KEYS[1])) or now
+if tat < now then tat = now end
+local wait = tat + interval - interval * tonumber(ARGV[2]) - now
+if wait > tonumber(ARGV[3]) then
+  return {0, math.ceil(wait)}
+end
+redis.call('SET', KEYS[1], string.format('%.3f', tat + interval), 'PX', math.ceil(tat + interval - now) + 1000)
+if wait < 0 then wait = 0 end
+return {1, math.ceil(wait)}
+`)
+
+// slotScript takes a concurrency slot: the key is a sorted set of holders
+// scored by when they took it, so the slots of a replica that died are
+// reclaimed after the lease. ARGV is the slot count, the holder's ID and
+// the lease in milliseconds. It returns 1 when the slot was taken.
+var slotScript = redis.NewScript(`
+if redis.replicate_commands then redis.replicate_commands() end
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - tonumber(ARGV[3]))
+if redis.call('ZCARD', KEYS[1]) < tonumber(ARGV[1]) then
+  redis.call('ZADD', KEYS[1], now, ARGV[2])
+  redis.call('PEXPIRE', KEYS[1], ARGV[3])
+  return 1
+end
+return 0
+`)
+
+// sharedToken takes a token from host's bucket in Redis.
+func sharedToken(ctx context.Context, host string, limit config.ServerLimit, maxWait time.Duration) (time.Duration, bool, error) {
+	client := config.RedisClient
+	if client == nil {
+		return 0, false, errNoRedis
+	}
+	interval := 1000 / limit.Rate
+	res, err := tokenScript.Run(ctx, client, []string{redisKeyPrefix + "bucket:" + host},
+		interval, limit.Burst, min(maxWait, time.Hour).Milliseconds()).Int64Slice()
+	if err != nil || len(res) != 2 {
+		return 0, false, sharedFailed(host, err)
+	}
+	return time.Duration(res[1]) * time.Millisecond, res[0] == 1, nil
+}
+
+// sharedSlot takes one of host's concurrency slots in Redis, polling until
+// one is free.
+func sharedSlot(ctx context.Context, host string, slots int) (func(), error) {
+	client := config.RedisClient
+	if client == nil {
+		return nil, errNoRedis
+	}
+	key := redisKeyPrefix + "slots:" + host
+	holder := utils.NewRequestID()
+	for {
+		taken, err := slotScript.Run(ctx, client, []string{key}, slots, holder, slotLease.Milliseconds()).Int()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, &LimitedError{Upstream: host}
+			}
+			return nil, sharedFailed(host, err)
+		}
+		if taken == 1 {
+			return func() {
+				ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+				defer cancel()
+				_ = client.ZRem(ctx, key, holder).Err()
+			}, nil
+		}
+		select {
+		case <-time.After(slotPoll):
+		case <-ctx.Done():
+			return nil, &LimitedError{Upstream: host}
+		}
+	}
+}
+
+// sharedFailed logs a Redis failure; the caller falls back to the local
+// limiter, which keeps this replica within the limit on its own.
+func sharedFailed(host string, err error) error {
+	if err == nil {
+		err = errors.New("unexpected reply")
+	}
+	slog.Warn("Redis unavailable for outbound rate limits, using local limits", "upstream", host, "err", err)
+	return err
+}
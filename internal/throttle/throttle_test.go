@@ -0,0 +1,161 @@
+package throttle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/serverlist"
+	"github.com/KincaidYang/whois/internal/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+// withLimits installs servers.limits for the duration of a test, starting
+// from no local limiter state.
+func withLimits(t *testing.T, limits map[string]config.ServerLimit) {
+	t.Helper()
+	old := config.Current()
+	s := *old
+	s.ServerLimits = limits
+	config.SetCurrent(&s)
+	mu.Lock()
+	locals = make(map[string]*local)
+	mu.Unlock()
+	t.Cleanup(func() { config.SetCurrent(old) })
+}
+
+func TestAcquireUnlimited(t *testing.T) {
+	withLimits(t, map[string]config.ServerLimit{"whois.other.example": {Rate: 0.001, Burst: 1}})
+	for range 10 {
+		release, err := Acquire(context.Background(), "whois.example:43")
+		if err != nil {
+			t.Fatalf("unlimited upstream refused: %v", err)
+		}
+		release()
+	}
+}
+
+func TestAcquireRate(t *testing.T) {
+	withLimits(t, map[string]config.ServerLimit{"rdap.example": {Rate: 10, Burst: 2}})
+
+	// The burst goes out at once; the next query waits for a token.
+	start := time.Now()
+	for range 3 {
+		release, err := Acquire(context.Background(), "RDAP.example")
+		if err != nil {
+			t.Fatalf("refused: %v", err)
+		}
+		release()
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("three queries at 10/s with burst 2 took %v", elapsed)
+	}
+
+	// A wait past the deadline is refused at once, without taking a token.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := Acquire(ctx, "rdap.example")
+	var limited *LimitedError
+	if !errors.As(err, &limited) || !errors.Is(err, utils.ErrUpstreamUnavailable) {
+		t.Fatalf("expected a LimitedError, got %v", err)
+	}
+	if limited.RetryAfter() < time.Second {
+		t.Errorf("RetryAfter = %v", limited.RetryAfter())
+	}
+}
+
+func TestAcquireConcurrency(t *testing.T) {
+	withLimits(t, map[string]config.ServerLimit{"whois.example": {Concurrency: 1}})
+
+	release, err := Acquire(context.Background(), "whois.example:43")
+	if err != nil {
+		t.Fatalf("first query refused: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := Acquire(ctx, "whois.example:4343"); err == nil {
+		t.Fatal("second query admitted while the only slot was held")
+	}
+
+	release()
+	release, err = Acquire(context.Background(), "whois.example:43")
+	if err != nil {
+		t.Fatalf("query refused after release: %v", err)
+	}
+	release()
+}
+
+// TestAcquireTLDRule verifies a "tld:" entry applies to the suffix's
+// current WHOIS and RDAP servers, and that a host's own entry wins.
+func TestAcquireTLDRule(t *testing.T) {
+	serverlist.SetWhoisOverrides(map[string]string{"zzthrottle": "whois.nic.zzthrottle:4343"})
+	serverlist.SetRdapOverrides(map[string]string{"zzthrottle": "https://rdap.nic.zzthrottle/v1/"})
+	t.Cleanup(func() {
+		serverlist.SetWhoisOverrides(nil)
+		serverlist.SetRdapOverrides(nil)
+	})
+	withLimits(t, map[string]config.ServerLimit{
+		"tld:zzthrottle":      {Concurrency: 1},
+		"rdap.nic.zzthrottle": {Concurrency: 2},
+	})
+
+	if limit, ok := limitFor("whois.nic.zzthrottle"); !ok || limit.Concurrency != 1 {
+		t.Errorf("WHOIS server limit = %+v, %v", limit, ok)
+	}
+	if limit, ok := limitFor("rdap.nic.zzthrottle"); !ok || limit.Concurrency != 2 {
+		t.Errorf("RDAP server limit = %+v, %v", limit, ok)
+	}
+	if _, ok := limitFor("whois.nic.other"); ok {
+		t.Error("unrelated server limited")
+	}
+}
+
+// TestLimitSuffixesCached verifies the "tld:" suffixes are sorted once per
+// installed settings and rebuilt after a reload.
+func TestLimitSuffixesCached(t *testing.T) {
+	withLimits(t, map[string]config.ServerLimit{"tld:zzb": {Rate: 1}, "tld:zza": {Rate: 1}, "whois.zzc": {Rate: 1}})
+	first := limitSuffixes(config.Current())
+	if len(first) != 2 || first[0] != "zza" || first[1] != "zzb" {
+		t.Fatalf("suffixes = %v, want [zza zzb]", first)
+	}
+	if again := limitSuffixes(config.Current()); &again[0] != &first[0] {
+		t.Error("suffixes rebuilt for the same settings")
+	}
+
+	withLimits(t, map[string]config.ServerLimit{"tld:zzd": {Rate: 1}})
+	if got := limitSuffixes(config.Current()); len(got) != 1 || got[0] != "zzd" {
+		t.Errorf("suffixes after reload = %v, want [zzd]", got)
+	}
+}
+
+// TestAcquireRedisDown verifies a failing Redis falls back to the local
+// limiters rather than failing or unlimiting queries.
+func TestAcquireRedisDown(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+	old := config.RedisClient
+	config.RedisClient = redis.NewClient(&redis.Options{Addr: addr, MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	t.Cleanup(func() {
+		_ = config.RedisClient.Close()
+		config.RedisClient = old
+	})
+	withLimits(t, map[string]config.ServerLimit{"whois.example": {Rate: 1, Burst: 1, Concurrency: 1}})
+
+	release, err := Acquire(context.Background(), "whois.example:43")
+	if err != nil {
+		t.Fatalf("first query refused: %v", err)
+	}
+	defer release()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := Acquire(ctx, "whois.example:43"); err == nil {
+		t.Error("local limit not applied while Redis is down")
+	}
+}
@@ -11,4 +11,8 @@ var (
 	ErrQueryDenied = errors.New("the registry denied the query")
 	// ErrDomainNotFound is returned when WHOIS data cannot be found or parsed.
 	ErrDomainNotFound = errors.New("domain not found")
+	// ErrUpstreamUnavailable is wrapped by errors returned without querying
+	// an upstream whose circuit breaker is open. Such errors may also have a
+	// RetryAfter() time.Duration method.
+	ErrUpstreamUnavailable = errors.New("upstream temporarily unavailable")
 )
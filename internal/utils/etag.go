@@ -36,6 +36,21 @@ func ETagMatches(ifNoneMatch, etag string) bool {
 	return false
 }
 
+// representationETag returns the entity tag of one representation of a
+// resource. Text, CSV and YAML renderings of the same data must never share a
+// tag, or a client revalidating one would be told another is unchanged, so
+// the media type is hashed with the body. JSON keeps the plain body tag it
+// had before other representations existed, so tags clients already hold
+// stay valid.
+func representationETag(contentType string, body []byte) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	if mediaType == "" || mediaType == "application/json" {
+		return ETagFor(body)
+	}
+	return ETagFor(append([]byte(mediaType+"\n"), body...))
+}
+
 // ConditionalWriter buffers a 200 response so an ETag can be computed over
 // the complete body and compared against the request's If-None-Match header;
 // a match turns the response into 304 Not Modified with no body. Responses
@@ -85,7 +100,7 @@ func (cw *ConditionalWriter) Finish() int {
 	if cw.passthrough {
 		return cw.code
 	}
-	etag := ETagFor(cw.buf.Bytes())
+	etag := representationETag(cw.Header().Get("Content-Type"), cw.buf.Bytes())
 	cw.Header().Set("ETag", etag)
 	if ETagMatches(cw.ifNoneMatch, etag) {
 		// A 304 carries no body, so the buffered Content-Type would only
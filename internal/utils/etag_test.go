@@ -110,3 +110,37 @@ func TestConditionalWriterPassthrough(t *testing.T) {
 		t.Errorf("error response carried an ETag: %q", etag)
 	}
 }
+
+// TestConditionalWriterPerRepresentation verifies the tag varies with the
+// media type, so a tag from one representation never revalidates another,
+// while JSON keeps the plain body tag.
+func TestConditionalWriterPerRepresentation(t *testing.T) {
+	body := []byte("objectClassName: domain\n")
+	tagFor := func(contentType, ifNoneMatch string) (string, int) {
+		rec := httptest.NewRecorder()
+		cw := NewConditionalWriter(rec, ifNoneMatch)
+		cw.Header().Set("Content-Type", contentType)
+		_, _ = cw.Write(body)
+		code := cw.Finish()
+		return rec.Header().Get("ETag"), code
+	}
+
+	jsonTag, _ := tagFor("application/json", "")
+	if jsonTag != ETagFor(body) {
+		t.Errorf("JSON tag changed: %q", jsonTag)
+	}
+	textTag, _ := tagFor("text/plain; charset=utf-8", "")
+	yamlTag, _ := tagFor("application/yaml", "")
+	if textTag == jsonTag || yamlTag == jsonTag || textTag == yamlTag {
+		t.Errorf("representations share a tag: json=%s text=%s yaml=%s", jsonTag, textTag, yamlTag)
+	}
+	if again, _ := tagFor("text/plain;charset=utf-8", ""); again != textTag {
+		t.Errorf("media type parameters changed the tag: %s != %s", again, textTag)
+	}
+	if _, code := tagFor("application/yaml", textTag); code != http.StatusOK {
+		t.Errorf("text tag revalidated the YAML representation: %d", code)
+	}
+	if _, code := tagFor("application/yaml", yamlTag); code != http.StatusNotModified {
+		t.Errorf("YAML tag did not revalidate YAML: %d", code)
+	}
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+// lookupIPAddr resolves host names for ResolvePublic.
+var lookupIPAddr = net.DefaultResolver.LookupIPAddr
+
+// ResolvePublic resolves host, a name or an IP literal, and returns the
+// address to dial. It refuses a host with any address that is loopback,
+// private (RFC 1918, RFC 4193), link-local, multicast, unspecified, carrier-
+// grade NAT, broadcast or NAT64 (see isPublicIP): hops made on the
+// strength of an upstream answer (WHOIS referrals, RDAP registrar links) must
+// not be steerable at the service's own network.
+func ResolvePublic(ctx context.Context, host string) (net.IP, error) {
+	addrs, err := lookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("%s has no addresses", host)
+	}
+	for _, addr := range addrs {
+		if !isPublicIP(addr.IP) {
+			return nil, fmt.Errorf("%s resolves to non-public address %s", host, addr.IP)
+		}
+	}
+	return addrs[0].IP, nil
+}
+
+// nonPublicNets are the ranges beyond those the net.IP predicates cover that
+// can reach hosts other than the public internet's.
+var nonPublicNets = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),          // "this network" (RFC 1122)
+	mustParseCIDR("100.64.0.0/10"),      // carrier-grade NAT (RFC 6598)
+	mustParseCIDR("255.255.255.255/32"), // limited broadcast
+	mustParseCIDR("64:ff9b::/96"),       // NAT64, which maps to any IPv4 address (RFC 6052)
+}
+
+func mustParseCIDR(s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// isPublicIP reports whether ip may be reached on the strength of an
+// upstream answer.
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, n := range nonPublicNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"testing"
+)
+
+func TestResolvePublic(t *testing.T) {
+	orig := lookupIPAddr
+	t.Cleanup(func() { lookupIPAddr = orig })
+	lookupIPAddr = func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		switch host {
+		case "public.test":
+			return []net.IPAddr{{IP: net.ParseIP("192.0.2.10")}}, nil
+		case "mixed.test":
+			return []net.IPAddr{{IP: net.ParseIP("192.0.2.10")}, {IP: net.ParseIP("10.0.0.1")}}, nil
+		}
+		return orig(ctx, host)
+	}
+
+	if ip, err := ResolvePublic(context.Background(), "public.test"); err != nil || !ip.Equal(net.ParseIP("192.0.2.10")) {
+		t.Errorf("public.test = %v, %v; want 192.0.2.10", ip, err)
+	}
+	for _, host := range []string{"100.63.255.255", "100.128.0.1", "1.1.1.1", "2001:db8::1"} {
+		if _, err := ResolvePublic(context.Background(), host); err != nil {
+			t.Errorf("%s refused: %v", host, err)
+		}
+	}
+	for _, host := range []string{"localhost", "mixed.test", "127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "::1", "fe80::1", "fd00::1", "0.0.0.0",
+		"0.1.2.3", "100.64.0.1", "100.127.255.254", "224.0.0.1", "239.1.2.3", "ff02::1", "255.255.255.255", "64:ff9b::7f00:1", "::ffff:127.0.0.1"} {
+		if ip, err := ResolvePublic(context.Background(), host); err == nil {
+			t.Errorf("%s resolved to %v, want it refused", host, ip)
+		}
+	}
+}
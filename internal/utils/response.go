@@ -101,6 +101,26 @@ func WriteBatchDisabled(w http.ResponseWriter) {
 		"Batch queries are turned off on this instance. The operator can enable them with batch.enabled in the configuration.")
 }
 
+// WriteJobsDisabled writes the 403 problem response returned when the
+// asynchronous jobs endpoint is requested but jobs.enabled is off (the
+// default).
+func WriteJobsDisabled(w http.ResponseWriter) {
+	writeProblem(w, http.StatusForbidden, "jobs-disabled",
+		"Asynchronous jobs are disabled",
+		"Asynchronous batch jobs are turned off on this instance. The operator can enable them with jobs.enabled in the configuration.")
+}
+
+// WriteTooManyJobs writes the problem response returned when a new job would
+// exceed jobs.maxRunningPerKey (429: the API key must wait for one of its
+// jobs to finish) or jobs.maxRunning (503: the instance is busy).
+func WriteTooManyJobs(w http.ResponseWriter, statusCode int) {
+	detail := "The API key already runs as many jobs as it may. Submit the job again once one of them has finished."
+	if statusCode == http.StatusServiceUnavailable {
+		detail = "The server already runs as many jobs as it may. Submit the job again later."
+	}
+	writeProblem(w, statusCode, "too-many-jobs", "Too many running jobs", detail)
+}
+
 // WriteRateLimitedBatch writes the 429 problem response returned when a batch
 // request asks for more items than the API key's per-minute budget could ever
 // grant, so no Retry-After would make it succeed — the batch must shrink.
@@ -110,6 +130,20 @@ func WriteRateLimitedBatch(w http.ResponseWriter) {
 		"The batch exceeds the API key's per-minute request budget. Reduce the batch size.")
 }
 
+// WriteUpstreamUnavailable writes the 503 problem response returned without
+// querying an upstream whose circuit breaker is open. When err knows when
+// the upstream will be tried again, that becomes the Retry-After header.
+func WriteUpstreamUnavailable(w http.ResponseWriter, err error) {
+	var retry interface{ RetryAfter() time.Duration }
+	if errors.As(err, &retry) {
+		seconds := max(int(math.Ceil(retry.RetryAfter().Seconds())), 1)
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
+	writeProblem(w, http.StatusServiceUnavailable, "upstream-unavailable",
+		"Upstream temporarily unavailable",
+		"The registry's server has been failing and is not being queried for now. Retry after the delay in the Retry-After header.")
+}
+
 // HandleQueryError handles common query errors with appropriate HTTP responses.
 // Unexpected errors are logged in full but reported to the client with a
 // generic message, so internal details such as upstream server addresses and
@@ -120,6 +154,9 @@ func HandleQueryError(ctx context.Context, w http.ResponseWriter, err error) {
 		writeProblem(w, http.StatusNotFound, "not-found", "Resource not found", "")
 	case errors.Is(err, ErrQueryDenied):
 		writeProblem(w, http.StatusForbidden, "query-denied", "The registry denied the query", "")
+	case errors.Is(err, ErrUpstreamUnavailable):
+		slog.WarnContext(ctx, "query rejected", "err", err)
+		WriteUpstreamUnavailable(w, err)
 	default:
 		// A canceled or expired context is the request's own lifecycle
 		// (client disconnect, request timeout), not an upstream failure;
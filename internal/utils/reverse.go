@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// ReverseZone returns the reverse DNS zone of a prefix: "2.0.192.in-addr.arpa"
+// for 192.0.2.0/24, "8.b.d.0.1.0.0.2.ip6.arpa" for 2001:db8::/32. Zones only
+// exist on octet (IPv4) or nibble (IPv6) boundaries, so the prefix length is
+// rounded down to one; the prefix must be valid.
+func ReverseZone(p netip.Prefix) string {
+	addr := p.Addr().Unmap()
+	bits := p.Bits()
+	if p.Addr().Is4In6() {
+		bits = max(bits-96, 0)
+	}
+
+	var labels []string
+	if addr.Is4() {
+		octets := addr.As4()
+		for i := bits/8 - 1; i >= 0; i-- {
+			labels = append(labels, strconv.Itoa(int(octets[i])))
+		}
+		return strings.Join(append(labels, "in-addr.arpa"), ".")
+	}
+	bytes := addr.As16()
+	for i := bits/4 - 1; i >= 0; i-- {
+		nibble := bytes[i/2] >> 4
+		if i%2 == 1 {
+			nibble = bytes[i/2] & 0x0f
+		}
+		labels = append(labels, strconv.FormatUint(uint64(nibble), 16))
+	}
+	return strings.Join(append(labels, "ip6.arpa"), ".")
+}
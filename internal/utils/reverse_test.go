@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestReverseZone(t *testing.T) {
+	tests := []struct {
+		prefix, want string
+	}{
+		{"192.0.2.0/24", "2.0.192.in-addr.arpa"},
+		{"192.0.2.0/23", "0.192.in-addr.arpa"}, // rounded down to /16
+		{"192.0.2.1/32", "1.2.0.192.in-addr.arpa"},
+		{"10.0.0.0/8", "10.in-addr.arpa"},
+		{"0.0.0.0/0", "in-addr.arpa"},
+		{"::ffff:192.0.2.0/120", "2.0.192.in-addr.arpa"},
+		{"2001:db8::/32", "8.b.d.0.1.0.0.2.ip6.arpa"},
+		{"2001:db8:abc0::/44", "c.b.a.8.b.d.0.1.0.0.2.ip6.arpa"},
+		{"2001:db8:abc0::/46", "c.b.a.8.b.d.0.1.0.0.2.ip6.arpa"}, // rounded down to /44
+	}
+	for _, tt := range tests {
+		if got := ReverseZone(netip.MustParsePrefix(tt.prefix)); got != tt.want {
+			t.Errorf("ReverseZone(%s) = %q, want %q", tt.prefix, got, tt.want)
+		}
+	}
+}
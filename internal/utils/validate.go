@@ -15,6 +15,10 @@ var (
 	// the final label may be either an alphabetic TLD or a punycode TLD such as
 	// "xn--fiqs8s" (.中国), which contains digits and hyphens.
 	domainRegex = regexp.MustCompile(`^(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+(?:[a-zA-Z]{2,}|xn--[a-zA-Z0-9-]+)$`)
+	// entityHandleRegex accepts the handle shapes registries issue
+	// ("ABC123-ARIN", "292-IANA", "RIPE-NCC-HM-MNT", "C_1234-VRSN") and
+	// nothing that could change the meaning of the upstream URL.
+	entityHandleRegex = regexp.MustCompile(`^[a-zA-Z0-9](?:[a-zA-Z0-9_.-]{0,253}[a-zA-Z0-9])?$`)
 )
 
 // IsASN reports whether the given resource is an Autonomous System Number (ASN).
@@ -32,6 +36,21 @@ const (
 	KindUnknown = "unknown"
 )
 
+// KindNameserver is the resource type of the typed /nameserver/ path. It is
+// never returned by ClassifyResource: a host name is syntactically a domain,
+// so only the path the request arrived on tells the two apart.
+const KindNameserver = "nameserver"
+
+// KindEntity is the resource type of the typed /entity/ path. Like
+// KindNameserver it is never returned by ClassifyResource: many handles
+// ("AS3356-ARIN") also read as another kind of resource.
+const KindEntity = "entity"
+
+// KindReverse is the resource type of the typed /reverse/ path: an IP
+// address or prefix whose reverse DNS delegation is looked up. Like
+// KindNameserver it is never returned by ClassifyResource.
+const KindReverse = "reverse"
+
 // ClassifyResource reports which kind of resource s names, along with the
 // canonical form the query should use. It is the single entry-point
 // classifier: the HTTP handler, the batch endpoint and the MCP tool all route
@@ -60,6 +79,13 @@ func ClassifyResource(s string) (kind, canonical string) {
 	return KindUnknown, s
 }
 
+// IsEntityHandle reports whether the given resource is a syntactically valid
+// RDAP entity handle. Whether its object tag names a known registry is left
+// to the lookup.
+func IsEntityHandle(resource string) bool {
+	return entityHandleRegex.MatchString(resource)
+}
+
 // IsDomain reports whether the given resource is a valid domain name.
 // IDN (Unicode) domains such as "müller.de" or "例子.cn" are converted to their
 // ASCII/punycode form before validation, matching the conversion HandleDomain
@@ -91,3 +91,30 @@ func TestIsDomain(t *testing.T) {
 		}
 	}
 }
+
+func TestIsEntityHandle(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"ABC123-ARIN", true},
+		{"292-IANA", true},
+		{"RIPE-NCC-HM-MNT", true},
+		{"CN-C-2.1-CNNIC", true},
+		{"C_1234-VRSN", true},
+		{"x", true},
+		{"-ARIN", false},
+		{"ABC123-", false},
+		{"ABC 123-ARIN", false},
+		{"ABC/123-ARIN", false},
+		{"ABC123-ARIN?x=1", false},
+		{"", false},
+	}
+
+	for _, test := range tests {
+		result := IsEntityHandle(test.input)
+		if result != test.expected {
+			t.Errorf("IsEntityHandle(%q) = %v; want %v", test.input, result, test.expected)
+		}
+	}
+}
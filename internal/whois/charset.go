@@ -0,0 +1,177 @@
+package whois
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/metrics"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// Charset names, as golang.org/x/text/encoding/htmlindex knows them.
+const (
+	charsetUTF8      = "utf-8"
+	charsetGB18030   = "gb18030"
+	charsetEUCKR     = "euc-kr"
+	charsetShiftJIS  = "shift_jis"
+	charsetISO2022JP = "iso-2022-jp"
+	charsetBig5      = "big5"
+	charsetKOI8R     = "koi8-r"
+	// charsetLatin1 is what "iso-8859-1" means on the wire (WHATWG), and
+	// the last resort: every byte sequence decodes.
+	charsetLatin1 = "windows-1252"
+)
+
+// defaultCharsets are the charsets registries known to answer outside UTF-8
+// use, by domain suffix. servers.charsets adds to and overrides them. A hint
+// is only consulted for an answer that is not valid UTF-8, so a registry
+// that has moved to UTF-8 is unaffected.
+var defaultCharsets = map[string]string{
+	"cn":           charsetGB18030,
+	"xn--fiqs8s":   charsetGB18030, // .中国
+	"xn--fiqz9s":   charsetGB18030, // .中國
+	"kr":           charsetEUCKR,
+	"xn--3e0b707e": charsetEUCKR, // .한국
+	"jp":           charsetShiftJIS,
+	"tw":           charsetBig5,
+	"xn--kprw13d":  charsetBig5, // .台湾
+	"xn--kpry57d":  charsetBig5, // .台灣
+	"ru":           charsetKOI8R,
+	"su":           charsetKOI8R,
+	"xn--p1ai":     charsetKOI8R, // .рф
+}
+
+// detectCharsets are tried in order on a non-UTF-8 answer without a usable
+// hint. The ones whose script is distinctive (kana, Hangul) come first and
+// must show it; the simplified Chinese reading of Big5 text is wrong but
+// plausible, so .tw and its IDNs rely on their hint.
+var detectCharsets = []struct {
+	name     string
+	script   []*unicode.RangeTable // runes that count as plausible text
+	requires []*unicode.RangeTable // at least one such rune must appear
+}{
+	{charsetShiftJIS, []*unicode.RangeTable{unicode.Hiragana, unicode.Katakana, unicode.Han, cjkPunctuation}, []*unicode.RangeTable{unicode.Hiragana, unicode.Katakana}},
+	{charsetEUCKR, []*unicode.RangeTable{unicode.Hangul, cjkPunctuation}, []*unicode.RangeTable{unicode.Hangul}},
+	{charsetKOI8R, []*unicode.RangeTable{unicode.Cyrillic}, nil},
+	{charsetGB18030, []*unicode.RangeTable{unicode.Han, cjkPunctuation}, nil},
+}
+
+// cjkPunctuation is CJK symbols and punctuation plus the full-width ASCII
+// forms, common in Chinese, Japanese and Korean text alike. Half-width
+// katakana (U+FF61–U+FF9F) is left out: it is rare in real text but is what
+// Chinese bytes look like read as Shift_JIS.
+var cjkPunctuation = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x3000, Hi: 0x303f, Stride: 1},
+		{Lo: 0xff01, Hi: 0xff60, Stride: 1},
+		{Lo: 0xffe0, Hi: 0xffe6, Stride: 1},
+	},
+}
+
+// minScriptShare is the share of a decoding's non-ASCII runes that must
+// fall in the candidate's script for detection to pick it.
+const minScriptShare = 0.9
+
+// decodeResponse returns a WHOIS answer as UTF-8 text along with the charset
+// it was decoded from. Answers already in UTF-8 (which includes plain ASCII)
+// are returned unchanged. Otherwise the hint for tld is tried, then each of
+// detectCharsets, and Latin-1 when nothing fits.
+func decodeResponse(body []byte, tld string) (text, charset string) {
+	// ISO-2022-JP is 7-bit and so valid UTF-8; its escape sequences give it
+	// away.
+	if bytes.Contains(body, []byte("\x1b$B")) || bytes.Contains(body, []byte("\x1b$@")) {
+		if text, ok := decodeAs(body, charsetISO2022JP); ok {
+			return transcoded(text, charsetISO2022JP)
+		}
+	}
+	if utf8.Valid(body) {
+		return string(body), charsetUTF8
+	}
+	if hint := charsetHint(tld); hint != "" {
+		if text, ok := decodeAs(body, hint); ok {
+			return transcoded(text, hint)
+		}
+	}
+	for _, candidate := range detectCharsets {
+		text, ok := decodeAs(body, candidate.name)
+		if ok && plausible(text, candidate.script, candidate.requires) {
+			return transcoded(text, candidate.name)
+		}
+	}
+	text, _ = decodeAs(body, charsetLatin1)
+	return transcoded(text, charsetLatin1)
+}
+
+// transcoded counts an answer decoded from charset.
+func transcoded(text, charset string) (string, string) {
+	metrics.WhoisTranscodedTotal.WithLabelValues(charset).Inc()
+	return text, charset
+}
+
+// charsetHint returns the configured or default charset for tld or, for a
+// multi-label suffix ("com.cn"), for the longest suffix of it that has one.
+func charsetHint(tld string) string {
+	overrides := config.Current().WhoisCharsets
+	for suffix := strings.ToLower(tld); suffix != ""; {
+		if charset, ok := overrides[suffix]; ok {
+			return charset
+		}
+		if charset, ok := defaultCharsets[suffix]; ok {
+			return charset
+		}
+		_, rest, found := strings.Cut(suffix, ".")
+		if !found {
+			break
+		}
+		suffix = rest
+	}
+	return ""
+}
+
+// decodeAs decodes body from charset, reporting false when the charset is
+// unknown or the body is not valid in it (the decoder replaced some of it).
+func decodeAs(body []byte, charset string) (string, bool) {
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return "", false
+	}
+	out, err := enc.NewDecoder().Bytes(body)
+	if err != nil {
+		return "", false
+	}
+	return string(out), !bytes.ContainsRune(out, utf8.RuneError)
+}
+
+// plausible reports whether text reads as written in script: most of its
+// non-ASCII runes fall in it, including at least one in requires. A rune
+// next to an ASCII letter does not count: that is an accented letter inside
+// a Latin word ("Zürich"), which single-byte charsets such as KOI8-R would
+// otherwise read as a word of Cyrillic.
+func plausible(text string, script, requires []*unicode.RangeTable) bool {
+	var nonASCII, inScript int
+	required := len(requires) == 0
+	runes := []rune(text)
+	for i, r := range runes {
+		if r < utf8.RuneSelf {
+			continue
+		}
+		nonASCII++
+		if (i > 0 && isASCIILetter(runes[i-1])) || (i+1 < len(runes) && isASCIILetter(runes[i+1])) {
+			continue
+		}
+		if unicode.In(r, script...) {
+			inScript++
+		}
+		if !required && unicode.In(r, requires...) {
+			required = true
+		}
+	}
+	return required && nonASCII > 0 && float64(inScript) >= minScriptShare*float64(nonASCII)
+}
+
+func isASCIILetter(r rune) bool {
+	return r < utf8.RuneSelf && unicode.IsLetter(r)
+}
@@ -0,0 +1,116 @@
+package whois
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/serverlist"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// encode renders UTF-8 text in enc, as a registry would send it.
+func encode(t *testing.T, enc encoding.Encoding, text string) []byte {
+	t.Helper()
+	out, err := enc.NewEncoder().Bytes([]byte(text))
+	if err != nil {
+		t.Fatalf("encode %q: %v", text, err)
+	}
+	return out
+}
+
+const (
+	textZH = "Domain Name: 例子.cn\nRegistrant: 北京某某科技有限公司\nSponsoring Registrar: 阿里云计算有限公司（万网）\n"
+	textKO = "도메인이름: 예제.kr\n등록인: 주식회사 예제\n책임자: 홍길동\n"
+	textJA = "a. [ドメイン名] 例え.jp\ng. [組織名] 株式会社サンプル\n[登録年月日] 2001/05/23\n[有効期限] 2027/05/31\n[状態] 有効\n"
+	textTW = "Domain Name: 範例.tw\nRegistrant: 範例股份有限公司\n"
+	textRU = "domain: ПРИМЕР.РФ\norg: Общество с ограниченной ответственностью Пример\n"
+	textDE = "Domain: beispiel.de\nOrt: Zürich, Straße 5\n"
+)
+
+func TestDecodeResponse(t *testing.T) {
+	cases := []struct {
+		name, tld, want string
+		body            []byte
+	}{
+		{"utf-8", "cn", charsetUTF8, []byte(textZH)},
+		{"ascii", "com", charsetUTF8, []byte("Domain Name: EXAMPLE.COM\n")},
+		{"gbk with hint", "cn", charsetGB18030, encode(t, simplifiedchinese.GBK, textZH)},
+		{"gbk detected", "com", charsetGB18030, encode(t, simplifiedchinese.GBK, textZH)},
+		{"gb18030 under a second-level suffix", "com.cn", charsetGB18030, encode(t, simplifiedchinese.GB18030, textZH)},
+		{"euc-kr detected", "com", charsetEUCKR, encode(t, korean.EUCKR, textKO)},
+		{"shift_jis detected", "com", charsetShiftJIS, encode(t, japanese.ShiftJIS, textJA)},
+		{"iso-2022-jp", "jp", charsetISO2022JP, encode(t, japanese.ISO2022JP, textJA)},
+		{"big5 with hint", "tw", charsetBig5, encode(t, traditionalchinese.Big5, textTW)},
+		{"koi8-r detected", "com", charsetKOI8R, encode(t, charmap.KOI8R, textRU)},
+		{"latin-1 fallback", "de", charsetLatin1, encode(t, charmap.ISO8859_1, textDE)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			text, charset := decodeResponse(tc.body, tc.tld)
+			if charset != tc.want {
+				t.Errorf("charset = %q, want %q", charset, tc.want)
+			}
+			want := map[string]string{
+				charsetUTF8: string(tc.body), charsetGB18030: textZH, charsetEUCKR: textKO,
+				charsetShiftJIS: textJA, charsetISO2022JP: textJA, charsetBig5: textTW,
+				charsetKOI8R: textRU, charsetLatin1: textDE,
+			}[tc.want]
+			if text != want {
+				t.Errorf("text = %q, want %q", text, want)
+			}
+		})
+	}
+}
+
+// TestDecodeResponseWrongHint verifies an answer that is not valid in its
+// TLD's hinted charset falls through to detection.
+func TestDecodeResponseWrongHint(t *testing.T) {
+	body := encode(t, korean.EUCKR, textKO)
+	body = append(body, 0xff) // invalid as a lone EUC-KR byte, and as GB18030
+	if _, charset := decodeResponse(body, "cn"); charset == charsetGB18030 {
+		t.Errorf("invalid GB18030 decoded as GB18030")
+	}
+}
+
+func TestDecodeResponseConfiguredCharset(t *testing.T) {
+	old := config.Current()
+	s := *old
+	s.WhoisCharsets = map[string]string{"zzcharset": charsetBig5}
+	config.SetCurrent(&s)
+	t.Cleanup(func() { config.SetCurrent(old) })
+
+	// Without the entry this would be detected as simplified Chinese.
+	text, charset := decodeResponse(encode(t, traditionalchinese.Big5, textTW), "zzcharset")
+	if charset != charsetBig5 || text != textTW {
+		t.Errorf("got %q, %q; want the configured big5", charset, text)
+	}
+}
+
+// TestWhoisTranscodes verifies a Shift_JIS answer reaches the JP parser as
+// UTF-8, so its Japanese field names match.
+func TestWhoisTranscodes(t *testing.T) {
+	addr, cleanup := startMockWhoisServer(string(encode(t, japanese.ShiftJIS, textJA)))
+	defer cleanup()
+	serverlist.TLDToWhoisServer = map[string]string{"jp": addr}
+
+	hops, err := WhoisWithReferrals(context.Background(), "例え.jp", "jp")
+	if err != nil {
+		t.Fatalf("WhoisWithReferrals: %v", err)
+	}
+	if hops[0].Charset != charsetShiftJIS || hops[0].Text != textJA {
+		t.Fatalf("hop = %+v", hops[0])
+	}
+	info, err := ParseWhoisResponseJP(hops[0].Text, "例え.jp")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if info.RegistrationDate == "" {
+		t.Errorf("登録年月日 not parsed from the transcoded answer: %+v", info)
+	}
+}
@@ -0,0 +1,55 @@
+package whois
+
+import (
+	"strings"
+
+	"github.com/KincaidYang/whois/internal/config"
+)
+
+// tldPrefix marks query template keys naming a domain suffix.
+const tldPrefix = "tld:"
+
+// defaultQueries are the query templates of registries whose plain query
+// leaves out data or answers in a format we parse less well, keyed by server
+// host name or by tldPrefix and a suffix. servers.queries adds to and
+// overrides them. JPRS's English output ("{domain}/e", named "jprs-en") is
+// left to servers.queries: a default would change every .jp answer.
+var defaultQueries = map[string]config.WhoisQuery{
+	// DENIC answers a bare name with its status only; -T dn,ace adds the
+	// delegation, IDNs in their ACE (punycode) form.
+	"whois.denic.de": {Query: "-T dn,ace " + config.WhoisQueryDomain, Name: "denic"},
+	// A bare name also matches name server and registrar records.
+	"whois.verisign-grs.com": {Query: "domain =" + config.WhoisQueryDomain, Name: "verisign"},
+}
+
+// plainQuery is the query sent to servers without a template: the domain.
+var plainQuery = config.WhoisQuery{Query: config.WhoisQueryDomain}
+
+// queryFor returns the query template for the WHOIS server host, queried for
+// a domain under suffix ("" for a referral, where the suffix says nothing
+// about the server). Configured entries win over built-in ones; within each,
+// the server's own entry wins over the longest suffix entry.
+func queryFor(host, suffix string) config.WhoisQuery {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, table := range []map[string]config.WhoisQuery{config.Current().WhoisQueries, defaultQueries} {
+		if q, ok := table[host]; ok {
+			return q
+		}
+		for s := strings.ToLower(suffix); s != ""; {
+			if q, ok := table[tldPrefix+s]; ok {
+				return q
+			}
+			_, rest, found := strings.Cut(s, ".")
+			if !found {
+				break
+			}
+			s = rest
+		}
+	}
+	return plainQuery
+}
+
+// queryLine returns the line q sends for domain, without the line ending.
+func queryLine(q config.WhoisQuery, domain string) string {
+	return strings.ReplaceAll(q.Query, config.WhoisQueryDomain, domain)
+}
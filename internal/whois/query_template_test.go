@@ -0,0 +1,131 @@
+package whois
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/serverlist"
+)
+
+// withQueries installs servers.queries for the duration of a test.
+func withQueries(t *testing.T, queries map[string]config.WhoisQuery) {
+	t.Helper()
+	old := config.Current()
+	s := *old
+	s.WhoisQueries = queries
+	config.SetCurrent(&s)
+	t.Cleanup(func() { config.SetCurrent(old) })
+}
+
+// startRecordingWhoisServer answers one connection with response, after
+// sending greeting if set, and sends what the client wrote on received. With
+// untilEOF the query is read up to the client's half-close rather than the
+// line ending.
+func startRecordingWhoisServer(t *testing.T, greeting, response string, untilEOF bool) (port int, received <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	ch := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		if greeting != "" {
+			_, _ = conn.Write([]byte(greeting))
+		}
+		var query string
+		if untilEOF {
+			b, _ := io.ReadAll(conn)
+			query = string(b)
+		} else {
+			query, _ = bufio.NewReader(conn).ReadString('\n')
+		}
+		ch <- query
+		_, _ = conn.Write([]byte(response))
+	}()
+	return ln.Addr().(*net.TCPAddr).Port, ch
+}
+
+func TestQueryFor(t *testing.T) {
+	withQueries(t, map[string]config.WhoisQuery{
+		"tld:zzq":        {Query: "{domain}/x", Name: "configured-tld"},
+		"tld:zzdefault":  {Query: "{domain}", Name: "configured-default"},
+		"whois.zzq.host": {Query: "host {domain}", Name: "configured-host"},
+	})
+
+	cases := []struct {
+		host, suffix, want string
+	}{
+		{"whois.zzq.host", "zzq", "configured-host"},                  // the server's entry wins over its suffix's
+		{"whois.other", "co.zzq", "configured-tld"},                   // a parent suffix's entry applies
+		{"whois.other", "", ""},                                       // referrals match by host only
+		{"WHOIS.DENIC.DE.", "de", "denic"},                            // built-in
+		{"whois.jprs.jp", "jp", ""},                                   // English output is opt-in
+		{"whois.verisign-grs.com", "zzdefault", "configured-default"}, // configured entry replaces the built-in one
+		{"whois.other", "com", ""},
+	}
+	for _, tc := range cases {
+		if got := queryFor(tc.host, tc.suffix).Name; got != tc.want {
+			t.Errorf("queryFor(%q, %q) = %q, want %q", tc.host, tc.suffix, got, tc.want)
+		}
+	}
+	if got := queryLine(queryFor("whois.denic.de", "de"), "example.de"); got != "-T dn,ace example.de" {
+		t.Errorf("DENIC query = %q", got)
+	}
+}
+
+// TestWhoisQueryTemplate verifies a suffix's template sets the query line and,
+// for a server address without one, the port, and that the hop names it.
+func TestWhoisQueryTemplate(t *testing.T) {
+	port, received := startRecordingWhoisServer(t, "", "Domain: example.zzq\n", false)
+	serverlist.SetWhoisOverrides(map[string]string{"zzq": "127.0.0.1"})
+	t.Cleanup(func() { serverlist.SetWhoisOverrides(nil) })
+	withQueries(t, map[string]config.WhoisQuery{
+		"tld:zzq": {Query: "-x {domain}", Port: port, Name: "zzq-full"},
+	})
+
+	hops, err := WhoisWithReferrals(context.Background(), "example.zzq", "zzq")
+	if err != nil {
+		t.Fatalf("WhoisWithReferrals: %v", err)
+	}
+	if got := <-received; got != "-x example.zzq\r\n" {
+		t.Errorf("query = %q", got)
+	}
+	if hops[0].Template != "zzq-full" || hops[0].Server != "127.0.0.1" || hops[0].Text != "Domain: example.zzq\n" {
+		t.Errorf("hop = %+v", hops[0])
+	}
+}
+
+// TestWhoisQueryGreetingHalfClose verifies a greeting is skipped before the
+// query and left out of the answer, and that the query is followed by a
+// half-close.
+func TestWhoisQueryGreetingHalfClose(t *testing.T) {
+	port, received := startRecordingWhoisServer(t, "% Welcome\r\n", "Domain: example.zzq\n", true)
+	serverlist.SetWhoisOverrides(map[string]string{"zzq": net.JoinHostPort("127.0.0.1", strconv.Itoa(port))})
+	t.Cleanup(func() { serverlist.SetWhoisOverrides(nil) })
+	withQueries(t, map[string]config.WhoisQuery{
+		"127.0.0.1": {Query: "{domain}", Greeting: true, HalfClose: true},
+	})
+
+	result, err := Whois(context.Background(), "example.zzq", "zzq")
+	if err != nil {
+		t.Fatalf("Whois: %v", err)
+	}
+	if got := <-received; got != "example.zzq\r\n" {
+		t.Errorf("query = %q", got)
+	}
+	if strings.Contains(result, "Welcome") || result != "Domain: example.zzq\n" {
+		t.Errorf("result = %q", result)
+	}
+}
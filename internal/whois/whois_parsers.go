@@ -142,6 +142,12 @@ var (
 	reJPStatus       = regexp.MustCompile(`\[状態\]\s+(.*)`)
 	reJPLockStatus   = regexp.MustCompile(`\[ロック状態\]\s+(.*)`)
 	reJPUpdatedDate  = regexp.MustCompile(`\[最終更新\]\s+(.*)`)
+	// 英文格式（"<域名>/e" 查询）
+	reJPCreationDateEN = regexp.MustCompile(`\[(?:Created on|Registered Date)\]\s+(.*)`)
+	reJPExpiryDateEN   = regexp.MustCompile(`\[Expires on\]\s+(.*)`)
+	reJPStatusEN       = regexp.MustCompile(`\[(?:Status|State)\]\s+(.*)`)
+	reJPLockStatusEN   = regexp.MustCompile(`\[Lock Status\]\s+(.*)`)
+	reJPUpdatedDateEN  = regexp.MustCompile(`\[Last Updated?\]\s+(.*)`)
 	// 工具正则
 	reJPExpiryInStatus = regexp.MustCompile(`\((\d{4}/\d{2}/\d{2})\)`)
 	reTZSuffix         = regexp.MustCompile(`\s*\([A-Z]+\)\s*$`)
@@ -849,8 +855,29 @@ func ParseWhoisResponseLA(response string, domain string) (model.DomainInfo, err
 	return domainInfo, nil
 }
 
+// jpLabels are the JPRS field labels that differ between the Japanese answer
+// and the English one.
+type jpLabels struct {
+	creation, expiry, status, lockStatus, updated *regexp.Regexp
+}
+
+var (
+	jpLabelsJA = jpLabels{reJPCreationDate, reJPExpiryDate, reJPStatus, reJPLockStatus, reJPUpdatedDate}
+	jpLabelsEN = jpLabels{reJPCreationDateEN, reJPExpiryDateEN, reJPStatusEN, reJPLockStatusEN, reJPUpdatedDateEN}
+)
+
 // ParseWhoisResponseJP parses WHOIS response for .jp domains (including .co.jp and other variants)
 func ParseWhoisResponseJP(response string, domain string) (model.DomainInfo, error) {
+	return parseWhoisResponseJP(response, domain, jpLabelsJA)
+}
+
+// ParseWhoisResponseJPEnglish parses JPRS's English answer, sent for a
+// "<domain>/e" query (the "jprs-en" query template).
+func ParseWhoisResponseJPEnglish(response string, domain string) (model.DomainInfo, error) {
+	return parseWhoisResponseJP(response, domain, jpLabelsEN)
+}
+
+func parseWhoisResponseJP(response, domain string, labels jpLabels) (model.DomainInfo, error) {
 	domainInfo := newDomainInfo(domain)
 
 	// 解析域名 - 尝试两种格式
@@ -877,18 +904,18 @@ func ParseWhoisResponseJP(response string, domain string) (model.DomainInfo, err
 	}
 
 	// 解析注册日期 (格式: 2001/05/23)
-	if dateStr := matchFirstGroup(reJPCreationDate, response, nil); dateStr != "" {
+	if dateStr := matchFirstGroup(labels.creation, response, nil); dateStr != "" {
 		domainInfo.RegistrationDate = normDate(dateStr, zoneJST)
 	}
 
 	// 解析过期日期 - 优先 [有効期限] 字段，再从 [状態] 中提取
-	if dateStr := matchFirstGroup(reJPExpiryDate, response, nil); dateStr != "" {
+	if dateStr := matchFirstGroup(labels.expiry, response, nil); dateStr != "" {
 		domainInfo.ExpirationDate = normDate(dateStr, zoneJST)
 	}
 
 	// 解析 [状態] - 同时提取过期日期(如有)和状态文本
 	var statuses []string
-	if statusStr := matchFirstGroup(reJPStatus, response, nil); statusStr != "" {
+	if statusStr := matchFirstGroup(labels.status, response, nil); statusStr != "" {
 		// 从状态中提取过期日期 (适用于 co.jp: "Connected (2026/10/31)")
 		if domainInfo.ExpirationDate == "" {
 			if matchExpiry := reJPExpiryInStatus.FindStringSubmatch(statusStr); len(matchExpiry) > 1 {
@@ -903,7 +930,7 @@ func ParseWhoisResponseJP(response string, domain string) (model.DomainInfo, err
 	}
 
 	// 解析锁定状态
-	for _, match := range reJPLockStatus.FindAllStringSubmatch(response, -1) {
+	for _, match := range labels.lockStatus.FindAllStringSubmatch(response, -1) {
 		if len(match) > 1 {
 			statuses = append(statuses, strings.TrimSpace(match[1]))
 		}
@@ -913,7 +940,7 @@ func ParseWhoisResponseJP(response string, domain string) (model.DomainInfo, err
 	}
 
 	// 解析最终更新时间 (格式: 2025/06/01 01:05:04 (JST))
-	if dateStr := matchFirstGroup(reJPUpdatedDate, response, nil); dateStr != "" {
+	if dateStr := matchFirstGroup(labels.updated, response, nil); dateStr != "" {
 		domainInfo.LastChangedDate = normDate(dateStr, zoneJST)
 	}
 
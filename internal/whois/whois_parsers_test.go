@@ -585,6 +585,65 @@ p. [ネームサーバ] ns1.example.co.jp
 	}
 }
 
+// TestParseWhoisResponseJPEnglish covers the answers to the "/e" query, for
+// a .jp domain and for a .co.jp one.
+func TestParseWhoisResponseJPEnglish(t *testing.T) {
+	jp := `[Domain Name]                   EXAMPLE.JP
+[Registrant]                    Example JP Corp
+[Name Server]                   ns1.example.jp
+[Name Server]                   ns2.example.jp
+[Signing Key]
+[Created on]                    2010/03/01
+[Expires on]                    2026/03/31
+[Status]                        Active
+[Last Updated]                  2025/04/01 01:05:07 (JST)`
+
+	info, err := ParseWhoisResponseJPEnglish(jp, "example.jp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Registrar != "Example JP Corp" || len(info.Nameservers) != 2 {
+		t.Errorf("Registrar / Nameservers: got %q, %v", info.Registrar, info.Nameservers)
+	}
+	if info.RegistrationDate != "2010-03-01" || info.ExpirationDate != "2026-03-31" {
+		t.Errorf("dates: got %q, %q", info.RegistrationDate, info.ExpirationDate)
+	}
+	if info.LastChangedDate != "2025-03-31T16:05:07Z" {
+		t.Errorf("LastChangedDate: got %q", info.LastChangedDate)
+	}
+	if len(info.Status) != 1 || info.Status[0] != "Active" {
+		t.Errorf("Status: got %v", info.Status)
+	}
+
+	coJP := `a. [Domain Name]                EXAMPLE.CO.JP
+g. [Organization]               Example CO JP Corp
+p. [Name Server]                ns1.example.co.jp
+[State]                         Connected (2026/03/31)
+[Lock Status]                   AgentChangeLocked
+[Registered Date]               2010/03/01
+[Connected Date]                2010/03/01
+[Last Update]                   2025/04/01 01:03:54 (JST)`
+
+	info, err = ParseWhoisResponseJPEnglish(coJP, "example.co.jp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.LdhName != "example.co.jp" || info.Registrar != "Example CO JP Corp" {
+		t.Errorf("LdhName / Registrar: got %q, %q", info.LdhName, info.Registrar)
+	}
+	if info.RegistrationDate != "2010-03-01" || info.ExpirationDate != "2026-03-31" {
+		t.Errorf("dates: got %q, %q", info.RegistrationDate, info.ExpirationDate)
+	}
+	if len(info.Status) != 2 || info.Status[0] != "Connected" || info.Status[1] != "AgentChangeLocked" {
+		t.Errorf("Status: got %v", info.Status)
+	}
+
+	// The Japanese parser finds no dates in the English answer.
+	if _, err := ParseWhoisResponseJP(jp, "example.jp"); !errors.Is(err, utils.ErrDomainNotFound) {
+		t.Errorf("Japanese parser on the English answer: got %v", err)
+	}
+}
+
 func TestParseWhoisResponseJP_NotFound(t *testing.T) {
 	response := `No match!!`
 	_, err := ParseWhoisResponseJP(response, "notfound.jp")
@@ -1,15 +1,22 @@
 package whois
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"io"
 	"log/slog"
 	"net"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/KincaidYang/whois/internal/breaker"
+	"github.com/KincaidYang/whois/internal/config"
 	"github.com/KincaidYang/whois/internal/metrics"
 	"github.com/KincaidYang/whois/internal/serverlist"
+	"github.com/KincaidYang/whois/internal/throttle"
+	"github.com/KincaidYang/whois/internal/utils"
 )
 
 const (
@@ -18,51 +25,270 @@ const (
 	// maxResponseSize caps how much we read from a WHOIS server to guard
 	// against a misbehaving or malicious server exhausting memory.
 	maxResponseSize = 2 << 20 // 2 MiB
+
+	// maxReferralHops bounds how many referrals one lookup follows past the
+	// registry: registry → registrar is the thin-registry case, and one more
+	// hop covers a registrar that refers on to a reseller.
+	maxReferralHops = 2
+	// referralTimeout is each referral hop's own budget. Registrar servers
+	// are slower and flakier than registries, and a hop that fails costs
+	// only its own data, so it must not be able to spend the whole request
+	// timeout.
+	referralTimeout = 5 * time.Second
 )
 
+// Hop is one server's answer in a WHOIS referral chain.
+type Hop struct {
+	Server   string // the host queried, without the default port
+	Text     string // the answer, transcoded to UTF-8
+	Charset  string // the charset the answer was decoded from
+	Template string // the name of the query template sent, "" for none
+}
+
+// referralAddr returns the dial address for a referred host: the address it
+// resolves to, refused when that is loopback, private or link-local (see
+// utils.ResolvePublic), so a referral cannot reach the service's own
+// network. Referrals name a host only, so the port is the default or the
+// host's query template's; tests replace this to reach loopback servers.
+var referralAddr = func(ctx context.Context, host, port string) (string, error) {
+	ip, err := utils.ResolvePublic(ctx, host)
+	if err != nil {
+		return "", err
+	}
+	return net.JoinHostPort(ip.String(), port), nil
+}
+
 // Whois function is used to query the WHOIS information for a given domain.
+// The query sent is the server's query template (see queryFor), and the
+// answer is transcoded to UTF-8 (see decodeResponse).
 func Whois(ctx context.Context, domain, tld string) (result string, err error) {
+	hop, err := query(ctx, domain, tld)
+	return hop.Text, err
+}
+
+// query is Whois, returning the answer as the registry's hop.
+func query(ctx context.Context, domain, tld string) (Hop, error) {
 	start := time.Now()
 	defer func() {
 		metrics.UpstreamDuration.WithLabelValues("whois", tld).Observe(time.Since(start).Seconds())
 	}()
-	whoisServer, ok := serverlist.TLDToWhoisServer[tld]
+	whoisServer, ok := serverlist.LookupWhoisServer(tld)
 	if !ok {
-		return "", fmt.Errorf("no Whois server known for TLD: %s", tld)
+		return Hop{}, fmt.Errorf("no Whois server known for TLD: %s", tld)
 	}
 
 	slog.DebugContext(ctx, "querying WHOIS", "domain", domain, "tld", tld, "server", whoisServer)
 
-	// Check if the server address already includes a port
-	if _, _, err := net.SplitHostPort(whoisServer); err != nil {
-		whoisServer = net.JoinHostPort(whoisServer, whoisDefaultPort)
+	// A port in the server address wins over the template's.
+	host := whoisServer
+	if h, _, err := net.SplitHostPort(whoisServer); err == nil {
+		host = h
+	}
+	q := queryFor(host, tld)
+	if host == whoisServer {
+		whoisServer = net.JoinHostPort(whoisServer, queryPort(q))
+	}
+
+	body, err := queryUpstream(ctx, whoisServer, whoisServer, domain, q)
+	if err != nil {
+		return Hop{}, err
+	}
+	text, charset := decodeResponse(body, tld)
+	return Hop{Server: strings.ToLower(host), Text: text, Charset: charset, Template: q.Name}, nil
+}
+
+// queryPort returns the port to dial for q when the server address names
+// none.
+func queryPort(q config.WhoisQuery) string {
+	if q.Port != 0 {
+		return strconv.Itoa(q.Port)
+	}
+	return whoisDefaultPort
+}
+
+// WhoisWithReferrals queries the TLD's WHOIS server like Whois, then follows
+// the referral in each answer ("Registrar WHOIS Server:" from a thin
+// registry, "refer:" from IANA) for up to maxReferralHops further servers.
+// The registry's answer is always the first hop. A referral hop that fails
+// ends the chain without failing the lookup: the registry has already
+// answered, and the registrar's data is a supplement to it. A server already
+// visited is never queried again, so two servers referring to each other
+// cannot loop.
+func WhoisWithReferrals(ctx context.Context, domain, tld string) ([]Hop, error) {
+	registry, err := query(ctx, domain, tld)
+	if err != nil {
+		return nil, err
 	}
+	hops := []Hop{registry}
 
+	visited := map[string]bool{hops[0].Server: true}
+	for len(hops) <= maxReferralHops {
+		next := findReferral(hops[len(hops)-1].Text)
+		if next == "" || visited[next] {
+			break
+		}
+		visited[next] = true
+
+		hop, err := queryReferral(ctx, next, domain)
+		if err != nil {
+			slog.WarnContext(ctx, "WHOIS referral failed", "domain", domain, "server", next, "err", err)
+			break
+		}
+		hops = append(hops, hop)
+	}
+	return hops, nil
+}
+
+// queryReferral queries one referred server under its own timeout budget.
+// Only a template keyed by the server's own host applies: the registrar's
+// query syntax and charset have nothing to do with the TLD's.
+func queryReferral(ctx context.Context, host, domain string) (Hop, error) {
+	ctx, cancel := context.WithTimeout(ctx, referralTimeout)
+	defer cancel()
+
+	start := time.Now()
+	defer func() {
+		metrics.UpstreamDuration.WithLabelValues("whois", "_referral").Observe(time.Since(start).Seconds())
+	}()
+	slog.DebugContext(ctx, "following WHOIS referral", "domain", domain, "server", host)
+	q := queryFor(host, "")
+	addr, err := referralAddr(ctx, host, queryPort(q))
+	if err != nil {
+		return Hop{}, err
+	}
+	body, err := queryUpstream(ctx, net.JoinHostPort(host, queryPort(q)), addr, domain, q)
+	if err != nil {
+		return Hop{}, err
+	}
+	text, charset := decodeResponse(body, "")
+	return Hop{Server: host, Text: text, Charset: charset, Template: q.Name}, nil
+}
+
+// queryUpstream queries the WHOIS server upstream (host:port), dialed at
+// addr, through its circuit breaker and paced by its servers.limits entry.
+// The breaker is asked first, so a query to a server that is down fails fast
+// without waiting for a token. Every error from the server itself is a
+// failure for its breaker: the answer is only interpreted (not found,
+// denied) by the parsers.
+func queryUpstream(ctx context.Context, upstream, addr, domain string, q config.WhoisQuery) ([]byte, error) {
+	done, err := breaker.Allow(upstream)
+	if err != nil {
+		return nil, err
+	}
+	release, err := throttle.Acquire(ctx, upstream)
+	if err != nil {
+		done(breaker.ErrNotSent)
+		return nil, err
+	}
+	defer release()
+	body, err := queryServer(ctx, addr, domain, q)
+	done(err)
+	return body, err
+}
+
+// queryServer sends the query q makes for domain to a WHOIS server and reads
+// the whole answer, undecoded.
+// The connection deadline is whoisTimeout or the context's deadline,
+// whichever comes first.
+func queryServer(ctx context.Context, addr, domain string, q config.WhoisQuery) ([]byte, error) {
 	d := net.Dialer{Timeout: whoisTimeout}
-	conn, err := d.DialContext(ctx, "tcp", whoisServer)
+	conn, err := d.DialContext(ctx, "tcp", addr)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer func() { _ = conn.Close() }()
 
 	// Set read/write deadline
-	if err := conn.SetDeadline(time.Now().Add(whoisTimeout)); err != nil {
-		return "", err
+	deadline := time.Now().Add(whoisTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
 	}
-
-	if _, err := conn.Write([]byte(domain + "\r\n")); err != nil {
-		return "", err
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, err
 	}
 
 	// Read one byte past the limit so an oversized response is detected and
-	// rejected rather than silently truncated and cached as if complete.
-	body, err := io.ReadAll(io.LimitReader(conn, maxResponseSize+1))
+	// rejected rather than silently truncated and cached as if complete. A
+	// greeting counts towards the limit too.
+	r := bufio.NewReader(io.LimitReader(conn, maxResponseSize+1))
+	if q.Greeting {
+		if _, err := r.ReadString('\n'); err != nil {
+			return nil, fmt.Errorf("reading greeting from %s: %w", addr, err)
+		}
+	}
+
+	if _, err := conn.Write([]byte(queryLine(q, domain) + "\r\n")); err != nil {
+		return nil, err
+	}
+	if q.HalfClose {
+		if c, ok := conn.(interface{ CloseWrite() error }); ok {
+			if err := c.CloseWrite(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	body, err := io.ReadAll(r)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if len(body) > maxResponseSize {
-		return "", fmt.Errorf("WHOIS response from %s exceeds %d bytes", whoisServer, maxResponseSize)
+		return nil, fmt.Errorf("WHOIS response from %s exceeds %d bytes", addr, maxResponseSize)
+	}
+
+	return body, nil
+}
+
+// referralPrefixes are the fields that name the next server to ask, matched
+// case-insensitively at the start of a line.
+var referralPrefixes = []string{"registrar whois server:", "refer:"}
+
+// findReferral returns the host a WHOIS answer refers to, or "" when it
+// names none. Registrars fill the field in several ways ("whois.example.com",
+// "whois://whois.example.com", "https://www.example.com/whois"); only the host
+// is kept. Anything that is not a host name — an IP literal, an empty field,
+// free text — is ignored rather than dialed; a host name that resolves to an
+// internal address is refused when dialed (see referralAddr).
+func findReferral(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		lower := strings.ToLower(line)
+		for _, prefix := range referralPrefixes {
+			if !strings.HasPrefix(lower, prefix) {
+				continue
+			}
+			host := strings.TrimSpace(lower[len(prefix):])
+			if _, rest, ok := strings.Cut(host, "://"); ok {
+				host = rest
+			}
+			host, _, _ = strings.Cut(host, "/")
+			if h, _, err := net.SplitHostPort(host); err == nil {
+				host = h
+			}
+			host = strings.TrimSuffix(host, ".")
+			if net.ParseIP(host) == nil && utils.IsDomain(host) {
+				return host
+			}
+		}
 	}
+	return ""
+}
 
-	return string(body), nil
+// JoinHops renders a referral chain as one text: the registry's answer
+// verbatim, then each referred server's answer under a "# server" banner.
+// A chain of one hop is returned unchanged.
+func JoinHops(hops []Hop) string {
+	if len(hops) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(hops[0].Text)
+	for _, hop := range hops[1:] {
+		if !strings.HasSuffix(b.String(), "\n") {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "\n# %s\n\n", hop.Server)
+		b.WriteString(hop.Text)
+	}
+	return b.String()
 }
@@ -2,6 +2,7 @@ package whois
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"strings"
 	"testing"
@@ -99,3 +100,153 @@ func TestWhoisUnknownTLD(t *testing.T) {
 		t.Errorf("Expected error %q, got %q", expectedError, err.Error())
 	}
 }
+
+// withReferralServers routes referred host names to loopback mock servers
+// for the duration of the test.
+func withReferralServers(t *testing.T, addrs map[string]string) {
+	t.Helper()
+	orig := referralAddr
+	referralAddr = func(_ context.Context, host, port string) (string, error) {
+		if addr, ok := addrs[host]; ok {
+			return addr, nil
+		}
+		return "127.0.0.1:1", nil // nothing listens here: the hop fails
+	}
+	t.Cleanup(func() { referralAddr = orig })
+}
+
+func TestWhoisWithReferrals(t *testing.T) {
+	registry := "Domain Name: EXAMPLE.COM\n   Registrar WHOIS Server: whois.registrar.test\n"
+	registrar := "Domain Name: example.com\nRegistrar: Example Registrar, Inc.\n"
+	registryAddr, cleanupRegistry := startMockWhoisServer(registry)
+	defer cleanupRegistry()
+	registrarAddr, cleanupRegistrar := startMockWhoisServer(registrar)
+	defer cleanupRegistrar()
+
+	serverlist.TLDToWhoisServer = map[string]string{"com": registryAddr}
+	withReferralServers(t, map[string]string{"whois.registrar.test": registrarAddr})
+
+	hops, err := WhoisWithReferrals(context.Background(), "example.com", "com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hops) != 2 {
+		t.Fatalf("got %d hops, want 2: %+v", len(hops), hops)
+	}
+	if hops[0].Server != "127.0.0.1" || hops[0].Text != registry {
+		t.Errorf("registry hop: %+v", hops[0])
+	}
+	if hops[1].Server != "whois.registrar.test" || hops[1].Text != registrar {
+		t.Errorf("registrar hop: %+v", hops[1])
+	}
+}
+
+// TestWhoisWithReferralsLoop verifies a server referring back to one already
+// visited ends the chain instead of querying it again.
+func TestWhoisWithReferralsLoop(t *testing.T) {
+	registryAddr, cleanupRegistry := startMockWhoisServer("refer: whois.a.test\n")
+	defer cleanupRegistry()
+	aAddr, cleanupA := startMockWhoisServer("Registrar WHOIS Server: whois.a.test\n")
+	defer cleanupA()
+
+	serverlist.TLDToWhoisServer = map[string]string{"com": registryAddr}
+	withReferralServers(t, map[string]string{"whois.a.test": aAddr})
+
+	hops, err := WhoisWithReferrals(context.Background(), "example.com", "com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hops) != 2 {
+		t.Errorf("got %d hops, want 2 (self-referral must not be followed): %+v", len(hops), hops)
+	}
+}
+
+// TestWhoisWithReferralsHopLimit verifies the chain stops after
+// maxReferralHops referrals even when every answer refers onward.
+func TestWhoisWithReferralsHopLimit(t *testing.T) {
+	registryAddr, cleanupRegistry := startMockWhoisServer("refer: whois.h1.test\n")
+	defer cleanupRegistry()
+	// Each server hN refers on to hN+1.
+	addrs := map[string]string{}
+	for i := 1; i <= 3; i++ {
+		addr, cleanup := startMockWhoisServer(fmt.Sprintf("refer: whois.h%d.test\n", i+1))
+		defer cleanup()
+		addrs[fmt.Sprintf("whois.h%d.test", i)] = addr
+	}
+
+	serverlist.TLDToWhoisServer = map[string]string{"com": registryAddr}
+	withReferralServers(t, addrs)
+
+	hops, err := WhoisWithReferrals(context.Background(), "example.com", "com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hops) != 1+maxReferralHops {
+		t.Errorf("got %d hops, want %d", len(hops), 1+maxReferralHops)
+	}
+}
+
+// TestWhoisWithReferralsFailedHop verifies an unreachable registrar server
+// leaves the registry's answer standing instead of failing the lookup.
+func TestWhoisWithReferralsFailedHop(t *testing.T) {
+	registry := "Registrar WHOIS Server: whois.down.test\n"
+	registryAddr, cleanup := startMockWhoisServer(registry)
+	defer cleanup()
+
+	serverlist.TLDToWhoisServer = map[string]string{"com": registryAddr}
+	withReferralServers(t, nil)
+
+	hops, err := WhoisWithReferrals(context.Background(), "example.com", "com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hops) != 1 || hops[0].Text != registry {
+		t.Errorf("hops: %+v", hops)
+	}
+}
+
+// TestQueryReferralRefusesInternal verifies a referral to a host resolving to
+// a loopback address is refused before anything is dialed.
+func TestQueryReferralRefusesInternal(t *testing.T) {
+	_, err := queryReferral(context.Background(), "localhost", "example.com")
+	if err == nil || !strings.Contains(err.Error(), "non-public") {
+		t.Errorf("err = %v, want the referral refused", err)
+	}
+}
+
+func TestFindReferral(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"thin registry", "   Registrar WHOIS Server: whois.MarkMonitor.com\n", "whois.markmonitor.com"},
+		{"iana", "domain: COM\nrefer:        whois.verisign-grs.com\n", "whois.verisign-grs.com"},
+		{"url form", "Registrar WHOIS Server: https://whois.example.net/lookup\n", "whois.example.net"},
+		{"whois scheme and port", "Registrar WHOIS Server: whois://whois.example.net:43\n", "whois.example.net"},
+		{"empty field", "Registrar WHOIS Server: \nDomain Name: x\n", ""},
+		{"ip literal", "Registrar WHOIS Server: 10.0.0.1\n", ""},
+		{"single label", "refer: localhost\n", ""},
+		{"none", "Domain Name: example.com\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findReferral(tt.text); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinHops(t *testing.T) {
+	if got := JoinHops([]Hop{{Server: "whois.registry.test", Text: "registry"}}); got != "registry" {
+		t.Errorf("single hop must be unchanged, got %q", got)
+	}
+	got := JoinHops([]Hop{
+		{Server: "whois.registry.test", Text: "registry\n"},
+		{Server: "whois.registrar.test", Text: "registrar\n"},
+	})
+	if want := "registry\n\n# whois.registrar.test\n\nregistrar\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,80 @@
+package whois
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/KincaidYang/whois/internal/model"
+)
+
+// 注册商 WHOIS 响应遵循 ICANN 2013 RAA 规定的统一格式，字段名固定；
+// 行首可能有缩进（部分注册商沿用 Verisign 的排版）。
+var (
+	reRefRegistrar       = regexp.MustCompile(`(?mi)^\s*Registrar:[ \t]*(.+)$`)
+	reRefRegistrarIANAID = regexp.MustCompile(`(?mi)^\s*Registrar IANA ID:[ \t]*(.+)$`)
+	reRefCreationDate    = regexp.MustCompile(`(?mi)^\s*Creation Date:[ \t]*(.+)$`)
+	reRefUpdatedDate     = regexp.MustCompile(`(?mi)^\s*Updated Date:[ \t]*(.+)$`)
+	reRefExpiryDate      = regexp.MustCompile(`(?mi)^\s*(?:Registrar Registration Expiration Date|Registry Expiry Date):[ \t]*(.+)$`)
+	reRefDomainStatus    = regexp.MustCompile(`(?mi)^\s*Domain Status:[ \t]*(.+)$`)
+	reRefNameServer      = regexp.MustCompile(`(?mi)^\s*Name Server:[ \t]*(.+)$`)
+	reRefDNSSEC          = regexp.MustCompile(`(?mi)^\s*DNSSEC:[ \t]*(.+)$`)
+)
+
+// parseReferralResponse parses a referred server's answer. Registrars are
+// not registries and each has its own quirks, so unlike the per-TLD parsers
+// this one never reports the domain as not found: it returns whatever fields
+// it recognizes, possibly none.
+func parseReferralResponse(response string, domain string) model.DomainInfo {
+	info := newDomainInfo(domain)
+	info.Registrar = matchFirstGroup(reRefRegistrar, response, nil)
+	info.RegistrarIANAID = matchFirstGroup(reRefRegistrarIANAID, response, nil)
+	if v := matchFirstGroup(reRefCreationDate, response, nil); v != "" {
+		info.RegistrationDate = normDate(v, time.UTC)
+	}
+	if v := matchFirstGroup(reRefUpdatedDate, response, nil); v != "" {
+		info.LastChangedDate = normDate(v, time.UTC)
+	}
+	if v := matchFirstGroup(reRefExpiryDate, response, nil); v != "" {
+		info.ExpirationDate = normDate(v, time.UTC)
+	}
+	if statuses := matchAllFirstGroup(reRefDomainStatus, response); len(statuses) > 0 {
+		info.Status = model.CleanStatus(statuses)
+	}
+	info.Nameservers = lowerAll(matchAllFirstGroup(reRefNameServer, response))
+	if v := matchFirstGroup(reRefDNSSEC, response, nil); v != "" {
+		info.SecureDNS = secureDNSFromString(v)
+	}
+	return info
+}
+
+// MergeReferrals fills the fields the registry left empty from the answers
+// of the servers it referred to (every hop after the first). The registry is
+// authoritative for what it does publish, so a field it filled is never
+// overwritten; earlier hops likewise win over later ones.
+func MergeReferrals(info *model.DomainInfo, hops []Hop, domain string) {
+	for _, hop := range hops {
+		ref := parseReferralResponse(hop.Text, domain)
+		fillString(&info.Registrar, ref.Registrar)
+		fillString(&info.RegistrarIANAID, ref.RegistrarIANAID)
+		fillString(&info.RegistrationDate, ref.RegistrationDate)
+		fillString(&info.ExpirationDate, ref.ExpirationDate)
+		fillString(&info.LastChangedDate, ref.LastChangedDate)
+		if len(info.Status) == 0 && len(ref.Status) > 0 {
+			info.Status = ref.Status
+		}
+		if len(info.Nameservers) == 0 && len(ref.Nameservers) > 0 {
+			info.Nameservers = ref.Nameservers
+		}
+		if info.SecureDNS == nil {
+			info.SecureDNS = ref.SecureDNS
+		}
+	}
+}
+
+// fillString sets *dst to src when *dst is empty.
+func fillString(dst *string, src string) {
+	if strings.TrimSpace(*dst) == "" {
+		*dst = src
+	}
+}
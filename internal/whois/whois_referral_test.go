@@ -0,0 +1,58 @@
+package whois
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestMergeReferrals verifies a registrar's answer fills only what the
+// registry left empty: the registry's own fields are never overwritten.
+func TestMergeReferrals(t *testing.T) {
+	info := newDomainInfo("example.com")
+	info.RegistrationDate = "1995-08-14T04:00:00Z"
+	info.Nameservers = []string{"a.iana-servers.net"}
+
+	registrar := `Domain Name: EXAMPLE.COM
+Registrar: Example Registrar, Inc.
+Registrar IANA ID: 376
+Creation Date: 2001-01-01T00:00:00Z
+Updated Date: 2024-08-14T07:01:34Z
+Registrar Registration Expiration Date: 2025-08-13T04:00:00Z
+Domain Status: clientTransferProhibited https://icann.org/epp#clientTransferProhibited
+Name Server: NS.REGISTRAR.TEST
+DNSSEC: signedDelegation
+`
+	MergeReferrals(&info, []Hop{{Server: "whois.registrar.test", Text: registrar}}, "example.com")
+
+	if info.Registrar != "Example Registrar, Inc." || info.RegistrarIANAID != "376" {
+		t.Errorf("registrar: %q / %q", info.Registrar, info.RegistrarIANAID)
+	}
+	if info.RegistrationDate != "1995-08-14T04:00:00Z" {
+		t.Errorf("registry registrationDate overwritten: %q", info.RegistrationDate)
+	}
+	if info.ExpirationDate != "2025-08-13T04:00:00Z" || info.LastChangedDate != "2024-08-14T07:01:34Z" {
+		t.Errorf("dates: expiration=%q lastChanged=%q", info.ExpirationDate, info.LastChangedDate)
+	}
+	if !reflect.DeepEqual(info.Status, []string{"clientTransferProhibited"}) {
+		t.Errorf("status: %v", info.Status)
+	}
+	if !reflect.DeepEqual(info.Nameservers, []string{"a.iana-servers.net"}) {
+		t.Errorf("registry nameservers overwritten: %v", info.Nameservers)
+	}
+	if info.SecureDNS == nil || !info.SecureDNS.DelegationSigned {
+		t.Errorf("secureDNS: %+v", info.SecureDNS)
+	}
+}
+
+// TestMergeReferralsUnrecognized verifies an answer with no recognizable
+// fields leaves the registry's data untouched.
+func TestMergeReferralsUnrecognized(t *testing.T) {
+	info := newDomainInfo("example.com")
+	info.Registrar = "Registry-Known Registrar"
+	want := info
+
+	MergeReferrals(&info, []Hop{{Server: "whois.registrar.test", Text: "Rate limit exceeded.\n"}}, "example.com")
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("got %+v, want %+v", info, want)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/KincaidYang/whois/internal/bgp"
 	"github.com/KincaidYang/whois/internal/config"
 	"github.com/KincaidYang/whois/internal/handlers"
 	"github.com/KincaidYang/whois/internal/mcp"
@@ -84,10 +85,11 @@ func clientForKey(key string) *config.AuthClient {
 	if key == "" {
 		return nil
 	}
+	clients := config.Current().AuthClients
 	var matched *config.AuthClient
-	for i := range config.AuthClients {
-		if subtle.ConstantTimeCompare([]byte(key), []byte(config.AuthClients[i].Key)) == 1 {
-			matched = &config.AuthClients[i]
+	for i := range clients {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(clients[i].Key)) == 1 {
+			matched = &clients[i]
 		}
 	}
 	return matched
@@ -104,7 +106,7 @@ func clientForKey(key string) *config.AuthClient {
 // whois_client_requests_total.
 func withAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if len(config.AuthClients) == 0 || r.URL.Path == "/health" || r.URL.Path == "/ready" {
+		if len(config.Current().AuthClients) == 0 || r.URL.Path == "/health" || r.URL.Path == "/ready" {
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -139,7 +141,7 @@ func withCORS(next http.Handler) http.Handler {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID, X-Cache, ETag, Retry-After")
 		if r.Method == http.MethodOptions {
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
 			// Mcp-Method and Mcp-Name are mandatory on every /mcp request from
 			// protocol revision 2026-07-28 on, so browser-based MCP clients
 			// cannot reach the endpoint at all unless preflight allows them.
@@ -172,6 +174,9 @@ func registerRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/ready", handlers.HandleReady)
 	mux.HandleFunc("/info", handlers.HandleInfo)
 
+	// Circuit breaker state of every upstream queried so far
+	mux.HandleFunc("/breakers", handlers.HandleBreakers)
+
 	// Prometheus metrics endpoint
 	mux.Handle("/metrics", promhttp.Handler())
 
@@ -186,11 +191,25 @@ func registerRoutes(mux *http.ServeMux) {
 	// Bulk query endpoint (off unless batch.enabled is set)
 	mux.HandleFunc("/batch", batchHandler)
 
+	// Asynchronous batch jobs (off unless jobs.enabled is set)
+	mux.HandleFunc("/jobs", jobsHandler)
+	mux.HandleFunc("/jobs/{id}", jobHandler)
+
 	// RFC 9082-style typed query paths. The ip path uses a rest wildcard so
 	// CIDR prefixes ("/ip/192.0.2.0/24") keep their slash.
 	mux.HandleFunc("/domain/{resource}", typedHandler(utils.KindDomain))
 	mux.HandleFunc("/ip/{resource...}", typedHandler(utils.KindIP))
 	mux.HandleFunc("/autnum/{resource}", typedHandler(utils.KindASN))
+	mux.HandleFunc("/nameserver/{resource}", typedHandler(utils.KindNameserver))
+	mux.HandleFunc("/entity/{resource}", typedHandler(utils.KindEntity))
+
+	// Reverse DNS delegation of an IP address or prefix (its in-addr.arpa or
+	// ip6.arpa zone at the RIR); a rest wildcard like /ip/.
+	mux.HandleFunc("/reverse/{resource...}", typedHandler(utils.KindReverse))
+
+	// Abuse contact of a domain, IP address or prefix, or ASN (auto-detected
+	// like the root path).
+	mux.HandleFunc("/abuse/{resource...}", typedHandler(wantAbuse))
 
 	// Main query handler (auto-detects the resource type)
 	mux.HandleFunc("/", handler)
@@ -236,21 +255,61 @@ func batchHandler(w http.ResponseWriter, r *http.Request) {
 	metrics.HTTPRequestDuration.WithLabelValues("batch").Observe(time.Since(start).Seconds())
 }
 
+// jobs runs the asynchronous batch jobs submitted to POST /jobs.
+var jobs = handlers.NewJobManager()
+
+// jobsHandler serves POST /jobs. Accepting a job is quick, so unlike
+// batchHandler it takes no concurrency slot: the job's queries each wait for
+// one as they run (handlers.JobManager).
+func jobsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		utils.WriteMethodNotAllowed(w, http.MethodPost)
+		return
+	}
+	sw := &statusWriter{ResponseWriter: w, code: http.StatusOK}
+	start := time.Now()
+	jobs.HandleCreate(r.Context(), sw, r)
+	metrics.HTTPRequestsTotal.WithLabelValues("jobs", strconv.Itoa(sw.code)).Inc()
+	metrics.HTTPRequestDuration.WithLabelValues("jobs").Observe(time.Since(start).Seconds())
+}
+
+// jobHandler serves GET (poll) and DELETE (cancel) /jobs/{id}.
+func jobHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodDelete {
+		utils.WriteMethodNotAllowed(w, "GET, DELETE")
+		return
+	}
+	sw := &statusWriter{ResponseWriter: w, code: http.StatusOK}
+	start := time.Now()
+	jobs.HandleJob(r.Context(), sw, r, r.PathValue("id"))
+	metrics.HTTPRequestsTotal.WithLabelValues("jobs", strconv.Itoa(sw.code)).Inc()
+	metrics.HTTPRequestDuration.WithLabelValues("jobs").Observe(time.Since(start).Seconds())
+}
+
 // typedHandler serves the RFC 9082-style typed paths (/domain/{resource},
-// /ip/{resource}, /autnum/{resource}); want names the resource type the path
-// requires.
+// /ip/{resource}, /autnum/{resource}, /nameserver/{resource},
+// /entity/{resource}) and the paths built like them (/reverse/{resource},
+// /abuse/{resource}); want names the resource type the path requires.
 func typedHandler(want string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		serve(w, r, r.PathValue("resource"), want)
 	}
 }
 
+// wantAbuse is the want of /abuse/{resource}: any domain, IP, prefix or ASN,
+// answered with its abuse contact rather than its registration data.
+const wantAbuse = "abuse"
+
 // typedPathError maps a required resource type to the 400 message returned
 // when the supplied resource is not of that type.
 var typedPathError = map[string]string{
-	utils.KindDomain: "The /domain/ path requires a valid domain name.",
-	utils.KindIP:     "The /ip/ path requires a valid IPv4 or IPv6 address.",
-	utils.KindASN:    "The /autnum/ path requires a valid AS number.",
+	utils.KindDomain:     "The /domain/ path requires a valid domain name.",
+	utils.KindIP:         "The /ip/ path requires a valid IPv4 or IPv6 address.",
+	utils.KindASN:        "The /autnum/ path requires a valid AS number.",
+	utils.KindNameserver: "The /nameserver/ path requires a valid host name.",
+	utils.KindEntity:     "The /entity/ path requires a valid entity handle.",
+	utils.KindReverse:    "The /reverse/ path requires a valid IPv4 or IPv6 address or CIDR prefix.",
+	wantAbuse:            "The /abuse/ path requires a domain, IP address, CIDR prefix or AS number.",
 }
 
 func serve(w http.ResponseWriter, r *http.Request, resource, want string) {
@@ -274,7 +333,23 @@ func serve(w http.ResponseWriter, r *http.Request, resource, want string) {
 
 	// Classify once, and query the canonical form: equivalent spellings of one
 	// IP or prefix must not each get their own cache entry and upstream query.
-	resourceType, resource := utils.ClassifyResource(strings.ToLower(resource))
+	// Entity handles are opaque registry identifiers, so they are only
+	// validated and keep their case.
+	var resourceType string
+	if want == utils.KindEntity {
+		resourceType = utils.KindUnknown
+		if utils.IsEntityHandle(resource) {
+			resourceType = utils.KindEntity
+		}
+	} else {
+		resourceType, resource = utils.ClassifyResource(strings.ToLower(resource))
+		if want == utils.KindNameserver && resourceType == utils.KindDomain {
+			resourceType = utils.KindNameserver
+		}
+		if want == utils.KindReverse && resourceType == utils.KindIP {
+			resourceType = utils.KindReverse
+		}
+	}
 
 	// ?raw requests the unparsed WHOIS text (domains only; RDAP-backed IP
 	// and ASN lookups have no raw-text form). ?raw=0 / ?raw=false opt out.
@@ -288,10 +363,32 @@ func serve(w http.ResponseWriter, r *http.Request, resource, want string) {
 	refreshValue := r.URL.Query().Get("refresh")
 	refresh := r.URL.Query().Has("refresh") && refreshValue != "0" && refreshValue != "false"
 
+	// ?follow=registrar supplements an RDAP domain answer with the
+	// registrar's own record (rdap.followRegistrar turns it on for every
+	// query). "registrar" is the only hop there is to follow.
+	follow := r.URL.Query().Get("follow")
+	followRegistrar := follow == "registrar"
+
+	// ?source=merged answers a domain query from RDAP and WHOIS together,
+	// filling the fields RDAP leaves empty from WHOIS.
+	source := r.URL.Query().Get("source")
+	merged := source == "merged"
+
+	// The representation comes from ?format= or the Accept header: JSON,
+	// aligned text, CSV, YAML, or the registry's RDAP answer unmodified (for
+	// the fields the normalized model drops: notices, links, port43,
+	// variants). ?raw overrides whatever Accept asked for.
+	format, formatOK := handlers.NegotiateFormat(r.URL.Query(), r.Header.Get("Accept"))
+	if raw {
+		format = handlers.FormatWhois
+	}
+
 	cacheKeyPrefix := handlers.CacheKeyPrefix
 
 	// GET responses are buffered so a 200 gets an ETag and an If-None-Match
 	// revalidation can be answered with 304 instead of the full body.
+	// The representation depends on Accept, so shared caches must key on it.
+	w.Header().Add("Vary", "Accept")
 	var cw *utils.ConditionalWriter
 	if r.Method == http.MethodGet {
 		cw = utils.NewConditionalWriter(w, r.Header.Get("If-None-Match"))
@@ -301,24 +398,66 @@ func serve(w http.ResponseWriter, r *http.Request, resource, want string) {
 	start := time.Now()
 
 	switch {
-	case want != "" && resourceType != want:
+	case want == wantAbuse && resourceType != utils.KindDomain && resourceType != utils.KindIP && resourceType != utils.KindASN:
 		utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, typedPathError[want])
-	case refresh && len(config.AuthClients) == 0:
+	case want != "" && want != wantAbuse && resourceType != want:
+		utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, typedPathError[want])
+	case refresh && len(config.Current().AuthClients) == 0:
 		utils.WriteRefreshRequiresAuth(sw)
+	case r.URL.Query().Has("follow") && !followRegistrar:
+		utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, `The follow parameter only accepts "registrar".`)
+	case followRegistrar && resourceType != utils.KindDomain:
+		utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, "Following registrar links is only supported for domain queries.")
+	case !formatOK:
+		utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, `The format parameter only accepts "json", "text", "csv", "yaml" or "rdap".`)
+	case raw && r.URL.Query().Has("format"):
+		utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, "Raw output cannot be combined with the format parameter.")
+	case followRegistrar && format == handlers.FormatRDAP:
+		utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, "Following registrar links is not supported with format=rdap.")
+	case r.URL.Query().Has("source") && !merged:
+		utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, `The source parameter only accepts "merged".`)
+	case merged && (resourceType != utils.KindDomain || want == wantAbuse):
+		utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, "Merged results are only supported for domain queries.")
+	case merged && (format == handlers.FormatWhois || format == handlers.FormatRDAP):
+		utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, "Merged results cannot be combined with raw output or format=rdap.")
+	case want == wantAbuse:
+		if raw || format == handlers.FormatRDAP {
+			utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, `The /abuse/ path only supports the "json", "text", "csv" and "yaml" formats.`)
+		} else {
+			handlers.HandleAbuse(ctx, sw, resourceType, resource, cacheKeyPrefix, format, refresh, followRegistrar)
+		}
 	case resourceType == utils.KindIP:
 		if raw {
 			utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, "Raw output is only supported for domain queries.")
 		} else {
-			handlers.HandleIP(ctx, sw, resource, cacheKeyPrefix, refresh)
+			handlers.HandleIP(ctx, sw, resource, cacheKeyPrefix, format, refresh)
 		}
 	case resourceType == utils.KindASN:
 		if raw {
 			utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, "Raw output is only supported for domain queries.")
 		} else {
-			handlers.HandleASN(ctx, sw, resource, cacheKeyPrefix, refresh)
+			handlers.HandleASN(ctx, sw, resource, cacheKeyPrefix, format, refresh)
 		}
 	case resourceType == utils.KindDomain:
-		handlers.HandleDomain(ctx, sw, resource, cacheKeyPrefix, raw, refresh)
+		handlers.HandleDomain(ctx, sw, resource, cacheKeyPrefix, format, refresh, followRegistrar, merged)
+	case resourceType == utils.KindNameserver:
+		if raw {
+			utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, "Raw output is only supported for domain queries.")
+		} else {
+			handlers.HandleNameserver(ctx, sw, resource, cacheKeyPrefix, format, refresh)
+		}
+	case resourceType == utils.KindEntity:
+		if raw {
+			utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, "Raw output is only supported for domain queries.")
+		} else {
+			handlers.HandleEntity(ctx, sw, resource, cacheKeyPrefix, format, refresh)
+		}
+	case resourceType == utils.KindReverse:
+		if raw {
+			utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, "Raw output is only supported for domain queries.")
+		} else {
+			handlers.HandleReverse(ctx, sw, resource, cacheKeyPrefix, format, refresh)
+		}
 	default:
 		utils.HandleHTTPError(sw, utils.ErrorTypeBadRequest, "Invalid input. Please provide a valid domain, IP, or ASN.")
 	}
@@ -328,23 +467,90 @@ func serve(w http.ResponseWriter, r *http.Request, resource, want string) {
 		code = cw.Finish()
 	}
 
+	if want == wantAbuse {
+		resourceType = wantAbuse
+	}
 	elapsed := time.Since(start).Seconds()
 	metrics.HTTPRequestsTotal.WithLabelValues(resourceType, strconv.Itoa(code)).Inc()
 	metrics.HTTPRequestDuration.WithLabelValues(resourceType).Observe(elapsed)
 }
 
+// bootstrapCacheKey is the Redis key the IANA bootstrap data is kept under,
+// and whoisTableCacheKey the one the discovered WHOIS server table is.
+const (
+	bootstrapCacheKey  = "whois:bootstrap"
+	whoisTableCacheKey = "whois:bootstrap:whois"
+)
+
+// bootstrapCaches returns where bootstrap.cacheFile and bootstrap.cacheRedis
+// keep the last-known-good IANA data.
+func bootstrapCaches() []serverlist.BootstrapCache {
+	var caches []serverlist.BootstrapCache
+	if config.BootstrapCacheFile != "" {
+		caches = append(caches, serverlist.FileCache(config.BootstrapCacheFile))
+	}
+	if config.BootstrapCacheRedis && config.RedisClient != nil {
+		caches = append(caches, serverlist.RedisCache{Client: config.RedisClient, Key: bootstrapCacheKey})
+	}
+	return caches
+}
+
+// whoisTableCaches returns where bootstrap.whoisCacheFile and
+// bootstrap.cacheRedis keep the discovered WHOIS server table.
+func whoisTableCaches() []serverlist.BootstrapCache {
+	var caches []serverlist.BootstrapCache
+	if config.BootstrapWhoisCacheFile != "" {
+		caches = append(caches, serverlist.FileCache(config.BootstrapWhoisCacheFile))
+	}
+	if config.BootstrapCacheRedis && config.RedisClient != nil {
+		caches = append(caches, serverlist.RedisCache{Client: config.RedisClient, Key: whoisTableCacheKey})
+	}
+	return caches
+}
+
+// configWatchInterval is how often the configuration file is checked for
+// changes.
+const configWatchInterval = 5 * time.Second
+
 func main() {
 	// Load configuration and initialize logger, Redis client and cache.
 	config.Load()
 
+	// Restore the IANA data and WHOIS server table an earlier run kept, so
+	// they are served instead of the compiled baseline until refreshed.
+	caches, whoisCaches := bootstrapCaches(), whoisTableCaches()
+	loadCtx, loadCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	serverlist.LoadBootstrapCache(loadCtx, caches...)
+	serverlist.LoadWhoisCache(loadCtx, whoisCaches...)
+	loadCancel()
+
 	// Start RDAP bootstrap refresh (initial fetch + periodic updates).
-	// Disabled when BootstrapInterval is 0 or unset.
+	// Either interval enables discovering WHOIS servers for unknown TLDs at
+	// whois.iana.org; BootstrapWhoisInterval also refreshes the whole WHOIS
+	// table on its own, longer schedule. Disabled when both are 0 or unset.
+	bootstrapCtx, bootstrapCancel := context.WithCancel(context.Background())
+	defer bootstrapCancel()
 	if config.BootstrapInterval > 0 {
-		bootstrapCtx, bootstrapCancel := context.WithCancel(context.Background())
-		defer bootstrapCancel()
-		serverlist.StartBootstrapRefresh(bootstrapCtx, config.HttpClient, config.BootstrapInterval)
+		serverlist.StartBootstrapRefresh(bootstrapCtx, config.HttpClient, config.BootstrapInterval, caches...)
+	}
+	if config.BootstrapInterval > 0 || config.BootstrapWhoisInterval > 0 {
+		serverlist.StartWhoisRefresh(bootstrapCtx, config.HttpClient, config.BootstrapWhoisInterval, whoisCaches...)
 	}
 
+	// Load the BGP RIB dump IP and ASN responses are enriched from, and
+	// reload it whenever it changes. Disabled when bgp.tableFile is empty.
+	if config.BGPTableFile != "" {
+		bgpCtx, bgpCancel := context.WithCancel(context.Background())
+		defer bgpCancel()
+		bgp.StartWatcher(bgpCtx, config.BGPTableFile, config.BGPReloadInterval)
+	}
+
+	// Reload the reloadable settings whenever the configuration file changes;
+	// SIGHUP below does the same on demand.
+	watchCtx, watchCancel := context.WithCancel(context.Background())
+	defer watchCancel()
+	config.StartWatcher(watchCtx, configWatchInterval)
+
 	registerRoutes(http.DefaultServeMux)
 
 	srv := &http.Server{
@@ -374,9 +580,17 @@ func main() {
 	// draining the in-flight ones: under sustained traffic the wait group
 	// never reaches zero while the listener keeps admitting requests (and
 	// waiting concurrently with new Add calls misuses the WaitGroup).
+	// SIGHUP reloads the configuration instead; a rejected file is logged
+	// and the running configuration kept.
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
-	<-sigCh
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range sigCh {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		slog.Info("SIGHUP received, reloading configuration")
+		_ = config.Reload()
+	}
 
 	slog.Info("shutdown signal received, draining in-flight requests")
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.RequestTimeout+5*time.Second)
@@ -385,6 +599,12 @@ func main() {
 		slog.Error("server shutdown error", "err", err)
 	}
 
+	// Interrupt running jobs; each records its final state so pollers learn
+	// it will not finish, and its in-flight queries drain with the rest below.
+	if err := jobs.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("timed out waiting for jobs to stop", "err", err)
+	}
+
 	// The listener is closed, so no new Wg.Add races this wait; it only
 	// covers requests Shutdown may have given up on, and is bounded so a
 	// hung upstream cannot stall the exit forever.
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestAbuseIP verifies /abuse/ finds an RIR's abuse entity nested under the
+// network's organization, for both JSON and text.
+func TestAbuseIP(t *testing.T) {
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"objectClassName":"ip network","handle":"NET-ZZABUSE",` +
+			`"entities":[{"handle":"EXAMPLE-ORG","roles":["registrant"],` +
+			`"entities":[` +
+			`{"handle":"NOC-ARIN","roles":["abuse"],"vcardArray":["vcard",[["tel",{},"text","+1-555-555-0199"]]]},` +
+			`{"handle":"ABUSE-ARIN","roles":["abuse","technical"],"vcardArray":["vcard",[["fn",{},"text","Abuse Desk"],["email",{},"text","abuse@example.net"],["tel",{},"text","+1-555-555-0100"]]]}]}]}`))
+	}, "203.0.113.0/25")
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/abuse/203.0.113.0/26", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	want := `{"query":"203.0.113.0/26","resourceType":"ip","email":"abuse@example.net","phone":"+1-555-555-0100","name":"Abuse Desk","handle":"ABUSE-ARIN","source":"rir"}`
+	if got := w.Body.String(); got != want {
+		t.Errorf("body:\n got %s\nwant %s", got, want)
+	}
+
+	w = httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/abuse/203.0.113.0/26?format=text", nil))
+	if got := w.Header().Get("X-Cache"); got != "HIT" {
+		t.Errorf("X-Cache: got %q, want HIT", got)
+	}
+	if !strings.Contains(w.Body.String(), "Email:         abuse@example.net\n") {
+		t.Errorf("text body: %s", w.Body.String())
+	}
+}
+
+// TestAbuseDomain verifies a domain's abuse contact is the registrar's.
+func TestAbuseDomain(t *testing.T) {
+	withFakeRegistryAndRegistrar(t, "zzabuse", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"objectClassName":"domain","ldhName":"example.zzabuse",` +
+			`"entities":[{"roles":["registrar"],"entities":[{"roles":["abuse"],"vcardArray":["vcard",[["email",{},"text","abuse@registrar.example"]]]}]}]}`))
+	})
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/abuse/example.zzabuse?follow=registrar", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	want := `{"query":"example.zzabuse","resourceType":"domain","email":"abuse@registrar.example","name":"Registry-Side Name","source":"registrar"}`
+	if got := w.Body.String(); got != want {
+		t.Errorf("body:\n got %s\nwant %s", got, want)
+	}
+}
+
+// TestAbuseNotPublished verifies registration data without an abuse contact
+// is a 404, while a failed lookup keeps its own status.
+func TestAbuseNotPublished(t *testing.T) {
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/203.0.113.200") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"objectClassName":"ip network","handle":"NET-ZZNOABUSE",` +
+			`"entities":[{"handle":"EXAMPLE-ORG","roles":["registrant"]}]}`))
+	}, "203.0.113.128/25")
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/abuse/203.0.113.129", nil))
+	if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "No abuse contact published for: 203.0.113.129") {
+		t.Errorf("expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/abuse/203.0.113.200", nil))
+	if w.Code != http.StatusNotFound || strings.Contains(w.Body.String(), "No abuse contact") {
+		t.Errorf("expected the lookup's 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAbuseBadRequest(t *testing.T) {
+	for _, path := range []string{"/abuse/not_a_resource", "/abuse/192.0.2.1?format=rdap", "/abuse/example.com?raw=1"} {
+		w := httptest.NewRecorder()
+		newTestMux().ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
@@ -24,9 +24,11 @@ func withTestAuthKeys(t *testing.T, keys ...string) {
 // withTestAuthClients configures full auth clients for the duration of a test.
 func withTestAuthClients(t *testing.T, clients []config.AuthClient) {
 	t.Helper()
-	old := config.AuthClients
-	config.AuthClients = clients
-	t.Cleanup(func() { config.AuthClients = old })
+	old := config.Current()
+	s := *old
+	s.AuthClients = clients
+	config.SetCurrent(&s)
+	t.Cleanup(func() { config.SetCurrent(old) })
 }
 
 // authRequest runs a request through the full production middleware chain.
@@ -16,9 +16,11 @@ import (
 // withTestBatch enables the batch endpoint for the duration of a test.
 func withTestBatch(t *testing.T, enabled bool, maxItems int) {
 	t.Helper()
-	oldEnabled, oldMax := config.BatchEnabled, config.BatchMaxItems
-	config.BatchEnabled, config.BatchMaxItems = enabled, maxItems
-	t.Cleanup(func() { config.BatchEnabled, config.BatchMaxItems = oldEnabled, oldMax })
+	old := config.Current()
+	s := *old
+	s.BatchEnabled, s.BatchMaxItems = enabled, maxItems
+	config.SetCurrent(&s)
+	t.Cleanup(func() { config.SetCurrent(old) })
 }
 
 func postBatch(t *testing.T, body string) *httptest.ResponseRecorder {
@@ -225,3 +227,66 @@ func TestBatchOverBudget(t *testing.T) {
 		t.Errorf("expected the over-budget detail, got: %s", w.Body.String())
 	}
 }
+
+// flushRecorder reports the body written so far on every flush.
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	flushed chan string
+}
+
+func (f *flushRecorder) Flush() {
+	f.ResponseRecorder.Flush()
+	f.flushed <- f.Body.String()
+}
+
+// TestBatchNDJSONStreams verifies Accept: application/x-ndjson streams each
+// item as soon as it completes — a cache hit is flushed while a slow
+// upstream is still answering — and ends with a summary line.
+func TestBatchNDJSONStreams(t *testing.T) {
+	withTestBatch(t, true, 10)
+	domain := "batchndjsontest.cn"
+	if err := config.CacheManager.Set(context.Background(), handlers.CacheKeyPrefix+domain, `{"ldhName":"`+domain+`"}`, time.Minute); err != nil {
+		t.Fatalf("failed to seed cache: %v", err)
+	}
+	release := make(chan struct{})
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_, _ = w.Write([]byte(`{"objectClassName":"ip network","handle":"NET-ZZNDJSON"}`))
+	}, "198.18.2.0/24")
+
+	req := httptest.NewRequest("POST", "/batch", strings.NewReader(`{"queries": ["198.18.2.1", "`+domain+`"]}`))
+	req.Header.Set("Accept", "application/x-ndjson")
+	w := &flushRecorder{ResponseRecorder: httptest.NewRecorder(), flushed: make(chan string, 10)}
+	done := make(chan struct{})
+	go func() {
+		batchHandler(w, req)
+		close(done)
+	}()
+
+	select {
+	case first := <-w.flushed:
+		if !strings.Contains(first, `"index":1`) || !strings.Contains(first, domain) {
+			t.Errorf("first flushed line is not the cache hit: %s", first)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("nothing flushed while the slow query was pending")
+	}
+	close(release)
+	<-done
+
+	if got := w.Header().Get("Content-Type"); got != "application/x-ndjson" {
+		t.Errorf("Content-Type: got %q", got)
+	}
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 2 items and a summary, got: %s", w.Body.String())
+	}
+	var item handlers.StreamedBatchItem
+	if err := json.Unmarshal([]byte(lines[1]), &item); err != nil || item.Index != 0 || item.Status != http.StatusOK {
+		t.Errorf("slow item line: %s", lines[1])
+	}
+	var summary handlers.BatchSummary
+	if err := json.Unmarshal([]byte(lines[2]), &summary); err != nil || summary.Summary.Total != 2 || summary.Summary.Succeeded != 2 {
+		t.Errorf("summary line: %s", lines[2])
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/KincaidYang/whois/internal/bgp"
+	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/handlers"
+)
+
+// withBGPTable loads a bgpdump -m table as the active BGP table for the
+// duration of the test.
+func withBGPTable(t *testing.T, lines ...string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rib.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	table, err := bgp.Load(path)
+	if err != nil {
+		t.Fatalf("bgp.Load: %v", err)
+	}
+	bgp.SetActive(table)
+	t.Cleanup(func() { bgp.SetActive(nil) })
+}
+
+// TestBGPEnrichment verifies IP responses report the covering announced
+// prefix and its origin, and ASN responses the prefixes the AS originates.
+func TestBGPEnrichment(t *testing.T) {
+	withBGPTable(t,
+		"TABLE_DUMP2|1700000000|B|198.51.100.1|64500|203.0.113.0/24|64500 4199999993|IGP",
+		"TABLE_DUMP2|1700000000|B|198.51.100.1|64500|203.0.113.64/26|64500 64496|IGP",
+		"TABLE_DUMP2|1700000000|B|198.51.100.1|64500|2001:db8:ab::/48|64500 4199999993|IGP",
+	)
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/autnum/") {
+			_, _ = w.Write([]byte(`{"objectClassName":"autnum","handle":"AS4199999993"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"objectClassName":"ip network","handle":"NET-ZZBGP"}`))
+	}, "203.0.113.0/24", "4199999990-4199999999")
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/ip/203.0.113.10", nil))
+	if !strings.Contains(w.Body.String(), `"announcedPrefix":"203.0.113.0/24","originAsn":4199999993`) {
+		t.Errorf("IP body: %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/autnum/4199999993", nil))
+	if !strings.Contains(w.Body.String(), `"prefixes":["203.0.113.0/24","2001:db8:ab::/48"]`) {
+		t.Errorf("ASN body: %s", w.Body.String())
+	}
+}
+
+// TestBGPEnrichmentNotCached verifies the BGP data is added per response: a
+// cached answer reports the table loaded now, in every rendering, and the
+// cache entry itself carries none of it.
+func TestBGPEnrichmentNotCached(t *testing.T) {
+	withBGPTable(t, "TABLE_DUMP2|1700000000|B|198.51.100.1|64500|203.0.113.0/24|64500 4199999993|IGP")
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"objectClassName":"ip network","handle":"NET-ZZBGPCACHE"}`))
+	}, "203.0.113.0/24")
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		newTestMux().ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+	if w := get("/ip/203.0.113.20"); !strings.Contains(w.Body.String(), `"originAsn":4199999993`) {
+		t.Fatalf("first answer: %s", w.Body.String())
+	}
+	cached, err := config.CacheManager.Get(context.Background(), handlers.CacheKeyPrefix+"203.0.113.20")
+	if err != nil || !cached.Found || strings.Contains(cached.Data, "originAsn") {
+		t.Errorf("cache entry = %+v, %v; want the registry's answer alone", cached, err)
+	}
+
+	withBGPTable(t, "TABLE_DUMP2|1700000000|B|198.51.100.1|64500|203.0.113.0/24|64500 4199999994|IGP")
+	w := get("/ip/203.0.113.20")
+	if w.Header().Get("X-Cache") != "HIT" || !strings.Contains(w.Body.String(), `"originAsn":4199999994`) {
+		t.Errorf("after reload: %s %s", w.Header().Get("X-Cache"), w.Body.String())
+	}
+	get("/ip/203.0.113.20?format=text")
+	if w := get("/ip/203.0.113.20?format=text"); w.Header().Get("X-Cache") != "HIT" || !strings.Contains(w.Body.String(), "4199999994") {
+		t.Errorf("text: %s %q", w.Header().Get("X-Cache"), w.Body.String())
+	}
+}
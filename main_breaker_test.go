@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/KincaidYang/whois/internal/handlers"
+)
+
+// TestCircuitBreaker verifies repeated 5xx answers from an RDAP server open
+// its breaker, after which queries fail fast with a 503 and Retry-After
+// without reaching the server, and /breakers reports it open.
+func TestCircuitBreaker(t *testing.T) {
+	withWhoisFallback(t, false)
+	hits := withFailingRDAP(t, "zzbreaker", http.StatusBadGateway, "")
+
+	mux := newTestMux()
+	for i := range 5 {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/domain/fail%d.zzbreaker", i), nil))
+		if w.Code != http.StatusInternalServerError {
+			t.Fatalf("query %d: expected 500, got %d: %s", i, w.Code, w.Body.String())
+		}
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/domain/open.zzbreaker", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("503 without Retry-After")
+	}
+	if !strings.Contains(w.Body.String(), "upstream-unavailable") {
+		t.Errorf("unexpected problem: %s", w.Body.String())
+	}
+	if hits.Load() != 5 {
+		t.Errorf("RDAP queried %d times, want 5", hits.Load())
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/breakers", nil))
+	var report handlers.BreakerReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode /breakers: %v", err)
+	}
+	if len(report.Breakers) == 0 || report.Breakers[0].State != "open" {
+		t.Errorf("/breakers does not list the open breaker first: %s", w.Body.String())
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/KincaidYang/whois/internal/serverlist"
+)
+
+// TestEntityMissAndHit drives an /entity/ query to the fake RDAP upstream its
+// object tag maps to, then serves the repeat from cache.
+func TestEntityMissAndHit(t *testing.T) {
+	var gotPath atomic.Value
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath.Store(r.URL.Path)
+		w.Header().Set("Content-Type", "application/rdap+json")
+		_, _ = w.Write([]byte(`{"objectClassName":"entity","handle":"Abc123-ZZTAG","roles":["abuse"],` +
+			`"vcardArray":["vcard",[["fn",{},"text","Abuse Desk"],["email",{},"text","abuse@example.net"]]]}`))
+	}, serverlist.ObjectTagKey("zztag"))
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/entity/Abc123-zztag", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("X-Cache"); got != "MISS" {
+		t.Errorf("X-Cache: got %q, want MISS", got)
+	}
+	// The handle keeps its case; only the tag match is case-insensitive.
+	if got, _ := gotPath.Load().(string); got != "/entity/Abc123-zztag" {
+		t.Errorf("upstream path: %q", got)
+	}
+	body := w.Body.String()
+	for _, want := range []string{`"objectClassName":"entity"`, `"fn":"Abuse Desk"`, `"email":["abuse@example.net"]`, `"tel":[]`, `"roles":["abuse"]`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %s: %s", want, body)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/entity/Abc123-zztag", nil))
+	if got := w.Header().Get("X-Cache"); got != "HIT" {
+		t.Errorf("X-Cache: got %q, want HIT", got)
+	}
+}
+
+// TestEntityUnknownTag verifies a handle whose tag no registry claims, or
+// that carries no tag at all, gets a 404 naming the problem.
+func TestEntityUnknownTag(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/entity/ABC123-ZZNOTAG", "No RDAP server known for object tag: ZZNOTAG"},
+		{"/entity/ABC123", "Entity handle has no object tag"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		newTestMux().ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d", tt.path, w.Code)
+		}
+		if !strings.Contains(w.Body.String(), tt.want) {
+			t.Errorf("%s: body %s, want %q", tt.path, w.Body.String(), tt.want)
+		}
+	}
+}
+
+// TestEntityRejectsInvalidHandle verifies the typed path turns away input
+// that is not a handle, and that ?raw is refused.
+func TestEntityRejectsInvalidHandle(t *testing.T) {
+	for _, path := range []string{"/entity/-ARIN", "/entity/ABC%20123-ARIN", "/entity/ABC123-ARIN?raw"} {
+		w := httptest.NewRecorder()
+		newTestMux().ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
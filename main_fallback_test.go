@@ -0,0 +1,110 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/serverlist"
+)
+
+// withWhoisFallback sets rdap.whoisFallback for the duration of a test.
+func withWhoisFallback(t *testing.T, enabled bool) {
+	t.Helper()
+	orig := config.RDAPWhoisFallback
+	config.RDAPWhoisFallback = enabled
+	t.Cleanup(func() { config.RDAPWhoisFallback = orig })
+}
+
+// withFailingRDAP routes a TLD's RDAP queries to a server answering with
+// status and body, and returns a counter of the queries it served.
+func withFailingRDAP(t *testing.T, tld string, status int, body string) *atomic.Int32 {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	serverlist.UpdateFromIANA(map[string]string{tld: srv.URL + "/"})
+	t.Cleanup(func() {
+		srv.Close()
+		serverlist.UpdateFromIANA(nil)
+	})
+	return &hits
+}
+
+const fallbackWhoisCN = `Domain Name: %s
+Domain Status: ok
+Sponsoring Registrar: FallbackRegistrar
+Name Server: ns1.example.cn
+Registration Time: 2003-03-17 12:20:05
+Expiration Time: 2027-03-17 12:20:05
+DNSSEC: unsigned
+`
+
+// TestRDAPWhoisFallback verifies a 5xx from the RDAP server is retried over
+// WHOIS, parsed with the TLD's parser and marked with its source.
+func TestRDAPWhoisFallback(t *testing.T) {
+	withWhoisFallback(t, true)
+	hits := withFailingRDAP(t, "cn", http.StatusServiceUnavailable, "")
+	withMockWhoisServer(t, strings.Replace(fallbackWhoisCN, "%s", "fallbacktest.cn", 1), "cn")
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/fallbacktest.cn", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	for _, want := range []string{`"source":"whois"`, `"registrar":"FallbackRegistrar"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %s: %s", want, body)
+		}
+	}
+	if hits.Load() != 1 {
+		t.Errorf("RDAP queried %d times, want 1", hits.Load())
+	}
+}
+
+// TestRDAPWhoisFallbackUnparseable verifies an unparseable RDAP answer falls
+// back too, to the raw WHOIS text when the TLD has no parser.
+func TestRDAPWhoisFallbackUnparseable(t *testing.T) {
+	withWhoisFallback(t, true)
+	withFailingRDAP(t, "zzfallbackraw", http.StatusOK, "<html>maintenance</html>")
+	withMockWhoisServer(t, "Domain Name: example.zzfallbackraw\n", "zzfallbackraw")
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/example.zzfallbackraw", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `"source":"whois"`) || !strings.Contains(body, `"unparsed":true`) {
+		t.Errorf("expected an unparsed WHOIS answer: %s", body)
+	}
+}
+
+// TestRDAPWhoisFallbackNotForAnswers verifies a not-found answer is final,
+// and that nothing falls back while the option is off.
+func TestRDAPWhoisFallbackNotForAnswers(t *testing.T) {
+	withMockWhoisServer(t, "Domain Name: example.zzfallbackno\n", "zzfallbackno", "zzfallbackoff")
+
+	withWhoisFallback(t, true)
+	withFailingRDAP(t, "zzfallbackno", http.StatusNotFound, "")
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/example.zzfallbackno", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("not found: expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+
+	withWhoisFallback(t, false)
+	withFailingRDAP(t, "zzfallbackoff", http.StatusBadGateway, "")
+	w = httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/example.zzfallbackoff", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("fallback off: expected 500, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// TestFormatRDAPDomain verifies ?format=rdap returns the registry's answer
+// byte for byte as application/rdap+json, from cache on the second request,
+// and caches apart from the parsed answer.
+func TestFormatRDAPDomain(t *testing.T) {
+	const upstream = `{"objectClassName":"domain","ldhName":"EXAMPLE.ZZFMTRDAP","port43":"whois.example","notices":[{"title":"Terms of Use"}]}`
+	var hits atomic.Int32
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/rdap+json")
+		_, _ = w.Write([]byte(upstream))
+	}, "zzfmtrdap")
+
+	for _, wantCache := range []string{"MISS", "HIT"} {
+		w := httptest.NewRecorder()
+		newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/example.zzfmtrdap?format=rdap", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if got := w.Header().Get("X-Cache"); got != wantCache {
+			t.Errorf("X-Cache: got %q, want %q", got, wantCache)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/rdap+json" {
+			t.Errorf("Content-Type: got %q", ct)
+		}
+		if w.Body.String() != upstream {
+			t.Errorf("body modified: %s", w.Body.String())
+		}
+	}
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/example.zzfmtrdap", nil))
+	if got := w.Header().Get("X-Cache"); got != "MISS" {
+		t.Errorf("parsed query X-Cache: got %q, want MISS", got)
+	}
+	if strings.Contains(w.Body.String(), "port43") {
+		t.Errorf("parsed query served the passthrough entry: %s", w.Body.String())
+	}
+	if n := hits.Load(); n != 2 {
+		t.Errorf("upstream hit %d times, want 2", n)
+	}
+}
+
+// TestFormatRDAPIP verifies passthrough for the RDAP-only resource types.
+func TestFormatRDAPIP(t *testing.T) {
+	const upstream = `{"objectClassName":"ip network","handle":"NET-ZZFMT","startAddress":"192.0.2.0","endAddress":"192.0.2.63","links":[]}`
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(upstream))
+	}, "192.0.2.0/26")
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/ip/192.0.2.10?format=rdap", nil))
+	if w.Code != http.StatusOK || w.Body.String() != upstream {
+		t.Fatalf("got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/rdap+json" {
+		t.Errorf("Content-Type: got %q", ct)
+	}
+}
+
+// TestFormatRDAPWhoisOnlyTLD verifies a TLD without an RDAP server is a 404
+// rather than a WHOIS answer in disguise.
+func TestFormatRDAPWhoisOnlyTLD(t *testing.T) {
+	withMockWhoisServer(t, "Domain Name: example.zzfmtwhois\n", "zzfmtwhois")
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/example.zzfmtwhois?format=rdap", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "No RDAP server known for TLD") {
+		t.Errorf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestFormatBadRequest(t *testing.T) {
+	for _, path := range []string{
+		"/example.com?format=xml",
+		"/example.com?format=text&raw=1",
+		"/example.com?format=rdap&raw=1",
+		"/example.com?format=rdap&follow=registrar",
+	} {
+		w := httptest.NewRecorder()
+		newTestMux().ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
+
+// TestAcceptNegotiation verifies Accept picks the rendering, each rendering
+// has its own cache entry and ETag, and responses vary on Accept.
+func TestAcceptNegotiation(t *testing.T) {
+	var hits atomic.Int32
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		_, _ = w.Write([]byte(`{"objectClassName":"autnum","handle":"AS64496","name":"ZZ-NEGOTIATE"}`))
+	}, "64496-64496")
+
+	get := func(accept, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/autnum/64496", nil)
+		req.Header.Set("Accept", accept)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		w := httptest.NewRecorder()
+		newTestMux().ServeHTTP(w, req)
+		return w
+	}
+
+	text := get("text/plain", "")
+	if text.Code != http.StatusOK || !strings.Contains(text.Body.String(), "Name:         ZZ-NEGOTIATE\n") {
+		t.Fatalf("text: %d %q", text.Code, text.Body.String())
+	}
+	if ct := text.Header().Get("Content-Type"); ct != "text/plain; charset=utf-8" {
+		t.Errorf("text Content-Type: %q", ct)
+	}
+	if v := text.Header().Values("Vary"); !slices.Contains(v, "Accept") {
+		t.Errorf("Vary: %v", v)
+	}
+
+	yaml := get("application/yaml", "")
+	if !strings.HasPrefix(yaml.Body.String(), "objectClassName: autnum\n") || yaml.Header().Get("X-Cache") != "MISS" {
+		t.Errorf("yaml: %s %q", yaml.Header().Get("X-Cache"), yaml.Body.String())
+	}
+
+	// JSON has its own entry and tag: the text tag must not revalidate it.
+	asJSON := get("application/json", text.Header().Get("ETag"))
+	if asJSON.Code != http.StatusOK || asJSON.Header().Get("X-Cache") != "MISS" {
+		t.Errorf("json: %d %s", asJSON.Code, asJSON.Header().Get("X-Cache"))
+	}
+	if asJSON.Header().Get("ETag") == text.Header().Get("ETag") {
+		t.Error("JSON and text share an ETag")
+	}
+
+	again := get("text/plain", text.Header().Get("ETag"))
+	if again.Code != http.StatusNotModified || again.Header().Get("X-Cache") != "HIT" {
+		t.Errorf("text revalidation: %d %s", again.Code, again.Header().Get("X-Cache"))
+	}
+	if n := hits.Load(); n != 3 {
+		t.Errorf("upstream hit %d times, want 3", n)
+	}
+}
+
+func TestBatchCSV(t *testing.T) {
+	withTestBatch(t, true, 10)
+	req := httptest.NewRequest("POST", "/batch?format=csv", strings.NewReader(`{"queries":["not a domain!"]}`))
+	w := httptest.NewRecorder()
+	batchHandler(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/csv; charset=utf-8" {
+		t.Errorf("Content-Type: %q", ct)
+	}
+	if !strings.HasPrefix(w.Body.String(), "query,status,error\nnot a domain!,400,") {
+		t.Errorf("body: %q", w.Body.String())
+	}
+
+	req = httptest.NewRequest("POST", "/batch?format=rdap", strings.NewReader(`{"queries":["example.com"]}`))
+	w = httptest.NewRecorder()
+	batchHandler(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("format=rdap on /batch: expected 400, got %d", w.Code)
+	}
+}
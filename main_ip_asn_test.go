@@ -189,7 +189,7 @@ func TestHandleIPRefresh(t *testing.T) {
 	}
 
 	w := httptest.NewRecorder()
-	handlers.HandleIP(context.Background(), w, "192.0.2.160", handlers.CacheKeyPrefix, true)
+	handlers.HandleIP(context.Background(), w, "192.0.2.160", handlers.CacheKeyPrefix, handlers.FormatParsed, true)
 
 	if w.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
@@ -242,7 +242,7 @@ func TestHandleASNMissAndHit(t *testing.T) {
 // (routes normally pre-validate, so this calls the handler directly).
 func TestHandleASNInvalidFormat(t *testing.T) {
 	w := httptest.NewRecorder()
-	handlers.HandleASN(context.Background(), w, "asnotanumber", handlers.CacheKeyPrefix, false)
+	handlers.HandleASN(context.Background(), w, "asnotanumber", handlers.CacheKeyPrefix, handlers.FormatParsed, false)
 	if w.Code != http.StatusBadRequest {
 		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
 	}
@@ -255,7 +255,7 @@ func TestHandleASNRefresh(t *testing.T) {
 	}, "4199999990-4199999999")
 
 	w := httptest.NewRecorder()
-	handlers.HandleASN(context.Background(), w, "as4199999991", handlers.CacheKeyPrefix, true)
+	handlers.HandleASN(context.Background(), w, "as4199999991", handlers.CacheKeyPrefix, handlers.FormatParsed, true)
 
 	if w.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
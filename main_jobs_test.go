@@ -0,0 +1,387 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/handlers"
+	"golang.org/x/time/rate"
+)
+
+// withTestJobs enables the jobs endpoint for the duration of a test.
+func withTestJobs(t *testing.T, enabled bool, maxItems int) {
+	t.Helper()
+	old := config.Current()
+	s := *old
+	s.JobsEnabled, s.JobsMaxItems, s.JobsRetention = enabled, maxItems, time.Minute
+	s.JobsMaxRunning, s.JobsMaxRunningPerKey = 8, 2
+	config.SetCurrent(&s)
+	t.Cleanup(func() { config.SetCurrent(old) })
+}
+
+// jobRequest runs a /jobs request through the full middleware chain.
+func jobRequest(t *testing.T, method, path, key, body string) (*httptest.ResponseRecorder, handlers.Job) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set("X-API-Key", key)
+	}
+	w := authRequest(req)
+	var job handlers.Job
+	if w.Code < 300 {
+		if err := json.Unmarshal(w.Body.Bytes(), &job); err != nil {
+			t.Fatalf("%s %s: response is not a job: %v: %s", method, path, err, w.Body.String())
+		}
+	}
+	return w, job
+}
+
+// waitForJob polls a job as client (nil without auth) until its status
+// leaves "running". It calls the handler directly, so polling spends none
+// of the client's rate-limit budget the job itself is paced by.
+func waitForJob(t *testing.T, id string, client *config.AuthClient) handlers.Job {
+	t.Helper()
+	ctx := context.Background()
+	if client != nil {
+		ctx = config.WithAuthClient(ctx, client)
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		w := httptest.NewRecorder()
+		jobs.HandleJob(ctx, w, httptest.NewRequest("GET", "/jobs/"+id, nil), id)
+		if w.Code != http.StatusOK {
+			t.Fatalf("poll: expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var job handlers.Job
+		if err := json.Unmarshal(w.Body.Bytes(), &job); err != nil {
+			t.Fatalf("poll: response is not a job: %v", err)
+		}
+		if job.Status != "running" {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job still running after 10s: %+v", job)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// TestJobsDisabled verifies the endpoint is off by default.
+func TestJobsDisabled(t *testing.T) {
+	withTestJobs(t, false, 10)
+
+	w, _ := jobRequest(t, "POST", "/jobs", "", `{"queries": ["example.com"]}`)
+	if w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), "#jobs-disabled") {
+		t.Errorf("expected the jobs-disabled problem, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// TestJobLifecycle verifies a job is accepted with 202 and a Location, and
+// polling reports its per-item results once completed.
+func TestJobLifecycle(t *testing.T) {
+	withTestJobs(t, true, 10)
+	domain := "jobcachedtest.cn"
+	if err := config.CacheManager.Set(context.Background(), handlers.CacheKeyPrefix+domain, `{"ldhName":"`+domain+`"}`, time.Minute); err != nil {
+		t.Fatalf("failed to seed cache: %v", err)
+	}
+
+	w, job := jobRequest(t, "POST", "/jobs", "", `{"queries": ["`+domain+`", "!!not-valid!!"]}`)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Location"); got != "/jobs/"+job.ID {
+		t.Errorf("Location: got %q for job %q", got, job.ID)
+	}
+	if job.Status != "running" || job.Total != 2 {
+		t.Errorf("accepted job: %+v", job)
+	}
+
+	job = waitForJob(t, job.ID, nil)
+	if job.Status != "completed" || job.Completed != 2 || job.Failed != 1 {
+		t.Fatalf("finished job: %+v", job)
+	}
+	if item := job.Results[0]; item == nil || item.Status != http.StatusOK || !strings.Contains(string(item.Data), domain) {
+		t.Errorf("cached item: %+v", item)
+	}
+	if item := job.Results[1]; item == nil || item.Status != http.StatusBadRequest {
+		t.Errorf("invalid item: %+v", item)
+	}
+
+	// Deleting a finished job changes nothing.
+	if w, job := jobRequest(t, "DELETE", "/jobs/"+job.ID, "", ""); w.Code != http.StatusOK || job.Status != "completed" {
+		t.Errorf("delete after completion: %d %+v", w.Code, job)
+	}
+}
+
+// TestJobStoredInChunks verifies a job's results are stored apart from the
+// header record its flushes rewrite, and reassembled in order for polls.
+func TestJobStoredInChunks(t *testing.T) {
+	withTestJobs(t, true, 250)
+	queries := make([]string, 250)
+	for i := range queries {
+		queries[i] = fmt.Sprintf(`"!!not-valid-%d!!"`, i)
+	}
+	w, job := jobRequest(t, "POST", "/jobs", "", `{"queries": [`+strings.Join(queries, ",")+`]}`)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+
+	job = waitForJob(t, job.ID, nil)
+	if job.Status != "completed" || job.Completed != len(queries) || len(job.Results) != len(queries) {
+		t.Fatalf("finished job: status %s, %d of %d completed, %d results", job.Status, job.Completed, job.Total, len(job.Results))
+	}
+	for i, item := range job.Results {
+		if want := fmt.Sprintf("!!not-valid-%d!!", i); item == nil || item.Query != want {
+			t.Fatalf("result %d: %+v, want the answer to %s", i, item, want)
+		}
+	}
+	header, err := config.CacheManager.Get(context.Background(), handlers.CacheKeyPrefix+"job:"+job.ID)
+	if err != nil || !header.Found {
+		t.Fatalf("header record: %+v, %v", header, err)
+	}
+	if strings.Contains(header.Data, `"results"`) {
+		t.Errorf("header record carries the results: %s", header.Data)
+	}
+}
+
+func TestJobBadRequests(t *testing.T) {
+	withTestJobs(t, true, 2)
+
+	if w, _ := jobRequest(t, "POST", "/jobs", "", `{"queries": ["a.cn", "b.cn", "c.cn"]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("over maxItems: expected 400, got %d", w.Code)
+	}
+	if w, _ := jobRequest(t, "GET", "/jobs", "", ""); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /jobs: expected 405, got %d", w.Code)
+	}
+	for _, id := range []string{"not-a-job", "AAAAAAAAAAAAAAAAAAAAAAAAAA"} {
+		if w, _ := jobRequest(t, "GET", "/jobs/"+id, "", ""); w.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d", id, w.Code)
+		}
+	}
+}
+
+// TestJobCancel verifies DELETE stops a running job: queries not started yet
+// stay pending and the status turns to cancelled.
+func TestJobCancel(t *testing.T) {
+	withTestJobs(t, true, 100)
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"objectClassName":"ip network","handle":"NET-ZZJOB"}`))
+	}, "198.18.0.0/24")
+
+	queries := make([]string, 30)
+	for i := range queries {
+		queries[i] = fmt.Sprintf("%q", fmt.Sprintf("198.18.0.%d", i+1))
+	}
+	w, job := jobRequest(t, "POST", "/jobs", "", `{"queries": [`+strings.Join(queries, ",")+`]}`)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	if w, _ := jobRequest(t, "DELETE", "/jobs/"+job.ID, "", ""); w.Code != http.StatusAccepted {
+		t.Fatalf("DELETE: expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+
+	job = waitForJob(t, job.ID, nil)
+	if job.Status != "cancelled" || job.Completed == job.Total || job.Results[len(queries)-1] != nil {
+		t.Errorf("cancelled job: status %s, %d of %d completed", job.Status, job.Completed, job.Total)
+	}
+}
+
+// TestJobPacedByRateLimit verifies a job waits for its key's rate-limit
+// tokens instead of failing its queries, and is only visible to that key.
+func TestJobPacedByRateLimit(t *testing.T) {
+	withTestJobs(t, true, 10)
+	withTestAuthClients(t, []config.AuthClient{
+		{Key: "job-key", Name: "jobber", RateLimit: 1200, Limiter: rate.NewLimiter(20, 1)},
+		{Key: "other-key", Name: "other"},
+	})
+	var queries []string
+	for _, d := range []string{"jobpacea.cn", "jobpaceb.cn", "jobpacec.cn", "jobpaced.cn"} {
+		if err := config.CacheManager.Set(context.Background(), handlers.CacheKeyPrefix+d, `{"ldhName":"`+d+`"}`, time.Minute); err != nil {
+			t.Fatalf("failed to seed cache: %v", err)
+		}
+		queries = append(queries, `"`+d+`"`)
+	}
+
+	start := time.Now()
+	w, job := jobRequest(t, "POST", "/jobs", "job-key", `{"queries": [`+strings.Join(queries, ",")+`]}`)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	if w, _ := jobRequest(t, "GET", "/jobs/"+job.ID, "other-key", ""); w.Code != http.StatusNotFound {
+		t.Errorf("another key's poll: expected 404, got %d", w.Code)
+	}
+
+	job = waitForJob(t, job.ID, &config.Current().AuthClients[0])
+	if job.Status != "completed" || job.Failed != 0 || job.Client != "jobber" {
+		t.Errorf("finished job: %+v", job)
+	}
+	// The POST spent the one token of burst, so the three queries after the
+	// first wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("job finished in %v; expected it to wait for rate-limit tokens", elapsed)
+	}
+}
+
+// TestJobOwnedByKey verifies a job is told apart by its API key, not the
+// key's name: another key with the same name cannot see it, and the stored
+// owner is not served.
+func TestJobOwnedByKey(t *testing.T) {
+	withTestJobs(t, true, 10)
+	withTestAuthClients(t, []config.AuthClient{
+		{Key: "owner-key", Name: "shared"},
+		{Key: "namesake-key", Name: "shared"},
+	})
+	domain := "jobownertest.cn"
+	if err := config.CacheManager.Set(context.Background(), handlers.CacheKeyPrefix+domain, `{"ldhName":"`+domain+`"}`, time.Minute); err != nil {
+		t.Fatalf("failed to seed cache: %v", err)
+	}
+
+	w, job := jobRequest(t, "POST", "/jobs", "owner-key", `{"queries": ["`+domain+`"]}`)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	waitForJob(t, job.ID, &config.Current().AuthClients[0])
+	if w, _ := jobRequest(t, "GET", "/jobs/"+job.ID, "namesake-key", ""); w.Code != http.StatusNotFound {
+		t.Errorf("poll by a key with the same name: expected 404, got %d", w.Code)
+	}
+	if w, _ := jobRequest(t, "DELETE", "/jobs/"+job.ID, "namesake-key", ""); w.Code != http.StatusNotFound {
+		t.Errorf("delete by a key with the same name: expected 404, got %d", w.Code)
+	}
+	w, job = jobRequest(t, "GET", "/jobs/"+job.ID, "owner-key", "")
+	if w.Code != http.StatusOK || job.Client != "shared" {
+		t.Fatalf("poll by the owner: %d %+v", w.Code, job)
+	}
+	if strings.Contains(w.Body.String(), `"owner"`) {
+		t.Errorf("the stored owner is served: %s", w.Body.String())
+	}
+}
+
+// TestJobPacedByReloadedRateLimit verifies a running job is paced by its
+// key's rate limit as currently configured, not the one it was created with.
+func TestJobPacedByReloadedRateLimit(t *testing.T) {
+	withTestJobs(t, true, 10)
+	// The key as the job was created: its one token spent, the next due in
+	// over a quarter of an hour.
+	created := config.AuthClient{Key: "reload-key", Name: "reloader", RateLimit: 1, Limiter: rate.NewLimiter(0.001, 1)}
+	created.Limiter.Allow()
+	// The key as reloaded since: unlimited.
+	withTestAuthClients(t, []config.AuthClient{{Key: "reload-key", Name: "reloader"}})
+
+	var queries []string
+	for _, d := range []string{"jobreloada.cn", "jobreloadb.cn", "jobreloadc.cn"} {
+		if err := config.CacheManager.Set(context.Background(), handlers.CacheKeyPrefix+d, `{"ldhName":"`+d+`"}`, time.Minute); err != nil {
+			t.Fatalf("failed to seed cache: %v", err)
+		}
+		queries = append(queries, `"`+d+`"`)
+	}
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/jobs", strings.NewReader(`{"queries": [`+strings.Join(queries, ",")+`]}`))
+	jobs.HandleCreate(config.WithAuthClient(context.Background(), &created), w, req)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	var job handlers.Job
+	if err := json.Unmarshal(w.Body.Bytes(), &job); err != nil {
+		t.Fatalf("response is not a job: %v", err)
+	}
+
+	if job = waitForJob(t, job.ID, &created); job.Status != "completed" || job.Completed != len(queries) {
+		t.Errorf("finished job: %+v", job)
+	}
+}
+
+// TestJobRunningCaps verifies a job beyond jobs.maxRunningPerKey is refused
+// with 429 and one beyond jobs.maxRunning with 503, and that a finished job
+// frees its place.
+func TestJobRunningCaps(t *testing.T) {
+	withTestJobs(t, true, 100)
+	withTestAuthClients(t, []config.AuthClient{
+		{Key: "cap-key-a", Name: "a"}, {Key: "cap-key-b", Name: "b"}, {Key: "cap-key-c", Name: "c"},
+	})
+	s := *config.Current()
+	s.JobsMaxRunning, s.JobsMaxRunningPerKey = 2, 1
+	config.SetCurrent(&s)
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"objectClassName":"ip network","handle":"NET-ZZJOB"}`))
+	}, "198.18.2.0/24")
+	body := `{"queries": ["198.18.2.1", "198.18.2.2", "198.18.2.3", "198.18.2.4", "198.18.2.5", "198.18.2.6"]}`
+
+	w, jobA := jobRequest(t, "POST", "/jobs", "cap-key-a", body)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("first job: expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	if w, _ := jobRequest(t, "POST", "/jobs", "cap-key-a", body); w.Code != http.StatusTooManyRequests || !strings.Contains(w.Body.String(), "#too-many-jobs") {
+		t.Errorf("second job of the key: expected the 429 too-many-jobs problem, got %d: %s", w.Code, w.Body.String())
+	}
+	w, jobB := jobRequest(t, "POST", "/jobs", "cap-key-b", body)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("another key's job: expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	if w, _ := jobRequest(t, "POST", "/jobs", "cap-key-c", body); w.Code != http.StatusServiceUnavailable || !strings.Contains(w.Body.String(), "#too-many-jobs") {
+		t.Errorf("job over the instance cap: expected the 503 too-many-jobs problem, got %d: %s", w.Code, w.Body.String())
+	}
+
+	clients := config.Current().AuthClients
+	for i, id := range []string{jobA.ID, jobB.ID} {
+		if w, _ := jobRequest(t, "DELETE", "/jobs/"+id, clients[i].Key, ""); w.Code != http.StatusAccepted {
+			t.Fatalf("DELETE: expected 202, got %d: %s", w.Code, w.Body.String())
+		}
+		waitForJob(t, id, &clients[i])
+	}
+	// The deferred cleanup of a finished job races the last poll briefly.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		w, job := jobRequest(t, "POST", "/jobs", "cap-key-c", `{"queries": ["198.18.2.1"]}`)
+		if w.Code == http.StatusAccepted {
+			waitForJob(t, job.ID, &clients[2])
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job after the others finished: expected 202, got %d: %s", w.Code, w.Body.String())
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// TestJobInterruptedByShutdown verifies a job still running at shutdown
+// records itself as interrupted.
+func TestJobInterruptedByShutdown(t *testing.T) {
+	withTestJobs(t, true, 10)
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"objectClassName":"ip network","handle":"NET-ZZJOB"}`))
+	}, "198.18.1.0/24")
+
+	m := handlers.NewJobManager()
+	w := httptest.NewRecorder()
+	m.HandleCreate(context.Background(), w, httptest.NewRequest("POST", "/jobs", strings.NewReader(`{"queries": ["198.18.1.1"]}`)))
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	var job handlers.Job
+	_ = json.Unmarshal(w.Body.Bytes(), &job)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := m.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if job = waitForJob(t, job.ID, nil); job.Status != "interrupted" {
+		t.Errorf("expected interrupted, got %+v", job)
+	}
+
+	w = httptest.NewRecorder()
+	m.HandleCreate(context.Background(), w, httptest.NewRequest("POST", "/jobs", strings.NewReader(`{"queries": ["198.18.1.2"]}`)))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("job after shutdown: expected 500, got %d", w.Code)
+	}
+}
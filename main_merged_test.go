@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/KincaidYang/whois/internal/model"
+)
+
+// TestMergedSource verifies ?source=merged fills the fields the RDAP answer
+// lacks from WHOIS, reports where each came from, and caches apart from the
+// plain RDAP answer.
+func TestMergedSource(t *testing.T) {
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rdap+json")
+		_, _ = w.Write([]byte(`{"objectClassName":"domain","ldhName":"mergetest.cn",` +
+			`"status":["active"],"nameservers":[{"ldhName":"ns1.rdap.cn"}]}`))
+	}, "cn")
+	withMockWhoisServer(t, strings.Replace(fallbackWhoisCN, "%s", "mergetest.cn", 1), "cn")
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/mergetest.cn?source=merged", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var info model.DomainInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &info); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if info.Source != model.DomainSourceMerged {
+		t.Errorf("source = %q, want merged", info.Source)
+	}
+	// RDAP's own values win; WHOIS fills only what RDAP left empty.
+	if len(info.Nameservers) != 1 || info.Nameservers[0] != "ns1.rdap.cn" {
+		t.Errorf("nameservers = %v, want RDAP's", info.Nameservers)
+	}
+	if info.Registrar != "FallbackRegistrar" || info.ExpirationDate == "" {
+		t.Errorf("WHOIS fields not filled: %+v", info)
+	}
+	for field, want := range map[string]string{
+		"nameservers":    model.DomainSourceRDAP,
+		"status":         model.DomainSourceRDAP,
+		"registrar":      model.DomainSourceWhois,
+		"expirationDate": model.DomainSourceWhois,
+	} {
+		if got := info.Provenance[field]; got != want {
+			t.Errorf("provenance[%s] = %q, want %q", field, got, want)
+		}
+	}
+
+	// The plain query is cached separately and carries no WHOIS data.
+	w = httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/mergetest.cn", nil))
+	if got := w.Header().Get("X-Cache"); got != "MISS" {
+		t.Errorf("plain query X-Cache: got %q, want MISS", got)
+	}
+	if body := w.Body.String(); strings.Contains(body, "FallbackRegistrar") || strings.Contains(body, "provenance") {
+		t.Errorf("plain query contains merged data: %s", body)
+	}
+}
+
+// TestMergedSourceSingleSource verifies an answer from one source alone
+// still reports provenance, attributing every populated field to it.
+func TestMergedSourceSingleSource(t *testing.T) {
+	t.Run("rdap", func(t *testing.T) {
+		withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/rdap+json")
+			_, _ = w.Write([]byte(`{"objectClassName":"domain","ldhName":"mergerdap.cn",` +
+				`"status":["active"],"nameservers":[{"ldhName":"ns1.rdap.cn"}]}`))
+		}, "cn")
+		withMockWhoisServer(t, "No matching record.\n", "cn")
+
+		info := getMerged(t, "/domain/mergerdap.cn?source=merged")
+		if info.Source != model.DomainSourceRDAP {
+			t.Errorf("source = %q, want rdap", info.Source)
+		}
+		for field, got := range info.Provenance {
+			if got != model.DomainSourceRDAP {
+				t.Errorf("provenance[%s] = %q, want rdap", field, got)
+			}
+		}
+		if info.Provenance["status"] != model.DomainSourceRDAP || info.Provenance["nameservers"] != model.DomainSourceRDAP {
+			t.Errorf("fields not attributed: %v", info.Provenance)
+		}
+	})
+	t.Run("whois", func(t *testing.T) {
+		withFailingRDAP(t, "cn", http.StatusServiceUnavailable, "")
+		withMockWhoisServer(t, strings.Replace(fallbackWhoisCN, "%s", "mergewhois.cn", 1), "cn")
+
+		info := getMerged(t, "/domain/mergewhois.cn?source=merged")
+		if info.Source != model.DomainSourceWhois {
+			t.Errorf("source = %q, want whois", info.Source)
+		}
+		if len(info.Provenance) == 0 {
+			t.Fatal("no provenance")
+		}
+		for field, got := range info.Provenance {
+			if got != model.DomainSourceWhois {
+				t.Errorf("provenance[%s] = %q, want whois", field, got)
+			}
+		}
+		if info.Provenance["registrar"] != model.DomainSourceWhois {
+			t.Errorf("registrar not attributed: %v", info.Provenance)
+		}
+	})
+}
+
+// getMerged requests path and decodes the 200 answer.
+func getMerged(t *testing.T, path string) model.DomainInfo {
+	t.Helper()
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var info model.DomainInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &info); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return info
+}
+
+// TestMergedSourceRDAPNotFound verifies an RDAP not-found answer stays final
+// even though WHOIS answers.
+func TestMergedSourceRDAPNotFound(t *testing.T) {
+	withFailingRDAP(t, "cn", http.StatusNotFound, "")
+	withMockWhoisServer(t, strings.Replace(fallbackWhoisCN, "%s", "mergegone.cn", 1), "cn")
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/mergegone.cn?source=merged", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestMergedSourceBadRequests(t *testing.T) {
+	for _, path := range []string{
+		"/domain/example.com?source=whois",
+		"/ip/192.0.2.1?source=merged",
+		"/abuse/example.com?source=merged",
+		"/domain/example.com?source=merged&raw=1",
+		"/domain/example.com?source=merged&format=rdap",
+	} {
+		w := httptest.NewRecorder()
+		newTestMux().ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// TestNameserverMissAndHit drives a /nameserver/ query through the fake RDAP
+// upstream of the host's TLD, then serves the repeat from cache.
+func TestNameserverMissAndHit(t *testing.T) {
+	var gotPath atomic.Value
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath.Store(r.URL.Path)
+		w.Header().Set("Content-Type", "application/rdap+json")
+		_, _ = w.Write([]byte(`{"objectClassName":"nameserver","ldhName":"NS1.EXAMPLE.ZZNSTEST","ipAddresses":{"v4":["192.0.2.53"]}}`))
+	}, "zznstest")
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/nameserver/NS1.Example.zznstest", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("X-Cache"); got != "MISS" {
+		t.Errorf("X-Cache: got %q, want MISS", got)
+	}
+	// The host name is queried as-is, not reduced to its registrable domain.
+	if got, _ := gotPath.Load().(string); got != "/nameserver/ns1.example.zznstest" {
+		t.Errorf("upstream path: %q", got)
+	}
+	body := w.Body.String()
+	for _, want := range []string{`"objectClassName":"nameserver"`, `"ldhName":"ns1.example.zznstest"`, `"v4":["192.0.2.53"]`, `"v6":[]`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %s: %s", want, body)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/nameserver/ns1.example.zznstest", nil))
+	if got := w.Header().Get("X-Cache"); got != "HIT" {
+		t.Errorf("X-Cache: got %q, want HIT", got)
+	}
+
+	// The domain path must not be answered from the nameserver entry.
+	w = httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/ns1.example.zznstest", nil))
+	if strings.Contains(w.Body.String(), `"objectClassName":"nameserver"`) {
+		t.Errorf("domain query served the nameserver object: %s", w.Body.String())
+	}
+}
+
+// TestNameserverRejectsNonHostName verifies the typed path turns IPs and
+// ASNs away instead of dispatching them to another handler.
+func TestNameserverRejectsNonHostName(t *testing.T) {
+	for _, path := range []string{"/nameserver/192.0.2.1", "/nameserver/as13335"} {
+		w := httptest.NewRecorder()
+		newTestMux().ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
+
+// TestNameserverNoRDAPServer verifies a host under a TLD without RDAP gets a
+// 404: nameserver objects have no WHOIS equivalent to fall back to.
+func TestNameserverNoRDAPServer(t *testing.T) {
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/nameserver/ns1.example.zzqqxxnotld", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "No RDAP server known") {
+		t.Errorf("body: %s", w.Body.String())
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/KincaidYang/whois/internal/config"
+	"github.com/KincaidYang/whois/internal/rdap"
+	"github.com/KincaidYang/whois/internal/serverlist"
+)
+
+// withFakeRegistryAndRegistrar serves a registry answer for every
+// /domain/ request and the registrar's record under /registrar/, which the
+// registry answer links to. The registrar hop only follows HTTPS links, so
+// the fake runs TLS and config.HttpClient is swapped for one trusting it.
+func withFakeRegistryAndRegistrar(t *testing.T, tld string, registrar http.HandlerFunc) *atomic.Int32 {
+	t.Helper()
+	var registrarHits atomic.Int32
+	var srv *httptest.Server
+	srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/registrar/") {
+			registrarHits.Add(1)
+			registrar(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rdap+json")
+		_, _ = w.Write([]byte(`{"objectClassName":"domain","ldhName":"example.` + tld + `",` +
+			`"entities":[{"roles":["registrar"],"vcardArray":["vcard",[["fn",{},"text","Registry-Side Name"]]]}],` +
+			`"events":[{"eventAction":"expiration","eventDate":"2030-01-01T00:00:00Z"}],` +
+			`"links":[{"rel":"related","type":"application/rdap+json","href":"` + srv.URL + `/registrar/domain/example.` + tld + `"}]}`))
+	}))
+	serverlist.UpdateFromIANA(map[string]string{tld: srv.URL + "/"})
+	origClient := config.HttpClient
+	config.HttpClient = srv.Client()
+	origResolve := rdap.ResolveRegistrarHost
+	rdap.ResolveRegistrarHost = func(context.Context, string) (net.IP, error) { return net.IPv4(127, 0, 0, 1), nil }
+	t.Cleanup(func() {
+		config.HttpClient = origClient
+		rdap.ResolveRegistrarHost = origResolve
+		srv.Close()
+		serverlist.UpdateFromIANA(nil)
+	})
+	return &registrarHits
+}
+
+// TestFollowRegistrar verifies ?follow=registrar merges the registrar's
+// abuse contact, reseller and expiration into the registry's answer without
+// touching the registry's own fields, and caches apart from plain queries.
+func TestFollowRegistrar(t *testing.T) {
+	hits := withFakeRegistryAndRegistrar(t, "zzregok", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"objectClassName":"domain","ldhName":"example.zzregok",` +
+			`"entities":[` +
+			`{"roles":["registrar"],"vcardArray":["vcard",[["fn",{},"text","Registrar-Side Name"]]],` +
+			`"entities":[{"roles":["abuse"],"vcardArray":["vcard",[["email",{},"text","abuse@registrar.example"],["tel",{},"uri","tel:+1.5555550100"]]]}]},` +
+			`{"roles":["reseller"],"vcardArray":["vcard",[["fn",{},"text","Example Reseller"]]]}],` +
+			`"events":[{"eventAction":"registrar expiration","eventDate":"2029-12-31T00:00:00Z"}]}`))
+	})
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/example.zzregok?follow=registrar", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		`"registrar":"Registry-Side Name"`,
+		`"expirationDate":"2030-01-01T00:00:00Z"`,
+		`"registrarExpirationDate":"2029-12-31T00:00:00Z"`,
+		`"reseller":"Example Reseller"`,
+		`"registrarAbuseContact":{"email":"abuse@registrar.example","phone":"+1.5555550100"}`,
+		`"registrarLookup":{"status":"ok","url":"https://`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %s: %s", want, body)
+		}
+	}
+
+	// A plain query neither follows the link nor reuses the followed entry.
+	w = httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/example.zzregok", nil))
+	if got := w.Header().Get("X-Cache"); got != "MISS" {
+		t.Errorf("plain query X-Cache: got %q, want MISS", got)
+	}
+	if strings.Contains(w.Body.String(), "registrarLookup") || strings.Contains(w.Body.String(), "reseller") {
+		t.Errorf("plain query followed the registrar link: %s", w.Body.String())
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("registrar fetched %d times, want 1", n)
+	}
+}
+
+// TestFollowRegistrarGlobal verifies rdap.followRegistrar follows the link
+// without the query parameter.
+func TestFollowRegistrarGlobal(t *testing.T) {
+	hits := withFakeRegistryAndRegistrar(t, "zzregglobal", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"objectClassName":"domain","ldhName":"example.zzregglobal"}`))
+	})
+	orig := config.RDAPFollowRegistrar
+	config.RDAPFollowRegistrar = true
+	t.Cleanup(func() { config.RDAPFollowRegistrar = orig })
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/example.zzregglobal", nil))
+	if !strings.Contains(w.Body.String(), `"registrarLookup":{"status":"ok"`) || hits.Load() != 1 {
+		t.Errorf("registrar not followed (hits=%d): %s", hits.Load(), w.Body.String())
+	}
+}
+
+// TestFollowRegistrarFailure verifies a failing registrar still answers with
+// the registry's data, marking the hop as failed.
+func TestFollowRegistrarFailure(t *testing.T) {
+	withFakeRegistryAndRegistrar(t, "zzregfail", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/example.zzregfail?follow=registrar", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `"registrarLookup":{"status":"failed"`) || !strings.Contains(body, `"registrar":"Registry-Side Name"`) {
+		t.Errorf("body: %s", body)
+	}
+}
+
+// TestFollowRegistrarWhoisTLD verifies a TLD answered over WHOIS, where there
+// is no registrar link to follow, shares the plain query's cache entry.
+func TestFollowRegistrarWhoisTLD(t *testing.T) {
+	withMockWhoisServer(t, "Domain Name: followwhois.cn\nDomain Status: ok\nSponsoring Registrar: MockRegistrar\n"+
+		"Registration Time: 2003-03-17 12:20:05\nExpiration Time: 2027-03-17 12:20:05\n", "cn")
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/followwhois.cn?follow=registrar", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/followwhois.cn", nil))
+	if got := w.Header().Get("X-Cache"); got != "HIT" {
+		t.Errorf("plain query X-Cache: got %q, want HIT", got)
+	}
+}
+
+func TestFollowRegistrarBadRequest(t *testing.T) {
+	for _, path := range []string{"/domain/example.com?follow=reseller", "/ip/192.0.2.1?follow=registrar"} {
+		w := httptest.NewRecorder()
+		newTestMux().ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestReverseWalksUpToDelegation verifies /reverse/ queries the RIR for the
+// address's /24 zone, then the /16 zone the delegation is registered under,
+// and reports its nameservers and DNSSEC state.
+func TestReverseWalksUpToDelegation(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path != "/domain/51.198.in-addr.arpa" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"objectClassName":"domain","ldhName":"51.198.in-addr.arpa",` +
+			`"nameservers":[{"ldhName":"ns1.example.net"},{"ldhName":"ns2.example.net"}],` +
+			`"secureDNS":{"delegationSigned":true,"dsData":[{"keyTag":12345,"algorithm":13,"digestType":2,"digest":"ABCDEF"}]}}`))
+	}, "198.51.0.0/16")
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/reverse/198.51.100.7", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		`"ldhName":"51.198.in-addr.arpa"`,
+		`"nameservers":["ns1.example.net","ns2.example.net"]`,
+		`"delegationSigned":true`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %s: %s", want, body)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []string{"/domain/100.51.198.in-addr.arpa", "/domain/51.198.in-addr.arpa"}; strings.Join(paths, " ") != strings.Join(want, " ") {
+		t.Errorf("upstream paths: %v, want %v", paths, want)
+	}
+}
+
+// TestReverseIPv6Prefix verifies an IPv6 prefix starts at its own nibble
+// zone and an RDAP passthrough is served as is.
+func TestReverseIPv6Prefix(t *testing.T) {
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/domain/c.b.a.0.8.b.d.0.1.0.0.2.ip6.arpa" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"objectClassName":"domain","ldhName":"c.b.a.0.8.b.d.0.1.0.0.2.ip6.arpa","port43":"whois.example.net"}`))
+	}, "2001:db8:a00::/40")
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/reverse/2001:db8:abc::/48?format=rdap", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"port43":"whois.example.net"`) {
+		t.Fatalf("expected the upstream answer, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// TestReverseNotDelegated verifies a 404 once every candidate zone is.
+func TestReverseNotDelegated(t *testing.T) {
+	withFakeRDAP(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}, "203.0.113.0/24")
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/reverse/203.0.113.0/24", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestReverseBadRequest(t *testing.T) {
+	for _, path := range []string{"/reverse/example.com", "/reverse/AS64496", "/reverse/192.0.2.1?raw=1"} {
+		w := httptest.NewRecorder()
+		newTestMux().ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
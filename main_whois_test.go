@@ -7,7 +7,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/KincaidYang/whois/internal/config"
 	"github.com/KincaidYang/whois/internal/serverlist"
+	"golang.org/x/text/encoding/simplifiedchinese"
 )
 
 // withMockWhoisServer starts a loopback WHOIS server answering every
@@ -117,6 +119,61 @@ func TestWhoisDomainWithoutParser(t *testing.T) {
 	}
 }
 
+// TestWhoisDomainCharset verifies a GBK answer is returned as UTF-8, both
+// parsed and raw, with the charset it came in recorded.
+func TestWhoisDomainCharset(t *testing.T) {
+	answer := strings.Replace(fallbackWhoisCN, "FallbackRegistrar", "北京新网数码信息技术有限公司", 1)
+	answer = strings.Replace(answer, "%s", "charsettest.cn", 1)
+	gbk, err := simplifiedchinese.GBK.NewEncoder().String(answer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	withMockWhoisServer(t, gbk, "cn")
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/charsettest.cn", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `"registrar":"北京新网数码信息技术有限公司"`) || !strings.Contains(body, `"charset":"gb18030"`) {
+		t.Errorf("GBK answer not transcoded: %s", body)
+	}
+
+	w = httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/charsettest.cn?raw=1", nil))
+	if !strings.Contains(w.Body.String(), "Sponsoring Registrar: 北京新网数码信息技术有限公司") {
+		t.Errorf("raw answer not transcoded: %q", w.Body.String())
+	}
+}
+
+// TestWhoisDomainQueryTemplate verifies an answer to a named query template
+// goes to that template's parser, even for a TLD without a parser of its own.
+func TestWhoisDomainQueryTemplate(t *testing.T) {
+	withMockWhoisServer(t, `[Domain Name]                   TEMPLATETEST.ZZJPRS
+[Registrant]                    Template Test Corp
+[Name Server]                   ns1.templatetest.zzjprs
+[Created on]                    2010/03/01
+[Expires on]                    2026/03/31
+[Status]                        Active
+`, "zzjprs")
+	old := config.Current()
+	s := *old
+	s.WhoisQueries = map[string]config.WhoisQuery{"127.0.0.1": {Query: "{domain}/e", Name: "jprs-en"}}
+	config.SetCurrent(&s)
+	t.Cleanup(func() { config.SetCurrent(old) })
+
+	w := httptest.NewRecorder()
+	newTestMux().ServeHTTP(w, httptest.NewRequest("GET", "/domain/templatetest.zzjprs", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	if strings.Contains(body, `"unparsed":true`) || !strings.Contains(body, `"expirationDate":"2026-03-31"`) {
+		t.Errorf("answer not parsed as JPRS English: %s", body)
+	}
+}
+
 // TestWhoisDomainRaw verifies ?raw=1 returns the bare WHOIS text as
 // text/plain and serves the follow-up request from the raw: cache namespace.
 func TestWhoisDomainRaw(t *testing.T) {
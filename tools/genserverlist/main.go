@@ -72,7 +72,7 @@ const rdapFileHeader = generatedHeader + `package serverlist
 // compiledRdapServers is the compiled-in IANA baseline data.
 // It is used as a fallback when the live IANA bootstrap fetch fails.
 // Source: https://data.iana.org/rdap/
-var compiledRdapServers = map[string]string{
+var compiledRdapServers = map[string][]string{
 `
 
 const whoisFileHeader = generatedHeader + `package serverlist
@@ -83,9 +83,9 @@ const whoisFileHeader = generatedHeader + `package serverlist
 var TLDToWhoisServer = map[string]string{
 `
 
-// rdapSections are the four bootstrap registries, in the order they appear in
-// the generated file. RDAP is looked up by IP address, by ASN and by TLD, and
-// each of those answers comes from its own IANA file.
+// rdapSections are the five bootstrap registries, in the order they appear in
+// the generated file. RDAP is looked up by IP address, by ASN, by TLD and by
+// entity handle tag, and each of those answers comes from its own IANA file.
 var rdapSections = []struct {
 	category string // key used by serverlist.FetchIANA
 	title    string
@@ -94,6 +94,7 @@ var rdapSections = []struct {
 	{"ipv6", "IPv6 RDAP servers"},
 	{"asn", "ASN RDAP servers"},
 	{"dns", "TLD RDAP servers"},
+	{"objecttags", "Object tag RDAP servers (RFC 8521)"},
 }
 
 func main() {
@@ -179,7 +180,7 @@ type rdapTable struct {
 	collisions bool
 }
 
-// buildRDAP renders rdap_servers.go from the four IANA bootstrap files and
+// buildRDAP renders rdap_servers.go from the five IANA bootstrap files and
 // reports whether any custom entry collides with them.
 func buildRDAP(ctx context.Context, dir string) (built rdapTable, err error) {
 	client := &http.Client{Timeout: 30 * time.Second}
@@ -189,19 +190,34 @@ func buildRDAP(ctx context.Context, dir string) (built rdapTable, err error) {
 		return built, fmt.Errorf("bootstrap fetch failed for %s: leaving the tables untouched", strings.Join(failed, ", "))
 	}
 
+	// fresh holds each key's base URLs joined by spaces, the form
+	// parseStringMap reads them back in, for the diff; primaries holds the
+	// first of them, which a custom entry replaces.
 	var buf bytes.Buffer
 	buf.WriteString(rdapFileHeader)
 	fresh := make(map[string]string)
+	primaries := make(map[string]string)
 	for i, section := range rdapSections {
 		if i > 0 {
 			buf.WriteString("\n")
 		}
 		fmt.Fprintf(&buf, "\t// %s\n", section.title)
-		entries := perCategory[section.category]
+		services := perCategory[section.category]
+		entries := make(map[string]string, len(services))
+		for key, urls := range services {
+			entries[key] = strings.Join(urls, " ")
+		}
 		for _, key := range sortByURLThenKey(entries) {
-			fmt.Fprintf(&buf, "\t%q: %q,\n", key, entries[key])
+			quoted := make([]string, len(services[key]))
+			for j, url := range services[key] {
+				quoted[j] = strconv.Quote(url)
+			}
+			fmt.Fprintf(&buf, "\t%q: {%s},\n", key, strings.Join(quoted, ", "))
 			fresh[key] = entries[key]
 		}
+		for key, url := range serverlist.PrimaryURLs(services) {
+			primaries[key] = url
+		}
 	}
 	buf.WriteString("}\n")
 
@@ -221,7 +237,7 @@ func buildRDAP(ctx context.Context, dir string) (built rdapTable, err error) {
 
 	var summary strings.Builder
 	writeDiff(&summary, "rdap_servers.go", old, fresh)
-	built.collisions = writeCollisions(&summary, custom, fresh)
+	built.collisions = writeCollisions(&summary, custom, primaries)
 	built.table = table{path: path, src: src, summary: summary.String()}
 	return built, nil
 }
@@ -397,32 +413,7 @@ func queryIANA(ctx context.Context, addr, tld string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-
-	record := strings.ToLower(string(body))
-	complete := false
-	for _, line := range strings.Split(record, "\n") {
-		line = strings.TrimSpace(line)
-		if rest, ok := strings.CutPrefix(line, "whois:"); ok {
-			// A record with an empty whois: field is IANA saying the registry
-			// publishes no WHOIS server, which is an answer, not a failure.
-			return strings.TrimSpace(rest), nil
-		}
-		// Every IANA record ends with its source, so seeing that line is what
-		// distinguishes "this TLD has no whois: field" from a record cut short
-		// before reaching one.
-		if rest, ok := strings.CutPrefix(line, "source:"); ok && strings.TrimSpace(rest) == "iana" {
-			complete = true
-		}
-	}
-
-	// No whois: line. Only a complete record — or IANA saying it has no record
-	// at all — may be read as "this TLD has no WHOIS server". A response that
-	// ended early says nothing, and taking it at face value would delete the
-	// entry from the table.
-	if complete || strings.Contains(record, "this query returned 0 objects") {
-		return "", nil
-	}
-	return "", fmt.Errorf("response ended before the record did (%d bytes)", len(body))
+	return serverlist.ParseIANAWhoisRecord(body)
 }
 
 // sortByURLThenKey orders one section's keys by server URL first, so entries
@@ -443,7 +434,8 @@ func sortByURLThenKey(entries map[string]string) []string {
 }
 
 // parseStringMap reads the named map[string]string variable out of a Go
-// source file. A file that does not exist yet parses as an empty map.
+// source file. A map[string][]string reads with each list joined by spaces.
+// A file that does not exist yet parses as an empty map.
 func parseStringMap(path, name string) (map[string]string, error) {
 	src, err := os.ReadFile(path)
 	if os.IsNotExist(err) {
@@ -481,7 +473,7 @@ func parseStringMap(path, name string) (map[string]string, error) {
 				if err != nil {
 					return nil, fmt.Errorf("%s: %w", path, err)
 				}
-				url, err := literalString(kv.Value)
+				url, err := literalValue(kv.Value)
 				if err != nil {
 					return nil, fmt.Errorf("%s: %w", path, err)
 				}
@@ -493,6 +485,23 @@ func parseStringMap(path, name string) (map[string]string, error) {
 	return nil, fmt.Errorf("%s: var %s not found", path, name)
 }
 
+// literalValue reads a string literal, or a list of them joined by spaces.
+func literalValue(expr ast.Expr) (string, error) {
+	list, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return literalString(expr)
+	}
+	values := make([]string, len(list.Elts))
+	for i, elt := range list.Elts {
+		value, err := literalString(elt)
+		if err != nil {
+			return "", err
+		}
+		values[i] = value
+	}
+	return strings.Join(values, " "), nil
+}
+
 func literalString(expr ast.Expr) (string, error) {
 	lit, ok := expr.(*ast.BasicLit)
 	if !ok || lit.Kind != token.STRING {
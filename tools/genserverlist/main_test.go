@@ -148,6 +148,26 @@ var servers = map[string]string{
 	if _, err := parseStringMap(path, "nosuchvar"); err == nil {
 		t.Error("expected an error for a variable that is not in the file")
 	}
+
+	lists := filepath.Join(dir, "lists.go")
+	src = `package serverlist
+
+var servers = map[string][]string{
+	"com": {"https://a.example/", "https://b.example/"},
+	"net": {"https://c.example/"},
+}
+`
+	if err := os.WriteFile(lists, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err = parseStringMap(lists, "servers")
+	if err != nil {
+		t.Fatalf("parseStringMap of lists: %v", err)
+	}
+	want = map[string]string{"com": "https://a.example/ https://b.example/", "net": "https://c.example/"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("list entries = %v, want %v", got, want)
+	}
 }
 
 // TestWriteCollisions verifies a custom RDAP entry that IANA now publishes
@@ -256,8 +276,8 @@ func TestBuildersDoNotWrite(t *testing.T) {
 	existing := map[string]string{
 		"rdap_servers.go": `package serverlist
 
-var compiledRdapServers = map[string]string{
-	"cn": "https://rdap.example/",
+var compiledRdapServers = map[string][]string{
+	"cn": {"https://rdap.example/"},
 }
 `,
 		"whois_servers.go": `package serverlist